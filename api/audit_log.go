@@ -0,0 +1,212 @@
+package api
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/pomerium/cli/proto"
+)
+
+// DefaultAuditLogSize is the number of events kept by the in-memory audit log
+const DefaultAuditLogSize = 1000
+
+// AuditLog records the operations changing the records and listeners
+type AuditLog interface {
+	// Append records the event
+	Append(evt *pb.AuditEvent) error
+	// List returns the most recent events that happened after since, up to
+	// limit if it is positive, oldest first
+	List(since time.Time, limit int) ([]*pb.AuditEvent, error)
+}
+
+// WithAuditLog records the operations to the provided log
+func WithAuditLog(l AuditLog) ServerOption {
+	return func(s *server) error {
+		s.auditLog = l
+		return nil
+	}
+}
+
+func withDefaultAuditLog() ServerOption {
+	return func(s *server) error {
+		if s.auditLog == nil {
+			s.auditLog = NewMemAuditLog(DefaultAuditLogSize)
+		}
+		return nil
+	}
+}
+
+func (s *server) ListAuditEvents(_ context.Context, req *pb.ListAuditEventsRequest) (*pb.ListAuditEventsResponse, error) {
+	var since time.Time
+	if req.Since != nil {
+		since = req.GetSince().AsTime()
+	}
+	events, err := s.auditLog.List(since, int(req.GetLimit()))
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.ListAuditEventsResponse{Events: events}, nil
+}
+
+// audit records the operation, it is logged rather than failing the
+// operation if the event cannot be recorded
+func (s *server) audit(ctx context.Context, operation string, ids []string, summary string, opErr error) {
+	evt := &pb.AuditEvent{
+		Ts:        timestamppb.Now(),
+		Operation: operation,
+		Caller:    auditCaller(ctx),
+		Ids:       ids,
+		Summary:   summary,
+	}
+	if opErr != nil {
+		evt.Error = proto.String(opErr.Error())
+	}
+	if err := s.auditLog.Append(evt); err != nil {
+		log.Ctx(ctx).Error().Err(err).Str("operation", operation).Msg("audit log: append")
+	}
+}
+
+// auditCaller describes the client of the request
+func auditCaller(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return "unknown"
+	}
+	caller := p.Addr.String()
+	if caller == "" || caller == "@" {
+		// unix domain sockets and named pipes are only accessible to the current user
+		caller = "local"
+	}
+	if info, ok := p.AuthInfo.(credentials.TLSInfo); ok && len(info.State.PeerCertificates) > 0 {
+		caller = info.State.PeerCertificates[0].Subject.String() + "@" + caller
+	}
+	return caller
+}
+
+func filterAuditEvents(events []*pb.AuditEvent, since time.Time, limit int) []*pb.AuditEvent {
+	out := make([]*pb.AuditEvent, 0, len(events))
+	for _, evt := range events {
+		if evt.GetTs().AsTime().After(since) {
+			out = append(out, evt)
+		}
+	}
+	if limit > 0 && len(out) > limit {
+		out = out[len(out)-limit:]
+	}
+	return out
+}
+
+type memAuditLog struct {
+	sync.Mutex
+	size   int
+	events []*pb.AuditEvent
+}
+
+// NewMemAuditLog creates an in-memory audit log that keeps up to size events
+func NewMemAuditLog(size int) AuditLog {
+	return &memAuditLog{size: size}
+}
+
+func (l *memAuditLog) Append(evt *pb.AuditEvent) error {
+	l.Lock()
+	defer l.Unlock()
+
+	l.events = append(l.events, evt)
+	if len(l.events) > l.size {
+		l.events = append([]*pb.AuditEvent(nil), l.events[len(l.events)-l.size:]...)
+	}
+	return nil
+}
+
+func (l *memAuditLog) List(since time.Time, limit int) ([]*pb.AuditEvent, error) {
+	l.Lock()
+	defer l.Unlock()
+
+	return filterAuditEvents(l.events, since, limit), nil
+}
+
+type fileAuditLog struct {
+	sync.Mutex
+	path string
+}
+
+// NewFileAuditLog creates an audit log that appends the events to the file,
+// one JSON object per line. The file is never truncated, so that it may be
+// rotated or shipped elsewhere by other tools.
+func NewFileAuditLog(path string) (AuditLog, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, fmt.Errorf("audit log: %w", err)
+	}
+	return &fileAuditLog{path: path}, nil
+}
+
+func (l *fileAuditLog) Append(evt *pb.AuditEvent) error {
+	data, err := protojson.Marshal(evt)
+	if err != nil {
+		return err
+	}
+
+	l.Lock()
+	defer l.Unlock()
+
+	f, err := os.OpenFile(l.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(append(data, '\n'))
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+func (l *fileAuditLog) List(since time.Time, limit int) ([]*pb.AuditEvent, error) {
+	l.Lock()
+	defer l.Unlock()
+
+	f, err := os.Open(l.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+
+	var events []*pb.AuditEvent
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, maxConfigFileBytes)
+	for scanner.Scan() {
+		evt := new(pb.AuditEvent)
+		// skip lines that were partially written, i.e. on crash
+		if err := protojson.Unmarshal(scanner.Bytes(), evt); err != nil {
+			continue
+		}
+		if !evt.GetTs().AsTime().After(since) {
+			continue
+		}
+		events = append(events, evt)
+		if limit > 0 && len(events) > 2*limit {
+			events = append([]*pb.AuditEvent(nil), events[len(events)-limit:]...)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return filterAuditEvents(events, since, limit), nil
+}
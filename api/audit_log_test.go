@@ -0,0 +1,103 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/pomerium/cli/proto"
+)
+
+func TestAuditLog(t *testing.T) {
+	t.Parallel()
+
+	const size = 5
+	path := filepath.Join(t.TempDir(), "audit", "audit.log")
+	newFileLog := func() AuditLog {
+		l, err := NewFileAuditLog(path)
+		require.NoError(t, err)
+		return l
+	}
+
+	start := time.Now().Add(-time.Hour)
+	for name, l := range map[string]AuditLog{
+		"mem":  NewMemAuditLog(size),
+		"file": newFileLog(),
+	} {
+		t.Run(name, func(t *testing.T) {
+			for i := 0; i < size*3; i++ {
+				require.NoError(t, l.Append(&pb.AuditEvent{
+					Operation: "Upsert",
+					Summary:   fmt.Sprint(i),
+					Ts:        timestamppb.New(start.Add(time.Duration(i) * time.Minute)),
+				}))
+			}
+
+			events, err := l.List(time.Time{}, size)
+			require.NoError(t, err)
+			if assert.Len(t, events, size) {
+				assert.Equal(t, fmt.Sprint(size*2), events[0].GetSummary())
+				assert.Equal(t, fmt.Sprint(size*3-1), events[size-1].GetSummary())
+			}
+
+			events, err = l.List(start.Add(time.Duration(size*3-3)*time.Minute), 0)
+			require.NoError(t, err)
+			assert.Len(t, events, 2)
+		})
+	}
+
+	// the file is append-only, so all events survive restarts
+	events, err := newFileLog().List(time.Time{}, 0)
+	require.NoError(t, err)
+	assert.Len(t, events, size*3)
+}
+
+func TestAuditOperations(t *testing.T) {
+	t.Parallel()
+
+	ctx := peer.NewContext(context.Background(), &peer.Peer{
+		Addr: &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 1234},
+	})
+	srv, err := NewServer(ctx)
+	require.NoError(t, err)
+
+	_, err = srv.Upsert(ctx, &pb.Record{
+		Id:   proto.String("one"),
+		Conn: &pb.Connection{Name: proto.String("db"), RemoteAddr: "db.example.com:5432"},
+	})
+	require.NoError(t, err)
+	_, err = srv.Upsert(ctx, &pb.Record{Id: proto.String("Invalid ID")})
+	require.Error(t, err)
+	_, err = srv.Delete(ctx, &pb.Selector{Ids: []string{"one"}})
+	require.NoError(t, err)
+
+	res, err := srv.ListAuditEvents(ctx, &pb.ListAuditEventsRequest{})
+	require.NoError(t, err)
+	require.Len(t, res.Events, 3)
+
+	assert.Equal(t, "Upsert", res.Events[0].GetOperation())
+	assert.Equal(t, "127.0.0.1:1234", res.Events[0].GetCaller())
+	assert.Equal(t, []string{"one"}, res.Events[0].GetIds())
+	assert.Contains(t, res.Events[0].GetSummary(), "db.example.com:5432")
+	assert.Nil(t, res.Events[0].Error)
+
+	assert.Equal(t, "Upsert", res.Events[1].GetOperation())
+	assert.NotNil(t, res.Events[1].Error, "failed operations are recorded")
+
+	assert.Equal(t, "Delete", res.Events[2].GetOperation())
+	assert.Equal(t, []string{"one"}, res.Events[2].GetIds())
+
+	res, err = srv.ListAuditEvents(ctx, &pb.ListAuditEventsRequest{Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, res.Events, 1)
+	assert.Equal(t, "Delete", res.Events[0].GetOperation())
+}
@@ -2,12 +2,14 @@ package api
 
 import (
 	"fmt"
+	"os"
 
 	"github.com/golang/groupcache/lru"
 	"google.golang.org/protobuf/proto"
 
 	"github.com/pomerium/pomerium/pkg/cryptutil"
 
+	"github.com/pomerium/cli/internal/tlsutil"
 	pb "github.com/pomerium/cli/proto"
 )
 
@@ -17,8 +19,10 @@ func withCertInfo(cache *lru.Cache, records []*pb.Record) []*pb.Record {
 			continue
 		}
 		cert := r.Conn.ClientCert
-		var err error
-		cert.Info, err = getCertInfo(cache, cert.Cert)
+		raw, err := clientCertPEM(cert)
+		if err == nil {
+			cert.Info, err = getCertInfo(cache, raw)
+		}
 		if err != nil {
 			cert.Info = certInfoError(err.Error())
 		}
@@ -26,6 +30,33 @@ func withCertInfo(cache *lru.Cache, records []*pb.Record) []*pb.Record {
 	return records
 }
 
+// clientCertPEM returns the PEM-encoded client certificate, reading it from
+// cert_path if set.
+func clientCertPEM(cert *pb.Certificate) ([]byte, error) {
+	if cert.GetCertPath() == "" {
+		return cert.Cert, nil
+	}
+	return os.ReadFile(cert.GetCertPath())
+}
+
+// clientKeyPath returns the path of the client key file, which defaults to
+// the certificate file.
+func clientKeyPath(cert *pb.Certificate) string {
+	if p := cert.GetKeyPath(); p != "" {
+		return p
+	}
+	return cert.GetCertPath()
+}
+
+// clientKeyPassphrase returns the passphrase of an encrypted client key, if
+// there is a passphrase file.
+func clientKeyPassphrase(cert *pb.Certificate) tlsutil.PassphraseFunc {
+	if p := cert.GetKeyPassphraseFile(); p != "" {
+		return tlsutil.PassphraseFromFile(p)
+	}
+	return nil
+}
+
 func certInfoError(message string) *pb.CertificateInfo {
 	return &pb.CertificateInfo{Error: proto.String(message)}
 }
@@ -4,6 +4,7 @@ package api
 import (
 	"errors"
 	"fmt"
+	"regexp"
 
 	"github.com/google/uuid"
 	"google.golang.org/grpc/codes"
@@ -21,6 +22,18 @@ var (
 	errTagIndexInconsistent = errors.New("tag index inconsistent. this is a bug")
 )
 
+// recordIDPattern matches valid record ids: lowercase slugs of up to 64
+// characters, which includes the generated UUIDs
+var recordIDPattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9._-]{0,62}[a-z0-9])?$`)
+
+func validateRecordID(id string) error {
+	if !recordIDPattern.MatchString(id) {
+		return fmt.Errorf("invalid record id %q: must be 1 to 64 lowercase letters, digits, '.', '_' or '-', "+
+			"starting and ending with a letter or digit", id)
+	}
+	return nil
+}
+
 type config struct {
 	byID  map[string]*pb.Record
 	byTag map[string]map[string]*pb.Record
@@ -45,6 +58,11 @@ func loadConfig(ls ConfigProvider) (*config, error) {
 		return cfg, nil
 	}
 
+	data, version, err := migrateConfig(data, configMigrations)
+	if err != nil {
+		return nil, err
+	}
+
 	any := new(anypb.Any)
 	opts := protojson.UnmarshalOptions{DiscardUnknown: true}
 	if err = opts.Unmarshal(data, any); err != nil {
@@ -60,6 +78,18 @@ func loadConfig(ls ConfigProvider) (*config, error) {
 		cfg.upsert(r)
 	}
 
+	// keep the data as it was before the migration
+	if version != configSchemaVersion {
+		if b, ok := ls.(ConfigBackup); ok {
+			if err := b.Backup(fmt.Sprintf("v%d.bak", version)); err != nil {
+				return nil, fmt.Errorf("backup: %w", err)
+			}
+		}
+		if err := cfg.save(ls); err != nil {
+			return nil, err
+		}
+	}
+
 	return cfg, nil
 }
 
@@ -69,7 +99,7 @@ func (cfg *config) save(ls ConfigProvider) error {
 		records = append(records, rec)
 	}
 
-	any := protoutil.NewAny(&pb.Records{Records: records})
+	any := protoutil.NewAny(&pb.Records{Records: records, SchemaVersion: configSchemaVersion})
 	data, err := protojson.MarshalOptions{}.Marshal(any)
 	if err != nil {
 		return fmt.Errorf("marshal: %w", err)
@@ -90,7 +120,7 @@ func (cfg *config) clearTags(r *pb.Record) error {
 	id := r.GetId()
 	current, ok := cfg.byID[id]
 	if !ok {
-		return fmt.Errorf("expect record id=%s be in byID", id)
+		return nil // new record with a client supplied id
 	}
 
 	for _, t := range current.Tags {
@@ -207,3 +237,84 @@ func (cfg *config) getTags() []string {
 	}
 	return tags
 }
+
+// mergeTags replaces all of the old tags with the new tag and returns the
+// updated records
+func (cfg *config) mergeTags(oldTags []string, newTag string) []*pb.Record {
+	replace := make(map[string]struct{}, len(oldTags))
+	updated := make(map[string]*pb.Record)
+	for _, tag := range oldTags {
+		replace[tag] = struct{}{}
+		for id, r := range cfg.byTag[tag] {
+			updated[id] = r
+		}
+	}
+
+	records := make([]*pb.Record, 0, len(updated))
+	for id, r := range updated {
+		tags := make([]string, 0, len(r.Tags))
+		seen := make(map[string]struct{}, len(r.Tags))
+		for _, tag := range r.Tags {
+			if _, ok := replace[tag]; ok {
+				tag = newTag
+			}
+			if _, ok := seen[tag]; ok {
+				continue
+			}
+			seen[tag] = struct{}{}
+			tags = append(tags, tag)
+		}
+
+		for _, tag := range r.Tags {
+			delete(cfg.byTag[tag], id)
+		}
+		r.Tags = tags
+		for _, tag := range r.Tags {
+			m := cfg.byTag[tag]
+			if m == nil {
+				m = make(map[string]*pb.Record)
+				cfg.byTag[tag] = m
+			}
+			m[id] = r
+		}
+		records = append(records, r)
+	}
+
+	for tag, m := range cfg.byTag {
+		if len(m) == 0 {
+			delete(cfg.byTag, tag)
+		}
+	}
+	return records
+}
+
+// exclude removes records matching any of the ids or tags
+func exclude(records []*pb.Record, ids, tags []string) []*pb.Record {
+	if len(ids) == 0 && len(tags) == 0 {
+		return records
+	}
+
+	skipIDs := make(map[string]struct{}, len(ids))
+	for _, id := range ids {
+		skipIDs[id] = struct{}{}
+	}
+	skipTags := make(map[string]struct{}, len(tags))
+	for _, tag := range tags {
+		skipTags[tag] = struct{}{}
+	}
+
+	out := records[:0]
+next:
+	for _, r := range records {
+		if _, ok := skipIDs[r.GetId()]; ok {
+			continue
+		}
+		for _, tag := range r.GetTags() {
+			if _, ok := skipTags[tag]; ok {
+				continue next
+			}
+		}
+		out = append(out, r)
+	}
+	return out
+}
@@ -0,0 +1,80 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+)
+
+// A configMigration upgrades the records, in their JSON encoding, from the
+// previous schema version, so that fields may be renamed or restructured
+// before unknown fields are discarded.
+type configMigration func(records map[string]any) error
+
+// configMigrations[i] migrates the records from schema version i to i+1, new
+// migrations must be appended.
+var configMigrations = []configMigration{
+	// 1 introduced the schema version
+	func(map[string]any) error { return nil },
+}
+
+// configSchemaVersion is the schema version of the records written by this
+// version.
+var configSchemaVersion = uint32(len(configMigrations))
+
+// A ConfigBackup is a ConfigProvider that can keep a copy of the stored data,
+// i.e. before it is migrated to a newer schema version.
+type ConfigBackup interface {
+	// Backup copies the stored data to a backup with the given name, it is
+	// not an error if there is no data.
+	Backup(name string) error
+}
+
+// migrateConfig migrates the JSON encoded records to the schema version of
+// the migrations, and returns the schema version they had. Records of a newer
+// schema version are rejected, as fields unknown to this version would be
+// lost when saving them.
+func migrateConfig(data []byte, migrations []configMigration) ([]byte, uint32, error) {
+	current := uint32(len(migrations))
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var records map[string]any
+	if err := dec.Decode(&records); err != nil {
+		return nil, 0, fmt.Errorf("unmarshal: %w", err)
+	}
+
+	var version uint32
+	if v, ok := records["schemaVersion"]; ok {
+		n, ok := v.(json.Number)
+		if !ok {
+			return nil, 0, fmt.Errorf("invalid schema version %v", v)
+		}
+		i, err := n.Int64()
+		if err != nil || i < 0 || i > math.MaxUint32 {
+			return nil, 0, fmt.Errorf("invalid schema version %v", v)
+		}
+		version = uint32(i)
+	}
+	switch {
+	case version == current:
+		return data, version, nil
+	case version > current:
+		return nil, version, fmt.Errorf("schema version %d is newer than the supported version %d, "+
+			"the config was written by a newer version", version, current)
+	}
+
+	for v := version; v < current; v++ {
+		if err := migrations[v](records); err != nil {
+			return nil, version, fmt.Errorf("migrate from schema version %d: %w", v, err)
+		}
+	}
+	records["schemaVersion"] = current
+
+	data, err := json.Marshal(records)
+	if err != nil {
+		return nil, version, fmt.Errorf("marshal: %w", err)
+	}
+	return data, version, nil
+}
@@ -2,13 +2,14 @@ package api
 
 import (
 	"context"
-	"crypto/tls"
+	"errors"
 	"fmt"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/encoding/protojson"
 
+	"github.com/pomerium/cli/internal/tlsutil"
 	pb "github.com/pomerium/cli/proto"
 )
 
@@ -20,7 +21,7 @@ func (s *server) List(_ context.Context, sel *pb.Selector) (*pb.Records, error)
 	if err != nil {
 		return nil, err
 	}
-	return &pb.Records{Records: records}, nil
+	return &pb.Records{Records: s.usage.withUsage(records)}, nil
 }
 
 func (s *server) listLocked(sel *pb.Selector) ([]*pb.Record, error) {
@@ -38,19 +39,23 @@ func (s *server) listLocked(sel *pb.Selector) ([]*pb.Record, error) {
 	if err != nil {
 		return nil, err
 	}
+	records = exclude(records, sel.GetNotIds(), sel.GetNotTags())
 	return withCertInfo(s.certInfo, records), nil
 }
 
-func (s *server) Delete(_ context.Context, sel *pb.Selector) (*pb.DeleteRecordsResponse, error) {
+func (s *server) Delete(ctx context.Context, sel *pb.Selector) (_ *pb.DeleteRecordsResponse, err error) {
 	s.Lock()
 	defer s.Unlock()
 
+	var ids []string
+	defer func() { s.audit(ctx, "Delete", ids, fmt.Sprintf("delete %d records", len(ids)), err) }()
+
 	recs, err := s.listLocked(sel)
 	if err != nil {
 		return nil, err
 	}
 
-	ids := make([]string, 0, len(recs))
+	ids = make([]string, 0, len(recs))
 	for _, r := range recs {
 		ids = append(ids, r.GetId())
 	}
@@ -64,28 +69,69 @@ func (s *server) Delete(_ context.Context, sel *pb.Selector) (*pb.DeleteRecordsR
 		}
 	}
 
-	if err := s.config.save(s.ConfigProvider); err != nil {
+	if err = s.config.save(s.ConfigProvider); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if err = s.usage.delete(ids...); err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
+	s.traffic.delete(ids...)
+	for _, id := range ids {
+		if err = s.eventLog.Delete(id); err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
 
 	return &pb.DeleteRecordsResponse{}, nil
 }
 
-func (s *server) Upsert(_ context.Context, r *pb.Record) (*pb.Record, error) {
+func (s *server) Upsert(ctx context.Context, r *pb.Record) (_ *pb.Record, err error) {
+	defer func() {
+		var ids []string
+		if r.Id != nil {
+			ids = []string{r.GetId()}
+		}
+		s.audit(ctx, "Upsert", ids,
+			fmt.Sprintf("upsert %q to %s", r.GetConn().GetName(), r.GetConn().GetRemoteAddr()), err)
+	}()
+
+	if r.Id != nil {
+		if err := validateRecordID(r.GetId()); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+	if err := checkAuthOverrides(r.GetConn(), s.trustedClients); err != nil {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+
 	s.Lock()
 	defer s.Unlock()
 
 	if r.Conn != nil && r.Conn.ClientCert != nil {
-		_, err := tls.X509KeyPair(r.Conn.ClientCert.Cert, r.Conn.ClientCert.Key)
+		cert := r.Conn.ClientCert
+		if cert.GetCertPath() != "" && (len(cert.Cert) > 0 || len(cert.Key) > 0) {
+			return nil, status.Error(codes.InvalidArgument, "client cert: cert_path cannot be combined with inline cert or key")
+		}
+		raw, err := clientCertPEM(cert)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("client cert: %s", err.Error()))
+		}
+		passphrase := clientKeyPassphrase(cert)
+		if cert.GetCertPath() != "" {
+			_, err = tlsutil.LoadX509KeyPair(cert.GetCertPath(), clientKeyPath(cert), passphrase)
+		} else {
+			_, err = tlsutil.X509KeyPair(cert.Cert, cert.Key, passphrase)
+		}
 		if err != nil {
 			return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("client cert: %s", err.Error()))
 		}
-		info, err := getCertInfo(s.certInfo, r.Conn.ClientCert.Cert)
+		info, err := getCertInfo(s.certInfo, raw)
 		if err != nil {
 			return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("client cert info: %s", err.Error()))
 		}
 		r.Conn.ClientCert.Info = info
 	}
+	r.Usage = nil
 	if err := s.config.clearTags(r); err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
@@ -111,22 +157,30 @@ func (s *server) Export(ctx context.Context, req *pb.ExportRequest) (*pb.ConfigD
 		opts.Multiline = true
 		opts.Indent = "  "
 	}
-	data, err := exportRecords(recs, req.RemoveTags, opts)
+	data, err := exportRecords(recs, req.RemoveTags, req.RemoveSecrets, opts)
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 	return &pb.ConfigData{Data: data}, nil
 }
 
-func (s *server) Import(_ context.Context, req *pb.ImportRequest) (*pb.ImportResponse, error) {
+func (s *server) Import(ctx context.Context, req *pb.ImportRequest) (_ *pb.ImportResponse, err error) {
 	s.Lock()
 	defer s.Unlock()
 
-	if err := importRecords(s.config, req); err != nil {
+	var ids []string
+	defer func() {
+		s.audit(ctx, "Import", ids, fmt.Sprintf("import %d records in %s format", len(ids), req.GetFormat()), err)
+	}()
+
+	if ids, err = importRecords(s.config, req, s.trustedClients); err != nil {
+		if errors.Is(err, errUntrustedAuthOverride) {
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		}
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	if err := s.config.save(s.ConfigProvider); err != nil {
+	if err = s.config.save(s.ConfigProvider); err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
@@ -139,3 +193,46 @@ func (s *server) GetTags(_ context.Context, req *pb.GetTagsRequest) (*pb.GetTags
 
 	return &pb.GetTagsResponse{Tags: s.config.getTags()}, nil
 }
+
+func (s *server) RenameTag(ctx context.Context, req *pb.RenameTagRequest) (*pb.Records, error) {
+	if req.GetOldTag() == "" {
+		return nil, status.Error(codes.InvalidArgument, "old tag is required")
+	}
+	return s.MergeTags(ctx, &pb.MergeTagsRequest{
+		OldTags: []string{req.GetOldTag()},
+		NewTag:  req.GetNewTag(),
+	})
+}
+
+func (s *server) MergeTags(ctx context.Context, req *pb.MergeTagsRequest) (_ *pb.Records, err error) {
+	var ids []string
+	defer func() {
+		s.audit(ctx, "MergeTags", ids, fmt.Sprintf("merge tags %q into %q", req.GetOldTags(), req.GetNewTag()), err)
+	}()
+
+	if len(req.GetOldTags()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "old tags are required")
+	}
+	if req.GetNewTag() == "" {
+		return nil, status.Error(codes.InvalidArgument, "new tag is required")
+	}
+
+	s.Lock()
+	defer s.Unlock()
+
+	for _, tag := range req.GetOldTags() {
+		if _, ok := s.config.byTag[tag]; !ok {
+			return nil, status.Error(codes.NotFound, tag)
+		}
+	}
+
+	records := s.config.mergeTags(req.GetOldTags(), req.GetNewTag())
+	for _, r := range records {
+		ids = append(ids, r.GetId())
+	}
+	if err = s.config.save(s.ConfigProvider); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.Records{Records: s.usage.withUsage(withCertInfo(s.certInfo, records))}, nil
+}
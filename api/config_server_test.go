@@ -3,10 +3,15 @@ package api_test
 import (
 	"bytes"
 	"context"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
 
 	"github.com/pomerium/cli/api"
@@ -84,6 +89,29 @@ func TestUpsertLoadSave(t *testing.T) {
 	})
 }
 
+func TestDeleteEventLog(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	eventLog, err := api.NewFileEventLog(dir, api.DefaultEventLogSize)
+	require.NoError(t, err)
+
+	srv, err := api.NewServer(ctx, api.WithConfigProvider(new(api.MemCP)), api.WithEventLog(eventLog))
+	require.NoError(t, err)
+	r, err := srv.Upsert(ctx, &pb.Record{
+		Conn: &pb.Connection{
+			Name:       proto.String("test"),
+			RemoteAddr: "test.example.com",
+		},
+	})
+	require.NoError(t, err)
+	require.NoError(t, eventLog.Append(&pb.ConnectionStatusUpdate{Id: r.GetId()}))
+	require.FileExists(t, filepath.Join(dir, r.GetId()+".log"))
+
+	_, err = srv.Delete(ctx, &pb.Selector{Ids: []string{r.GetId()}})
+	require.NoError(t, err)
+	assert.NoFileExists(t, filepath.Join(dir, r.GetId()+".log"))
+}
+
 func TestCertInfo(t *testing.T) {
 	ctx := context.Background()
 
@@ -158,6 +186,15 @@ KSwExwUr94Fr+qoXl/okwJY=
 				tc[&pb.Certificate{Cert: cd, Key: kd}] = valid
 			}
 		}
+
+		dir := t.TempDir()
+		certPath, keyPath := filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+		require.NoError(t, os.WriteFile(certPath, certData, 0o600))
+		require.NoError(t, os.WriteFile(keyPath, keyData, 0o600))
+		tc[&pb.Certificate{CertPath: proto.String(certPath), KeyPath: proto.String(keyPath)}] = true
+		tc[&pb.Certificate{CertPath: proto.String(certPath)}] = false
+		tc[&pb.Certificate{CertPath: proto.String(filepath.Join(dir, "missing.pem")), KeyPath: proto.String(keyPath)}] = false
+		tc[&pb.Certificate{CertPath: proto.String(certPath), KeyPath: proto.String(keyPath), Cert: certData}] = false
 		for crt, valid := range tc {
 			r, err := cfg.Upsert(ctx, &pb.Record{
 				Tags: []string{"one"},
@@ -234,10 +271,12 @@ func TestExportImport(t *testing.T) {
 		},
 	}
 
+	var ids []string
 	for _, r := range testRecords {
 		r, err := srv.Upsert(ctx, r)
 		if assert.NoError(t, err) {
 			assert.NotNil(t, r.Id)
+			ids = append(ids, r.GetId())
 		}
 	}
 
@@ -255,6 +294,15 @@ func TestExportImport(t *testing.T) {
 	_, err = srv.Import(ctx, &pb.ImportRequest{Data: data.Data})
 	require.NoError(t, err)
 
+	recs, err = srv.List(ctx, &pb.Selector{All: true})
+	require.NoError(t, err)
+	require.Len(t, recs.Records, len(testRecords))
+	_, err = srv.List(ctx, &pb.Selector{Ids: ids})
+	assert.NoError(t, err, "ids are kept across export and import")
+
+	// importing again replaces the records with the same ids
+	_, err = srv.Import(ctx, &pb.ImportRequest{Data: data.Data})
+	require.NoError(t, err)
 	recs, err = srv.List(ctx, &pb.Selector{All: true})
 	require.NoError(t, err)
 	require.Len(t, recs.Records, len(testRecords))
@@ -267,3 +315,120 @@ func TestExportImport(t *testing.T) {
 	require.NoError(t, err)
 	require.Len(t, recs.Records, len(testRecords))
 }
+
+func TestUpsertClientID(t *testing.T) {
+	ctx := context.Background()
+	provider := api.WithConfigProvider(new(api.MemCP))
+
+	srv, err := api.NewServer(ctx, provider)
+	require.NoError(t, err)
+
+	for _, id := range []string{"", "DB", "-db", "db-", "db/1", strings.Repeat("a", 65)} {
+		_, err := srv.Upsert(ctx, &pb.Record{Id: proto.String(id), Conn: &pb.Connection{RemoteAddr: "db.example.com:5432"}})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err), "id %q", id)
+	}
+
+	r, err := srv.Upsert(ctx, &pb.Record{
+		Id:   proto.String("prod-db.1"),
+		Tags: []string{"db"},
+		Conn: &pb.Connection{Name: proto.String("prod"), RemoteAddr: "db.example.com:5432"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "prod-db.1", r.GetId())
+
+	// renaming keeps the id
+	_, err = srv.Upsert(ctx, &pb.Record{
+		Id:   proto.String("prod-db.1"),
+		Tags: []string{"db"},
+		Conn: &pb.Connection{Name: proto.String("production"), RemoteAddr: "db.example.com:5432"},
+	})
+	require.NoError(t, err)
+
+	srv, err = api.NewServer(ctx, provider)
+	require.NoError(t, err, "reload")
+	recs, err := srv.List(ctx, &pb.Selector{Ids: []string{"prod-db.1"}})
+	require.NoError(t, err)
+	require.Len(t, recs.GetRecords(), 1)
+	assert.Equal(t, "production", recs.GetRecords()[0].GetConn().GetName())
+
+	recs, err = srv.List(ctx, &pb.Selector{All: true})
+	require.NoError(t, err)
+	assert.Len(t, recs.GetRecords(), 1)
+}
+
+func TestUpsertAuthOverrides(t *testing.T) {
+	ctx := context.Background()
+
+	for _, conn := range []*pb.Connection{
+		{RemoteAddr: "db.example.com:5432", BrowserCmd: proto.String("sh -c 'id'")},
+		{RemoteAddr: "db.example.com:5432", ServiceAccountFile: proto.String("/etc/shadow")},
+	} {
+		trusted, err := api.NewServer(ctx, api.WithConfigProvider(new(api.MemCP)), api.WithTrustedClients(true))
+		require.NoError(t, err)
+		_, err = trusted.Upsert(ctx, &pb.Record{Conn: conn})
+		require.NoError(t, err, "upsert from trusted clients")
+		data, err := trusted.Export(ctx, &pb.ExportRequest{Selector: &pb.Selector{All: true}})
+		require.NoError(t, err)
+		_, err = trusted.Import(ctx, &pb.ImportRequest{Data: data.Data})
+		assert.NoError(t, err, "import from trusted clients")
+
+		srv, err := api.NewServer(ctx, api.WithConfigProvider(new(api.MemCP)))
+		require.NoError(t, err)
+		_, err = srv.Upsert(ctx, &pb.Record{Conn: conn})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err), "upsert from untrusted clients")
+		_, err = srv.Import(ctx, &pb.ImportRequest{Data: data.Data})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err), "import from untrusted clients")
+		recs, err := srv.List(ctx, &pb.Selector{All: true})
+		require.NoError(t, err)
+		assert.Empty(t, recs.GetRecords())
+	}
+
+	srv, err := api.NewServer(ctx, api.WithConfigProvider(new(api.MemCP)))
+	require.NoError(t, err)
+	_, err = srv.Upsert(ctx, &pb.Record{Conn: &pb.Connection{
+		RemoteAddr:     "db.example.com:5432",
+		ServiceAccount: proto.String("ci"),
+	}})
+	assert.NoError(t, err, "an inline service account is accepted")
+}
+
+func TestImportCSV(t *testing.T) {
+	ctx := context.Background()
+
+	srv, err := api.NewServer(ctx, api.WithConfigProvider(new(api.MemCP)))
+	require.NoError(t, err)
+
+	data := []byte(`name,remote addr,listen addr,pomerium url,tags
+postgres, db.example.com:5432, :5432, https://pomerium.example.com, "db,prod"
+,redis.example.com:6379,,,cache;dev
+`)
+	_, err = srv.Import(ctx, &pb.ImportRequest{Data: data, Format: pb.ImportRequest_IMPORT_FORMAT_CSV})
+	require.NoError(t, err)
+
+	recs, err := srv.List(ctx, &pb.Selector{Tags: []string{"db"}})
+	require.NoError(t, err)
+	require.Len(t, recs.GetRecords(), 1)
+	r := recs.GetRecords()[0]
+	assert.Equal(t, "postgres", r.GetConn().GetName())
+	assert.Equal(t, "db.example.com:5432", r.GetConn().GetRemoteAddr())
+	assert.Equal(t, ":5432", r.GetConn().GetListenAddr())
+	assert.Equal(t, "https://pomerium.example.com", r.GetConn().GetPomeriumUrl())
+	assert.Equal(t, []string{"db", "prod"}, r.GetTags())
+
+	recs, err = srv.List(ctx, &pb.Selector{Tags: []string{"dev"}})
+	require.NoError(t, err)
+	require.Len(t, recs.GetRecords(), 1)
+	r = recs.GetRecords()[0]
+	assert.Nil(t, r.GetConn().Name)
+	assert.Nil(t, r.GetConn().ListenAddr)
+	assert.Equal(t, []string{"cache", "dev"}, r.GetTags())
+
+	for _, data := range []string{
+		"postgres\n",
+		"postgres,,:5432\n",
+		"a,b,c,d,e,f\n",
+	} {
+		_, err = srv.Import(ctx, &pb.ImportRequest{Data: []byte(data), Format: pb.ImportRequest_IMPORT_FORMAT_CSV})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err), data)
+	}
+}
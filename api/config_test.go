@@ -1,12 +1,14 @@
 package api
 
 import (
+	"slices"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
 
 	pb "github.com/pomerium/cli/proto"
@@ -136,3 +138,151 @@ func (s *stubConfigProvider) Save(b []byte) error {
 func ptr[T any](value T) *T {
 	return &value
 }
+
+func TestExclude(t *testing.T) {
+	cfg := NewConfig()
+	for id, tags := range map[string][]string{
+		"a": {"vpn"},
+		"b": {"work"},
+		"c": {"work", "personal"},
+		"d": nil,
+	} {
+		cfg.upsert(&pb.Record{Id: proto.String(id), Tags: tags})
+	}
+
+	ids := func(records []*pb.Record) []string {
+		var out []string
+		for _, r := range records {
+			out = append(out, r.GetId())
+		}
+		return out
+	}
+	sorted := cmpopts.SortSlices(func(a, b string) bool { return a < b })
+
+	assert.Empty(t, cmp.Diff([]string{"a", "b", "c", "d"},
+		ids(exclude(cfg.listAll(), nil, nil)), sorted))
+	assert.Empty(t, cmp.Diff([]string{"b", "c", "d"},
+		ids(exclude(cfg.listAll(), nil, []string{"vpn"})), sorted))
+	assert.Empty(t, cmp.Diff([]string{"a", "d"},
+		ids(exclude(cfg.listAll(), []string{"b"}, []string{"personal"})), sorted))
+	assert.Empty(t, cmp.Diff([]string{"b"},
+		ids(exclude(cfg.listAll(), []string{"d"}, []string{"vpn", "personal"})), sorted))
+}
+
+func TestMergeTags(t *testing.T) {
+	cfg := NewConfig()
+	for id, tags := range map[string][]string{
+		"a": {"dev", "vpn"},
+		"b": {"development", "dev"},
+		"c": {"prod"},
+	} {
+		cfg.upsert(&pb.Record{Id: proto.String(id), Tags: tags})
+	}
+
+	sorted := cmpopts.SortSlices(func(a, b string) bool { return a < b })
+	updated := cfg.mergeTags([]string{"dev", "development"}, "staging")
+	assert.Len(t, updated, 2)
+	assert.Empty(t, cmp.Diff([]string{"staging", "vpn", "prod"}, cfg.getTags(), sorted))
+	assert.Equal(t, []string{"staging", "vpn"}, cfg.byID["a"].Tags)
+	assert.Equal(t, []string{"staging"}, cfg.byID["b"].Tags)
+
+	recs, err := cfg.listByTags([]string{"staging"})
+	require.NoError(t, err)
+	assert.Len(t, recs, 2)
+
+	// renaming into an existing tag merges
+	updated = cfg.mergeTags([]string{"prod"}, "vpn")
+	assert.Len(t, updated, 1)
+	assert.Empty(t, cmp.Diff([]string{"staging", "vpn"}, cfg.getTags(), sorted))
+}
+
+type backupConfigProvider struct {
+	stubConfigProvider
+	backups map[string][]byte
+}
+
+func (s *backupConfigProvider) Backup(name string) error {
+	s.backups[name] = s.data
+	return nil
+}
+
+func TestConfigMigration(t *testing.T) {
+	cp := &backupConfigProvider{
+		stubConfigProvider: stubConfigProvider{[]byte(exampleConfig)},
+		backups:            make(map[string][]byte),
+	}
+	_, err := loadConfig(cp)
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"v0.bak": []byte(exampleConfig)}, cp.backups,
+		"should keep the config as it was before the migration")
+	_, version, err := migrateConfig(cp.data, configMigrations)
+	require.NoError(t, err)
+	assert.Equal(t, configSchemaVersion, version, "should save the migrated config")
+
+	_, err = loadConfig(cp)
+	require.NoError(t, err)
+	assert.Len(t, cp.backups, 1, "should not migrate the current schema version")
+
+	_, err = loadConfig(&stubConfigProvider{[]byte(`{"@type": "type.googleapis.com/pomerium.cli.Records", "schemaVersion": 99}`)})
+	assert.ErrorContains(t, err, "newer than the supported version")
+
+	// a field renamed in version 2 is migrated instead of being discarded
+	migrations := append(slices.Clone(configMigrations), func(records map[string]any) error {
+		for _, r := range records["records"].([]any) {
+			conn := r.(map[string]any)["conn"].(map[string]any)
+			conn["name"] = conn["foo"]
+			delete(conn, "foo")
+		}
+		return nil
+	})
+	data, version, err := migrateConfig([]byte(exampleConfig), migrations)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(0), version)
+	assert.JSONEq(t, `{
+		"@type": "type.googleapis.com/pomerium.cli.Records",
+		"schemaVersion": 2,
+		"records": [{
+			"id": "114acc22-c18f-4326-8606-425acc2b3eb5",
+			"tags": ["admin"],
+			"conn": {
+				"name": "bar",
+				"remoteAddr": "example.route.pomerium.com:5000",
+				"listenAddr": "127.0.0.1:5000",
+				"disableTlsVerification": false
+			}
+		}]
+	}`, string(data))
+}
+
+func TestExportRemoveSecrets(t *testing.T) {
+	recs := []*pb.Record{{
+		Id:   proto.String("one"),
+		Tags: []string{"tag"},
+		Conn: &pb.Connection{
+			RemoteAddr:         "tcp.example.com:22",
+			ServiceAccount:     proto.String("jwt"),
+			ServiceAccountFile: proto.String("/path/to/jwt"),
+			ClientCert:         &pb.Certificate{Cert: []byte("cert"), Key: []byte("key")},
+		},
+	}}
+
+	data, err := exportRecords(recs, false, false, protojson.MarshalOptions{})
+	require.NoError(t, err)
+	exported, err := unmarshalRecords(data)
+	require.NoError(t, err)
+	require.Len(t, exported, 1)
+	assert.True(t, proto.Equal(recs[0], exported[0]), "secrets are kept by default")
+
+	data, err = exportRecords(recs, true, true, protojson.MarshalOptions{})
+	require.NoError(t, err)
+	exported, err = unmarshalRecords(data)
+	require.NoError(t, err)
+	require.Len(t, exported, 1)
+	assert.Empty(t, exported[0].Tags)
+	assert.Nil(t, exported[0].Conn.ServiceAccount)
+	assert.Nil(t, exported[0].Conn.ClientCert.Key)
+	assert.Equal(t, []byte("cert"), exported[0].Conn.ClientCert.Cert)
+	assert.Equal(t, "/path/to/jwt", exported[0].Conn.GetServiceAccountFile(), "only the file path is kept")
+
+	assert.Equal(t, []byte("key"), recs[0].Conn.ClientCert.Key, "records are not modified")
+}
@@ -0,0 +1,143 @@
+package api
+
+import (
+	"bytes"
+	"crypto/cipher"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/pomerium/cli/internal/keyring"
+	"github.com/pomerium/cli/internal/profile"
+	"github.com/pomerium/pomerium/pkg/cryptutil"
+)
+
+const (
+	// configKeyringService is the keyring service the config encryption key
+	// of the default profile is stored under
+	configKeyringService = "pomerium-cli"
+	configKeyringAccount = "config-encryption-key"
+
+	// encryptedConfigPrefix marks encrypted config data, data without it was
+	// written without encryption
+	encryptedConfigPrefix = "pomerium-cli-encrypted:v1:"
+)
+
+// EncryptedConfigProvider encrypts the data of another provider at rest, with
+// a key kept in the keyring of the operating system.
+type EncryptedConfigProvider struct {
+	ConfigProvider
+	encrypt bool
+
+	mu      sync.Mutex
+	keyring keyring.Keyring
+	service string
+	aead    cipher.AEAD
+}
+
+// NewEncryptedConfigProvider wraps the provider so that the data is encrypted
+// if encrypt is true, or stored as plaintext otherwise. Data stored the other
+// way is migrated when loaded, so encryption may be turned on and off.
+func NewEncryptedConfigProvider(cp ConfigProvider, encrypt bool) *EncryptedConfigProvider {
+	return &EncryptedConfigProvider{
+		ConfigProvider: cp,
+		encrypt:        encrypt,
+		keyring:        keyring.System(),
+		service:        profile.KeyringService(configKeyringService),
+	}
+}
+
+// IsEncryptedConfig reports whether the data was stored encrypted
+func IsEncryptedConfig(data []byte) bool {
+	return bytes.HasPrefix(data, []byte(encryptedConfigPrefix))
+}
+
+// Load loads and, if needed, decrypts the data
+func (p *EncryptedConfigProvider) Load() ([]byte, error) {
+	data, err := p.ConfigProvider.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	sealed, encrypted := bytes.CutPrefix(data, []byte(encryptedConfigPrefix))
+	if encrypted {
+		if data, err = p.decrypt(sealed); err != nil {
+			return nil, fmt.Errorf("decrypt config: %w", err)
+		}
+	}
+	if len(data) > 0 && encrypted != p.encrypt {
+		if err := p.Save(data); err != nil {
+			return nil, fmt.Errorf("migrate config: %w", err)
+		}
+	}
+	return data, nil
+}
+
+// Save stores the data, encrypting it if enabled
+func (p *EncryptedConfigProvider) Save(data []byte) error {
+	if !p.encrypt {
+		return p.ConfigProvider.Save(data)
+	}
+
+	aead, err := p.getAEAD(true)
+	if err != nil {
+		return fmt.Errorf("encrypt config: %w", err)
+	}
+	sealed := cryptutil.Encrypt(aead, data, nil)
+	return p.ConfigProvider.Save(append([]byte(encryptedConfigPrefix), base64.StdEncoding.EncodeToString(sealed)...))
+}
+
+// Backup copies the stored data as it is, if the wrapped provider supports it
+func (p *EncryptedConfigProvider) Backup(name string) error {
+	if b, ok := p.ConfigProvider.(ConfigBackup); ok {
+		return b.Backup(name)
+	}
+	return nil
+}
+
+func (p *EncryptedConfigProvider) decrypt(sealed []byte) ([]byte, error) {
+	aead, err := p.getAEAD(false)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := base64.StdEncoding.DecodeString(string(sealed))
+	if err != nil {
+		return nil, err
+	}
+	return cryptutil.Decrypt(aead, raw, nil)
+}
+
+// getAEAD returns the cipher with the key from the keyring, which is generated
+// if there is none and create is true
+func (p *EncryptedConfigProvider) getAEAD(create bool) (cipher.AEAD, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.aead != nil {
+		return p.aead, nil
+	}
+
+	var key []byte
+	encoded, err := p.keyring.Get(p.service, configKeyringAccount)
+	switch {
+	case errors.Is(err, keyring.ErrNotFound) && create:
+		key = cryptutil.NewKey()
+		if err := p.keyring.Set(p.service, configKeyringAccount, base64.StdEncoding.EncodeToString(key)); err != nil {
+			return nil, fmt.Errorf("store key in keyring: %w", err)
+		}
+	case errors.Is(err, keyring.ErrNotFound):
+		return nil, errors.New("the encryption key is missing from the keyring")
+	case err != nil:
+		return nil, fmt.Errorf("keyring: %w", err)
+	default:
+		if key, err = base64.StdEncoding.DecodeString(encoded); err != nil || len(key) != cryptutil.DefaultKeySize {
+			return nil, errors.New("invalid encryption key in keyring")
+		}
+	}
+
+	if p.aead, err = cryptutil.NewAEADCipher(key); err != nil {
+		return nil, err
+	}
+	return p.aead, nil
+}
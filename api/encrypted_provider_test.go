@@ -0,0 +1,74 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pomerium/cli/internal/keyring"
+)
+
+type memoryKeyring map[string]string
+
+func (k memoryKeyring) Get(service, account string) (string, error) {
+	secret, ok := k[service+"/"+account]
+	if !ok {
+		return "", keyring.ErrNotFound
+	}
+	return secret, nil
+}
+
+func (k memoryKeyring) Set(service, account, secret string) error {
+	k[service+"/"+account] = secret
+	return nil
+}
+
+func (k memoryKeyring) Delete(service, account string) error {
+	delete(k, service+"/"+account)
+	return nil
+}
+
+func TestEncryptedConfigProvider(t *testing.T) {
+	t.Parallel()
+
+	kr := make(memoryKeyring)
+	newProvider := func(cp ConfigProvider, encrypt bool) *EncryptedConfigProvider {
+		p := NewEncryptedConfigProvider(cp, encrypt)
+		p.keyring = kr
+		return p
+	}
+	plaintext := []byte(`{"records":[{"id":"a"}]}`)
+
+	cp := new(MemCP)
+	require.NoError(t, cp.Save(plaintext))
+
+	// plaintext data is encrypted when loaded with encryption enabled
+	data, err := newProvider(cp, true).Load()
+	require.NoError(t, err)
+	assert.Equal(t, plaintext, data)
+	stored, _ := cp.Load()
+	assert.Contains(t, string(stored), encryptedConfigPrefix)
+	assert.NotContains(t, string(stored), `"records"`)
+	assert.Len(t, kr, 1, "should store the key in the keyring")
+
+	data, err = newProvider(cp, true).Load()
+	require.NoError(t, err)
+	assert.Equal(t, plaintext, data)
+
+	// and decrypted again with encryption disabled
+	data, err = newProvider(cp, false).Load()
+	require.NoError(t, err)
+	assert.Equal(t, plaintext, data)
+	stored, _ = cp.Load()
+	assert.Equal(t, plaintext, stored)
+
+	empty, err := newProvider(new(MemCP), true).Load()
+	assert.NoError(t, err)
+	assert.Empty(t, empty)
+
+	require.NoError(t, newProvider(cp, true).Save(plaintext))
+	clear(kr)
+	_, err = newProvider(cp, true).Load()
+	assert.ErrorContains(t, err, "missing from the keyring")
+}
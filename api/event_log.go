@@ -0,0 +1,256 @@
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	pb "github.com/pomerium/cli/proto"
+)
+
+// DefaultEventLogSize is the default number of events kept per connection
+const DefaultEventLogSize = 500
+
+// EventLog keeps a bounded history of connection status updates that,
+// unlike the EventBroadcaster history, survives listener and server restarts
+type EventLog interface {
+	// Append records the event
+	Append(evt *pb.ConnectionStatusUpdate) error
+	// Get returns events for the connection that happened after since, oldest first
+	Get(id string, since time.Time) ([]*pb.ConnectionStatusUpdate, error)
+	// Delete removes all events of the connection
+	Delete(id string) error
+}
+
+// WithEventLog persists connection status updates to the provided log
+func WithEventLog(l EventLog) ServerOption {
+	return func(s *server) error {
+		s.eventLog = l
+		s.EventBroadcaster = &eventLogBroadcaster{EventBroadcaster: s.EventBroadcaster, log: l}
+		return nil
+	}
+}
+
+func withDefaultEventLog() ServerOption {
+	return func(s *server) error {
+		if s.eventLog == nil {
+			return WithEventLog(NewMemEventLog(DefaultEventLogSize))(s)
+		}
+		return nil
+	}
+}
+
+type eventLogBroadcaster struct {
+	EventBroadcaster
+	log EventLog
+}
+
+func (b *eventLogBroadcaster) Update(ctx context.Context, evt *pb.ConnectionStatusUpdate) error {
+	if err := b.log.Append(evt); err != nil {
+		log.Ctx(ctx).Error().Err(err).Str("id", evt.GetId()).Msg("event log: append")
+	}
+	return b.EventBroadcaster.Update(ctx, evt)
+}
+
+func filterEvents(events []*pb.ConnectionStatusUpdate, since time.Time, limit int) []*pb.ConnectionStatusUpdate {
+	out := make([]*pb.ConnectionStatusUpdate, 0, len(events))
+	for _, evt := range events {
+		if evt.GetTs().AsTime().After(since) {
+			out = append(out, evt)
+		}
+	}
+	if len(out) > limit {
+		out = out[len(out)-limit:]
+	}
+	return out
+}
+
+type memEventLog struct {
+	sync.Mutex
+	size   int
+	events map[string][]*pb.ConnectionStatusUpdate
+}
+
+// NewMemEventLog creates an in-memory event log that keeps up to size events per connection
+func NewMemEventLog(size int) EventLog {
+	return &memEventLog{
+		size:   size,
+		events: make(map[string][]*pb.ConnectionStatusUpdate),
+	}
+}
+
+func (l *memEventLog) Append(evt *pb.ConnectionStatusUpdate) error {
+	l.Lock()
+	defer l.Unlock()
+
+	events := append(l.events[evt.GetId()], evt)
+	if len(events) > l.size {
+		events = append([]*pb.ConnectionStatusUpdate(nil), events[len(events)-l.size:]...)
+	}
+	l.events[evt.GetId()] = events
+	return nil
+}
+
+func (l *memEventLog) Get(id string, since time.Time) ([]*pb.ConnectionStatusUpdate, error) {
+	l.Lock()
+	defer l.Unlock()
+
+	return filterEvents(l.events[id], since, l.size), nil
+}
+
+func (l *memEventLog) Delete(id string) error {
+	l.Lock()
+	defer l.Unlock()
+
+	delete(l.events, id)
+	return nil
+}
+
+type fileEventLog struct {
+	sync.Mutex
+	dir    string
+	size   int
+	counts map[string]int
+}
+
+// NewFileEventLog creates an event log that stores events in the given directory,
+// one file per connection. Each file is compacted to the last size events once
+// it grows to twice that size.
+func NewFileEventLog(dir string, size int) (EventLog, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("event log: %w", err)
+	}
+	return &fileEventLog{
+		dir:    dir,
+		size:   size,
+		counts: make(map[string]int),
+	}, nil
+}
+
+func (l *fileEventLog) path(id string) string {
+	return filepath.Join(l.dir, url.PathEscape(id)+".log")
+}
+
+func (l *fileEventLog) Append(evt *pb.ConnectionStatusUpdate) error {
+	l.Lock()
+	defer l.Unlock()
+
+	id := evt.GetId()
+	count, ok := l.counts[id]
+	if !ok {
+		events, err := l.readLocked(id)
+		if err != nil {
+			return err
+		}
+		count = len(events)
+	}
+
+	data, err := protojson.Marshal(evt)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(l.path(id), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(append(data, '\n'))
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return err
+	}
+	count++
+
+	if count >= l.size*2 {
+		if count, err = l.compactLocked(id); err != nil {
+			return err
+		}
+	}
+	l.counts[id] = count
+	return nil
+}
+
+func (l *fileEventLog) Get(id string, since time.Time) ([]*pb.ConnectionStatusUpdate, error) {
+	l.Lock()
+	defer l.Unlock()
+
+	events, err := l.readLocked(id)
+	if err != nil {
+		return nil, err
+	}
+	return filterEvents(events, since, l.size), nil
+}
+
+func (l *fileEventLog) Delete(id string) error {
+	l.Lock()
+	defer l.Unlock()
+
+	delete(l.counts, id)
+	if err := os.Remove(l.path(id)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (l *fileEventLog) readLocked(id string) ([]*pb.ConnectionStatusUpdate, error) {
+	data, err := os.ReadFile(l.path(id))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var events []*pb.ConnectionStatusUpdate
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(nil, maxConfigFileBytes)
+	for scanner.Scan() {
+		evt := new(pb.ConnectionStatusUpdate)
+		// skip lines that were partially written, i.e. on crash
+		if err := protojson.Unmarshal(scanner.Bytes(), evt); err != nil {
+			continue
+		}
+		events = append(events, evt)
+	}
+	return events, scanner.Err()
+}
+
+func (l *fileEventLog) compactLocked(id string) (int, error) {
+	events, err := l.readLocked(id)
+	if err != nil {
+		return 0, err
+	}
+	if len(events) > l.size {
+		events = events[len(events)-l.size:]
+	}
+
+	var buf bytes.Buffer
+	for _, evt := range events {
+		data, err := protojson.Marshal(evt)
+		if err != nil {
+			return 0, err
+		}
+		buf.Write(data)
+		buf.WriteByte('\n')
+	}
+
+	tmp := l.path(id) + ".tmp"
+	if err := os.WriteFile(tmp, buf.Bytes(), 0o600); err != nil {
+		return 0, err
+	}
+	if err := os.Rename(tmp, l.path(id)); err != nil {
+		return 0, err
+	}
+	return len(events), nil
+}
@@ -0,0 +1,76 @@
+package api
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/pomerium/cli/proto"
+)
+
+func TestEventLog(t *testing.T) {
+	t.Parallel()
+
+	const size = 5
+	newFileLog := func(dir string) EventLog {
+		l, err := NewFileEventLog(dir, size)
+		require.NoError(t, err)
+		return l
+	}
+
+	dir := t.TempDir()
+	for name, l := range map[string]EventLog{
+		"mem":  NewMemEventLog(size),
+		"file": newFileLog(dir),
+	} {
+		t.Run(name, func(t *testing.T) {
+			start := time.Now().Add(-time.Hour)
+			for i := 0; i < size*3; i++ {
+				for _, id := range []string{"a", "../b"} {
+					require.NoError(t, l.Append(&pb.ConnectionStatusUpdate{
+						Id:        id,
+						LastError: proto.String(fmt.Sprint(i)),
+						Ts:        timestamppb.New(start.Add(time.Duration(i) * time.Minute)),
+					}))
+				}
+			}
+
+			events, err := l.Get("a", time.Time{})
+			require.NoError(t, err)
+			if assert.Len(t, events, size) {
+				assert.Equal(t, fmt.Sprint(size*2), events[0].GetLastError())
+				assert.Equal(t, fmt.Sprint(size*3-1), events[size-1].GetLastError())
+			}
+
+			events, err = l.Get("../b", start.Add(time.Duration(size*3-3)*time.Minute))
+			require.NoError(t, err)
+			assert.Len(t, events, 2)
+
+			events, err = l.Get("c", time.Time{})
+			require.NoError(t, err)
+			assert.Empty(t, events)
+		})
+	}
+
+	// history survives restarts
+	events, err := newFileLog(dir).Get("a", time.Time{})
+	require.NoError(t, err)
+	assert.Len(t, events, size)
+
+	t.Run("delete", func(t *testing.T) {
+		l := newFileLog(dir)
+		require.NoError(t, l.Delete("a"))
+		assert.NoFileExists(t, filepath.Join(dir, "a.log"))
+		events, err := l.Get("a", time.Time{})
+		require.NoError(t, err)
+		assert.Empty(t, events)
+
+		require.NoError(t, l.Delete("a"), "should ignore missing files")
+	})
+}
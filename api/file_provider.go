@@ -26,3 +26,14 @@ func (f FileConfigProvider) Load() ([]byte, error) {
 func (f FileConfigProvider) Save(data []byte) error {
 	return os.WriteFile(string(f), data, 0o600)
 }
+
+// Backup copies the file to one with the name appended, i.e. config.json.v1.bak
+func (f FileConfigProvider) Backup(name string) error {
+	data, err := os.ReadFile(string(f))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	return os.WriteFile(string(f)+"."+name, data, 0o600)
+}
@@ -0,0 +1,85 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+const (
+	// HealthCheckInterval is how often WatchHealth checks the server.
+	HealthCheckInterval = 10 * time.Second
+	// HealthCheckTimeout is how long the server may take to respond to a check
+	// before it is reported as not serving.
+	HealthCheckTimeout = 5 * time.Second
+)
+
+var errUnresponsive = errors.New("the server is unresponsive")
+
+// Healthy returns an error if the server does not respond before the context
+// is done, which happens when a request holds the server for too long.
+func (s *server) Healthy(ctx context.Context) error {
+	done := make(chan struct{})
+	// the goroutine is left blocked if the server is wedged, and returns
+	// once it is not anymore
+	go func() {
+		s.RLock()
+		s.RUnlock()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return errUnresponsive
+	}
+}
+
+// WatchHealth sets the overall status of the health server by checking the
+// server at the interval, until the context is done. The status is set to not
+// serving on return.
+func WatchHealth(ctx context.Context, srv Server, hs *health.Server, interval time.Duration) {
+	defer hs.Shutdown()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		checkCtx, cancel := context.WithTimeout(ctx, HealthCheckTimeout)
+		err := srv.Healthy(checkCtx)
+		cancel()
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			hs.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+		} else {
+			hs.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// HealthHandler serves the overall status of the health server over HTTP,
+// with 200 if serving and 503 otherwise.
+func HealthHandler(hs healthpb.HealthServer) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		res, err := hs.Check(r.Context(), &healthpb.HealthCheckRequest{})
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.Header().Set("Cache-Control", "no-store")
+		if err != nil || res.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte("not serving\n"))
+			return
+		}
+		_, _ = w.Write([]byte("ok\n"))
+	})
+}
@@ -0,0 +1,66 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestHealth(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	srv, err := NewServer(ctx)
+	require.NoError(t, err)
+	s := srv.(*server)
+
+	hs := health.NewServer()
+	handler := HealthHandler(hs)
+	get := func() int {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+		return w.Code
+	}
+	servingStatus := func() healthpb.HealthCheckResponse_ServingStatus {
+		res, err := hs.Check(ctx, &healthpb.HealthCheckRequest{})
+		require.NoError(t, err)
+		return res.GetStatus()
+	}
+
+	require.NoError(t, srv.Healthy(ctx))
+
+	watchCtx, stopWatch := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		WatchHealth(watchCtx, srv, hs, 10*time.Millisecond)
+		close(done)
+	}()
+	assert.Eventually(t, func() bool { return servingStatus() == healthpb.HealthCheckResponse_SERVING },
+		time.Second, 10*time.Millisecond)
+	assert.Equal(t, http.StatusOK, get())
+
+	// a request holding the server wedges it
+	s.Lock()
+	checkCtx, cancelCheck := context.WithTimeout(ctx, 10*time.Millisecond)
+	assert.ErrorIs(t, srv.Healthy(checkCtx), errUnresponsive)
+	cancelCheck()
+	assert.Eventually(t, func() bool { return servingStatus() == healthpb.HealthCheckResponse_NOT_SERVING },
+		2*HealthCheckTimeout, 10*time.Millisecond)
+	assert.Equal(t, http.StatusServiceUnavailable, get())
+	s.Unlock()
+
+	assert.Eventually(t, func() bool { return servingStatus() == healthpb.HealthCheckResponse_SERVING },
+		time.Second, 10*time.Millisecond)
+
+	stopWatch()
+	<-done
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, servingStatus(), "not serving once stopped")
+	assert.Equal(t, http.StatusServiceUnavailable, get())
+}
@@ -0,0 +1,61 @@
+package api
+
+import (
+	"sync"
+	"time"
+)
+
+// idleTimer calls onIdle once a listener had no active client connections
+// for the timeout. A nil idleTimer never fires.
+type idleTimer struct {
+	sync.Mutex
+	active  int
+	timeout time.Duration
+	timer   *time.Timer
+}
+
+func newIdleTimer(timeout time.Duration, onIdle func()) *idleTimer {
+	if timeout <= 0 {
+		return nil
+	}
+	return &idleTimer{
+		timeout: timeout,
+		timer:   time.AfterFunc(timeout, onIdle),
+	}
+}
+
+// acquire marks a client connection active
+func (t *idleTimer) acquire() {
+	if t == nil {
+		return
+	}
+	t.Lock()
+	defer t.Unlock()
+
+	t.active++
+	t.timer.Stop()
+}
+
+// release marks a client connection closed, starting the timer once none are left
+func (t *idleTimer) release() {
+	if t == nil {
+		return
+	}
+	t.Lock()
+	defer t.Unlock()
+
+	t.active--
+	if t.active == 0 {
+		t.timer.Reset(t.timeout)
+	}
+}
+
+func (t *idleTimer) stop() {
+	if t == nil {
+		return
+	}
+	t.Lock()
+	defer t.Unlock()
+
+	t.timer.Stop()
+}
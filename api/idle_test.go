@@ -0,0 +1,42 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdleTimer(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, newIdleTimer(0, func() { t.Error("should never fire") }))
+
+	fired := make(chan struct{}, 1)
+	idle := newIdleTimer(50*time.Millisecond, func() { fired <- struct{}{} })
+
+	idle.acquire()
+	idle.acquire()
+	idle.release()
+	select {
+	case <-fired:
+		t.Fatal("should not fire with active connections")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	idle.release()
+	select {
+	case <-fired:
+	case <-time.After(time.Second):
+		t.Fatal("should fire once idle")
+	}
+
+	idle.acquire()
+	idle.release()
+	idle.stop()
+	select {
+	case <-fired:
+		t.Fatal("should not fire once stopped")
+	case <-time.After(100 * time.Millisecond):
+	}
+}
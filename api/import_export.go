@@ -1,7 +1,12 @@
 package api
 
 import (
+	"bytes"
+	"encoding/csv"
+	"errors"
 	"fmt"
+	"io"
+	"strings"
 
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
@@ -12,35 +17,145 @@ import (
 	pb "github.com/pomerium/cli/proto"
 )
 
-func importRecords(dst *config, req *pb.ImportRequest) error {
-	any := new(anypb.Any)
-	if err := protojson.Unmarshal(req.Data, any); err != nil {
-		return fmt.Errorf("unmarshal: %w", err)
+// importRecords upserts the records and returns their ids; unless the clients
+// are trusted, records may not override the browser command or service account file
+func importRecords(dst *config, req *pb.ImportRequest, trusted bool) ([]string, error) {
+	var records []*pb.Record
+	var err error
+	switch req.GetFormat() {
+	case pb.ImportRequest_IMPORT_FORMAT_UNDEFINED, pb.ImportRequest_IMPORT_FORMAT_JSON:
+		records, err = unmarshalRecords(req.Data)
+	case pb.ImportRequest_IMPORT_FORMAT_CSV:
+		records, err = parseCSVRecords(req.Data)
+	default:
+		err = fmt.Errorf("unsupported import format %s", req.GetFormat())
+	}
+	if err != nil {
+		return nil, err
 	}
 
-	records := new(pb.Records)
-	if err := anypb.UnmarshalTo(any, records, proto.UnmarshalOptions{}); err != nil {
-		return fmt.Errorf("unmarshal to: %w", err)
+	for _, r := range records {
+		if r.Id != nil {
+			if err := validateRecordID(r.GetId()); err != nil {
+				return nil, err
+			}
+		}
+		if err := checkAuthOverrides(r.GetConn(), trusted); err != nil {
+			return nil, err
+		}
 	}
 
-	for _, r := range records.Records {
+	// records with an id replace the existing record with the same id
+	ids := make([]string, 0, len(records))
+	for _, r := range records {
 		if req.OverrideTag != nil {
 			r.Tags = []string{*req.OverrideTag}
 		}
-		// TODO: add deduplication
 		dst.upsert(r)
+		ids = append(ids, r.GetId())
 	}
 
-	return nil
+	return ids, nil
 }
 
-func exportRecords(recs []*pb.Record, removeTags bool, opts protojson.MarshalOptions) ([]byte, error) {
-	rec := proto.Clone(&pb.Records{Records: recs}).(*pb.Records)
+func unmarshalRecords(data []byte) ([]*pb.Record, error) {
+	data, _, err := migrateConfig(data, configMigrations)
+	if err != nil {
+		return nil, err
+	}
+
+	any := new(anypb.Any)
+	if err := protojson.Unmarshal(data, any); err != nil {
+		return nil, fmt.Errorf("unmarshal: %w", err)
+	}
+
+	records := new(pb.Records)
+	if err := anypb.UnmarshalTo(any, records, proto.UnmarshalOptions{}); err != nil {
+		return nil, fmt.Errorf("unmarshal to: %w", err)
+	}
+	return records.Records, nil
+}
+
+// csv columns, only the remote address is required
+const (
+	csvColumnName = iota
+	csvColumnRemoteAddr
+	csvColumnListenAddr
+	csvColumnPomeriumURL
+	csvColumnTags
+	csvColumnCount
+)
+
+// parseCSVRecords parses connections from CSV. A first row naming the
+// columns is skipped.
+func parseCSVRecords(data []byte) ([]*pb.Record, error) {
+	r := csv.NewReader(bytes.NewReader(data))
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+
+	var records []*pb.Record
+	for {
+		row, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("csv: %w", err)
+		}
+		line, _ := r.FieldPos(0)
+
+		if line == 1 && isCSVHeader(row) {
+			continue
+		}
+		if len(row) <= csvColumnRemoteAddr || len(row) > csvColumnCount {
+			return nil, fmt.Errorf("csv line %d: expected 2 to %d columns, got %d", line, csvColumnCount, len(row))
+		}
+		row = append(row, make([]string, csvColumnCount-len(row))...)
+
+		conn := &pb.Connection{RemoteAddr: strings.TrimSpace(row[csvColumnRemoteAddr])}
+		if conn.RemoteAddr == "" {
+			return nil, fmt.Errorf("csv line %d: remote addr is required", line)
+		}
+		if v := strings.TrimSpace(row[csvColumnName]); v != "" {
+			conn.Name = proto.String(v)
+		}
+		if v := strings.TrimSpace(row[csvColumnListenAddr]); v != "" {
+			conn.ListenAddr = proto.String(v)
+		}
+		if v := strings.TrimSpace(row[csvColumnPomeriumURL]); v != "" {
+			conn.PomeriumUrl = proto.String(v)
+		}
+
+		var tags []string
+		for _, tag := range strings.FieldsFunc(row[csvColumnTags], func(r rune) bool { return r == ',' || r == ';' }) {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				tags = append(tags, tag)
+			}
+		}
+
+		records = append(records, &pb.Record{Tags: tags, Conn: conn})
+	}
+	return records, nil
+}
+
+func isCSVHeader(row []string) bool {
+	return len(row) > csvColumnRemoteAddr &&
+		strings.EqualFold(strings.TrimSpace(row[csvColumnName]), "name") &&
+		strings.HasPrefix(strings.ToLower(strings.TrimSpace(row[csvColumnRemoteAddr])), "remote")
+}
+
+func exportRecords(recs []*pb.Record, removeTags, removeSecrets bool, opts protojson.MarshalOptions) ([]byte, error) {
+	rec := proto.Clone(&pb.Records{Records: recs, SchemaVersion: configSchemaVersion}).(*pb.Records)
+	// ids are kept, so they remain stable when the records are imported again
 	for _, r := range rec.Records {
-		r.Id = nil
 		if removeTags {
 			r.Tags = nil
 		}
+		if removeSecrets && r.Conn != nil {
+			r.Conn.ServiceAccount = nil
+			if r.Conn.ClientCert != nil {
+				r.Conn.ClientCert.Key = nil
+			}
+		}
 	}
 
 	any := protoutil.NewAny(rec)
@@ -0,0 +1,152 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/pomerium/cli/internal/ipc"
+	"github.com/pomerium/cli/jwt"
+	pb "github.com/pomerium/cli/proto"
+)
+
+const jwtCacheRequestTimeout = 5 * time.Second
+
+// WithJWTCache customizes the JWT cache shared via the JWTCache service
+func WithJWTCache(cache jwt.Cache) ServerOption {
+	return func(s *server) error {
+		s.jwtCache = cache
+		return nil
+	}
+}
+
+func withDefaultJWTCache() ServerOption {
+	return func(s *server) error {
+		if s.jwtCache == nil {
+			s.jwtCache = jwt.GetCache()
+		}
+		return nil
+	}
+}
+
+// RegisterJWTCacheServer registers the JWTCache service of srv, unless any
+// local user could read the raw JWTs: the callers must be authenticated, with
+// an api token or a TLS client certificate, or the api server must listen on
+// a Unix domain socket or named pipe. It reports whether the service was
+// registered.
+func RegisterJWTCacheServer(r grpc.ServiceRegistrar, srv pb.JWTCacheServer, addr string, authenticated bool) bool {
+	if !authenticated && !ipc.IsLocal(addr) {
+		return false
+	}
+	pb.RegisterJWTCacheServer(r, srv)
+	return true
+}
+
+func (s *server) LoadJWT(_ context.Context, req *pb.LoadJWTRequest) (*pb.LoadJWTResponse, error) {
+	rawJWT, err := s.jwtCache.LoadJWT(req.GetKey())
+	res := &pb.LoadJWTResponse{RawJwt: rawJWT}
+	switch {
+	case err == nil:
+		res.Status = pb.LoadJWTResponse_STATUS_VALID
+	case errors.Is(err, jwt.ErrNotFound):
+		res.Status = pb.LoadJWTResponse_STATUS_NOT_FOUND
+	case errors.Is(err, jwt.ErrExpired):
+		res.Status = pb.LoadJWTResponse_STATUS_EXPIRED
+	case errors.Is(err, jwt.ErrInvalid):
+		res.Status = pb.LoadJWTResponse_STATUS_INVALID
+	default:
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return res, nil
+}
+
+func (s *server) StoreJWT(_ context.Context, req *pb.StoreJWTRequest) (*pb.StoreJWTResponse, error) {
+	if err := s.jwtCache.StoreJWT(req.GetKey(), req.GetRawJwt()); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.StoreJWTResponse{}, nil
+}
+
+func (s *server) DeleteJWT(_ context.Context, req *pb.DeleteJWTRequest) (*pb.DeleteJWTResponse, error) {
+	if err := s.jwtCache.DeleteJWT(req.GetKey()); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.DeleteJWTResponse{}, nil
+}
+
+// remoteJWTCache is a jwt.Cache backed by the JWTCache service of a running api server
+type remoteJWTCache struct {
+	client   pb.JWTCacheClient
+	fallback jwt.Cache
+}
+
+// NewRemoteJWTCache creates a jwt.Cache that uses the JWTCache service of a
+// running api server. If the api server is unavailable or does not serve the
+// JWT cache, fallback is used instead.
+func NewRemoteJWTCache(cc grpc.ClientConnInterface, fallback jwt.Cache) jwt.Cache {
+	return &remoteJWTCache{client: pb.NewJWTCacheClient(cc), fallback: fallback}
+}
+
+func (c *remoteJWTCache) LoadJWT(key string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), jwtCacheRequestTimeout)
+	defer cancel()
+
+	res, err := c.client.LoadJWT(ctx, &pb.LoadJWTRequest{Key: key})
+	if c.useFallback(err) {
+		return c.fallback.LoadJWT(key)
+	} else if err != nil {
+		return "", err
+	}
+
+	switch res.GetStatus() {
+	case pb.LoadJWTResponse_STATUS_NOT_FOUND:
+		return "", jwt.ErrNotFound
+	case pb.LoadJWTResponse_STATUS_EXPIRED:
+		return res.GetRawJwt(), jwt.ErrExpired
+	case pb.LoadJWTResponse_STATUS_INVALID:
+		return res.GetRawJwt(), jwt.ErrInvalid
+	}
+	return res.GetRawJwt(), nil
+}
+
+func (c *remoteJWTCache) StoreJWT(key string, rawJWT string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), jwtCacheRequestTimeout)
+	defer cancel()
+
+	_, err := c.client.StoreJWT(ctx, &pb.StoreJWTRequest{Key: key, RawJwt: rawJWT})
+	if c.useFallback(err) {
+		return c.fallback.StoreJWT(key, rawJWT)
+	}
+	return err
+}
+
+func (c *remoteJWTCache) DeleteJWT(key string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), jwtCacheRequestTimeout)
+	defer cancel()
+
+	_, err := c.client.DeleteJWT(ctx, &pb.DeleteJWTRequest{Key: key})
+	if c.useFallback(err) {
+		return c.fallback.DeleteJWT(key)
+	}
+	return err
+}
+
+func (c *remoteJWTCache) useFallback(err error) bool {
+	if c.fallback == nil {
+		return false
+	}
+	switch status.Code(err) {
+	case codes.Unavailable:
+		log.Warn().Err(err).Msg("api server unavailable, using local JWT cache")
+	case codes.Unimplemented:
+		log.Warn().Err(err).Msg("api server does not share its JWT cache, using local JWT cache")
+	default:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,132 @@
+package api
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	"github.com/pomerium/cli/internal/apiauth"
+	"github.com/pomerium/cli/jwt"
+	pb "github.com/pomerium/cli/proto"
+)
+
+func TestJWTCacheServer(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	srv := &server{jwtCache: jwt.NewMemoryCache()}
+
+	res, err := srv.LoadJWT(ctx, &pb.LoadJWTRequest{Key: "a"})
+	require.NoError(t, err)
+	assert.Equal(t, pb.LoadJWTResponse_STATUS_NOT_FOUND, res.GetStatus())
+
+	_, err = srv.StoreJWT(ctx, &pb.StoreJWTRequest{Key: "a", RawJwt: "not-a-jwt"})
+	require.NoError(t, err)
+	res, err = srv.LoadJWT(ctx, &pb.LoadJWTRequest{Key: "a"})
+	require.NoError(t, err)
+	assert.Equal(t, pb.LoadJWTResponse_STATUS_INVALID, res.GetStatus())
+
+	_, err = srv.DeleteJWT(ctx, &pb.DeleteJWTRequest{Key: "a"})
+	require.NoError(t, err)
+	res, err = srv.LoadJWT(ctx, &pb.LoadJWTRequest{Key: "a"})
+	require.NoError(t, err)
+	assert.Equal(t, pb.LoadJWTResponse_STATUS_NOT_FOUND, res.GetStatus())
+}
+
+func TestRegisterJWTCacheServer(t *testing.T) {
+	t.Parallel()
+
+	srv := &server{jwtCache: jwt.NewMemoryCache()}
+	// serves the services registered for the api address on a loopback listener
+	serve := func(t *testing.T, addr string, token string) pb.JWTCacheClient {
+		t.Helper()
+
+		var opts []grpc.ServerOption
+		if token != "" {
+			opts = append(opts, grpc.UnaryInterceptor(apiauth.UnaryServerInterceptor(token)))
+		}
+		grpcSrv := grpc.NewServer(opts...)
+		t.Cleanup(grpcSrv.Stop)
+		RegisterJWTCacheServer(grpcSrv, srv, addr, token != "")
+
+		li, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		go func() { _ = grpcSrv.Serve(li) }()
+
+		cc, err := grpc.NewClient("passthrough:///"+li.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = cc.Close() })
+		return pb.NewJWTCacheClient(cc)
+	}
+
+	t.Run("unauthenticated tcp", func(t *testing.T) {
+		client := serve(t, "127.0.0.1:8800", "")
+		_, err := client.LoadJWT(context.Background(), &pb.LoadJWTRequest{Key: "a"})
+		assert.Equal(t, codes.Unimplemented, status.Code(err), "the JWT cache should not be served")
+	})
+	t.Run("token", func(t *testing.T) {
+		client := serve(t, "127.0.0.1:8800", "secret")
+		_, err := client.LoadJWT(context.Background(), &pb.LoadJWTRequest{Key: "a"})
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+		_, err = client.LoadJWT(context.Background(), &pb.LoadJWTRequest{Key: "a"},
+			grpc.PerRPCCredentials(apiauth.TokenCredentials("secret")))
+		assert.NoError(t, err)
+	})
+	t.Run("unix socket", func(t *testing.T) {
+		client := serve(t, "unix:/run/pomerium/api.sock", "")
+		_, err := client.LoadJWT(context.Background(), &pb.LoadJWTRequest{Key: "a"})
+		assert.NoError(t, err)
+	})
+}
+
+type unavailableConn struct{}
+
+func (unavailableConn) Invoke(context.Context, string, any, any, ...grpc.CallOption) error {
+	return status.Error(codes.Unavailable, "connection refused")
+}
+
+func (unavailableConn) NewStream(context.Context, *grpc.StreamDesc, string, ...grpc.CallOption) (grpc.ClientStream, error) {
+	return nil, status.Error(codes.Unavailable, "connection refused")
+}
+
+type unimplementedConn struct{}
+
+func (unimplementedConn) Invoke(context.Context, string, any, any, ...grpc.CallOption) error {
+	return status.Error(codes.Unimplemented, "unknown service")
+}
+
+func (unimplementedConn) NewStream(context.Context, *grpc.StreamDesc, string, ...grpc.CallOption) (grpc.ClientStream, error) {
+	return nil, status.Error(codes.Unimplemented, "unknown service")
+}
+
+func TestRemoteJWTCacheFallback(t *testing.T) {
+	t.Parallel()
+
+	fallback := jwt.NewMemoryCache()
+	cache := NewRemoteJWTCache(unavailableConn{}, fallback)
+
+	require.NoError(t, cache.StoreJWT("a", "not-a-jwt"))
+	_, err := fallback.LoadJWT("a")
+	assert.ErrorIs(t, err, jwt.ErrInvalid)
+	_, err = cache.LoadJWT("a")
+	assert.ErrorIs(t, err, jwt.ErrInvalid)
+	require.NoError(t, cache.DeleteJWT("a"))
+	_, err = cache.LoadJWT("a")
+	assert.ErrorIs(t, err, jwt.ErrNotFound)
+
+	cache = NewRemoteJWTCache(unimplementedConn{}, fallback)
+	require.NoError(t, cache.StoreJWT("a", "not-a-jwt"))
+	_, err = fallback.LoadJWT("a")
+	assert.ErrorIs(t, err, jwt.ErrInvalid, "should fall back when the JWT cache is not served")
+
+	cache = NewRemoteJWTCache(unavailableConn{}, nil)
+	_, err = cache.LoadJWT("a")
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+}
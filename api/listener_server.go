@@ -2,30 +2,38 @@ package api
 
 import (
 	"context"
+	"crypto/x509"
 	"fmt"
 	"io"
 	"net"
+	"time"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/rs/zerolog/log"
 
+	"github.com/pomerium/cli/internal/tlsutil"
 	pb "github.com/pomerium/cli/proto"
+	"github.com/pomerium/cli/tunnel"
 )
 
-func (s *server) Update(ctx context.Context, req *pb.ListenerUpdateRequest) (*pb.ListenerStatusResponse, error) {
+func (s *server) Update(ctx context.Context, req *pb.ListenerUpdateRequest) (_ *pb.ListenerStatusResponse, err error) {
 	s.Lock()
 	defer s.Unlock()
 
 	var fn func(ids []string) (map[string]*pb.ListenerStatus, error)
+	summary := "connect"
 	if req.Connected {
 		fn = s.connectLocked
 	} else {
 		fn = s.disconnectLocked
+		summary = "disconnect"
 	}
+	defer func() { s.audit(ctx, "Update", req.GetConnectionIds(), summary, err) }()
 
 	listeners, err := fn(req.GetConnectionIds())
 	if err != nil {
@@ -44,7 +52,7 @@ func (s *server) connectLocked(ids []string) (map[string]*pb.ListenerStatus, err
 			continue
 		}
 
-		addr, err := s.connectTunnelLocked(id)
+		addr, err := s.connectTunnelLocked(id, "")
 		if err != nil {
 			txt := err.Error()
 			listeners[id] = &pb.ListenerStatus{LastError: &txt}
@@ -56,33 +64,93 @@ func (s *server) connectLocked(ids []string) (map[string]*pb.ListenerStatus, err
 			Listening:  true,
 			ListenAddr: &concreteAddr,
 		}
+		if err := s.listenerState.setListening(id, concreteAddr); err != nil {
+			log.Error().Err(err).Msg("failed to save listener state")
+		}
 	}
 
 	return listeners, nil
 }
 
-func (s *server) connectTunnelLocked(id string) (net.Addr, error) {
+// connectTunnelLocked starts listening for the connection. If the connection
+// is configured to listen on a random port, the preferred address is tried first.
+func (s *server) connectTunnelLocked(id string, preferredAddr string) (net.Addr, error) {
 	rec, there := s.byID[id]
 	if !there {
 		return nil, errNotFound
 	}
 
-	tun, listenAddr, err := newTunnel(rec.GetConn(), s.browserCmd, s.serviceAccount, s.serviceAccountFile)
+	setTLSOptions := func(opts *tlsutil.Options) {
+		opts.OnUserPresencePrompt = func() {
+			s.sendStatusUpdate(&pb.ConnectionStatusUpdate{
+				Id:     id,
+				Status: pb.ConnectionStatusUpdate_CONNECTION_STATUS_USER_PRESENCE_REQUIRED,
+			})
+		}
+		if s.certExpiryWarning > 0 {
+			opts.ClientCertExpiryWarning = s.certExpiryWarning
+			opts.OnClientCertExpiring = func(leaf *x509.Certificate) {
+				s.onClientCertExpiring(id, leaf)
+			}
+		}
+	}
+	tun, listenAddr, err := newTunnel(rec.GetConn(), s.browserCmd, s.serviceAccount, s.serviceAccountFile, setTLSOptions)
 	if err != nil {
 		return nil, err
 	}
 
+	connect := s.connectTCPTunnelLocked
 	if rec.GetConn().GetProtocol() == pb.Protocol_UDP {
-		return s.connectUDPTunnelLocked(id, tun, listenAddr)
+		connect = s.connectUDPTunnelLocked
 	}
 
-	return s.connectTCPTunnelLocked(id, tun, listenAddr)
+	if preferredAddr != "" && preferredAddr != listenAddr && isRandomPort(listenAddr) {
+		if addr, err := connect(id, tun, preferredAddr); err == nil {
+			return addr, nil
+		}
+	}
+	return connect(id, tun, listenAddr)
+}
+
+// sendStatusUpdate sends a status update that is not related to a particular
+// local connection.
+func (s *server) sendStatusUpdate(upd *pb.ConnectionStatusUpdate) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	upd.Ts = timestamppb.Now()
+	if err := s.EventBroadcaster.Update(ctx, upd); err != nil {
+		log.Error().Err(err).Str("update", protojson.Format(upd)).Msg("failed to send status update")
+	}
+}
+
+// onClientCertExpiring warns that the client certificate of the connection
+// expires soon, and runs the renew hook, if any, in the background.
+func (s *server) onClientCertExpiring(id string, leaf *x509.Certificate) {
+	log.Warn().Str("id", id).Str("subject", leaf.Subject.String()).Time("not-after", leaf.NotAfter).
+		Msg("client certificate expires soon")
+	s.sendStatusUpdate(&pb.ConnectionStatusUpdate{
+		Id:                 id,
+		Status:             pb.ConnectionStatusUpdate_CONNECTION_STATUS_CLIENT_CERT_EXPIRING,
+		ClientCertNotAfter: timestamppb.New(leaf.NotAfter),
+	})
+	if s.certRenewHook == "" {
+		return
+	}
+	go func() {
+		if err := tlsutil.RunRenewHook(s.certRenewHook, leaf); err != nil {
+			log.Error().Err(err).Str("id", id).Msg("failed to renew client certificate")
+		}
+	}()
+}
+
+func isRandomPort(listenAddr string) bool {
+	_, port, err := net.SplitHostPort(listenAddr)
+	return err == nil && (port == "" || port == "0")
 }
 
 func (s *server) connectTCPTunnelLocked(id string, tun Tunnel, listenAddr string) (net.Addr, error) {
 	ctx, cancel := context.WithCancel(context.Background())
-	lc := new(net.ListenConfig)
-	li, err := lc.Listen(ctx, "tcp", listenAddr)
+	li, err := tunnel.Listen(ctx, listenAddr)
 	if err != nil {
 		_ = s.EventBroadcaster.Update(ctx, &pb.ConnectionStatusUpdate{
 			Id:        id,
@@ -102,7 +170,15 @@ func (s *server) connectTCPTunnelLocked(id string, tun Tunnel, listenAddr string
 		cancel()
 		return nil, err
 	}
-	go tunnelAcceptLoop(ctx, id, li, tun, s.EventBroadcaster)
+	conn := s.byID[id].GetConn()
+	idle := newIdleTimer(conn.GetIdleTimeout().AsDuration(), func() {
+		s.disconnectIdleListener(ctx, id)
+	})
+	context.AfterFunc(ctx, idle.stop)
+	// the tunnel keeps a connection to the proxy host open until it is closed
+	context.AfterFunc(ctx, func() { _ = tun.Close() })
+	go tunnelAcceptLoop(ctx, id, li, tun, s.EventBroadcaster, s.usage, s.traffic.record(id), idle,
+		int(conn.GetMaxConnections()))
 	go onContextCancel(ctx, li)
 
 	return li.Addr(), nil
@@ -136,11 +212,11 @@ func (s *server) connectUDPTunnelLocked(id string, tun Tunnel, listenAddr string
 
 	go func() {
 		defer cancel()
-		evt := &tunnelEvents{EventBroadcaster: s.EventBroadcaster, id: id}
+		evt := &tunnelEvents{EventBroadcaster: s.EventBroadcaster, usage: s.usage, id: id}
 		defer evt.onTunnelClosed()
 		evt.onListening(ctx)
 
-		err := tun.RunUDPSessionManager(ctx, conn, evt)
+		err := tun.RunUDPSessionManager(ctx, conn, udpTrafficEvents{tunnelEvents: evt, traffic: s.traffic.record(id)})
 		if err != nil {
 			log.Ctx(ctx).Error().Err(err).Msg("error serving local connection")
 		}
@@ -149,6 +225,19 @@ func (s *server) connectUDPTunnelLocked(id string, tun Tunnel, listenAddr string
 	return addr, nil
 }
 
+// disconnectIdleListener disconnects the listener unless it was already
+// disconnected, in which case its context is canceled
+func (s *server) disconnectIdleListener(ctx context.Context, id string) {
+	s.Lock()
+	defer s.Unlock()
+
+	if ctx.Err() != nil {
+		return
+	}
+	log.Info().Str("id", id).Msg("disconnecting idle listener")
+	_, _ = s.disconnectLocked([]string{id})
+}
+
 func onContextCancel(ctx context.Context, cl io.Closer) {
 	<-ctx.Done()
 	_ = cl.Close()
@@ -165,6 +254,9 @@ func (s *server) disconnectLocked(ids []string) (map[string]*pb.ListenerStatus,
 			listeners[id] = s.GetListenerStatus(id)
 		}
 	}
+	if err := s.listenerState.setNotListening(ids...); err != nil {
+		log.Error().Err(err).Msg("failed to save listener state")
+	}
 
 	return listeners, nil
 }
@@ -183,6 +275,19 @@ func (s *server) StatusUpdates(req *pb.StatusUpdatesRequest, upd pb.Listener_Sta
 	return nil
 }
 
+func (s *server) GetEvents(_ context.Context, req *pb.GetEventsRequest) (*pb.GetEventsResponse, error) {
+	var since time.Time
+	if req.Since != nil {
+		since = req.Since.AsTime()
+	}
+
+	events, err := s.eventLog.Get(req.GetConnectionId(), since)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.GetEventsResponse{Events: events}, nil
+}
+
 func (s *server) GetStatus(ctx context.Context, sel *pb.Selector) (*pb.ListenerStatusResponse, error) {
 	s.RLock()
 	defer s.RUnlock()
@@ -194,8 +299,58 @@ func (s *server) GetStatus(ctx context.Context, sel *pb.Selector) (*pb.ListenerS
 
 	listeners := make(map[string]*pb.ListenerStatus, len(recs))
 	for _, r := range recs {
-		listeners[r.GetId()] = s.GetListenerStatus(r.GetId())
+		st := s.GetListenerStatus(r.GetId())
+		st.Usage = s.usage.get(r.GetId())
+		listeners[r.GetId()] = st
 	}
 
 	return &pb.ListenerStatusResponse{Listeners: listeners}, nil
 }
+
+func (s *server) GetStats(_ context.Context, sel *pb.Selector) (*pb.TrafficStatsResponse, error) {
+	s.RLock()
+	defer s.RUnlock()
+
+	recs, err := s.listLocked(sel)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := make(map[string]*pb.TrafficStats, len(recs))
+	for _, r := range recs {
+		st := s.traffic.get(r.GetId())
+		if st == nil {
+			st = new(pb.TrafficStats)
+		}
+		stats[r.GetId()] = st
+	}
+	return &pb.TrafficStatsResponse{Stats: stats, Ts: timestamppb.Now()}, nil
+}
+
+func (s *server) StreamStats(req *pb.StreamStatsRequest, stream pb.Listener_StreamStatsServer) error {
+	interval := trafficSampleInterval
+	if req.Interval != nil {
+		if err := req.Interval.CheckValid(); err != nil || req.Interval.AsDuration() < 100*time.Millisecond {
+			return status.Error(codes.InvalidArgument, "interval must be at least 100ms")
+		}
+		interval = req.Interval.AsDuration()
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		stats, err := s.GetStats(stream.Context(), req.GetSelector())
+		if err != nil {
+			return err
+		}
+		if err := stream.Send(stats); err != nil {
+			return err
+		}
+
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
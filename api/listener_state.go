@@ -0,0 +1,188 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/rs/zerolog/log"
+)
+
+// listenerState keeps track of the connections that are listening,
+// so that they may be restored when the server is started again
+type listenerState struct {
+	sync.Mutex
+	ConfigProvider
+	// byID maps connection IDs to their concrete listen addresses
+	byID map[string]string
+}
+
+// WithListenerStateProvider customizes listener state persistence
+func WithListenerStateProvider(cp ConfigProvider) ServerOption {
+	return func(s *server) error {
+		st, err := loadListenerState(cp)
+		if err != nil {
+			return fmt.Errorf("listener state: %w", err)
+		}
+		s.listenerState = st
+		return nil
+	}
+}
+
+func withDefaultListenerStateProvider() ServerOption {
+	return func(s *server) error {
+		if s.listenerState == nil {
+			return WithListenerStateProvider(new(MemCP))(s)
+		}
+		return nil
+	}
+}
+
+// WithRestoreListeners restores the connections that were listening
+// when the server was last stopped
+func WithRestoreListeners(restore bool) ServerOption {
+	return func(s *server) error {
+		s.restoreListeners = restore
+		return nil
+	}
+}
+
+// WithAutostartListeners sets whether the connections marked to autostart
+// start listening when the server is created, which they do by default
+func WithAutostartListeners(autostart bool) ServerOption {
+	return func(s *server) error {
+		s.autostartListeners = autostart
+		return nil
+	}
+}
+
+func loadListenerState(cp ConfigProvider) (*listenerState, error) {
+	st := &listenerState{
+		ConfigProvider: cp,
+		byID:           make(map[string]string),
+	}
+
+	data, err := cp.Load()
+	if err != nil {
+		return nil, fmt.Errorf("load: %w", err)
+	}
+	if len(data) == 0 {
+		return st, nil
+	}
+
+	if err := json.Unmarshal(data, &st.byID); err != nil {
+		return nil, fmt.Errorf("unmarshal: %w", err)
+	}
+	return st, nil
+}
+
+// listening returns the IDs of the listening connections and their listen addresses
+func (st *listenerState) listening() map[string]string {
+	st.Lock()
+	defer st.Unlock()
+
+	out := make(map[string]string, len(st.byID))
+	for id, addr := range st.byID {
+		out[id] = addr
+	}
+	return out
+}
+
+func (st *listenerState) setListening(id, addr string) error {
+	st.Lock()
+	defer st.Unlock()
+
+	if cur, ok := st.byID[id]; ok && cur == addr {
+		return nil
+	}
+	st.byID[id] = addr
+	return st.saveLocked()
+}
+
+func (st *listenerState) setNotListening(ids ...string) error {
+	st.Lock()
+	defer st.Unlock()
+
+	changed := false
+	for _, id := range ids {
+		if _, ok := st.byID[id]; ok {
+			delete(st.byID, id)
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+	return st.saveLocked()
+}
+
+func (st *listenerState) saveLocked() error {
+	data, err := json.Marshal(st.byID)
+	if err != nil {
+		return fmt.Errorf("marshal: %w", err)
+	}
+	return st.Save(data)
+}
+
+// restoreListenersLocked reconnects the connections that were listening when
+// the server was last stopped. The previous listen address is preferred so that
+// random ports remain stable, falling back to the configured listen address.
+// Connections that no longer exist are forgotten.
+func (s *server) restoreListenersLocked() {
+	listening := s.listenerState.listening()
+	ids := make([]string, 0, len(listening))
+	for id := range listening {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	var stale []string
+	for _, id := range ids {
+		if _, there := s.byID[id]; !there {
+			stale = append(stale, id)
+			continue
+		}
+
+		addr, err := s.connectTunnelLocked(id, listening[id])
+		if err != nil {
+			// keep the state, so that it is retried on the next start
+			log.Error().Err(err).Str("id", id).Msg("failed to restore listener")
+			continue
+		}
+		log.Info().Str("id", id).Str("address", addr.String()).Msg("restored listener")
+		if err := s.listenerState.setListening(id, addr.String()); err != nil {
+			log.Error().Err(err).Msg("failed to save listener state")
+		}
+	}
+
+	if err := s.listenerState.setNotListening(stale...); err != nil {
+		log.Error().Err(err).Msg("failed to save listener state")
+	}
+}
+
+// autostartListenersLocked connects the connections that are configured to
+// start automatically and are not listening yet, i.e. were not restored.
+// The last listen address is preferred, as when restoring.
+func (s *server) autostartListenersLocked() {
+	listening := s.listenerState.listening()
+	var ids []string
+	for id, rec := range s.byID {
+		if rec.GetConn().GetAutostart() && !s.GetListenerStatus(id).Listening {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		addr, err := s.connectTunnelLocked(id, listening[id])
+		if err != nil {
+			log.Error().Err(err).Str("id", id).Msg("failed to autostart listener")
+			continue
+		}
+		log.Info().Str("id", id).Str("address", addr.String()).Msg("autostarted listener")
+		if err := s.listenerState.setListening(id, addr.String()); err != nil {
+			log.Error().Err(err).Msg("failed to save listener state")
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package api_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/pomerium/cli/api"
+	pb "github.com/pomerium/cli/proto"
+)
+
+func TestRestoreListeners(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	configCP, stateCP := new(api.MemCP), new(api.MemCP)
+	srv, err := api.NewServer(ctx,
+		api.WithConfigProvider(configCP),
+		api.WithListenerStateProvider(stateCP),
+	)
+	require.NoError(t, err)
+
+	rec, err := srv.Upsert(ctx, &pb.Record{
+		Conn: &pb.Connection{
+			RemoteAddr: "tcp.localhost.pomerium.io:99",
+			ListenAddr: proto.String("127.0.0.1:0"),
+		},
+	})
+	require.NoError(t, err)
+	id := rec.GetId()
+
+	status, err := srv.Update(ctx, &pb.ListenerUpdateRequest{
+		ConnectionIds: []string{id},
+		Connected:     true,
+	})
+	require.NoError(t, err)
+	require.True(t, status.Listeners[id].GetListening())
+	listenAddr := status.Listeners[id].GetListenAddr()
+
+	// the state as it was when the server stopped
+	saved, err := stateCP.Load()
+	require.NoError(t, err)
+	assert.Contains(t, string(saved), id)
+
+	_, err = srv.Update(ctx, &pb.ListenerUpdateRequest{
+		ConnectionIds: []string{id},
+		Connected:     false,
+	})
+	require.NoError(t, err)
+	data, err := stateCP.Load()
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), id)
+
+	t.Run("disabled", func(t *testing.T) {
+		stateCP := new(api.MemCP)
+		require.NoError(t, stateCP.Save(saved))
+		srv, err := api.NewServer(ctx,
+			api.WithConfigProvider(configCP),
+			api.WithListenerStateProvider(stateCP),
+		)
+		require.NoError(t, err)
+
+		status, err := srv.GetStatus(ctx, &pb.Selector{All: true})
+		require.NoError(t, err)
+		assert.False(t, status.Listeners[id].GetListening())
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		stateCP := new(api.MemCP)
+		require.NoError(t, stateCP.Save(saved))
+		srv, err := api.NewServer(ctx,
+			api.WithConfigProvider(configCP),
+			api.WithListenerStateProvider(stateCP),
+			api.WithRestoreListeners(true),
+		)
+		require.NoError(t, err)
+
+		status, err := srv.GetStatus(ctx, &pb.Selector{All: true})
+		require.NoError(t, err)
+		assert.True(t, status.Listeners[id].GetListening())
+		assert.Equal(t, listenAddr, status.Listeners[id].GetListenAddr(),
+			"should prefer the previous listen address")
+
+		_, err = srv.Update(ctx, &pb.ListenerUpdateRequest{
+			ConnectionIds: []string{id},
+			Connected:     false,
+		})
+		require.NoError(t, err)
+	})
+}
+
+func TestAutostartListeners(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	configCP := new(api.MemCP)
+	srv, err := api.NewServer(ctx, api.WithConfigProvider(configCP))
+	require.NoError(t, err)
+
+	var ids []string
+	for _, autostart := range []bool{true, false} {
+		rec, err := srv.Upsert(ctx, &pb.Record{
+			Conn: &pb.Connection{
+				RemoteAddr: "tcp.localhost.pomerium.io:99",
+				ListenAddr: proto.String("127.0.0.1:0"),
+				Autostart:  proto.Bool(autostart),
+			},
+		})
+		require.NoError(t, err)
+		ids = append(ids, rec.GetId())
+	}
+
+	status, err := srv.GetStatus(ctx, &pb.Selector{All: true})
+	require.NoError(t, err)
+	assert.False(t, status.Listeners[ids[0]].GetListening(), "should only autostart on startup")
+
+	stateCP := new(api.MemCP)
+	srv, err = api.NewServer(ctx,
+		api.WithConfigProvider(configCP),
+		api.WithListenerStateProvider(stateCP),
+	)
+	require.NoError(t, err)
+
+	status, err = srv.GetStatus(ctx, &pb.Selector{All: true})
+	require.NoError(t, err)
+	assert.True(t, status.Listeners[ids[0]].GetListening())
+	assert.False(t, status.Listeners[ids[1]].GetListening())
+	data, err := stateCP.Load()
+	require.NoError(t, err)
+	assert.Contains(t, string(data), ids[0])
+
+	_, err = srv.Update(ctx, &pb.ListenerUpdateRequest{
+		ConnectionIds: ids[:1],
+		Connected:     false,
+	})
+	require.NoError(t, err)
+}
@@ -2,30 +2,28 @@ package api
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
 
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
 
 	"github.com/pomerium/cli/internal/portal"
 	pb "github.com/pomerium/cli/proto"
+	"github.com/pomerium/cli/tunnel"
 )
 
 func (srv *server) FetchRoutes(
 	ctx context.Context,
 	req *pb.FetchRoutesRequest,
 ) (*pb.FetchRoutesResponse, error) {
-	tlsConfig, err := getTLSConfig(req)
-	if err != nil {
-		return nil, err
-	}
-
-	p := portal.New(
-		portal.WithBrowserCommand(srv.browserCmd),
-		portal.WithServiceAccount(srv.serviceAccount),
-		portal.WithServiceAccountFile(srv.serviceAccountFile),
-		portal.WithTLSConfig(tlsConfig),
-	)
-
-	routes, err := p.ListRoutes(ctx, req.GetServerUrl())
+	routes, err := srv.listPortalRoutes(ctx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -47,3 +45,130 @@ func (srv *server) FetchRoutes(
 	}
 	return res, nil
 }
+
+func (srv *server) ImportFromPortal(
+	ctx context.Context,
+	req *pb.ImportFromPortalRequest,
+) (_ *pb.Records, err error) {
+	var ids []string
+	defer func() {
+		srv.audit(ctx, "ImportFromPortal", ids,
+			fmt.Sprintf("import %d routes from %s", len(ids), req.GetRoutes().GetServerUrl()), err)
+	}()
+
+	serverURL, err := url.Parse(req.GetRoutes().GetServerUrl())
+	if err != nil || serverURL.Host == "" {
+		return nil, status.Error(codes.InvalidArgument, "invalid server url")
+	}
+	tag := serverURL.Hostname()
+	if req.Tag != nil {
+		tag = req.GetTag()
+	}
+
+	routes, err := srv.listPortalRoutes(ctx, req.GetRoutes())
+	if err != nil {
+		return nil, err
+	}
+
+	srv.Lock()
+	defer srv.Unlock()
+
+	var records []*pb.Record
+	for _, route := range routes {
+		rec, err := portalRouteRecord(req.GetRoutes(), route)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Str("route", route.Name).Msg("skipping route")
+			continue
+		} else if rec == nil {
+			continue
+		}
+
+		// keep the local settings of previously imported records
+		if current, ok := srv.byID[rec.GetId()]; ok {
+			conn := proto.Clone(current.GetConn()).(*pb.Connection)
+			if conn == nil {
+				conn = new(pb.Connection)
+			}
+			conn.Name, conn.Protocol = rec.Conn.Name, rec.Conn.Protocol
+			conn.RemoteAddr, conn.PomeriumUrl = rec.Conn.RemoteAddr, rec.Conn.PomeriumUrl
+			rec.Conn = conn
+			rec.Tags = slices.Clone(current.GetTags())
+		}
+		if tag != "" && !slices.Contains(rec.Tags, tag) {
+			rec.Tags = append(rec.Tags, tag)
+		}
+		srv.config.upsert(rec)
+		records = append(records, rec)
+		ids = append(ids, rec.GetId())
+	}
+
+	if err := srv.config.save(srv.ConfigProvider); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.Records{Records: srv.usage.withUsage(withCertInfo(srv.certInfo, records))}, nil
+}
+
+func (srv *server) listPortalRoutes(ctx context.Context, req *pb.FetchRoutesRequest) ([]portal.Route, error) {
+	tlsConfig, err := getTLSConfig(req)
+	if err != nil {
+		return nil, err
+	}
+
+	p := portal.New(
+		portal.WithBrowserCommand(srv.browserCmd),
+		portal.WithServiceAccount(srv.serviceAccount),
+		portal.WithServiceAccountFile(srv.serviceAccountFile),
+		portal.WithTLSConfig(tlsConfig),
+	)
+	return p.ListRoutes(ctx, req.GetServerUrl())
+}
+
+// portalRouteRecord returns the record of a TCP or UDP route, or nil for other
+// routes. The id of the record is derived from the server url and route, so
+// that importing the routes again updates the same records.
+func portalRouteRecord(req *pb.FetchRoutesRequest, route portal.Route) (*pb.Record, error) {
+	var protocol pb.Protocol
+	switch strings.ToLower(route.Type) {
+	case "tcp":
+		protocol = pb.Protocol_TCP
+	case "udp":
+		protocol = pb.Protocol_UDP
+	default:
+		return nil, nil
+	}
+
+	destinationAddr, proxyURL, err := tunnel.ParseURLs(route.From, "")
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", route.From, err)
+	}
+
+	key := route.ID
+	if key == "" {
+		key = route.From
+	}
+	sum := sha256.Sum256([]byte(req.GetServerUrl() + "\x00" + key))
+
+	conn := &pb.Connection{
+		Name:                proto.String(route.Name),
+		Protocol:            protocol.Enum(),
+		RemoteAddr:          destinationAddr,
+		PomeriumUrl:         proto.String(proxyURL.String()),
+		ClientCert:          req.ClientCert,
+		ClientCertFromStore: req.ClientCertFromStore,
+		CaDir:               req.CaDir,
+		TlsMinVersion:       req.TlsMinVersion,
+		CheckRevocation:     req.CheckRevocation,
+	}
+	switch opt := req.GetTlsOptions().(type) {
+	case *pb.FetchRoutesRequest_DisableTlsVerification:
+		conn.TlsOptions = &pb.Connection_DisableTlsVerification{DisableTlsVerification: opt.DisableTlsVerification}
+	case *pb.FetchRoutesRequest_CaCert:
+		conn.TlsOptions = &pb.Connection_CaCert{CaCert: opt.CaCert}
+	}
+
+	return &pb.Record{
+		Id:     proto.String("portal-" + hex.EncodeToString(sum[:8])),
+		Conn:   conn,
+		Source: proto.String(req.GetServerUrl()),
+	}, nil
+}
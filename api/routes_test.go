@@ -0,0 +1,55 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/pomerium/cli/internal/portal"
+	pb "github.com/pomerium/cli/proto"
+)
+
+func TestPortalRouteRecord(t *testing.T) {
+	t.Parallel()
+
+	req := &pb.FetchRoutesRequest{
+		ServerUrl:  "https://portal.example.com",
+		TlsOptions: &pb.FetchRoutesRequest_CaCert{CaCert: []byte("ca")},
+		PinSha256:  []string{"pin"},
+	}
+
+	rec, err := portalRouteRecord(req, portal.Route{
+		ID: "r1", Name: "db", Type: "tcp", From: "tcp+https://db.example.com:5432",
+	})
+	require.NoError(t, err)
+	assert.True(t, proto.Equal(&pb.Connection{
+		Name:        proto.String("db"),
+		Protocol:    pb.Protocol_TCP.Enum(),
+		RemoteAddr:  "db.example.com:5432",
+		PomeriumUrl: proto.String("https://db.example.com:443"),
+		TlsOptions:  &pb.Connection_CaCert{CaCert: []byte("ca")},
+	}, rec.GetConn()), "unexpected connection: %v", rec.GetConn())
+	assert.Equal(t, "https://portal.example.com", rec.GetSource())
+	assert.NoError(t, validateRecordID(rec.GetId()))
+
+	again, err := portalRouteRecord(req, portal.Route{
+		ID: "r1", Name: "renamed", Type: "tcp", From: "tcp+https://db.example.com:5433",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, rec.GetId(), again.GetId(), "ids should be stable")
+
+	rec, err = portalRouteRecord(req, portal.Route{
+		Name: "dns", Type: "udp", From: "udp+https://proxy.example.com:8443/dns.internal:53",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, pb.Protocol_UDP, rec.GetConn().GetProtocol())
+	assert.Equal(t, "dns.internal:53", rec.GetConn().GetRemoteAddr())
+	assert.Equal(t, "https://proxy.example.com:8443", rec.GetConn().GetPomeriumUrl())
+	assert.NotEqual(t, again.GetId(), rec.GetId())
+
+	rec, err = portalRouteRecord(req, portal.Route{ID: "r3", Type: "http", From: "https://app.example.com"})
+	assert.NoError(t, err)
+	assert.Nil(t, rec, "should skip http routes")
+}
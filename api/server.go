@@ -3,16 +3,17 @@ package api
 import (
 	"context"
 	"crypto/tls"
-	"crypto/x509"
 	"errors"
 	"fmt"
 	"io"
 	"net"
 	"sync"
+	"time"
 
 	"github.com/golang/groupcache/lru"
 
-	"github.com/pomerium/cli/certstore"
+	"github.com/pomerium/cli/internal/tlsutil"
+	"github.com/pomerium/cli/jwt"
 	pb "github.com/pomerium/cli/proto"
 	"github.com/pomerium/cli/tunnel"
 )
@@ -44,12 +45,19 @@ type ListenerStatus interface {
 type Tunnel interface {
 	Run(context.Context, io.ReadWriter, tunnel.EventSink) error
 	RunUDPSessionManager(ctx context.Context, conn *net.UDPConn, eventSink tunnel.EventSink) error
+	// Close closes the connections to the proxy host shared by the tunnels
+	Close() error
 }
 
-// Server implements both config and listener interfaces
+// Server implements the config, listener and JWT cache interfaces
 type Server interface {
 	pb.ConfigServer
 	pb.ListenerServer
+	pb.JWTCacheServer
+
+	// Healthy returns an error if the server does not respond before the
+	// context is done
+	Healthy(ctx context.Context) error
 }
 
 type server struct {
@@ -58,10 +66,21 @@ type server struct {
 	EventBroadcaster
 	ListenerStatus
 	*config
+	eventLog           EventLog
+	auditLog           AuditLog
+	usage              *usageStats
+	traffic            *trafficStats
+	listenerState      *listenerState
+	restoreListeners   bool
+	autostartListeners bool
+	jwtCache           jwt.Cache
 	browserCmd         string
 	serviceAccount     string
 	serviceAccountFile string
+	trustedClients     bool
 	certInfo           *lru.Cache
+	certExpiryWarning  time.Duration
+	certRenewHook      string
 }
 
 var (
@@ -73,19 +92,37 @@ var (
 // NewServer creates new configuration management server
 func NewServer(ctx context.Context, opts ...ServerOption) (Server, error) {
 	srv := &server{
-		ListenerStatus:   newListenerStatus(),
-		EventBroadcaster: NewEventsBroadcaster(ctx),
-		certInfo:         lru.New(256),
+		ListenerStatus:     newListenerStatus(),
+		EventBroadcaster:   NewEventsBroadcaster(ctx),
+		certInfo:           lru.New(256),
+		certExpiryWarning:  tlsutil.DefaultClientCertExpiryWarning,
+		traffic:            newTrafficStats(),
+		autostartListeners: true,
 	}
+	go srv.traffic.run(ctx, trafficSampleInterval)
 
 	for _, opt := range append(opts,
 		withDefaultConfigProvider(),
+		withDefaultEventLog(),
+		withDefaultAuditLog(),
+		withDefaultUsageStatsProvider(),
+		withDefaultListenerStateProvider(),
+		withDefaultJWTCache(),
 	) {
 		if err := opt(srv); err != nil {
 			return nil, err
 		}
 	}
 
+	srv.Lock()
+	if srv.restoreListeners {
+		srv.restoreListenersLocked()
+	}
+	if srv.autostartListeners {
+		srv.autostartListenersLocked()
+	}
+	srv.Unlock()
+
 	return srv, nil
 }
 
@@ -135,6 +172,27 @@ func WithServiceAccountFile(serviceAccountFile string) ServerOption {
 	}
 }
 
+// WithTrustedClients records whether only the current user can call the api,
+// because clients authenticate or it only listens on Unix domain sockets or
+// named pipes. Only then may connections override the browser command and the
+// service account file, which run a command and read a file as the current user.
+func WithTrustedClients(trusted bool) ServerOption {
+	return func(s *server) error {
+		s.trustedClients = trusted
+		return nil
+	}
+}
+
+// WithClientCertExpiry sets how long before a client certificate expires to
+// warn about it, and the command to run to renew it, if any.
+func WithClientCertExpiry(warning time.Duration, renewHook string) ServerOption {
+	return func(s *server) error {
+		s.certExpiryWarning = warning
+		s.certRenewHook = renewHook
+		return nil
+	}
+}
+
 // MemCP is in-memory config provider
 type MemCP struct {
 	data []byte
@@ -153,49 +211,59 @@ func (s *MemCP) Save(data []byte) error {
 
 type tlsOptions interface {
 	GetCaCert() []byte
+	GetCaDir() string
 	GetClientCert() *pb.Certificate
 	GetClientCertFromStore() *pb.ClientCertFromStore
 	GetDisableTlsVerification() bool
+	GetTlsServerName() string
+	GetTlsMinVersion() pb.TLSVersion
+	GetPinSha256() []string
+	GetCheckRevocation() bool
 }
 
 func getTLSConfig(conn tlsOptions) (*tls.Config, error) {
-	cfg := &tls.Config{
-		//nolint: gosec
+	opts, err := getTLSOptions(conn)
+	if err != nil {
+		return nil, err
+	}
+	return tlsutil.NewConfig(opts)
+}
+
+func getTLSOptions(conn tlsOptions) (*tlsutil.Options, error) {
+	opts := &tlsutil.Options{
 		InsecureSkipVerify: conn.GetDisableTlsVerification(),
+		CA:                 conn.GetCaCert(),
+		CADir:              conn.GetCaDir(),
+		ServerName:         conn.GetTlsServerName(),
+		PinnedSPKIHashes:   conn.GetPinSha256(),
+		CheckRevocation:    conn.GetCheckRevocation(),
+	}
+
+	switch v := conn.GetTlsMinVersion(); v {
+	case pb.TLSVersion_TLS_VERSION_DEFAULT:
+	case pb.TLSVersion_TLS_VERSION_1_2:
+		opts.MinVersion = tls.VersionTLS12
+	case pb.TLSVersion_TLS_VERSION_1_3:
+		opts.MinVersion = tls.VersionTLS13
+	default:
+		return nil, fmt.Errorf("unsupported TLS version %v", v)
 	}
 
-	if c := conn.GetClientCert(); c != nil {
+	if c := conn.GetClientCert(); c.GetCertPath() != "" {
+		opts.ClientCertFile, opts.ClientKeyFile = c.GetCertPath(), clientKeyPath(c)
+	} else if c != nil {
 		if len(c.Cert) == 0 {
 			return nil, fmt.Errorf("client cert: certificate is missing")
 		}
-		if len(c.Key) == 0 {
-			return nil, fmt.Errorf("client cert: key is missing")
-		}
-		cert, err := tls.X509KeyPair(c.Cert, c.Key)
-		if err != nil {
-			return nil, fmt.Errorf("client cert: %w", err)
-		}
-		cfg.Certificates = append(cfg.Certificates, cert)
+		opts.ClientCert, opts.ClientKey = c.Cert, c.Key
 	}
+	opts.ClientKeyPassphrase = clientKeyPassphrase(conn.GetClientCert())
 	if c := conn.GetClientCertFromStore(); c != nil {
-		f, err := certstore.GetClientCertificateFunc(c.GetIssuerFilter(), c.GetSubjectFilter())
-		if err != nil {
-			return nil, fmt.Errorf("client cert from store: %w", err)
-		}
-		cfg.GetClientCertificate = f
-	}
-
-	if len(conn.GetCaCert()) == 0 {
-		return cfg, nil
+		opts.ClientCertFromStore = true
+		opts.ClientCertIssuerFilter = c.GetIssuerFilter()
+		opts.ClientCertSubjectFilter = c.GetSubjectFilter()
+		opts.ClientCertRequireUserPresence = c.GetRequireUserPresence()
 	}
 
-	rootCA, err := x509.SystemCertPool()
-	if err != nil {
-		return nil, fmt.Errorf("get system cert pool: %w", err)
-	}
-	if ok := rootCA.AppendCertsFromPEM(conn.GetCaCert()); !ok {
-		return nil, fmt.Errorf("failed to append provided certificate")
-	}
-	cfg.RootCAs = rootCA
-	return cfg, nil
+	return opts, nil
 }
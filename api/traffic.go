@@ -0,0 +1,114 @@
+package api
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	pb "github.com/pomerium/cli/proto"
+)
+
+// trafficSampleInterval is how often the throughput of each record is sampled
+const trafficSampleInterval = time.Second
+
+// trafficStats keeps track of the live traffic of each record since the api
+// server started, unlike usageStats which are persisted
+type trafficStats struct {
+	sync.Mutex
+	byID map[string]*recordTraffic
+}
+
+// recordTraffic counts the traffic of a single record
+type recordTraffic struct {
+	active                atomic.Int64
+	total, sent, received atomic.Uint64
+
+	// set by sample, protected by the trafficStats lock
+	lastSent, lastReceived uint64
+	sentRate, receivedRate float64
+}
+
+func newTrafficStats() *trafficStats {
+	return &trafficStats{byID: make(map[string]*recordTraffic)}
+}
+
+// record returns the traffic counters of the record, creating them if needed
+func (t *trafficStats) record(id string) *recordTraffic {
+	t.Lock()
+	defer t.Unlock()
+
+	rt, ok := t.byID[id]
+	if !ok {
+		rt = new(recordTraffic)
+		t.byID[id] = rt
+	}
+	return rt
+}
+
+// get returns the traffic statistics of the record, or nil if it had no
+// traffic since the api server started
+func (t *trafficStats) get(id string) *pb.TrafficStats {
+	t.Lock()
+	defer t.Unlock()
+
+	rt, ok := t.byID[id]
+	if !ok {
+		return nil
+	}
+	return &pb.TrafficStats{
+		ActiveConnections: uint64(max(0, rt.active.Load())),
+		TotalConnections:  rt.total.Load(),
+		BytesSent:         rt.sent.Load(),
+		BytesReceived:     rt.received.Load(),
+		SentRate:          rt.sentRate,
+		ReceivedRate:      rt.receivedRate,
+	}
+}
+
+func (t *trafficStats) delete(ids ...string) {
+	t.Lock()
+	defer t.Unlock()
+
+	for _, id := range ids {
+		delete(t.byID, id)
+	}
+}
+
+// run samples the throughput of each record until the context is canceled
+func (t *trafficStats) run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	last := time.Now()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			t.sample(now.Sub(last))
+			last = now
+		}
+	}
+}
+
+func (t *trafficStats) sample(elapsed time.Duration) {
+	t.Lock()
+	defer t.Unlock()
+
+	for _, rt := range t.byID {
+		sent, received := rt.sent.Load(), rt.received.Load()
+		rt.sentRate = float64(sent-rt.lastSent) / elapsed.Seconds()
+		rt.receivedRate = float64(received-rt.lastReceived) / elapsed.Seconds()
+		rt.lastSent, rt.lastReceived = sent, received
+	}
+}
+
+func (rt *recordTraffic) connOpened() {
+	rt.active.Add(1)
+	rt.total.Add(1)
+}
+
+func (rt *recordTraffic) connClosed() {
+	rt.active.Add(-1)
+}
@@ -0,0 +1,43 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrafficStats(t *testing.T) {
+	t.Parallel()
+
+	ts := newTrafficStats()
+	assert.Nil(t, ts.get("a"))
+
+	rt := ts.record("a")
+	assert.Same(t, rt, ts.record("a"))
+	rt.connOpened()
+	rt.connOpened()
+	rt.connClosed()
+	rt.sent.Add(2000)
+	rt.received.Add(500)
+	ts.sample(2 * time.Second)
+
+	st := ts.get("a")
+	require.NotNil(t, st)
+	assert.Equal(t, uint64(1), st.ActiveConnections)
+	assert.Equal(t, uint64(2), st.TotalConnections)
+	assert.Equal(t, uint64(2000), st.BytesSent)
+	assert.Equal(t, uint64(500), st.BytesReceived)
+	assert.Equal(t, 1000.0, st.SentRate)
+	assert.Equal(t, 250.0, st.ReceivedRate)
+
+	rt.sent.Add(100)
+	ts.sample(time.Second)
+	st = ts.get("a")
+	assert.Equal(t, 100.0, st.SentRate, "rates should only count the bytes since the last sample")
+	assert.Equal(t, 0.0, st.ReceivedRate)
+
+	ts.delete("a")
+	assert.Nil(t, ts.get("a"))
+}
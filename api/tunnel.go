@@ -7,19 +7,32 @@ import (
 	"fmt"
 	"net"
 	"net/url"
+	"sync/atomic"
 	"time"
 
 	"github.com/cenkalti/backoff/v4"
 	"github.com/rs/zerolog/log"
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/pomerium/cli/internal/tlsutil"
 	pb "github.com/pomerium/cli/proto"
 	"github.com/pomerium/cli/tunnel"
 )
 
-func newTunnel(conn *pb.Connection, browserCmd, serviceAccount, serviceAccountFile string) (Tunnel, string, error) {
+// newTunnel creates the tunnel for the connection, authenticating with the
+// given browser command and service account unless the connection overrides
+// them. setTLSOptions, if not nil, adds callbacks to the TLS options, e.g. for
+// when the user is asked to approve the use of the client certificate.
+func newTunnel(
+	conn *pb.Connection,
+	browserCmd, serviceAccount, serviceAccountFile string,
+	setTLSOptions func(*tlsutil.Options),
+) (Tunnel, string, error) {
+	browserCmd, serviceAccount, serviceAccountFile = getAuthOptions(conn, browserCmd, serviceAccount, serviceAccountFile)
+
 	listenAddr := "127.0.0.1:0"
 	if conn.ListenAddr != nil {
 		listenAddr = *conn.ListenAddr
@@ -32,7 +45,14 @@ func newTunnel(conn *pb.Connection, browserCmd, serviceAccount, serviceAccountFi
 
 	var tlsCfg *tls.Config
 	if proxyURL.Scheme == "https" {
-		tlsCfg, err = getTLSConfig(conn)
+		opts, err := getTLSOptions(conn)
+		if err != nil {
+			return nil, "", fmt.Errorf("tls: %w", err)
+		}
+		if setTLSOptions != nil {
+			setTLSOptions(opts)
+		}
+		tlsCfg, err = tlsutil.NewConfig(opts)
 		if err != nil {
 			return nil, "", fmt.Errorf("tls: %w", err)
 		}
@@ -40,14 +60,56 @@ func newTunnel(conn *pb.Connection, browserCmd, serviceAccount, serviceAccountFi
 
 	return tunnel.New(
 		tunnel.WithDestinationHost(destinationAddr),
+		tunnel.WithKeepAlive(conn.GetKeepAlive().AsDuration()),
 		tunnel.WithProxyHost(proxyURL.Host),
 		tunnel.WithServiceAccount(serviceAccount),
 		tunnel.WithServiceAccountFile(serviceAccountFile),
 		tunnel.WithTLSConfig(tlsCfg),
 		tunnel.WithBrowserCommand(browserCmd),
+		tunnel.WithUDPSettings(getUDPSettings(conn.GetUdpSettings())),
 	), listenAddr, nil
 }
 
+// getAuthOptions returns the browser command and service account of the
+// connection, or the given defaults for those it does not override.
+func getAuthOptions(
+	conn *pb.Connection,
+	browserCmd, serviceAccount, serviceAccountFile string,
+) (string, string, string) {
+	if conn.BrowserCmd != nil {
+		browserCmd = conn.GetBrowserCmd()
+	}
+	// the service account takes precedence over the file, so both are replaced
+	if conn.ServiceAccount != nil || conn.ServiceAccountFile != nil {
+		serviceAccount, serviceAccountFile = conn.GetServiceAccount(), conn.GetServiceAccountFile()
+	}
+	return browserCmd, serviceAccount, serviceAccountFile
+}
+
+var errUntrustedAuthOverride = errors.New(
+	"browser_cmd and service_account_file can only be set when the api requires authentication")
+
+// checkAuthOverrides rejects the overrides of the connection that run a command
+// or read a file, unless the clients of the api are trusted.
+func checkAuthOverrides(conn *pb.Connection, trusted bool) error {
+	if !trusted && (conn.BrowserCmd != nil || conn.ServiceAccountFile != nil) {
+		return errUntrustedAuthOverride
+	}
+	return nil
+}
+
+func getUDPSettings(s *pb.UdpSettings) tunnel.UDPSettings {
+	var settings tunnel.UDPSettings
+	if s.GetSessionTimeout() != nil {
+		settings.SessionTimeout = s.GetSessionTimeout().AsDuration()
+	}
+	settings.MaxSessions = int(s.GetMaxSessions())
+	settings.MaxPacketSize = int(s.GetMaxPacketSize())
+	settings.ReadBufferSize = int(s.GetReadBufferSize())
+	settings.WriteBufferSize = int(s.GetWriteBufferSize())
+	return settings
+}
+
 func getProxy(conn *pb.Connection) (*url.URL, error) {
 	host, _, err := net.SplitHostPort(conn.GetRemoteAddr())
 	if err != nil {
@@ -82,10 +144,24 @@ func getProxy(conn *pb.Connection) (*url.URL, error) {
 	return u, nil
 }
 
-func tunnelAcceptLoop(ctx context.Context, id string, li net.Listener, tun Tunnel, b EventBroadcaster) {
-	evt := &tunnelEvents{EventBroadcaster: b, id: id}
+// tunnelAcceptLoop serves client connections until the context is canceled.
+// If maxConns is positive, connections beyond that many are rejected.
+func tunnelAcceptLoop(
+	ctx context.Context,
+	id string,
+	li net.Listener,
+	tun Tunnel,
+	b EventBroadcaster,
+	usage *usageStats,
+	traffic *recordTraffic,
+	idle *idleTimer,
+	maxConns int,
+) {
+	evt := &tunnelEvents{EventBroadcaster: b, usage: usage, id: id}
 	evt.onListening(ctx)
 
+	var active atomic.Int64
+
 	bo := backoff.NewExponentialBackOff()
 	bo.MaxElapsedTime = 0
 
@@ -110,22 +186,39 @@ func tunnelAcceptLoop(ctx context.Context, id string, li net.Listener, tun Tunne
 		}
 		bo.Reset()
 
+		if n := active.Add(1); maxConns > 0 && n > int64(maxConns) {
+			active.Add(-1)
+			evt.withPeer(c).onRejected(ctx, fmt.Errorf("connection limit of %d reached", maxConns))
+			_ = c.Close()
+			continue
+		}
+		idle.acquire()
+		traffic.connOpened()
+
 		go func(conn net.Conn) {
+			defer active.Add(-1)
+			defer idle.release()
+			defer traffic.connClosed()
 			defer func() { _ = conn.Close() }()
 
 			cEvt := evt.withPeer(conn)
-			err := tun.Run(ctx, conn, cEvt)
+			cc := &countingConn{Conn: conn, traffic: traffic}
+			err := tun.Run(ctx, cc, cEvt)
 			if err != nil {
 				log.Ctx(ctx).Error().Err(err).Msg("error serving local connection")
 			}
+			if err := usage.addBytes(id, cc.read.Load(), cc.written.Load()); err != nil {
+				log.Ctx(ctx).Error().Err(err).Msg("failed to update usage stats")
+			}
 		}(c)
 	}
 }
 
 type tunnelEvents struct {
 	EventBroadcaster
-	id   string
-	peer *string
+	usage *usageStats
+	id    string
+	peer  *string
 }
 
 func (evt *tunnelEvents) withPeer(conn net.Conn) *tunnelEvents {
@@ -160,6 +253,27 @@ func (evt *tunnelEvents) onTunnelClosed() {
 	})
 }
 
+func (evt *tunnelEvents) onRejected(ctx context.Context, err error) {
+	log.Ctx(ctx).Warn().Err(err).Msg("rejected local connection")
+	evt.update(ctx, &pb.ConnectionStatusUpdate{
+		Status:    pb.ConnectionStatusUpdate_CONNECTION_STATUS_DISCONNECTED,
+		LastError: proto.String(err.Error()),
+	})
+}
+
+// udpTrafficEvents counts the sessions and traffic of a UDP listener
+type udpTrafficEvents struct {
+	*tunnelEvents
+	traffic *recordTraffic
+}
+
+var _ tunnel.TrafficCounter = udpTrafficEvents{}
+
+func (evt udpTrafficEvents) OnSessionStarted() { evt.traffic.connOpened() }
+func (evt udpTrafficEvents) OnSessionStopped() { evt.traffic.connClosed() }
+func (evt udpTrafficEvents) OnSent(n int)      { evt.traffic.sent.Add(uint64(n)) }
+func (evt udpTrafficEvents) OnReceived(n int)  { evt.traffic.received.Add(uint64(n)) }
+
 // OnConnecting is called when listener is accepting a new connection from client
 func (evt *tunnelEvents) OnConnecting(ctx context.Context) {
 	log.Ctx(ctx).Info().Msg("connecting")
@@ -170,10 +284,25 @@ func (evt *tunnelEvents) OnConnecting(ctx context.Context) {
 
 // OnConnected is called when a connection is successfully
 // established to the remote destination via pomerium proxy
-func (evt *tunnelEvents) OnConnected(ctx context.Context) {
-	log.Ctx(ctx).Info().Msg("connected")
+func (evt *tunnelEvents) OnConnected(ctx context.Context, info tunnel.ConnectionInfo) {
+	log.Ctx(ctx).Info().
+		Str("protocol", string(info.Protocol)).
+		Str("proxy-ip", info.ProxyIP).
+		Dur("time-to-connect", info.TimeToConnect).
+		Msg("connected")
+	if err := evt.usage.onConnected(evt.id); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("failed to update usage stats")
+	}
 	evt.update(ctx, &pb.ConnectionStatusUpdate{
 		Status: pb.ConnectionStatusUpdate_CONNECTION_STATUS_CONNECTED,
+		Details: &pb.ConnectionDetails{
+			Protocol:       string(info.Protocol),
+			Alpn:           info.ALPN,
+			ProxyIp:        info.ProxyIP,
+			TlsVersion:     info.TLSVersionName(),
+			TlsCipherSuite: info.CipherSuiteName(),
+			TimeToConnect:  durationpb.New(info.TimeToConnect),
+		},
 	})
 }
 
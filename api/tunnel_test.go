@@ -1,12 +1,19 @@
 package api
 
 import (
+	"context"
+	"io"
+	"net"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
 
 	pb "github.com/pomerium/cli/proto"
+	"github.com/pomerium/cli/tunnel"
 )
 
 func TestGetProxy(t *testing.T) {
@@ -100,3 +107,108 @@ func TestGetProxy(t *testing.T) {
 		}
 	}
 }
+
+func TestGetUDPSettings(t *testing.T) {
+	assert.Equal(t, tunnel.UDPSettings{}, getUDPSettings(nil))
+	assert.Equal(t, tunnel.UDPSettings{
+		SessionTimeout:  time.Minute,
+		MaxSessions:     10,
+		MaxPacketSize:   1500,
+		ReadBufferSize:  1 << 20,
+		WriteBufferSize: 2 << 20,
+	}, getUDPSettings(&pb.UdpSettings{
+		SessionTimeout:  durationpb.New(time.Minute),
+		MaxSessions:     proto.Uint32(10),
+		MaxPacketSize:   proto.Uint32(1500),
+		ReadBufferSize:  proto.Uint32(1 << 20),
+		WriteBufferSize: proto.Uint32(2 << 20),
+	}))
+}
+
+func TestGetAuthOptions(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		conn     *pb.Connection
+		expected [3]string
+	}{
+		{"defaults", &pb.Connection{}, [3]string{"browser", "sa", "sa.jwt"}},
+		{"browser", &pb.Connection{BrowserCmd: proto.String("firefox")}, [3]string{"firefox", "sa", "sa.jwt"}},
+		{"service account", &pb.Connection{ServiceAccount: proto.String("ci")}, [3]string{"browser", "ci", ""}},
+		{"service account file", &pb.Connection{ServiceAccountFile: proto.String("ci.jwt")}, [3]string{"browser", "", "ci.jwt"}},
+		{"interactive", &pb.Connection{ServiceAccount: proto.String("")}, [3]string{"browser", "", ""}},
+	} {
+		browserCmd, serviceAccount, serviceAccountFile := getAuthOptions(tc.conn, "browser", "sa", "sa.jwt")
+		assert.Equal(t, tc.expected, [3]string{browserCmd, serviceAccount, serviceAccountFile}, tc.name)
+	}
+}
+
+type blockingTunnel struct {
+	started chan struct{}
+}
+
+func (t blockingTunnel) Run(ctx context.Context, _ io.ReadWriter, _ tunnel.EventSink) error {
+	t.started <- struct{}{}
+	<-ctx.Done()
+	return nil
+}
+
+func (t blockingTunnel) RunUDPSessionManager(context.Context, *net.UDPConn, tunnel.EventSink) error {
+	return nil
+}
+
+func (t blockingTunnel) Close() error {
+	return nil
+}
+
+func TestTunnelAcceptLoopMaxConnections(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	li, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go onContextCancel(ctx, li)
+
+	b := NewEventsBroadcaster(ctx)
+	updates, err := b.Subscribe(ctx, "a")
+	require.NoError(t, err)
+	rejected := make(chan *pb.ConnectionStatusUpdate, 1)
+	go func() {
+		for upd := range updates {
+			if upd.LastError != nil {
+				rejected <- upd
+			}
+		}
+	}()
+	usage, err := loadUsageStats(new(MemCP))
+	require.NoError(t, err)
+
+	tun := blockingTunnel{started: make(chan struct{}, 2)}
+	go tunnelAcceptLoop(ctx, "a", li, tun, b, usage, new(recordTraffic), nil, 1)
+
+	c1, err := net.Dial("tcp", li.Addr().String())
+	require.NoError(t, err)
+	defer func() { _ = c1.Close() }()
+	select {
+	case <-tun.started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("first connection should be served")
+	}
+
+	c2, err := net.Dial("tcp", li.Addr().String())
+	require.NoError(t, err)
+	defer func() { _ = c2.Close() }()
+	_ = c2.SetReadDeadline(time.Now().Add(5 * time.Second))
+	_, err = c2.Read(make([]byte, 1))
+	assert.ErrorIs(t, err, io.EOF, "second connection should be rejected")
+
+	select {
+	case upd := <-rejected:
+		assert.Contains(t, upd.GetLastError(), "connection limit of 1 reached")
+		assert.Equal(t, pb.ConnectionStatusUpdate_CONNECTION_STATUS_DISCONNECTED, upd.GetStatus())
+	case <-time.After(5 * time.Second):
+		t.Fatal("rejection should be reported as a status update")
+	}
+	assert.Len(t, tun.started, 0)
+}
@@ -0,0 +1,165 @@
+package api
+
+import (
+	"fmt"
+	"net"
+	"sync"
+	"sync/atomic"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/pomerium/cli/proto"
+)
+
+// usageStats keeps track of per-record connection usage
+type usageStats struct {
+	sync.Mutex
+	ConfigProvider
+	byID map[string]*pb.UsageStats
+}
+
+// WithUsageStatsProvider customizes usage statistics persistence
+func WithUsageStatsProvider(cp ConfigProvider) ServerOption {
+	return func(s *server) error {
+		u, err := loadUsageStats(cp)
+		if err != nil {
+			return fmt.Errorf("usage stats: %w", err)
+		}
+		s.usage = u
+		return nil
+	}
+}
+
+func withDefaultUsageStatsProvider() ServerOption {
+	return func(s *server) error {
+		if s.usage == nil {
+			return WithUsageStatsProvider(new(MemCP))(s)
+		}
+		return nil
+	}
+}
+
+func loadUsageStats(cp ConfigProvider) (*usageStats, error) {
+	u := &usageStats{
+		ConfigProvider: cp,
+		byID:           make(map[string]*pb.UsageStats),
+	}
+
+	data, err := cp.Load()
+	if err != nil {
+		return nil, fmt.Errorf("load: %w", err)
+	}
+	if len(data) == 0 {
+		return u, nil
+	}
+
+	stats := new(pb.UsageStatsByID)
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(data, stats); err != nil {
+		return nil, fmt.Errorf("unmarshal: %w", err)
+	}
+	for id, st := range stats.GetStats() {
+		u.byID[id] = st
+	}
+	return u, nil
+}
+
+// get returns a copy of the usage statistics of the record, or nil if it was never used
+func (u *usageStats) get(id string) *pb.UsageStats {
+	u.Lock()
+	defer u.Unlock()
+
+	st, ok := u.byID[id]
+	if !ok {
+		return nil
+	}
+	return proto.Clone(st).(*pb.UsageStats)
+}
+
+func (u *usageStats) onConnected(id string) error {
+	u.Lock()
+	defer u.Unlock()
+
+	st := u.statsLocked(id)
+	st.LastConnected = timestamppb.Now()
+	st.TotalSessions++
+	return u.saveLocked()
+}
+
+func (u *usageStats) addBytes(id string, sent, received uint64) error {
+	if sent == 0 && received == 0 {
+		return nil
+	}
+
+	u.Lock()
+	defer u.Unlock()
+
+	st := u.statsLocked(id)
+	st.BytesSent += sent
+	st.BytesReceived += received
+	return u.saveLocked()
+}
+
+func (u *usageStats) delete(ids ...string) error {
+	u.Lock()
+	defer u.Unlock()
+
+	for _, id := range ids {
+		delete(u.byID, id)
+	}
+	return u.saveLocked()
+}
+
+func (u *usageStats) statsLocked(id string) *pb.UsageStats {
+	st, ok := u.byID[id]
+	if !ok {
+		st = new(pb.UsageStats)
+		u.byID[id] = st
+	}
+	return st
+}
+
+func (u *usageStats) saveLocked() error {
+	data, err := protojson.Marshal(&pb.UsageStatsByID{Stats: u.byID})
+	if err != nil {
+		return fmt.Errorf("marshal: %w", err)
+	}
+	if err := u.Save(data); err != nil {
+		return fmt.Errorf("save: %w", err)
+	}
+	return nil
+}
+
+// withUsage returns copies of the records with usage statistics set
+func (u *usageStats) withUsage(records []*pb.Record) []*pb.Record {
+	out := make([]*pb.Record, 0, len(records))
+	for _, r := range records {
+		r = proto.Clone(r).(*pb.Record)
+		r.Usage = u.get(r.GetId())
+		out = append(out, r)
+	}
+	return out
+}
+
+// countingConn counts bytes read from and written to the local client, for
+// the usage statistics when it is closed and live in the record traffic
+type countingConn struct {
+	net.Conn
+	read, written atomic.Uint64
+	traffic       *recordTraffic
+}
+
+func (c *countingConn) Read(b []byte) (int, error) {
+	n, err := c.Conn.Read(b)
+	c.read.Add(uint64(n))
+	c.traffic.sent.Add(uint64(n))
+	return n, err
+}
+
+func (c *countingConn) Write(b []byte) (int, error) {
+	n, err := c.Conn.Write(b)
+	c.written.Add(uint64(n))
+	c.traffic.received.Add(uint64(n))
+	return n, err
+}
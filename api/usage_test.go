@@ -0,0 +1,49 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/pomerium/cli/proto"
+)
+
+func TestUsageStats(t *testing.T) {
+	t.Parallel()
+
+	cp := new(MemCP)
+	u, err := loadUsageStats(cp)
+	require.NoError(t, err)
+	assert.Nil(t, u.get("a"))
+
+	require.NoError(t, u.onConnected("a"))
+	require.NoError(t, u.onConnected("a"))
+	require.NoError(t, u.addBytes("a", 10, 20))
+	require.NoError(t, u.addBytes("a", 1, 2))
+	require.NoError(t, u.onConnected("b"))
+
+	// reload from the persisted data
+	u, err = loadUsageStats(cp)
+	require.NoError(t, err)
+
+	st := u.get("a")
+	require.NotNil(t, st)
+	assert.NotNil(t, st.LastConnected)
+	assert.Equal(t, uint64(2), st.TotalSessions)
+	assert.Equal(t, uint64(11), st.BytesSent)
+	assert.Equal(t, uint64(22), st.BytesReceived)
+
+	recs := []*pb.Record{{Id: proto.String("a")}, {Id: proto.String("c")}}
+	out := u.withUsage(recs)
+	assert.Equal(t, uint64(2), out[0].GetUsage().GetTotalSessions())
+	assert.Nil(t, out[1].Usage)
+	assert.Nil(t, recs[0].Usage, "should not modify stored records")
+
+	require.NoError(t, u.delete("a"))
+	u, err = loadUsageStats(cp)
+	require.NoError(t, err)
+	assert.Nil(t, u.get("a"))
+	assert.Equal(t, uint64(1), u.get("b").GetTotalSessions())
+}
@@ -11,9 +11,12 @@ import (
 	"os"
 	"strings"
 
+	"github.com/rs/zerolog/log"
 	"golang.org/x/sync/errgroup"
 
 	"github.com/pomerium/cli/internal/httputil"
+	"github.com/pomerium/cli/internal/metrics"
+	"github.com/pomerium/cli/jwt"
 )
 
 // An AuthClient retrieves an authentication JWT via the Pomerium login API.
@@ -41,12 +44,26 @@ func (client *AuthClient) CheckBearerToken(ctx context.Context, serverURL *url.U
 	req.Header.Set("Accept", "application/json")
 	req.Header.Set("Authorization", "Bearer "+bearerToken)
 
-	_, err = httputil.Fetch(ctx, client.cfg.tlsConfig, req)
+	_, err = httputil.Fetch(ctx, client.cfg.tlsConfig, client.cfg.proxyURL, client.cfg.dialContext, req)
 	return err
 }
 
-// GetJWT retrieves a JWT from Pomerium.
-func (client *AuthClient) GetJWT(ctx context.Context, serverURL *url.URL, onOpenBrowser func(string)) (rawJWT string, err error) {
+// GetJWT retrieves a JWT from Pomerium. If an expected user is configured,
+// a JWT issued for any other user results in jwt.ErrUnexpectedUser.
+func (client *AuthClient) GetJWT(ctx context.Context, serverURL *url.URL, onOpenBrowser func(string)) (string, error) {
+	rawJWT, err := client.getJWT(ctx, serverURL, onOpenBrowser)
+	metrics.AuthRefreshed(err)
+	if err != nil {
+		return "", err
+	}
+
+	if err := jwt.CheckUser(rawJWT, client.cfg.expectedUser); err != nil {
+		return "", fmt.Errorf("%w (sign out of the identity provider in your browser and try again)", err)
+	}
+	return rawJWT, nil
+}
+
+func (client *AuthClient) getJWT(ctx context.Context, serverURL *url.URL, onOpenBrowser func(string)) (rawJWT string, err error) {
 	if client.cfg.serviceAccount != "" {
 		return client.cfg.serviceAccount, nil
 	}
@@ -59,7 +76,11 @@ func (client *AuthClient) GetJWT(ctx context.Context, serverURL *url.URL, onOpen
 		return strings.TrimSpace(string(rawJWTBytes)), nil
 	}
 
-	li, err := net.Listen("tcp", "127.0.0.1:0")
+	if client.cfg.noBrowserInput != nil {
+		return client.getJWTWithoutBrowser(ctx, serverURL, onOpenBrowser)
+	}
+
+	li, err := client.listenCallback()
 	if err != nil {
 		return "", fmt.Errorf("failed to start listener: %w", err)
 	}
@@ -96,12 +117,17 @@ func (client *AuthClient) runHTTPServer(ctx context.Context, li net.Listener, in
 			return ctx
 		},
 		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			jwt := r.FormValue("pomerium_jwt")
-			if jwt == "" {
+			if client.cfg.callbackPath != "" && r.URL.Path != client.cfg.callbackPath {
+				http.Error(w, "not found", http.StatusNotFound)
+				return
+			}
+
+			rawJWT := r.FormValue("pomerium_jwt")
+			if rawJWT == "" {
 				http.Error(w, "not found", http.StatusNotFound)
 				return
 			}
-			incomingJWT <- jwt
+			incomingJWT <- rawJWT
 
 			w.Header().Set("Content-Type", "text/plain")
 			_, _ = io.WriteString(w, "login complete, you may close this page")
@@ -122,32 +148,47 @@ func (client *AuthClient) runHTTPServer(ctx context.Context, li net.Listener, in
 }
 
 func (client *AuthClient) runOpenBrowser(ctx context.Context, li net.Listener, serverURL *url.URL, onOpenBrowser func(string)) error {
+	loginURL, err := client.getLoginURL(ctx, serverURL, client.callbackURL(li))
+	if err != nil {
+		return err
+	}
+
+	onOpenBrowser(loginURL)
+	err = client.cfg.open(loginURL)
+	if err != nil {
+		// the login can still be completed by visiting the URL manually
+		log.Ctx(ctx).Debug().Err(err).Msg("failed to open browser")
+		_, _ = fmt.Fprintf(os.Stderr, "Unable to open a browser, please visit:\n\n%s\n\n", loginURL)
+		client.printQRCode(ctx, loginURL)
+		return nil
+	}
+
+	_, _ = fmt.Fprintf(os.Stderr, "Your browser has been opened to visit:\n\n%s\n\n", loginURL)
+	client.printQRCode(ctx, loginURL)
+	return nil
+}
+
+// getLoginURL returns the URL to visit to log in, after which Pomerium
+// redirects to the callback URL with the JWT in the pomerium_jwt parameter.
+func (client *AuthClient) getLoginURL(ctx context.Context, serverURL *url.URL, callbackURL string) (string, error) {
 	browserURL := getBrowserURL(serverURL)
 	dst := browserURL.ResolveReference(&url.URL{
 		Path: "/.pomerium/api/v1/login",
 		RawQuery: url.Values{
-			"pomerium_redirect_uri": {fmt.Sprintf("http://%s", li.Addr().String())},
+			"pomerium_redirect_uri": {callbackURL},
 		}.Encode(),
 	})
 
 	req, err := http.NewRequest("GET", dst.String(), nil)
 	if err != nil {
-		return err
-	}
-
-	bs, err := httputil.Fetch(ctx, client.cfg.tlsConfig, req)
-	if err != nil {
-		return err
+		return "", err
 	}
 
-	onOpenBrowser(string(bs))
-	err = client.cfg.open(string(bs))
+	bs, err := httputil.Fetch(ctx, client.cfg.tlsConfig, client.cfg.proxyURL, client.cfg.dialContext, req)
 	if err != nil {
-		return fmt.Errorf("failed to open browser url: %w", err)
+		return "", err
 	}
-
-	_, _ = fmt.Fprintf(os.Stderr, "Your browser has been opened to visit:\n\n%s\n\n", string(bs))
-	return nil
+	return string(bs), nil
 }
 
 func getBrowserURL(serverURL *url.URL) *url.URL {
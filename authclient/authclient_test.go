@@ -2,11 +2,13 @@ package authclient
 
 import (
 	"context"
+	"errors"
 	"net"
 	"net/http"
 	"net/url"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -77,6 +79,47 @@ func TestAuthClient(t *testing.T) {
 		assert.Equal(t, "TEST", rawJWT)
 	})
 
+	t.Run("no browser", func(t *testing.T) {
+		t.Parallel()
+
+		li, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		t.Cleanup(func() { li.Close() })
+
+		go func() {
+			h := chi.NewMux()
+			h.Get("/.pomerium/api/v1/login", func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "http://127.0.0.1:8800/callback", r.FormValue("pomerium_redirect_uri"))
+				_, _ = w.Write([]byte("https://example.com/login"))
+			})
+			srv := &http.Server{
+				BaseContext: func(li net.Listener) context.Context {
+					return ctx
+				},
+				Handler: h,
+			}
+			_ = srv.Serve(li)
+		}()
+
+		ac := New(
+			WithNoBrowser(true),
+			WithCallbackPath("/callback"),
+			WithCallbackPortRange(PortRange{First: 8800, Last: 8810}))
+		ac.cfg.open = func(_ string) error {
+			return errors.New("unexpected browser")
+		}
+		ac.cfg.noBrowserInput = strings.NewReader("http://127.0.0.1:8800/callback?pomerium_jwt=TEST&x=y\n")
+
+		var loginURL string
+		rawJWT, err := ac.GetJWT(ctx, &url.URL{
+			Scheme: "http",
+			Host:   li.Addr().String(),
+		}, func(rawURL string) { loginURL = rawURL })
+		assert.NoError(t, err)
+		assert.Equal(t, "TEST", rawJWT)
+		assert.Equal(t, "https://example.com/login", loginURL)
+	})
+
 	t.Run("service account", func(t *testing.T) {
 		t.Parallel()
 
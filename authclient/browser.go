@@ -0,0 +1,126 @@
+package authclient
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/skratchdot/open-golang/open"
+)
+
+// urlPlaceholders are replaced with the URL in browser commands. {url} is
+// used by --browser-cmd, %s by the BROWSER environment variable convention.
+var urlPlaceholders = []string{"{url}", "%s"}
+
+// fallbackBrowsers are tried on platforms without a single system opener
+// when neither BROWSER nor xdg-open work, i.e. on minimal Linux desktops.
+var fallbackBrowsers = []string{
+	"sensible-browser",
+	"x-www-browser",
+	"www-browser",
+	"firefox",
+	"google-chrome",
+	"chromium",
+	"chromium-browser",
+}
+
+// openURL opens the URL using the browser command if given. Otherwise, the
+// commands in the BROWSER environment variable, the Windows host browser when
+// running in WSL, the system opener and the fallback browsers are tried in
+// order until one of them succeeds.
+func openURL(rawURL, browserCommand string) error {
+	if browserCommand != "" {
+		if hasURLPlaceholder(browserCommand) {
+			return startBrowserCommand(expandBrowserCommand(browserCommand, rawURL))
+		}
+		return open.RunWith(rawURL, browserCommand)
+	}
+
+	var errs []error
+	for _, launch := range defaultBrowserLaunchers() {
+		err := launch(rawURL)
+		if err == nil {
+			return nil
+		}
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+func defaultBrowserLaunchers() []func(rawURL string) error {
+	var launchers []func(rawURL string) error
+	for _, command := range filepath.SplitList(os.Getenv("BROWSER")) {
+		if strings.TrimSpace(command) == "" {
+			continue
+		}
+		launchers = append(launchers, func(rawURL string) error {
+			return startBrowserCommand(expandBrowserCommand(command, rawURL))
+		})
+	}
+
+	if isWSL() {
+		launchers = append(launchers, wslBrowserLaunchers()...)
+	}
+	launchers = append(launchers, open.Run)
+
+	switch runtime.GOOS {
+	case "darwin", "windows":
+	default:
+		for _, browser := range fallbackBrowsers {
+			launchers = append(launchers, func(rawURL string) error {
+				return startBrowserCommand([]string{browser, rawURL})
+			})
+		}
+	}
+	return launchers
+}
+
+func hasURLPlaceholder(command string) bool {
+	for _, p := range urlPlaceholders {
+		if strings.Contains(command, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// expandBrowserCommand splits the command into arguments and replaces URL
+// placeholders with the URL. Without a placeholder, the URL is appended.
+func expandBrowserCommand(command, rawURL string) []string {
+	args := strings.Fields(command)
+	if !hasURLPlaceholder(command) {
+		return append(args, rawURL)
+	}
+
+	for i, arg := range args {
+		for _, p := range urlPlaceholders {
+			arg = strings.ReplaceAll(arg, p, rawURL)
+		}
+		args[i] = arg
+	}
+	return args
+}
+
+// startBrowserCommand starts the browser without waiting for it to exit,
+// as the browser may keep running for the rest of the session.
+func startBrowserCommand(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("empty browser command")
+	}
+
+	path, err := exec.LookPath(args[0])
+	if err != nil {
+		return err
+	}
+
+	cmd := exec.Command(path, args[1:]...) //nolint: gosec
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("%s: %w", args[0], err)
+	}
+	go func() { _ = cmd.Wait() }()
+	return nil
+}
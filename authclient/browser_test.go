@@ -0,0 +1,39 @@
+package authclient
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpandBrowserCommand(t *testing.T) {
+	t.Parallel()
+
+	const u = "https://example.com/login?a=b"
+	for _, tc := range []struct {
+		command string
+		expect  []string
+	}{
+		{"firefox", []string{"firefox", u}},
+		{"firefox --new-window", []string{"firefox", "--new-window", u}},
+		{"chrome --app={url} --incognito", []string{"chrome", "--app=" + u, "--incognito"}},
+		{"w3m %s", []string{"w3m", u}},
+	} {
+		assert.Equal(t, tc.expect, expandBrowserCommand(tc.command, u), tc.command)
+	}
+}
+
+func TestOpenURLFallback(t *testing.T) {
+	t.Setenv("BROWSER", "pomerium-cli-nonexistent-browser")
+	t.Setenv("PATH", t.TempDir())
+
+	assert.Error(t, openURL("https://example.com", ""))
+	assert.Error(t, openURL("https://example.com", "pomerium-cli-nonexistent-browser {url}"))
+}
+
+func TestPowershellQuote(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, `'https://example.com/?a=b&c=d'`, powershellQuote("https://example.com/?a=b&c=d"))
+	assert.Equal(t, `'https://example.com/it''s'`, powershellQuote("https://example.com/it's"))
+}
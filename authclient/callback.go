@@ -0,0 +1,96 @@
+package authclient
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// A PortRange is an inclusive range of TCP ports for the login callback
+// listener. The zero value selects a random port.
+type PortRange struct {
+	First, Last int
+}
+
+// ParsePortRange parses a single port (8800) or a port range (8800-8810).
+func ParsePortRange(s string) (PortRange, error) {
+	if s == "" {
+		return PortRange{}, nil
+	}
+
+	first, last, isRange := strings.Cut(s, "-")
+	if !isRange {
+		last = first
+	}
+
+	var r PortRange
+	var err error
+	if r.First, err = strconv.Atoi(strings.TrimSpace(first)); err != nil {
+		return PortRange{}, fmt.Errorf("invalid port range %q: %w", s, err)
+	}
+	if r.Last, err = strconv.Atoi(strings.TrimSpace(last)); err != nil {
+		return PortRange{}, fmt.Errorf("invalid port range %q: %w", s, err)
+	}
+	if r.First < 1 || r.Last > 65535 || r.First > r.Last {
+		return PortRange{}, fmt.Errorf("invalid port range %q", s)
+	}
+	return r, nil
+}
+
+func (r PortRange) String() string {
+	if r.First == r.Last {
+		return strconv.Itoa(r.First)
+	}
+	return fmt.Sprintf("%d-%d", r.First, r.Last)
+}
+
+// listenCallback starts the login callback listener on the configured
+// redirect address, or on the first free port of the configured range, or on
+// a random port if neither is configured.
+func (client *AuthClient) listenCallback() (net.Listener, error) {
+	if client.cfg.redirectAddr != "" {
+		addr, err := client.redirectAddr(callbackListenHost())
+		if err != nil {
+			return nil, err
+		}
+		return net.Listen("tcp", addr)
+	}
+
+	host := callbackListenHost()
+	r := client.cfg.callbackPorts
+	if r == (PortRange{}) {
+		return net.Listen("tcp", net.JoinHostPort(host, "0"))
+	}
+
+	var err error
+	for port := r.First; port <= r.Last; port++ {
+		var li net.Listener
+		li, err = net.Listen("tcp", net.JoinHostPort(host, strconv.Itoa(port)))
+		if err == nil {
+			return li, nil
+		}
+	}
+	return nil, fmt.Errorf("no free callback port in %s: %w", r, err)
+}
+
+// redirectAddr returns the configured redirect address, with the host
+// defaulting to the given one.
+func (client *AuthClient) redirectAddr(defaultHost string) (string, error) {
+	host, port, err := net.SplitHostPort(client.cfg.redirectAddr)
+	if err != nil {
+		return "", fmt.Errorf("invalid callback address %q: %w", client.cfg.redirectAddr, err)
+	}
+	if p, err := strconv.Atoi(port); err != nil || p < 1 || p > 65535 {
+		return "", fmt.Errorf("invalid callback address %q: invalid port", client.cfg.redirectAddr)
+	}
+	if host == "" {
+		host = defaultHost
+	}
+	return net.JoinHostPort(host, port), nil
+}
+
+// callbackURL returns the URL Pomerium redirects to after login.
+func (client *AuthClient) callbackURL(li net.Listener) string {
+	return "http://" + li.Addr().String() + client.cfg.callbackPath
+}
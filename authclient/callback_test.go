@@ -0,0 +1,84 @@
+package authclient
+
+import (
+	"net"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePortRange(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		in     string
+		expect PortRange
+		err    bool
+	}{
+		{"", PortRange{}, false},
+		{"8800", PortRange{8800, 8800}, false},
+		{"8800-8810", PortRange{8800, 8810}, false},
+		{"8810-8800", PortRange{}, true},
+		{"0", PortRange{}, true},
+		{"8800-70000", PortRange{}, true},
+		{"http", PortRange{}, true},
+	} {
+		r, err := ParsePortRange(tc.in)
+		if tc.err {
+			assert.Error(t, err, tc.in)
+			continue
+		}
+		assert.NoError(t, err, tc.in)
+		assert.Equal(t, tc.expect, r, tc.in)
+	}
+}
+
+func TestListenCallback(t *testing.T) {
+	t.Parallel()
+
+	busy, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = busy.Close() })
+	port := busy.Addr().(*net.TCPAddr).Port
+
+	// the first port of the range is taken, so the next one is used
+	ac := New(WithCallbackPortRange(PortRange{port, port + 1}), WithCallbackPath("callback"))
+	li, err := ac.listenCallback()
+	if err != nil {
+		t.Skipf("port %d is not available: %v", port+1, err)
+	}
+	t.Cleanup(func() { _ = li.Close() })
+	assert.Equal(t, "http://127.0.0.1:"+strconv.Itoa(port+1)+"/callback", ac.callbackURL(li))
+
+	ac = New(WithCallbackPortRange(PortRange{port, port}))
+	_, err = ac.listenCallback()
+	assert.Error(t, err)
+}
+
+func TestRedirectAddress(t *testing.T) {
+	t.Parallel()
+
+	free, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := free.Addr().String()
+	require.NoError(t, free.Close())
+
+	ac := New(WithRedirectAddress(addr), WithCallbackPath("callback"))
+	li, err := ac.listenCallback()
+	if err != nil {
+		t.Skipf("%s is not available: %v", addr, err)
+	}
+	t.Cleanup(func() { _ = li.Close() })
+	assert.Equal(t, "http://"+addr+"/callback", ac.callbackURL(li))
+
+	callbackURL, err := New(WithRedirectAddress(":18000")).manualCallbackURL()
+	require.NoError(t, err)
+	assert.Equal(t, "http://127.0.0.1:18000", callbackURL)
+
+	for _, addr := range []string{"127.0.0.1", "127.0.0.1:http", ":0"} {
+		_, err = New(WithRedirectAddress(addr)).listenCallback()
+		assert.Error(t, err, addr)
+	}
+}
@@ -2,12 +2,24 @@ package authclient
 
 import (
 	"crypto/tls"
+	"io"
+	"net/url"
+	"os"
+	"strings"
 
-	"github.com/skratchdot/open-golang/open"
+	"github.com/pomerium/cli/internal/httputil"
 )
 
 type config struct {
 	open               func(rawURL string) error
+	callbackPath       string
+	callbackPorts      PortRange
+	dialContext        httputil.DialContextFunc
+	expectedUser       string
+	noBrowserInput     io.Reader
+	proxyURL           *url.URL
+	qrCode             bool
+	redirectAddr       string
 	serviceAccount     string
 	serviceAccountFile string
 	tlsConfig          *tls.Config
@@ -26,18 +38,89 @@ func getConfig(options ...Option) *config {
 type Option func(*config)
 
 // WithBrowserCommand returns an option to configure the browser command.
+// The command may contain a {url} placeholder, otherwise the URL is passed
+// as the last argument. If empty, a chain of default browsers is tried.
 func WithBrowserCommand(browserCommand string) Option {
 	return func(cfg *config) {
-		if browserCommand == "" {
-			cfg.open = open.Run
-		} else {
-			cfg.open = func(rawURL string) error {
-				return open.RunWith(rawURL, browserCommand)
-			}
+		cfg.open = func(rawURL string) error {
+			return openURL(rawURL, browserCommand)
 		}
 	}
 }
 
+// WithCallbackPath returns an option to configure the URL path of the login callback.
+func WithCallbackPath(callbackPath string) Option {
+	return func(cfg *config) {
+		if callbackPath != "" && !strings.HasPrefix(callbackPath, "/") {
+			callbackPath = "/" + callbackPath
+		}
+		cfg.callbackPath = callbackPath
+	}
+}
+
+// WithCallbackPortRange returns an option to configure the ports the login
+// callback listener may use.
+func WithCallbackPortRange(ports PortRange) Option {
+	return func(cfg *config) {
+		cfg.callbackPorts = ports
+	}
+}
+
+// WithExpectedUser returns an option to require that JWTs are issued for the
+// given user, identified by email or subject.
+func WithExpectedUser(expectedUser string) Option {
+	return func(cfg *config) {
+		cfg.expectedUser = expectedUser
+	}
+}
+
+// WithDialContext returns an option to connect to Pomerium using the given
+// dial function, i.e. through another Pomerium proxy.
+func WithDialContext(dial httputil.DialContextFunc) Option {
+	return func(cfg *config) {
+		cfg.dialContext = dial
+	}
+}
+
+// WithNoBrowser returns an option to log in without opening a browser or
+// running a callback listener: the login URL is printed, and the URL the
+// browser is redirected to after login, or the JWT it carries, is read from
+// stdin.
+func WithNoBrowser(enabled bool) Option {
+	return func(cfg *config) {
+		cfg.noBrowserInput = nil
+		if enabled {
+			cfg.noBrowserInput = os.Stdin
+		}
+	}
+}
+
+// WithOutboundProxy returns an option to connect via an outbound HTTP proxy.
+func WithOutboundProxy(proxyURL *url.URL) Option {
+	return func(cfg *config) {
+		cfg.proxyURL = proxyURL
+	}
+}
+
+// WithQRCode returns an option to also render the login URL as a QR code on
+// stderr, so that it can be opened with a phone.
+func WithQRCode(enabled bool) Option {
+	return func(cfg *config) {
+		cfg.qrCode = enabled
+	}
+}
+
+// WithRedirectAddress returns an option to listen for the login callback on
+// the given host and port, i.e. 127.0.0.1:18000, so that the redirect URI can
+// be registered with an identity provider or allowed by a firewall. If the
+// host is empty, i.e. :18000, the default callback host is used. It takes
+// precedence over the callback port range.
+func WithRedirectAddress(addr string) Option {
+	return func(cfg *config) {
+		cfg.redirectAddr = addr
+	}
+}
+
 // WithServiceAccount sets the service account in the config.
 func WithServiceAccount(serviceAccount string) Option {
 	return func(cfg *config) {
@@ -0,0 +1,86 @@
+package authclient
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// getJWTWithoutBrowser logs in by printing the login URL and reading the JWT,
+// or the callback URL it was sent to, from the no browser input.
+func (client *AuthClient) getJWTWithoutBrowser(ctx context.Context, serverURL *url.URL, onOpenBrowser func(string)) (string, error) {
+	callbackURL, err := client.manualCallbackURL()
+	if err != nil {
+		return "", err
+	}
+	loginURL, err := client.getLoginURL(ctx, serverURL, callbackURL)
+	if err != nil {
+		return "", err
+	}
+
+	onOpenBrowser(loginURL)
+	_, _ = fmt.Fprintf(os.Stderr, "Please visit:\n\n%s\n\n", loginURL)
+	client.printQRCode(ctx, loginURL)
+	_, _ = fmt.Fprint(os.Stderr, "After logging in, the browser is redirected to a page that fails to load.\n"+
+		"Paste the URL of that page, or the value of its pomerium_jwt parameter, here: ")
+
+	type result struct {
+		line string
+		err  error
+	}
+	results := make(chan result, 1)
+	go func() {
+		line, err := bufio.NewReader(client.cfg.noBrowserInput).ReadString('\n')
+		if line != "" {
+			err = nil
+		}
+		results <- result{line, err}
+	}()
+
+	select {
+	case <-ctx.Done():
+		return "", context.Cause(ctx)
+	case res := <-results:
+		if res.err != nil {
+			return "", fmt.Errorf("failed to read the login result: %w", res.err)
+		}
+		return parseManualLoginInput(res.line)
+	}
+}
+
+// manualCallbackURL returns the callback URL to log in without a listener.
+// Nothing answers it, the user copies it from the browser instead.
+func (client *AuthClient) manualCallbackURL() (string, error) {
+	host := "127.0.0.1"
+	if client.cfg.redirectAddr != "" {
+		var err error
+		if host, err = client.redirectAddr(host); err != nil {
+			return "", err
+		}
+	} else if port := client.cfg.callbackPorts.First; port != 0 {
+		host = net.JoinHostPort(host, strconv.Itoa(port))
+	}
+	return "http://" + host + client.cfg.callbackPath, nil
+}
+
+// parseManualLoginInput returns the JWT from the pasted callback URL, or the
+// pasted JWT itself.
+func parseManualLoginInput(input string) (string, error) {
+	input = strings.TrimSpace(input)
+	if i := strings.Index(input, "pomerium_jwt="); i >= 0 {
+		q, err := url.ParseQuery(input[i:])
+		if err != nil {
+			return "", fmt.Errorf("invalid login result: %w", err)
+		}
+		input = q.Get("pomerium_jwt")
+	}
+	if input == "" {
+		return "", fmt.Errorf("invalid login result: no JWT found")
+	}
+	return input, nil
+}
@@ -0,0 +1,26 @@
+package authclient
+
+import (
+	"context"
+	"os"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/pomerium/cli/internal/qrcode"
+)
+
+// printQRCode renders the URL as a QR code on stderr if enabled, so that it
+// can be opened with a phone.
+func (client *AuthClient) printQRCode(ctx context.Context, rawURL string) {
+	if !client.cfg.qrCode {
+		return
+	}
+
+	code, err := qrcode.Encode([]byte(rawURL))
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to render the url as a qr code")
+		return
+	}
+	_ = code.WriteANSI(os.Stderr)
+	_, _ = os.Stderr.WriteString("\n")
+}
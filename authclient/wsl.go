@@ -0,0 +1,76 @@
+package authclient
+
+import (
+	"net"
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// isWSL reports whether we are running inside the Windows Subsystem for Linux.
+var isWSL = sync.OnceValue(func() bool {
+	if runtime.GOOS != "linux" {
+		return false
+	}
+	if os.Getenv("WSL_DISTRO_NAME") != "" {
+		return true
+	}
+	osRelease, err := os.ReadFile("/proc/sys/kernel/osrelease")
+	if err != nil {
+		return false
+	}
+	return strings.Contains(strings.ToLower(string(osRelease)), "microsoft")
+})
+
+// wslBrowserLaunchers open URLs with the browser of the Windows host.
+func wslBrowserLaunchers() []func(rawURL string) error {
+	return []func(rawURL string) error{
+		func(rawURL string) error {
+			return startBrowserCommand([]string{"wslview", rawURL})
+		},
+		func(rawURL string) error {
+			return startBrowserCommand([]string{
+				"powershell.exe", "-NoProfile", "-NonInteractive",
+				"-Command", "Start-Process " + powershellQuote(rawURL),
+			})
+		},
+	}
+}
+
+func powershellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// callbackListenHost returns the host for the login callback listener.
+// Inside WSL2 the Windows host browser may not be able to reach the loopback
+// address of the Linux VM, so the WSL NAT address is used instead.
+func callbackListenHost() string {
+	if isWSL() {
+		if ip := wslNATAddr(); ip != nil {
+			return ip.String()
+		}
+	}
+	return "127.0.0.1"
+}
+
+func wslNATAddr() net.IP {
+	iface, err := net.InterfaceByName("eth0")
+	if err != nil {
+		return nil
+	}
+	addrs, err := iface.Addrs()
+	if err != nil {
+		return nil
+	}
+	for _, addr := range addrs {
+		ipNet, ok := addr.(*net.IPNet)
+		if !ok || ipNet.IP.IsLoopback() {
+			continue
+		}
+		if ip := ipNet.IP.To4(); ip != nil {
+			return ip
+		}
+	}
+	return nil
+}
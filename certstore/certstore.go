@@ -37,9 +37,13 @@ var errNotSupported = errors.New("this build of pomerium-cli does not support th
 //
 // Names containing multiple values for the same attribute are not supported.
 func GetClientCertificateFunc(
-	issuerFilter, subjectFilter string,
+	issuerFilter, subjectFilter string, options ...Option,
 ) (func(*tls.CertificateRequestInfo) (*tls.Certificate, error), error) {
-	if !IsCertstoreSupported {
+	var o config
+	for _, option := range options {
+		option(&o)
+	}
+	if !IsCertstoreSupported || (o.tpm && !IsTPMSupported) {
 		return nil, errNotSupported
 	}
 
@@ -47,12 +51,62 @@ func GetClientCertificateFunc(
 	if err != nil {
 		return nil, err
 	}
+	s := &selector{policy: o.selectPolicy, prompt: o.onSelectPrompt}
 
 	return func(cri *tls.CertificateRequestInfo) (*tls.Certificate, error) {
-		return loadCert(cri.AcceptableCAs, f)
+		cert, err := s.selectCert(func(filter func(*x509.Certificate) bool) (*tls.Certificate, error) {
+			return loadCert(cri.AcceptableCAs, filter, o.tpm)
+		}, f)
+		if err != nil || !o.requireUserPresence {
+			return cert, err
+		}
+		return requireUserPresence(cert, o.onUserPresencePrompt)
 	}, nil
 }
 
+type config struct {
+	requireUserPresence  bool
+	onUserPresencePrompt func()
+	tpm                  bool
+	selectPolicy         SelectPolicy
+	onSelectPrompt       func([]*x509.Certificate) (int, error)
+}
+
+// An Option customizes the client certificate returned from the store.
+type Option func(*config)
+
+// WithRequireUserPresence returns an option to require the user to approve
+// each use of the private key, i.e. each TLS handshake, with Touch ID or the
+// account password on macOS and Windows Hello on Windows. The user is never
+// prompted in a Windows service, so signing fails there. If not nil, onPrompt
+// is called whenever the user is prompted.
+func WithRequireUserPresence(onPrompt func()) Option {
+	return func(cfg *config) {
+		cfg.requireUserPresence = true
+		cfg.onUserPresencePrompt = onPrompt
+	}
+}
+
+// WithSelect returns an option to choose the client certificate by the policy
+// when more than one matches. For SelectPrompt, prompt is called with the
+// matching certificates and returns the index of the chosen one, which is
+// remembered for as long as it matches.
+func WithSelect(policy SelectPolicy, prompt func([]*x509.Certificate) (int, error)) Option {
+	return func(cfg *config) {
+		cfg.selectPolicy = policy
+		cfg.onSelectPrompt = prompt
+	}
+}
+
+// WithTPM returns an option to only return certificates whose private key is
+// protected by the TPM, i.e. in the Microsoft Platform Crypto Provider. It is
+// only supported on Windows.
+func WithTPM() Option {
+	return func(cfg *config) {
+		cfg.tpm = true
+	}
+}
+
 func filterCallback(issuerFilter, subjectFilter string) (func(*x509.Certificate) bool, error) {
 	issuerAttr, issuerValue, err := parseFilterCondition(issuerFilter)
 	if err != nil {
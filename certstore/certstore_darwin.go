@@ -17,9 +17,10 @@ func init() {
 }
 
 // loadCert searches the macOS Keychain for a client certificate, according to
-// a list of acceptable CA Distinguished Names and an additional filter.
+// a list of acceptable CA Distinguished Names and an additional filter. There
+// is no TPM on macOS, so tpm is never set.
 func loadCert(
-	acceptableCAs [][]byte, filterCallback func(*x509.Certificate) bool,
+	acceptableCAs [][]byte, filterCallback func(*x509.Certificate) bool, _ bool,
 ) (*tls.Certificate, error) {
 	cred, err := keychain.Cred(acceptableCAs, filterCallback)
 	if err != nil {
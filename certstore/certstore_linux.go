@@ -0,0 +1,61 @@
+//go:build linux && cgo
+
+package certstore
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/pomerium/cli/version"
+)
+
+var IsCertstoreSupported = true
+
+func init() {
+	version.Features = append(version.Features, "nss")
+}
+
+// the database is read once, as the softoken cannot be initialized again
+var loadNSSCreds = sync.OnceValues(func() ([]credential, error) {
+	cfg, err := nssConfig()
+	if err != nil {
+		return nil, err
+	}
+	creds, err := loadPKCS11Creds(cfg, os.Getenv(NSSPasswordEnv))
+	if err != nil {
+		return nil, fmt.Errorf("nss: %w", err)
+	}
+	return creds, nil
+})
+
+// loadCert searches the NSS database for a client certificate, according to
+// a list of acceptable CA Distinguished Names and an additional filter.
+func loadCert(
+	acceptableCAs [][]byte, filterCallback func(*x509.Certificate) bool, tpm bool,
+) (*tls.Certificate, error) {
+	if tpm {
+		return nil, errors.New("the NSS database has no keys of the TPM, use a TSS2 key file instead")
+	}
+
+	creds, err := loadNSSCreds()
+	if err != nil {
+		return nil, err
+	}
+	for _, cred := range creds {
+		chain := cred.CertificateChain()
+		cert, err := x509.ParseCertificate(chain[0])
+		if err != nil || !isIssuedByAcceptableCA(chain, acceptableCAs) || !filterCallback(cert) {
+			continue
+		}
+		return toTLSCertificate(cred), nil
+	}
+	return nil, errors.New("no matching certificate found")
+}
+
+func requireUserPresence(*tls.Certificate, func()) (*tls.Certificate, error) {
+	return nil, errNotSupported
+}
@@ -1,4 +1,4 @@
-//go:build !(cgo && (darwin || windows))
+//go:build !(cgo && (darwin || windows || linux))
 
 package certstore
 
@@ -11,6 +11,10 @@ var IsCertstoreSupported = false
 
 // loadCert is a stub that always returns an error, for builds where this
 // feature is not supported.
-func loadCert([][]byte, func(*x509.Certificate) bool) (*tls.Certificate, error) {
+func loadCert([][]byte, func(*x509.Certificate) bool, bool) (*tls.Certificate, error) {
+	return nil, errNotSupported
+}
+
+func requireUserPresence(*tls.Certificate, func()) (*tls.Certificate, error) {
 	return nil, errNotSupported
 }
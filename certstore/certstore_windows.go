@@ -18,16 +18,21 @@ func init() {
 
 // loadCert searches the Windows trust store for a client certificate,
 // according to a list of acceptable CA Distinguished Names and an additional
-// filter.
+// filter. If tpm is set, only keys of the TPM are considered.
 func loadCert(
-	acceptableCAs [][]byte, filterCallback func(*x509.Certificate) bool,
+	acceptableCAs [][]byte, filterCallback func(*x509.Certificate) bool, tpm bool,
 ) (*tls.Certificate, error) {
+	var keyProvider string
+	if tpm {
+		keyProvider = ncrypt.PlatformCryptoProvider
+	}
+
 	// Try the MY store in both the CURRENT_USER and LOCAL_MACHINE locations.
-	cred, err := ncrypt.Cred(acceptableCAs, filterCallback, "MY", "current_user")
+	cred, err := ncrypt.Cred(acceptableCAs, filterCallback, "MY", "current_user", keyProvider)
 	if err == nil {
 		return toTLSCertificate(cred), nil
 	}
-	cred, err = ncrypt.Cred(acceptableCAs, filterCallback, "MY", "local_machine")
+	cred, err = ncrypt.Cred(acceptableCAs, filterCallback, "MY", "local_machine", keyProvider)
 	if err == nil {
 		return toTLSCertificate(cred), nil
 	}
@@ -1,5 +1,3 @@
-//go:build cgo && (darwin || windows)
-
 package certstore
 
 import (
@@ -0,0 +1,44 @@
+package certstore
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// NSSDBEnv is the environment variable holding the directory of the NSS
+// database searched for client certificates on Linux, i.e. a Firefox profile.
+// By default it is ~/.pki/nssdb, which is used by Chrome.
+const NSSDBEnv = "POMERIUM_CLI_NSS_DB"
+
+// NSSPasswordEnv is the environment variable holding the password of the NSS
+// database, if it has one.
+const NSSPasswordEnv = "POMERIUM_CLI_NSS_DB_PASSWORD"
+
+// nssModule is the NSS softoken, which provides the database as a PKCS#11
+// token.
+const nssModule = "libsoftokn3.so"
+
+// nssConfig returns the PKCS#11 config to open the NSS database read-only.
+func nssConfig() (*PKCS11Config, error) {
+	dir := os.Getenv(NSSDBEnv)
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, err
+		}
+		dir = filepath.Join(home, ".pki", "nssdb")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "cert9.db")); err != nil {
+		return nil, fmt.Errorf("no NSS database found in %s, set %s", dir, NSSDBEnv)
+	}
+
+	quote := strings.NewReplacer(`\`, `\\`, `'`, `\'`)
+	return &PKCS11Config{
+		Module: nssModule,
+		pinEnv: NSSPasswordEnv,
+		params: fmt.Sprintf("configdir='sql:%s' certPrefix='' keyPrefix='' secmod='secmod.db' flags='readOnly,noModDB'",
+			quote.Replace(dir)),
+	}, nil
+}
@@ -0,0 +1,31 @@
+package certstore
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNSSConfig(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "it's")
+	require.NoError(t, os.Mkdir(dir, 0o700))
+	t.Setenv(NSSDBEnv, dir)
+
+	_, err := nssConfig()
+	assert.ErrorContains(t, err, "no NSS database found")
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "cert9.db"), nil, 0o600))
+	cfg, err := nssConfig()
+	require.NoError(t, err)
+	assert.Equal(t, "libsoftokn3.so", cfg.Module)
+	assert.Contains(t, cfg.params, `configdir='sql:`+filepath.Dir(dir)+`/it\'s'`)
+	assert.Contains(t, cfg.params, `flags='readOnly,noModDB'`)
+
+	t.Setenv(NSSDBEnv, "")
+	t.Setenv("HOME", dir)
+	_, err = nssConfig()
+	assert.ErrorContains(t, err, filepath.Join(dir, ".pki", "nssdb"))
+}
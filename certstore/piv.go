@@ -0,0 +1,512 @@
+package certstore
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/subtle"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// PIVPINEnv is the environment variable holding the PIN of the PIV applet.
+const PIVPINEnv = "POMERIUM_CLI_PIV_PIN"
+
+// PIV key slots, see NIST SP 800-73-4 part 1 table 4b.
+const (
+	PIVSlotAuthentication     byte = 0x9a
+	PIVSlotSignature          byte = 0x9c
+	PIVSlotKeyManagement      byte = 0x9d
+	PIVSlotCardAuthentication byte = 0x9e
+)
+
+// A PIVConfig selects the client certificate in a slot of the PIV applet of a
+// smart card, i.e. a YubiKey.
+type PIVConfig struct {
+	// Slot is the key slot, 9a by default.
+	Slot byte
+	// Reader restricts the search to the readers whose name contains it,
+	// otherwise the first reader with a card is used.
+	Reader string
+}
+
+// ParsePIVConfig parses a comma-separated list of key=value pairs, i.e.
+// "slot=9a,reader=Yubico". The keys are slot, which is the hex-encoded key
+// slot, and reader.
+func ParsePIVConfig(s string) (*PIVConfig, error) {
+	cfg := &PIVConfig{Slot: PIVSlotAuthentication}
+	for _, kv := range strings.Split(s, ",") {
+		k, v, ok := strings.Cut(kv, "=")
+		if !ok {
+			return nil, fmt.Errorf("piv: expected key=value, but was %q", kv)
+		}
+		switch strings.TrimSpace(k) {
+		case "slot":
+			slot, err := strconv.ParseUint(v, 16, 8)
+			if err != nil || pivObjectID(byte(slot)) == nil {
+				return nil, fmt.Errorf("piv: invalid slot %q", v)
+			}
+			cfg.Slot = byte(slot)
+		case "reader":
+			cfg.Reader = v
+		default:
+			return nil, fmt.Errorf("piv: unknown key %q", k)
+		}
+	}
+	return cfg, nil
+}
+
+// GetPIVClientCertificateFunc returns a function suitable for use as a
+// [tls.Config.GetClientCertificate] callback. This function returns the
+// certificate in the PIV slot, if it was issued by one of the acceptable CAs
+// from the Certificate Request message. The PIN is read from the PIVPINEnv
+// environment variable, if set.
+func GetPIVClientCertificateFunc(
+	cfg *PIVConfig,
+) (func(*tls.CertificateRequestInfo) (*tls.Certificate, error), error) {
+	if !IsPIVSupported {
+		return nil, errNotSupported
+	}
+
+	card, err := openPIVCard(cfg.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("piv: %w", err)
+	}
+	key, err := newPIVKey(card, cfg.Slot, os.Getenv(PIVPINEnv))
+	if err != nil {
+		return nil, fmt.Errorf("piv: %w", err)
+	}
+
+	return func(cri *tls.CertificateRequestInfo) (*tls.Certificate, error) {
+		if !isIssuedByAcceptableCA(key.CertificateChain(), cri.AcceptableCAs) {
+			return nil, errors.New("piv: the certificate was not issued by an acceptable CA")
+		}
+		return toTLSCertificate(key), nil
+	}, nil
+}
+
+// A pivCard exchanges APDUs with a smart card.
+type pivCard interface {
+	// begin starts a transaction, giving exclusive access to the card.
+	begin() error
+	end()
+	transmit(apdu []byte) ([]byte, error)
+}
+
+// pivObjectID returns the ID of the data object holding the certificate of
+// the slot, see NIST SP 800-73-4 part 1 table 3 and table 4b.
+func pivObjectID(slot byte) []byte {
+	switch {
+	case slot == PIVSlotAuthentication:
+		return []byte{0x5f, 0xc1, 0x05}
+	case slot == PIVSlotSignature:
+		return []byte{0x5f, 0xc1, 0x0a}
+	case slot == PIVSlotKeyManagement:
+		return []byte{0x5f, 0xc1, 0x0b}
+	case slot == PIVSlotCardAuthentication:
+		return []byte{0x5f, 0xc1, 0x01}
+	case slot >= 0x82 && slot <= 0x95:
+		// retired key management slots
+		return []byte{0x5f, 0xc1, 0x0d + slot - 0x82}
+	}
+	return nil
+}
+
+// pivAID is the application identifier of the PIV applet.
+var pivAID = []byte{0xa0, 0x00, 0x00, 0x03, 0x08}
+
+// PIV instructions, see NIST SP 800-73-4 part 2 section 3.
+const (
+	insSelect       = 0xa4
+	insGetData      = 0xcb
+	insVerify       = 0x20
+	insGeneralAuth  = 0x87
+	insGetResponse  = 0xc0
+	claChaining     = 0x10
+	swOK            = 0x9000
+	swSecurityState = 0x6982
+	swPINBlocked    = 0x6983
+	swNotFound      = 0x6a82
+)
+
+type pivError struct {
+	sw uint16
+}
+
+func (e pivError) Error() string {
+	switch {
+	case e.sw&0xfff0 == 0x63c0:
+		return fmt.Sprintf("wrong PIN, %d retries left", e.sw&0xf)
+	case e.sw == swSecurityState:
+		return fmt.Sprintf("the slot requires a PIN, set %s", PIVPINEnv)
+	case e.sw == swPINBlocked:
+		return "the PIN is blocked"
+	case e.sw == swNotFound:
+		return "not found"
+	}
+	return fmt.Sprintf("card returned status 0x%04x", e.sw)
+}
+
+// pivCommand sends the command, chaining it if the data does not fit into a
+// single APDU, and returns the response data. Responses of more than 256
+// bytes are collected with GET RESPONSE.
+func pivCommand(card pivCard, ins, p1, p2 byte, data []byte) ([]byte, error) {
+	for len(data) > 0xff {
+		if _, err := pivTransmit(card, []byte{claChaining, ins, p1, p2, 0xff}, data[:0xff]); err != nil {
+			return nil, err
+		}
+		data = data[0xff:]
+	}
+	apdu := []byte{0x00, ins, p1, p2}
+	if len(data) > 0 {
+		apdu = append(apdu, byte(len(data)))
+	}
+	return pivTransmit(card, apdu, data, []byte{0x00})
+}
+
+func pivTransmit(card pivCard, parts ...[]byte) ([]byte, error) {
+	var apdu []byte
+	for _, p := range parts {
+		apdu = append(apdu, p...)
+	}
+	var data []byte
+	for {
+		resp, err := card.transmit(apdu)
+		if err != nil {
+			return nil, err
+		}
+		if len(resp) < 2 {
+			return nil, fmt.Errorf("invalid response length %d", len(resp))
+		}
+		sw := binary.BigEndian.Uint16(resp[len(resp)-2:])
+		data = append(data, resp[:len(resp)-2]...)
+		switch {
+		case sw == swOK:
+			return data, nil
+		case sw>>8 == 0x61:
+			apdu = []byte{0x00, insGetResponse, 0x00, 0x00, byte(sw)}
+		default:
+			return nil, pivError{sw}
+		}
+	}
+}
+
+// appendTLV appends a BER-TLV data object with a single-byte tag.
+func appendTLV(b []byte, tag byte, value []byte) []byte {
+	b = append(b, tag)
+	switch n := len(value); {
+	case n < 0x80:
+		b = append(b, byte(n))
+	case n <= 0xff:
+		b = append(b, 0x81, byte(n))
+	default:
+		b = append(b, 0x82, byte(n>>8), byte(n))
+	}
+	return append(b, value...)
+}
+
+// parseTLVs parses a sequence of BER-TLV data objects with single-byte tags.
+func parseTLVs(b []byte) (map[byte][]byte, error) {
+	tlvs := make(map[byte][]byte)
+	for len(b) > 0 {
+		if len(b) < 2 {
+			return nil, errors.New("truncated data object")
+		}
+		tag, n, rest := b[0], int(b[1]), b[2:]
+		switch n {
+		case 0x81:
+			if len(rest) < 1 {
+				return nil, errors.New("truncated data object")
+			}
+			n, rest = int(rest[0]), rest[1:]
+		case 0x82:
+			if len(rest) < 2 {
+				return nil, errors.New("truncated data object")
+			}
+			n, rest = int(binary.BigEndian.Uint16(rest)), rest[2:]
+		default:
+			if n >= 0x80 {
+				return nil, fmt.Errorf("unsupported length encoding 0x%02x", n)
+			}
+		}
+		if len(rest) < n {
+			return nil, errors.New("truncated data object")
+		}
+		tlvs[tag] = rest[:n]
+		b = rest[n:]
+	}
+	return tlvs, nil
+}
+
+// A pivKey is the certificate and private key in a slot of the PIV applet.
+type pivKey struct {
+	card pivCard
+	slot byte
+	pin  string
+	alg  byte
+	cert *x509.Certificate
+	mu   sync.Mutex
+}
+
+// newPIVKey reads the certificate of the slot.
+func newPIVKey(card pivCard, slot byte, pin string) (*pivKey, error) {
+	k := &pivKey{card: card, slot: slot, pin: pin}
+	var cert *x509.Certificate
+	err := k.transact(false, func() error {
+		data, err := pivCommand(card, insGetData, 0x3f, 0xff, appendTLV(nil, 0x5c, pivObjectID(slot)))
+		if errors.Is(err, pivError{swNotFound}) {
+			return fmt.Errorf("no certificate in slot %02x", slot)
+		} else if err != nil {
+			return fmt.Errorf("reading certificate: %w", err)
+		}
+		cert, err = parsePIVCertificate(data)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	k.cert = cert
+	k.alg, err = pivAlgorithm(cert.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	return k, nil
+}
+
+// parsePIVCertificate parses the certificate data object, see NIST SP
+// 800-73-4 part 1 appendix A.
+func parsePIVCertificate(data []byte) (*x509.Certificate, error) {
+	outer, err := parseTLVs(data)
+	if err != nil {
+		return nil, err
+	}
+	tlvs, err := parseTLVs(outer[0x53])
+	if err != nil {
+		return nil, err
+	}
+	der := tlvs[0x70]
+	if info := tlvs[0x71]; len(info) > 0 && info[0]&0x01 != 0 {
+		// the certificate is gzip-compressed
+		r, err := gzip.NewReader(bytes.NewReader(der))
+		if err != nil {
+			return nil, fmt.Errorf("decompressing certificate: %w", err)
+		}
+		if der, err = io.ReadAll(r); err != nil {
+			return nil, fmt.Errorf("decompressing certificate: %w", err)
+		}
+	}
+	return x509.ParseCertificate(der)
+}
+
+// pivAlgorithm returns the algorithm identifier of the key, see NIST SP
+// 800-78-4 table 6-2. RSA 3072 and 4096 are YubiKey extensions.
+func pivAlgorithm(pub crypto.PublicKey) (byte, error) {
+	switch pub := pub.(type) {
+	case *rsa.PublicKey:
+		switch pub.N.BitLen() {
+		case 1024:
+			return 0x06, nil
+		case 2048:
+			return 0x07, nil
+		case 3072:
+			return 0x05, nil
+		case 4096:
+			return 0x16, nil
+		}
+		return 0, fmt.Errorf("unsupported RSA key size %d", pub.N.BitLen())
+	case *ecdsa.PublicKey:
+		switch pub.Curve {
+		case elliptic.P256():
+			return 0x11, nil
+		case elliptic.P384():
+			return 0x14, nil
+		}
+		return 0, fmt.Errorf("unsupported curve %s", pub.Curve.Params().Name)
+	}
+	return 0, fmt.Errorf("unsupported key type %T", pub)
+}
+
+// transact selects the PIV applet and calls f in a transaction, so other
+// applications cannot interfere. The applet is selected every time as another
+// application may have selected a different one in between.
+func (k *pivKey) transact(verifyPIN bool, f func() error) error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if err := k.card.begin(); err != nil {
+		return err
+	}
+	defer k.card.end()
+
+	if _, err := pivCommand(k.card, insSelect, 0x04, 0x00, pivAID); err != nil {
+		return fmt.Errorf("selecting the PIV applet: %w", err)
+	}
+	if verifyPIN && k.pin != "" {
+		// the PIN is padded with 0xff to 8 bytes
+		pin := bytes.Repeat([]byte{0xff}, 8)
+		if len(k.pin) > len(pin) {
+			return errors.New("the PIN is longer than 8 characters")
+		}
+		copy(pin, k.pin)
+		if _, err := pivCommand(k.card, insVerify, 0x00, 0x80, pin); err != nil {
+			return fmt.Errorf("verifying the PIN: %w", err)
+		}
+	}
+	return f()
+}
+
+func (k *pivKey) CertificateChain() [][]byte {
+	return [][]byte{k.cert.Raw}
+}
+
+func (k *pivKey) Public() crypto.PublicKey {
+	return k.cert.PublicKey
+}
+
+func (k *pivKey) Sign(_ io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	var challenge []byte
+	switch pub := k.cert.PublicKey.(type) {
+	case *ecdsa.PublicKey:
+		// the digest is truncated or padded to the size of the curve
+		size := (pub.Curve.Params().BitSize + 7) / 8
+		challenge = make([]byte, size)
+		if len(digest) >= size {
+			copy(challenge, digest[:size])
+		} else {
+			copy(challenge[size-len(digest):], digest)
+		}
+	case *rsa.PublicKey:
+		// the card computes the raw RSA operation, so the padding is added here
+		var err error
+		if pssOpts, ok := opts.(*rsa.PSSOptions); ok {
+			challenge, err = emsaPSSEncode(pub, opts.HashFunc(), pssOpts.SaltLength, digest)
+		} else {
+			challenge, err = emsaPKCS1v15Encode(pub, opts.HashFunc(), digest)
+		}
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported key type %T", pub)
+	}
+
+	// a dynamic authentication template with an empty response and the
+	// challenge, see NIST SP 800-73-4 part 2 section 3.2.4
+	var template []byte
+	template = appendTLV(template, 0x82, nil)
+	template = appendTLV(template, 0x81, challenge)
+	var resp []byte
+	err := k.transact(true, func() error {
+		var err error
+		resp, err = pivCommand(k.card, insGeneralAuth, k.alg, k.slot, appendTLV(nil, 0x7c, template))
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("piv: signing: %w", err)
+	}
+
+	outer, err := parseTLVs(resp)
+	if err != nil {
+		return nil, fmt.Errorf("piv: signing: %w", err)
+	}
+	tlvs, err := parseTLVs(outer[0x7c])
+	if err != nil {
+		return nil, fmt.Errorf("piv: signing: %w", err)
+	}
+	sig := tlvs[0x82]
+	if len(sig) == 0 {
+		return nil, errors.New("piv: signing: empty signature")
+	}
+	// ECDSA signatures are already ASN.1-encoded
+	return sig, nil
+}
+
+// emsaPKCS1v15Encode returns the padded DigestInfo, see RFC 8017 section 9.2.
+func emsaPKCS1v15Encode(pub *rsa.PublicKey, hash crypto.Hash, digest []byte) ([]byte, error) {
+	t, err := pkcs1v15DigestInfo(hash, digest)
+	if err != nil {
+		return nil, err
+	}
+	k := pub.Size()
+	if k < len(t)+11 {
+		return nil, rsa.ErrMessageTooLong
+	}
+	em := make([]byte, k)
+	em[1] = 0x01
+	for i := 2; i < k-len(t)-1; i++ {
+		em[i] = 0xff
+	}
+	copy(em[k-len(t):], t)
+	return em, nil
+}
+
+// emsaPSSEncode returns the PSS encoding of the digest with a random salt, see
+// RFC 8017 section 9.1.1, left-padded to the size of the modulus.
+func emsaPSSEncode(pub *rsa.PublicKey, hash crypto.Hash, saltLength int, digest []byte) ([]byte, error) {
+	if !hash.Available() {
+		return nil, fmt.Errorf("unsupported hash %v", hash)
+	}
+	hLen := hash.Size()
+	if len(digest) != hLen {
+		return nil, fmt.Errorf("invalid digest length %d for %v", len(digest), hash)
+	}
+	emBits := pub.N.BitLen() - 1
+	emLen := (emBits + 7) / 8
+	if saltLength == rsa.PSSSaltLengthAuto || saltLength == rsa.PSSSaltLengthEqualsHash {
+		saltLength = hLen
+	}
+	if saltLength < 0 || emLen < hLen+saltLength+2 {
+		return nil, rsa.ErrMessageTooLong
+	}
+
+	salt := make([]byte, saltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	h := hash.New()
+	h.Write(make([]byte, 8))
+	h.Write(digest)
+	h.Write(salt)
+	mHash := h.Sum(nil)
+
+	// DB = PS || 0x01 || salt
+	db := make([]byte, emLen-hLen-1)
+	db[len(db)-saltLength-1] = 0x01
+	copy(db[len(db)-saltLength:], salt)
+	subtle.XORBytes(db, db, mgf1(hash, mHash, len(db)))
+	db[0] &= 0xff >> (8*emLen - emBits)
+
+	em := make([]byte, pub.Size())
+	off := len(em) - emLen
+	copy(em[off:], db)
+	copy(em[off+len(db):], mHash)
+	em[len(em)-1] = 0xbc
+	return em, nil
+}
+
+// mgf1 is the mask generation function, see RFC 8017 appendix B.2.1.
+func mgf1(hash crypto.Hash, seed []byte, length int) []byte {
+	var mask []byte
+	var counter [4]byte
+	for len(mask) < length {
+		h := hash.New()
+		h.Write(seed)
+		h.Write(counter[:])
+		mask = h.Sum(mask)
+		binary.BigEndian.PutUint32(counter[:], binary.BigEndian.Uint32(counter[:])+1)
+	}
+	return mask[:length]
+}
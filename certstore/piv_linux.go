@@ -0,0 +1,252 @@
+//go:build linux && cgo
+
+package certstore
+
+/*
+#cgo LDFLAGS: -ldl
+
+#include <dlfcn.h>
+#include <stdlib.h>
+
+// The subset of the PC/SC Lite API used, see winscard.h and pcsclite.h.
+typedef long LONG;
+typedef unsigned long DWORD;
+typedef LONG SCARDCONTEXT;
+typedef LONG SCARDHANDLE;
+
+typedef struct {
+	DWORD dwProtocol;
+	DWORD cbPciLength;
+} SCARD_IO_REQUEST;
+
+typedef struct {
+	LONG (*SCardEstablishContext)(DWORD, const void *, const void *, SCARDCONTEXT *);
+	LONG (*SCardReleaseContext)(SCARDCONTEXT);
+	LONG (*SCardListReaders)(SCARDCONTEXT, const char *, char *, DWORD *);
+	LONG (*SCardConnect)(SCARDCONTEXT, const char *, DWORD, DWORD, SCARDHANDLE *, DWORD *);
+	LONG (*SCardReconnect)(SCARDHANDLE, DWORD, DWORD, DWORD, DWORD *);
+	LONG (*SCardBeginTransaction)(SCARDHANDLE);
+	LONG (*SCardEndTransaction)(SCARDHANDLE, DWORD);
+	LONG (*SCardTransmit)(SCARDHANDLE, const SCARD_IO_REQUEST *, const unsigned char *, DWORD,
+		SCARD_IO_REQUEST *, unsigned char *, DWORD *);
+} pcsc_functions;
+
+static pcsc_functions pcsc;
+
+// pcsc_load loads the library and returns 0 on success.
+static int pcsc_load() {
+	void *handle = dlopen("libpcsclite.so.1", RTLD_NOW | RTLD_LOCAL);
+	if (handle == NULL) {
+		handle = dlopen("libpcsclite.so", RTLD_NOW | RTLD_LOCAL);
+	}
+	if (handle == NULL) {
+		return -1;
+	}
+	pcsc.SCardEstablishContext = dlsym(handle, "SCardEstablishContext");
+	pcsc.SCardReleaseContext = dlsym(handle, "SCardReleaseContext");
+	pcsc.SCardListReaders = dlsym(handle, "SCardListReaders");
+	pcsc.SCardConnect = dlsym(handle, "SCardConnect");
+	pcsc.SCardReconnect = dlsym(handle, "SCardReconnect");
+	pcsc.SCardBeginTransaction = dlsym(handle, "SCardBeginTransaction");
+	pcsc.SCardEndTransaction = dlsym(handle, "SCardEndTransaction");
+	pcsc.SCardTransmit = dlsym(handle, "SCardTransmit");
+	if (pcsc.SCardEstablishContext == NULL || pcsc.SCardReleaseContext == NULL ||
+		pcsc.SCardListReaders == NULL ||
+		pcsc.SCardConnect == NULL || pcsc.SCardReconnect == NULL ||
+		pcsc.SCardBeginTransaction == NULL || pcsc.SCardEndTransaction == NULL ||
+		pcsc.SCardTransmit == NULL) {
+		dlclose(handle);
+		return -1;
+	}
+	return 0;
+}
+
+// cgo cannot call function pointers, so each function has a wrapper.
+
+static LONG pcsc_establish_context(SCARDCONTEXT *ctx) {
+	return pcsc.SCardEstablishContext(2, NULL, NULL, ctx); // SCARD_SCOPE_SYSTEM
+}
+
+static LONG pcsc_release_context(SCARDCONTEXT ctx) {
+	return pcsc.SCardReleaseContext(ctx);
+}
+
+static LONG pcsc_list_readers(SCARDCONTEXT ctx, char *readers, DWORD *len) {
+	return pcsc.SCardListReaders(ctx, NULL, readers, len);
+}
+
+static LONG pcsc_connect(SCARDCONTEXT ctx, const char *reader, SCARDHANDLE *card, DWORD *protocol) {
+	return pcsc.SCardConnect(ctx, reader, 2, 3, card, protocol); // SCARD_SHARE_SHARED, T0 | T1
+}
+
+static LONG pcsc_reconnect(SCARDHANDLE card, DWORD *protocol) {
+	return pcsc.SCardReconnect(card, 2, 3, 0, protocol); // SCARD_LEAVE_CARD
+}
+
+static LONG pcsc_begin_transaction(SCARDHANDLE card) {
+	return pcsc.SCardBeginTransaction(card);
+}
+
+static LONG pcsc_end_transaction(SCARDHANDLE card) {
+	return pcsc.SCardEndTransaction(card, 0); // SCARD_LEAVE_CARD
+}
+
+static LONG pcsc_transmit(SCARDHANDLE card, DWORD protocol, const unsigned char *send, DWORD sendLen,
+		unsigned char *recv, DWORD *recvLen) {
+	SCARD_IO_REQUEST pci = {protocol, sizeof(SCARD_IO_REQUEST)};
+	return pcsc.SCardTransmit(card, &pci, send, sendLen, NULL, recv, recvLen);
+}
+*/
+import "C"
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"unsafe"
+
+	"github.com/pomerium/cli/version"
+)
+
+var IsPIVSupported = true
+
+func init() {
+	version.Features = append(version.Features, "piv")
+}
+
+// pcsclite.h constants
+const (
+	scardSuccess            = 0x00000000 // SCARD_S_SUCCESS
+	scardNoSmartcard        = 0x8010000c // SCARD_E_NO_SMARTCARD
+	scardNoService          = 0x8010001d // SCARD_E_NO_SERVICE
+	scardNoReadersAvailable = 0x8010002e // SCARD_E_NO_READERS_AVAILABLE
+	scardUnresponsiveCard   = 0x80100066 // SCARD_W_UNRESPONSIVE_CARD
+	scardUnpoweredCard      = 0x80100067 // SCARD_W_UNPOWERED_CARD
+	scardResetCard          = 0x80100068 // SCARD_W_RESET_CARD
+	scardRemovedCard        = 0x80100069 // SCARD_W_REMOVED_CARD
+	scardMaxResponseLength  = 258        // a short response and its status word
+)
+
+var errNoCard = errors.New("no smart card reader with a card found")
+
+type pcscError struct {
+	function string
+	rv       C.LONG
+}
+
+func (e pcscError) Error() string {
+	switch e.rv {
+	case scardNoService:
+		return fmt.Sprintf("%s failed: the pcscd service is not running", e.function)
+	case scardRemovedCard:
+		return fmt.Sprintf("%s failed: the card was removed", e.function)
+	}
+	return fmt.Sprintf("%s failed: 0x%x", e.function, uint64(e.rv))
+}
+
+func checkSCardRV(function string, rv C.LONG) error {
+	if rv == scardSuccess {
+		return nil
+	}
+	return pcscError{function, rv}
+}
+
+var loadPCSC = sync.OnceValue(func() error {
+	if C.pcsc_load() != 0 {
+		return errors.New("failed to load libpcsclite, is pcsc-lite installed?")
+	}
+	return nil
+})
+
+// A pcscCard is a connection to a card in a reader.
+type pcscCard struct {
+	handle   C.SCARDHANDLE
+	protocol C.DWORD
+}
+
+// openPIVCard connects to the card in the first reader whose name contains
+// the given string. The connection is kept for the lifetime of the process.
+func openPIVCard(reader string) (pivCard, error) {
+	if err := loadPCSC(); err != nil {
+		return nil, err
+	}
+
+	var ctx C.SCARDCONTEXT
+	if err := checkSCardRV("SCardEstablishContext", C.pcsc_establish_context(&ctx)); err != nil {
+		return nil, err
+	}
+	card, err := pcscConnect(ctx, reader)
+	if err != nil {
+		C.pcsc_release_context(ctx)
+		return nil, err
+	}
+	return card, nil
+}
+
+func pcscConnect(ctx C.SCARDCONTEXT, reader string) (*pcscCard, error) {
+	var n C.DWORD
+	rv := C.pcsc_list_readers(ctx, nil, &n)
+	if rv == scardNoReadersAvailable {
+		return nil, errNoCard
+	} else if err := checkSCardRV("SCardListReaders", rv); err != nil {
+		return nil, err
+	}
+	buf := (*C.char)(C.malloc(C.size_t(n)))
+	defer C.free(unsafe.Pointer(buf))
+	if err := checkSCardRV("SCardListReaders", C.pcsc_list_readers(ctx, buf, &n)); err != nil {
+		return nil, err
+	}
+
+	// the reader names are a list of NUL-terminated strings
+	names := bytes.Split(C.GoBytes(unsafe.Pointer(buf), C.int(n)), []byte{0})
+	for _, name := range names {
+		if len(name) == 0 || !strings.Contains(string(name), reader) {
+			continue
+		}
+		cName := C.CString(string(name))
+		card := new(pcscCard)
+		rv := C.pcsc_connect(ctx, cName, &card.handle, &card.protocol)
+		C.free(unsafe.Pointer(cName))
+		switch rv {
+		case scardSuccess:
+			return card, nil
+		case scardNoSmartcard, scardUnresponsiveCard, scardUnpoweredCard:
+			continue
+		default:
+			return nil, pcscError{"SCardConnect", rv}
+		}
+	}
+	return nil, errNoCard
+}
+
+func (c *pcscCard) begin() error {
+	rv := C.pcsc_begin_transaction(c.handle)
+	if rv == scardResetCard {
+		// another application reset the card, which logs out the PIN
+		if err := checkSCardRV("SCardReconnect", C.pcsc_reconnect(c.handle, &c.protocol)); err != nil {
+			return err
+		}
+		rv = C.pcsc_begin_transaction(c.handle)
+	}
+	return checkSCardRV("SCardBeginTransaction", rv)
+}
+
+func (c *pcscCard) end() {
+	C.pcsc_end_transaction(c.handle)
+}
+
+func (c *pcscCard) transmit(apdu []byte) ([]byte, error) {
+	send := C.CBytes(apdu)
+	defer C.free(send)
+	recv := (*C.uchar)(C.malloc(scardMaxResponseLength))
+	defer C.free(unsafe.Pointer(recv))
+	n := C.DWORD(scardMaxResponseLength)
+	err := checkSCardRV("SCardTransmit", C.pcsc_transmit(c.handle, c.protocol,
+		(*C.uchar)(send), C.DWORD(len(apdu)), recv, &n))
+	if err != nil {
+		return nil, err
+	}
+	return C.GoBytes(unsafe.Pointer(recv), C.int(n)), nil
+}
@@ -0,0 +1,11 @@
+//go:build !(linux && cgo)
+
+package certstore
+
+var IsPIVSupported = false
+
+// openPIVCard is a stub that always returns an error, for builds where this
+// feature is not supported.
+func openPIVCard(string) (pivCard, error) {
+	return nil, errNotSupported
+}
@@ -0,0 +1,448 @@
+package certstore
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePIVConfig(t *testing.T) {
+	cfg, err := ParsePIVConfig("slot=9c,reader=Yubico")
+	require.NoError(t, err)
+	assert.Equal(t, &PIVConfig{Slot: PIVSlotSignature, Reader: "Yubico"}, cfg)
+
+	cfg, err = ParsePIVConfig("reader=Yubico")
+	require.NoError(t, err)
+	assert.Equal(t, PIVSlotAuthentication, cfg.Slot)
+
+	cfg, err = ParsePIVConfig("slot=95")
+	require.NoError(t, err)
+	assert.Equal(t, byte(0x95), cfg.Slot)
+
+	for _, s := range []string{
+		"",
+		"slot",
+		"slot=9b",
+		"slot=xyz",
+		"slot=100",
+		"pin=123456",
+	} {
+		_, err := ParsePIVConfig(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestPIVKey(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+
+	digest256 := sha256.Sum256([]byte("data"))
+	digest512 := sha512.Sum512([]byte("data"))
+
+	t.Run("rsa", func(t *testing.T) {
+		card := newFakePIVCard(t, PIVSlotAuthentication, rsaKey, "123456", false)
+		key, err := newPIVKey(card, PIVSlotAuthentication, "123456")
+		require.NoError(t, err)
+		assert.Equal(t, &rsaKey.PublicKey, key.Public())
+
+		sig, err := key.Sign(rand.Reader, digest256[:], crypto.SHA256)
+		require.NoError(t, err)
+		assert.NoError(t, rsa.VerifyPKCS1v15(&rsaKey.PublicKey, crypto.SHA256, digest256[:], sig))
+
+		sig, err = key.Sign(rand.Reader, digest512[:], &rsa.PSSOptions{
+			SaltLength: rsa.PSSSaltLengthEqualsHash,
+			Hash:       crypto.SHA512,
+		})
+		require.NoError(t, err)
+		assert.NoError(t, rsa.VerifyPSS(&rsaKey.PublicKey, crypto.SHA512, digest512[:], sig, nil))
+	})
+	t.Run("ecdsa", func(t *testing.T) {
+		card := newFakePIVCard(t, PIVSlotCardAuthentication, ecKey, "", true)
+		key, err := newPIVKey(card, PIVSlotCardAuthentication, "")
+		require.NoError(t, err)
+
+		sig, err := key.Sign(rand.Reader, digest256[:], crypto.SHA256)
+		require.NoError(t, err)
+		assert.True(t, ecdsa.VerifyASN1(&ecKey.PublicKey, digest256[:], sig))
+
+		sig, err = key.Sign(rand.Reader, digest512[:], crypto.SHA512)
+		require.NoError(t, err)
+		assert.True(t, ecdsa.VerifyASN1(&ecKey.PublicKey, digest512[:], sig))
+	})
+	t.Run("wrong pin", func(t *testing.T) {
+		card := newFakePIVCard(t, PIVSlotAuthentication, ecKey, "123456", false)
+		key, err := newPIVKey(card, PIVSlotAuthentication, "654321")
+		require.NoError(t, err)
+		_, err = key.Sign(rand.Reader, digest256[:], crypto.SHA256)
+		assert.ErrorContains(t, err, "wrong PIN, 2 retries left")
+	})
+	t.Run("no pin", func(t *testing.T) {
+		card := newFakePIVCard(t, PIVSlotAuthentication, ecKey, "123456", false)
+		key, err := newPIVKey(card, PIVSlotAuthentication, "")
+		require.NoError(t, err)
+		_, err = key.Sign(rand.Reader, digest256[:], crypto.SHA256)
+		assert.ErrorContains(t, err, PIVPINEnv)
+	})
+	t.Run("empty slot", func(t *testing.T) {
+		card := newFakePIVCard(t, PIVSlotAuthentication, ecKey, "", false)
+		_, err := newPIVKey(card, PIVSlotSignature, "")
+		assert.ErrorContains(t, err, "no certificate in slot 9c")
+	})
+}
+
+func TestTLV(t *testing.T) {
+	for _, tc := range []struct {
+		n      int
+		header string
+	}{
+		{0, "5300"},
+		{0x7f, "537f"},
+		{0x80, "538180"},
+		{0xff, "5381ff"},
+		{0x100, "53820100"},
+		{0xffff, "5382ffff"},
+	} {
+		value := bytes.Repeat([]byte{0xaa}, tc.n)
+		b := appendTLV(nil, 0x53, value)
+		assert.Equal(t, tc.header, hex.EncodeToString(b[:len(b)-tc.n]), tc.n)
+
+		tlvs, err := parseTLVs(b)
+		require.NoError(t, err, tc.n)
+		assert.Equal(t, map[byte][]byte{0x53: value}, tlvs, tc.n)
+	}
+
+	// a GENERAL AUTHENTICATE response
+	outer, err := parseTLVs(mustDecodeHex(t, "7c088206010203040506"))
+	require.NoError(t, err)
+	tlvs, err := parseTLVs(outer[0x7c])
+	require.NoError(t, err)
+	assert.Equal(t, mustDecodeHex(t, "010203040506"), tlvs[0x82])
+
+	tlvs, err = parseTLVs(mustDecodeHex(t, "700201027100fe00"))
+	require.NoError(t, err)
+	assert.Equal(t, map[byte][]byte{0x70: {0x01, 0x02}, 0x71: {}, 0xfe: {}}, tlvs)
+
+	for _, s := range []string{
+		"53",
+		"5305000000",
+		"5381",
+		"538201",
+		"538201000000",
+		"5383000000",
+		"5380",
+	} {
+		_, err := parseTLVs(mustDecodeHex(t, s))
+		assert.Error(t, err, s)
+	}
+}
+
+func FuzzTLV(f *testing.F) {
+	f.Add(byte(0x53), []byte{})
+	f.Add(byte(0x7c), bytes.Repeat([]byte{0x01}, 0x80))
+	f.Add(byte(0x70), bytes.Repeat([]byte{0x02}, 0x100))
+	f.Fuzz(func(t *testing.T, tag byte, value []byte) {
+		if len(value) > 0xffff {
+			t.Skip()
+		}
+		tlvs, err := parseTLVs(appendTLV(nil, tag, value))
+		require.NoError(t, err)
+		assert.Equal(t, map[byte][]byte{tag: value}, tlvs)
+	})
+}
+
+func FuzzParseTLVs(f *testing.F) {
+	f.Add(mustDecodeHex(f, "7c088206010203040506"))
+	f.Add(mustDecodeHex(f, "538180"))
+	f.Add(mustDecodeHex(f, "53820100"))
+	f.Fuzz(func(t *testing.T, b []byte) {
+		tlvs, err := parseTLVs(b)
+		if err != nil {
+			return
+		}
+		n := 0
+		for _, v := range tlvs {
+			n += 2 + len(v)
+		}
+		assert.LessOrEqual(t, n, len(b))
+	})
+}
+
+func FuzzParsePIVCertificate(f *testing.F) {
+	key := testECKey(f)
+	f.Add(newPIVCertificateObject(f, key, false))
+	f.Add(newPIVCertificateObject(f, key, true))
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		// must not panic
+		_, _ = parsePIVCertificate(b)
+	})
+}
+
+func TestPIVCommand(t *testing.T) {
+	t.Run("chaining", func(t *testing.T) {
+		card := &recordingPIVCard{responses: []string{"9000", "9000"}}
+		data := bytes.Repeat([]byte{0xaa}, 300)
+		_, err := pivCommand(card, insGeneralAuth, 0x11, 0x9a, data)
+		require.NoError(t, err)
+		require.Len(t, card.apdus, 2)
+		assert.Equal(t, mustDecodeHex(t, "1087119aff"), card.apdus[0][:5])
+		assert.Equal(t, data[:0xff], card.apdus[0][5:])
+		assert.Equal(t, mustDecodeHex(t, "0087119a2d"), card.apdus[1][:5])
+		assert.Equal(t, data[0xff:], card.apdus[1][5:len(card.apdus[1])-1])
+		assert.Equal(t, byte(0x00), card.apdus[1][len(card.apdus[1])-1], "Le")
+	})
+	t.Run("get response", func(t *testing.T) {
+		card := &recordingPIVCard{responses: []string{"01026103", "0304059000"}}
+		resp, err := pivCommand(card, insGetData, 0x3f, 0xff, mustDecodeHex(t, "5c035fc105"))
+		require.NoError(t, err)
+		assert.Equal(t, mustDecodeHex(t, "0102030405"), resp)
+		assert.Equal(t, [][]byte{
+			mustDecodeHex(t, "00cb3fff055c035fc10500"),
+			mustDecodeHex(t, "00c0000003"),
+		}, card.apdus)
+	})
+	t.Run("errors", func(t *testing.T) {
+		for sw, msg := range map[string]string{
+			"63c1": "wrong PIN, 1 retries left",
+			"6982": PIVPINEnv,
+			"6983": "the PIN is blocked",
+			"6a82": "not found",
+			"6d00": "card returned status 0x6d00",
+			"90":   "invalid response length 1",
+		} {
+			card := &recordingPIVCard{responses: []string{sw}}
+			_, err := pivCommand(card, insVerify, 0x00, 0x80, nil)
+			assert.ErrorContains(t, err, msg, sw)
+		}
+	})
+}
+
+func TestEMSAPKCS1v15Encode(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	digest := sha256.Sum256([]byte("data"))
+
+	// 00 01 ff...ff 00 || DigestInfo, see RFC 8017 section 9.2 note 1
+	em, err := emsaPKCS1v15Encode(&key.PublicKey, crypto.SHA256, digest[:])
+	require.NoError(t, err)
+	want := append([]byte{0x00, 0x01}, bytes.Repeat([]byte{0xff}, 256-3-19-32)...)
+	want = append(want, 0x00)
+	want = append(want, mustDecodeHex(t, "3031300d060960864801650304020105000420")...)
+	want = append(want, digest[:]...)
+	assert.Equal(t, want, em)
+
+	// the raw RSA operation on the encoding is a PKCS #1 v1.5 signature
+	sig, err := rsa.SignPKCS1v15(nil, key, crypto.SHA256, digest[:])
+	require.NoError(t, err)
+	assert.Equal(t, sig, rawRSASign(key, em))
+
+	// a 512-bit modulus is too small for a SHA-512 DigestInfo
+	small := &rsa.PublicKey{N: new(big.Int).Lsh(big.NewInt(1), 511), E: 65537}
+	digest512 := sha512.Sum512([]byte("data"))
+	_, err = emsaPKCS1v15Encode(small, crypto.SHA512, digest512[:])
+	assert.ErrorIs(t, err, rsa.ErrMessageTooLong)
+}
+
+func FuzzEMSAPSSEncode(f *testing.F) {
+	key, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(f, err)
+	f.Add(int(rsa.PSSSaltLengthAuto), []byte("data"))
+	f.Add(int(rsa.PSSSaltLengthEqualsHash), []byte{})
+	f.Add(0, []byte("data"))
+	f.Add(94, []byte("data"))
+	f.Fuzz(func(t *testing.T, saltLength int, data []byte) {
+		digest := sha256.Sum256(data)
+		em, err := emsaPSSEncode(&key.PublicKey, crypto.SHA256, saltLength, digest[:])
+		if err != nil {
+			return
+		}
+		require.Len(t, em, key.Size())
+		sig := rawRSASign(key, em)
+		assert.NoError(t, rsa.VerifyPSS(&key.PublicKey, crypto.SHA256, digest[:], sig, &rsa.PSSOptions{
+			SaltLength: saltLength,
+		}))
+	})
+}
+
+func rawRSASign(key *rsa.PrivateKey, em []byte) []byte {
+	return new(big.Int).Exp(new(big.Int).SetBytes(em), key.D, key.N).FillBytes(make([]byte, key.Size()))
+}
+
+func testECKey(tb testing.TB) *ecdsa.PrivateKey {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(tb, err)
+	return key
+}
+
+func mustDecodeHex(tb testing.TB, s string) []byte {
+	b, err := hex.DecodeString(s)
+	require.NoError(tb, err)
+	return b
+}
+
+// A recordingPIVCard records the APDUs and returns the hex-encoded responses
+// in order.
+type recordingPIVCard struct {
+	apdus     [][]byte
+	responses []string
+}
+
+func (c *recordingPIVCard) begin() error { return nil }
+
+func (c *recordingPIVCard) end() {}
+
+func (c *recordingPIVCard) transmit(apdu []byte) ([]byte, error) {
+	c.apdus = append(c.apdus, append([]byte{}, apdu...))
+	resp := c.responses[0]
+	c.responses = c.responses[1:]
+	return hex.DecodeString(resp)
+}
+
+// A fakePIVCard implements the subset of the PIV applet used, with short
+// APDUs only, to test command and response chaining.
+type fakePIVCard struct {
+	t        *testing.T
+	slot     byte
+	key      crypto.Signer
+	cert     []byte
+	pin      string
+	selected bool
+	verified bool
+	pending  []byte // the command data received so far
+	response []byte // the response data not yet returned
+}
+
+func newFakePIVCard(t *testing.T, slot byte, key crypto.Signer, pin string, compress bool) *fakePIVCard {
+	cert := newPIVCertificateObject(t, key, compress)
+	return &fakePIVCard{t: t, slot: slot, key: key, cert: cert, pin: pin}
+}
+
+// newPIVCertificateObject returns a certificate data object with a
+// self-signed certificate for the key.
+func newPIVCertificateObject(tb testing.TB, key crypto.Signer, compress bool) []byte {
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "piv"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, key.Public(), key)
+	require.NoError(tb, err)
+
+	info := byte(0x00)
+	if compress {
+		var buf bytes.Buffer
+		w := gzip.NewWriter(&buf)
+		_, err := w.Write(der)
+		require.NoError(tb, err)
+		require.NoError(tb, w.Close())
+		der, info = buf.Bytes(), 0x01
+	}
+	var obj []byte
+	obj = appendTLV(obj, 0x70, der)
+	obj = appendTLV(obj, 0x71, []byte{info})
+	obj = appendTLV(obj, 0xfe, nil)
+	return appendTLV(nil, 0x53, obj)
+}
+
+func (c *fakePIVCard) begin() error { return nil }
+
+func (c *fakePIVCard) end() {}
+
+func (c *fakePIVCard) transmit(apdu []byte) ([]byte, error) {
+	require.GreaterOrEqual(c.t, len(apdu), 4)
+	cla, ins, p1, p2 := apdu[0], apdu[1], apdu[2], apdu[3]
+	if ins == insGetResponse {
+		return c.respond(nil, swOK), nil
+	}
+
+	var data []byte
+	if len(apdu) > 5 {
+		n := int(apdu[4])
+		require.GreaterOrEqual(c.t, len(apdu), 5+n, "short APDU")
+		data = apdu[5 : 5+n]
+	}
+	c.pending = append(c.pending, data...)
+	if cla&claChaining != 0 {
+		return []byte{0x90, 0x00}, nil
+	}
+	data, c.pending = c.pending, nil
+
+	switch ins {
+	case insSelect:
+		c.selected = bytes.Equal(data, pivAID)
+		c.verified = false
+		return c.respond(nil, swOK), nil
+	}
+	require.True(c.t, c.selected, "the PIV applet is not selected")
+	switch ins {
+	case insVerify:
+		if string(bytes.TrimRight(data, "\xff")) != c.pin {
+			return c.respond(nil, 0x63c2), nil
+		}
+		c.verified = true
+		return c.respond(nil, swOK), nil
+	case insGetData:
+		if !bytes.Equal(data, appendTLV(nil, 0x5c, pivObjectID(c.slot))) {
+			return c.respond(nil, swNotFound), nil
+		}
+		return c.respond(c.cert, swOK), nil
+	case insGeneralAuth:
+		require.Equal(c.t, c.slot, p2)
+		alg, err := pivAlgorithm(c.key.Public())
+		require.NoError(c.t, err)
+		require.Equal(c.t, alg, p1)
+		if c.pin != "" && !c.verified {
+			return c.respond(nil, swSecurityState), nil
+		}
+		outer, err := parseTLVs(data)
+		require.NoError(c.t, err)
+		tlvs, err := parseTLVs(outer[0x7c])
+		require.NoError(c.t, err)
+		challenge := tlvs[0x81]
+
+		var sig []byte
+		switch key := c.key.(type) {
+		case *rsa.PrivateKey:
+			require.Len(c.t, challenge, key.Size())
+			sig = rawRSASign(key, challenge)
+		case *ecdsa.PrivateKey:
+			require.Len(c.t, challenge, (key.Curve.Params().BitSize+7)/8)
+			sig, err = ecdsa.SignASN1(rand.Reader, key, challenge)
+			require.NoError(c.t, err)
+		}
+		return c.respond(appendTLV(nil, 0x7c, appendTLV(nil, 0x82, sig)), swOK), nil
+	}
+	return c.respond(nil, 0x6d00), nil
+}
+
+// respond returns up to 256 bytes of the response data, indicating how many
+// bytes remain with the status word 61xx.
+func (c *fakePIVCard) respond(data []byte, sw uint16) []byte {
+	if data != nil {
+		c.response = data
+	}
+	n := min(len(c.response), 0x100)
+	resp := append([]byte{}, c.response[:n]...)
+	c.response = c.response[n:]
+	if len(c.response) > 0 {
+		return append(resp, 0x61, byte(min(len(c.response), 0xff)))
+	}
+	return append(resp, byte(sw>>8), byte(sw))
+}
@@ -0,0 +1,165 @@
+package certstore
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// PKCS11PINEnv is the environment variable holding the user PIN of the
+// PKCS#11 token.
+const PKCS11PINEnv = "POMERIUM_CLI_PKCS11_PIN"
+
+// A PKCS11Config selects a client certificate and its private key on a
+// PKCS#11 token, i.e. a smart card or HSM.
+type PKCS11Config struct {
+	// Module is the path to the PKCS#11 module, e.g. /usr/lib/opensc-pkcs11.so.
+	Module string
+	// Slot and Token restrict the search to the token in the slot with the
+	// ID, or to the token with the label.
+	Slot  *uint
+	Token string
+	// Label and ID restrict the search to the certificate and key with the
+	// CKA_LABEL or the CKA_ID.
+	Label string
+	ID    []byte
+
+	// params are the library parameters passed to C_Initialize, as used by
+	// the NSS softoken, and pinEnv overrides the PIN environment variable
+	// named in errors.
+	params, pinEnv string
+}
+
+// ParsePKCS11Config parses a comma-separated list of key=value pairs, i.e.
+// "module=/usr/lib/opensc-pkcs11.so,token=PIV,id=01". The keys are module,
+// which is required, slot, token, label and id, which is hex-encoded.
+func ParsePKCS11Config(s string) (*PKCS11Config, error) {
+	cfg := new(PKCS11Config)
+	for _, kv := range strings.Split(s, ",") {
+		k, v, ok := strings.Cut(kv, "=")
+		if !ok {
+			return nil, fmt.Errorf("pkcs11: expected key=value, but was %q", kv)
+		}
+		switch strings.TrimSpace(k) {
+		case "module":
+			cfg.Module = v
+		case "slot":
+			slot, err := strconv.ParseUint(v, 10, 0)
+			if err != nil {
+				return nil, fmt.Errorf("pkcs11: invalid slot %q", v)
+			}
+			cfg.Slot = new(uint)
+			*cfg.Slot = uint(slot)
+		case "token":
+			cfg.Token = v
+		case "label":
+			cfg.Label = v
+		case "id":
+			id, err := hex.DecodeString(v)
+			if err != nil {
+				return nil, fmt.Errorf("pkcs11: invalid id %q, expected hex", v)
+			}
+			cfg.ID = id
+		default:
+			return nil, fmt.Errorf("pkcs11: unknown key %q", k)
+		}
+	}
+	if cfg.Module == "" {
+		return nil, fmt.Errorf("pkcs11: module is required")
+	}
+	return cfg, nil
+}
+
+// GetPKCS11ClientCertificateFunc returns a function suitable for use as a
+// [tls.Config.GetClientCertificate] callback. This function returns the first
+// certificate on the PKCS#11 token which was issued by one of the acceptable
+// CAs from the Certificate Request message. The user PIN is read from the
+// PKCS11PINEnv environment variable, if the token requires it.
+func GetPKCS11ClientCertificateFunc(
+	cfg *PKCS11Config,
+) (func(*tls.CertificateRequestInfo) (*tls.Certificate, error), error) {
+	if !IsPKCS11Supported {
+		return nil, errNotSupported
+	}
+
+	creds, err := loadPKCS11Creds(cfg, os.Getenv(PKCS11PINEnv))
+	if err != nil {
+		return nil, fmt.Errorf("pkcs11: %w", err)
+	}
+	if len(creds) == 0 {
+		return nil, fmt.Errorf("pkcs11: no certificate with a private key found")
+	}
+
+	return func(cri *tls.CertificateRequestInfo) (*tls.Certificate, error) {
+		for _, cred := range creds {
+			if isIssuedByAcceptableCA(cred.CertificateChain(), cri.AcceptableCAs) {
+				return toTLSCertificate(cred), nil
+			}
+		}
+		return nil, errors.New("pkcs11: no certificate issued by an acceptable CA found")
+	}, nil
+}
+
+// isIssuedByAcceptableCA checks whether a certificate of the chain was issued
+// by one of the CAs, any chain is acceptable if there are none.
+func isIssuedByAcceptableCA(chain [][]byte, acceptableCAs [][]byte) bool {
+	if len(acceptableCAs) == 0 {
+		return true
+	}
+	for _, der := range chain {
+		cert, err := x509.ParseCertificate(der)
+		if err != nil {
+			continue
+		}
+		for _, ca := range acceptableCAs {
+			if bytes.Equal(cert.RawIssuer, ca) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// DigestInfo prefixes of PKCS #1 v1.5 signatures, see RFC 8017 section 9.2.
+var digestInfoPrefixes = map[crypto.Hash][]byte{
+	crypto.SHA1:   {0x30, 0x21, 0x30, 0x09, 0x06, 0x05, 0x2b, 0x0e, 0x03, 0x02, 0x1a, 0x05, 0x00, 0x04, 0x14},
+	crypto.SHA224: {0x30, 0x2d, 0x30, 0x0d, 0x06, 0x09, 0x60, 0x86, 0x48, 0x01, 0x65, 0x03, 0x04, 0x02, 0x04, 0x05, 0x00, 0x04, 0x1c},
+	crypto.SHA256: {0x30, 0x31, 0x30, 0x0d, 0x06, 0x09, 0x60, 0x86, 0x48, 0x01, 0x65, 0x03, 0x04, 0x02, 0x01, 0x05, 0x00, 0x04, 0x20},
+	crypto.SHA384: {0x30, 0x41, 0x30, 0x0d, 0x06, 0x09, 0x60, 0x86, 0x48, 0x01, 0x65, 0x03, 0x04, 0x02, 0x02, 0x05, 0x00, 0x04, 0x30},
+	crypto.SHA512: {0x30, 0x51, 0x30, 0x0d, 0x06, 0x09, 0x60, 0x86, 0x48, 0x01, 0x65, 0x03, 0x04, 0x02, 0x03, 0x05, 0x00, 0x04, 0x40},
+}
+
+// pkcs1v15DigestInfo returns the DigestInfo to sign with CKM_RSA_PKCS, which
+// expects the hash to be wrapped.
+func pkcs1v15DigestInfo(hash crypto.Hash, digest []byte) ([]byte, error) {
+	prefix, ok := digestInfoPrefixes[hash]
+	if !ok {
+		return nil, fmt.Errorf("unsupported hash %v", hash)
+	}
+	if len(digest) != hash.Size() {
+		return nil, fmt.Errorf("invalid digest length %d for %v", len(digest), hash)
+	}
+	return append(append([]byte{}, prefix...), digest...), nil
+}
+
+// ecdsaSignatureToASN1 converts a CKM_ECDSA signature, which is r and s
+// concatenated, to the ASN.1 encoding used by Go.
+func ecdsaSignatureToASN1(sig []byte) ([]byte, error) {
+	if len(sig) == 0 || len(sig)%2 != 0 {
+		return nil, fmt.Errorf("invalid ecdsa signature length %d", len(sig))
+	}
+	n := len(sig) / 2
+	return asn1.Marshal(struct{ R, S *big.Int }{
+		R: new(big.Int).SetBytes(sig[:n]),
+		S: new(big.Int).SetBytes(sig[n:]),
+	})
+}
@@ -0,0 +1,252 @@
+//go:build linux && cgo
+
+package certstore
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"unsafe"
+
+	"github.com/miekg/pkcs11"
+
+	"github.com/pomerium/cli/version"
+)
+
+var IsPKCS11Supported = true
+
+func init() {
+	version.Features = append(version.Features, "pkcs11")
+}
+
+// hash mechanisms and mask generation functions for CKM_RSA_PKCS_PSS
+var pssParams = map[crypto.Hash]struct{ hashAlg, mgf uint }{
+	crypto.SHA256: {pkcs11.CKM_SHA256, pkcs11.CKG_MGF1_SHA256},
+	crypto.SHA384: {pkcs11.CKM_SHA384, pkcs11.CKG_MGF1_SHA384},
+	crypto.SHA512: {pkcs11.CKM_SHA512, pkcs11.CKG_MGF1_SHA512},
+}
+
+// A pkcs11Session is a session with a token, its operations are serialized.
+type pkcs11Session struct {
+	ctx    *pkcs11.Ctx
+	handle pkcs11.SessionHandle
+	mu     sync.Mutex
+}
+
+// loadPKCS11Creds loads the module and returns the certificates with a
+// private key on the selected tokens.
+func loadPKCS11Creds(cfg *PKCS11Config, pin string) ([]credential, error) {
+	ctx := pkcs11.New(cfg.Module)
+	if ctx == nil {
+		return nil, fmt.Errorf("failed to load module %s", cfg.Module)
+	}
+
+	var opts []pkcs11.InitializeOption
+	if cfg.params != "" {
+		// the parameters are a NUL-terminated string, read during C_Initialize
+		params := append([]byte(cfg.params), 0)
+		opts = append(opts, pkcs11.InitializeWithReserved(unsafe.Pointer(&params[0])))
+	}
+	err := ctx.Initialize(opts...)
+	if err != nil && !errors.Is(err, pkcs11.Error(pkcs11.CKR_CRYPTOKI_ALREADY_INITIALIZED)) {
+		return nil, fmt.Errorf("C_Initialize: %w", err)
+	}
+
+	slots, err := ctx.GetSlotList(true)
+	if err != nil {
+		return nil, fmt.Errorf("C_GetSlotList: %w", err)
+	}
+
+	var creds []credential
+	for _, slot := range slots {
+		if cfg.Slot != nil && slot != *cfg.Slot {
+			continue
+		}
+		info, err := ctx.GetTokenInfo(slot)
+		if err != nil {
+			return nil, fmt.Errorf("C_GetTokenInfo: %w", err)
+		}
+		if cfg.Token != "" && info.Label != cfg.Token {
+			continue
+		}
+
+		handle, err := ctx.OpenSession(slot, pkcs11.CKF_SERIAL_SESSION)
+		if err != nil {
+			return nil, fmt.Errorf("C_OpenSession: %w", err)
+		}
+		s := &pkcs11Session{ctx: ctx, handle: handle}
+		if err := s.login(info.Flags, pin, cfg.pinEnv); err != nil {
+			return nil, err
+		}
+		found, err := s.findCreds(cfg)
+		if err != nil {
+			return nil, err
+		}
+		creds = append(creds, found...)
+	}
+	return creds, nil
+}
+
+func (s *pkcs11Session) login(tokenFlags uint, pin, pinEnv string) error {
+	switch {
+	case tokenFlags&pkcs11.CKF_LOGIN_REQUIRED == 0:
+		return nil
+	case pin != "":
+	case tokenFlags&pkcs11.CKF_PROTECTED_AUTHENTICATION_PATH != 0:
+		// the PIN is entered on a PIN pad, an empty PIN is passed as NULL
+	default:
+		if pinEnv == "" {
+			pinEnv = PKCS11PINEnv
+		}
+		return fmt.Errorf("the token requires a PIN, set %s", pinEnv)
+	}
+	err := s.ctx.Login(s.handle, pkcs11.CKU_USER, pin)
+	if err != nil && !errors.Is(err, pkcs11.Error(pkcs11.CKR_USER_ALREADY_LOGGED_IN)) {
+		return fmt.Errorf("C_Login: %w", err)
+	}
+	return nil
+}
+
+// findCreds returns the certificates with a private key of the same CKA_ID.
+func (s *pkcs11Session) findCreds(cfg *PKCS11Config) ([]credential, error) {
+	template := []*pkcs11.Attribute{pkcs11.NewAttribute(pkcs11.CKA_CLASS, pkcs11.CKO_CERTIFICATE)}
+	if cfg.Label != "" {
+		template = append(template, pkcs11.NewAttribute(pkcs11.CKA_LABEL, cfg.Label))
+	}
+	if cfg.ID != nil {
+		template = append(template, pkcs11.NewAttribute(pkcs11.CKA_ID, cfg.ID))
+	}
+	certs, err := s.findObjects(template)
+	if err != nil {
+		return nil, err
+	}
+
+	var creds []credential
+	for _, obj := range certs {
+		attrs, err := s.getAttributes(obj, pkcs11.CKA_VALUE, pkcs11.CKA_ID)
+		if err != nil {
+			return nil, err
+		}
+		cert, err := x509.ParseCertificate(attrs[0].Value)
+		if err != nil {
+			continue
+		}
+		keys, err := s.findObjects([]*pkcs11.Attribute{
+			pkcs11.NewAttribute(pkcs11.CKA_CLASS, pkcs11.CKO_PRIVATE_KEY),
+			pkcs11.NewAttribute(pkcs11.CKA_ID, attrs[1].Value),
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(keys) == 0 {
+			continue
+		}
+		creds = append(creds, &pkcs11Key{session: s, handle: keys[0], cert: cert})
+	}
+	return creds, nil
+}
+
+func (s *pkcs11Session) findObjects(template []*pkcs11.Attribute) ([]pkcs11.ObjectHandle, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.ctx.FindObjectsInit(s.handle, template); err != nil {
+		return nil, fmt.Errorf("C_FindObjectsInit: %w", err)
+	}
+	defer s.ctx.FindObjectsFinal(s.handle)
+
+	var objects []pkcs11.ObjectHandle
+	for {
+		batch, _, err := s.ctx.FindObjects(s.handle, 16)
+		if err != nil {
+			return nil, fmt.Errorf("C_FindObjects: %w", err)
+		}
+		if len(batch) == 0 {
+			return objects, nil
+		}
+		objects = append(objects, batch...)
+	}
+}
+
+// getAttributes returns the values of the attributes of the object, in order.
+func (s *pkcs11Session) getAttributes(obj pkcs11.ObjectHandle, types ...uint) ([]*pkcs11.Attribute, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	template := make([]*pkcs11.Attribute, len(types))
+	for i, typ := range types {
+		template[i] = pkcs11.NewAttribute(typ, nil)
+	}
+	attrs, err := s.ctx.GetAttributeValue(s.handle, obj, template)
+	if err != nil {
+		return nil, fmt.Errorf("C_GetAttributeValue: %w", err)
+	}
+	return attrs, nil
+}
+
+// sign signs the data with the key in a single-part operation.
+func (s *pkcs11Session) sign(key pkcs11.ObjectHandle, mechanism *pkcs11.Mechanism, data []byte) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.ctx.SignInit(s.handle, []*pkcs11.Mechanism{mechanism}, key); err != nil {
+		return nil, fmt.Errorf("C_SignInit: %w", err)
+	}
+	sig, err := s.ctx.Sign(s.handle, data)
+	if err != nil {
+		return nil, fmt.Errorf("C_Sign: %w", err)
+	}
+	return sig, nil
+}
+
+// A pkcs11Key is a certificate and its private key on a token.
+type pkcs11Key struct {
+	session *pkcs11Session
+	handle  pkcs11.ObjectHandle
+	cert    *x509.Certificate
+}
+
+func (k *pkcs11Key) CertificateChain() [][]byte {
+	return [][]byte{k.cert.Raw}
+}
+
+func (k *pkcs11Key) Public() crypto.PublicKey {
+	return k.cert.PublicKey
+}
+
+func (k *pkcs11Key) Sign(_ io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	switch pub := k.cert.PublicKey.(type) {
+	case *ecdsa.PublicKey:
+		sig, err := k.session.sign(k.handle, pkcs11.NewMechanism(pkcs11.CKM_ECDSA, nil), digest)
+		if err != nil {
+			return nil, err
+		}
+		return ecdsaSignatureToASN1(sig)
+	case *rsa.PublicKey:
+		if pssOpts, ok := opts.(*rsa.PSSOptions); ok {
+			p, ok := pssParams[opts.HashFunc()]
+			if !ok {
+				return nil, fmt.Errorf("unsupported hash %v", opts.HashFunc())
+			}
+			saltLength := pssOpts.SaltLength
+			if saltLength == rsa.PSSSaltLengthAuto || saltLength == rsa.PSSSaltLengthEqualsHash {
+				saltLength = opts.HashFunc().Size()
+			}
+			params := pkcs11.NewPSSParams(p.hashAlg, p.mgf, uint(saltLength))
+			return k.session.sign(k.handle, pkcs11.NewMechanism(pkcs11.CKM_RSA_PKCS_PSS, params), digest)
+		}
+
+		data, err := pkcs1v15DigestInfo(opts.HashFunc(), digest)
+		if err != nil {
+			return nil, err
+		}
+		return k.session.sign(k.handle, pkcs11.NewMechanism(pkcs11.CKM_RSA_PKCS, nil), data)
+	default:
+		return nil, fmt.Errorf("unsupported key type %T", pub)
+	}
+}
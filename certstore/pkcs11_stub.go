@@ -0,0 +1,11 @@
+//go:build !(linux && cgo)
+
+package certstore
+
+var IsPKCS11Supported = false
+
+// loadPKCS11Creds is a stub that always returns an error, for builds where
+// this feature is not supported.
+func loadPKCS11Creds(*PKCS11Config, string) ([]credential, error) {
+	return nil, errNotSupported
+}
@@ -0,0 +1,96 @@
+package certstore
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePKCS11Config(t *testing.T) {
+	cfg, err := ParsePKCS11Config("module=/usr/lib/opensc-pkcs11.so,slot=1,token=PIV Card,label=Auth,id=0a01")
+	require.NoError(t, err)
+	slot := uint(1)
+	assert.Equal(t, &PKCS11Config{
+		Module: "/usr/lib/opensc-pkcs11.so",
+		Slot:   &slot,
+		Token:  "PIV Card",
+		Label:  "Auth",
+		ID:     []byte{0x0a, 0x01},
+	}, cfg)
+
+	for _, s := range []string{
+		"",
+		"token=PIV",
+		"module",
+		"module=a.so,slot=x",
+		"module=a.so,id=xyz",
+		"module=a.so,pin=1234",
+	} {
+		_, err := ParsePKCS11Config(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestPKCS1v15DigestInfo(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	for _, hash := range []crypto.Hash{crypto.SHA256, crypto.SHA384, crypto.SHA512} {
+		h := hash.New()
+		h.Write([]byte("data"))
+		digest := h.Sum(nil)
+
+		data, err := pkcs1v15DigestInfo(hash, digest)
+		require.NoError(t, err)
+		// like CKM_RSA_PKCS, sign the data as it is
+		sig, err := rsa.SignPKCS1v15(rand.Reader, key, 0, data)
+		require.NoError(t, err)
+		assert.NoError(t, rsa.VerifyPKCS1v15(&key.PublicKey, hash, digest, sig), hash)
+	}
+
+	digest := sha512.Sum512([]byte("data"))
+	_, err = pkcs1v15DigestInfo(crypto.SHA256, digest[:])
+	assert.Error(t, err)
+	_, err = pkcs1v15DigestInfo(crypto.MD5, digest[:16])
+	assert.Error(t, err)
+}
+
+func TestECDSASignatureToASN1(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	digest := sha256.Sum256([]byte("data"))
+	r, s, err := ecdsa.Sign(rand.Reader, key, digest[:])
+	require.NoError(t, err)
+
+	// like CKM_ECDSA, r and s are padded to the size of the curve
+	raw := make([]byte, 64)
+	r.FillBytes(raw[:32])
+	s.FillBytes(raw[32:])
+	sig, err := ecdsaSignatureToASN1(raw)
+	require.NoError(t, err)
+	assert.True(t, ecdsa.VerifyASN1(&key.PublicKey, digest[:], sig))
+
+	_, err = ecdsaSignatureToASN1(raw[:63])
+	assert.Error(t, err)
+}
+
+func TestIsIssuedByAcceptableCA(t *testing.T) {
+	p, _ := pem.Decode([]byte(testCertPEM))
+	cert, err := x509.ParseCertificate(p.Bytes)
+	require.NoError(t, err)
+
+	chain := [][]byte{cert.Raw}
+	assert.True(t, isIssuedByAcceptableCA(chain, nil))
+	assert.True(t, isIssuedByAcceptableCA(chain, [][]byte{[]byte("other"), cert.RawIssuer}))
+	assert.False(t, isIssuedByAcceptableCA(chain, [][]byte{[]byte("other")}))
+}
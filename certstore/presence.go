@@ -0,0 +1,63 @@
+package certstore
+
+import (
+	"crypto"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// prompts are shown one at a time
+var userPresenceMu sync.Mutex
+
+// A presencePrompter asks the user to prove their presence for the reason,
+// and returns an error unless they did.
+type presencePrompter func(reason string) error
+
+// presenceSigner asks the user to approve each signature
+type presenceSigner struct {
+	crypto.Signer
+	reason   string
+	onPrompt func()
+	prompt   presencePrompter
+}
+
+// withUserPresence returns a copy of the certificate whose key asks the user
+// to approve each signature with prompt.
+func withUserPresence(cert *tls.Certificate, onPrompt func(), prompt presencePrompter) (*tls.Certificate, error) {
+	signer, ok := cert.PrivateKey.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unexpected private key type %T", cert.PrivateKey)
+	}
+
+	name := "a client certificate"
+	if len(cert.Certificate) > 0 {
+		if leaf, err := x509.ParseCertificate(cert.Certificate[0]); err == nil && leaf.Subject.CommonName != "" {
+			name = fmt.Sprintf("the client certificate %q", leaf.Subject.CommonName)
+		}
+	}
+
+	c := *cert
+	c.PrivateKey = &presenceSigner{
+		Signer:   signer,
+		reason:   "pomerium-cli wants to use " + name + " to connect",
+		onPrompt: onPrompt,
+		prompt:   prompt,
+	}
+	return &c, nil
+}
+
+func (s *presenceSigner) Sign(rand io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	userPresenceMu.Lock()
+	defer userPresenceMu.Unlock()
+
+	if s.onPrompt != nil {
+		s.onPrompt()
+	}
+	if err := s.prompt(s.reason); err != nil {
+		return nil, fmt.Errorf("user presence: %w", err)
+	}
+	return s.Signer.Sign(rand, digest, opts)
+}
@@ -0,0 +1,64 @@
+//go:build darwin && cgo
+
+package certstore
+
+/*
+#cgo CFLAGS: -x objective-c -fobjc-arc -mmacosx-version-min=10.12
+#cgo LDFLAGS: -framework Foundation -framework LocalAuthentication
+
+#include <stdlib.h>
+#include <string.h>
+#import <Foundation/Foundation.h>
+#import <LocalAuthentication/LocalAuthentication.h>
+
+// confirmUserPresence asks for Touch ID or the account password. It returns
+// 1 if the user was authenticated, otherwise 0 and the error description in
+// errOut, which must be freed.
+static int confirmUserPresence(const char *reason, char **errOut) {
+	@autoreleasepool {
+		LAContext *ctx = [[LAContext alloc] init];
+		dispatch_semaphore_t done = dispatch_semaphore_create(0);
+		__block BOOL ok = NO;
+		__block NSString *msg = nil;
+		[ctx evaluatePolicy:LAPolicyDeviceOwnerAuthentication
+		    localizedReason:[NSString stringWithUTF8String:reason]
+		              reply:^(BOOL success, NSError *error) {
+			ok = success;
+			if (!success && error != nil) {
+				msg = [error localizedDescription];
+			}
+			dispatch_semaphore_signal(done);
+		}];
+		dispatch_semaphore_wait(done, DISPATCH_TIME_FOREVER);
+		if (!ok) {
+			*errOut = strdup(msg != nil ? [msg UTF8String] : "authentication failed");
+		}
+		return ok ? 1 : 0;
+	}
+}
+*/
+import "C"
+
+import (
+	"crypto/tls"
+	"errors"
+	"unsafe"
+)
+
+func requireUserPresence(cert *tls.Certificate, onPrompt func()) (*tls.Certificate, error) {
+	return withUserPresence(cert, onPrompt, confirmUserPresence)
+}
+
+// confirmUserPresence asks the user to authenticate with Touch ID or the
+// account password
+func confirmUserPresence(reason string) error {
+	cReason := C.CString(reason)
+	defer C.free(unsafe.Pointer(cReason))
+
+	var cErr *C.char
+	if C.confirmUserPresence(cReason, &cErr) == 1 {
+		return nil
+	}
+	defer C.free(unsafe.Pointer(cErr))
+	return errors.New(C.GoString(cErr))
+}
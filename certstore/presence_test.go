@@ -0,0 +1,130 @@
+package certstore
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"math/big"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakePrompter records the prompts and answers them with err
+type fakePrompter struct {
+	err     error
+	reasons []string
+}
+
+func (p *fakePrompter) prompt(reason string) error {
+	p.reasons = append(p.reasons, reason)
+	return p.err
+}
+
+func newPresenceTestCert(t *testing.T, commonName string) (*tls.Certificate, *ecdsa.PrivateKey) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.CreateCertificate(rand.Reader, &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}, &x509.Certificate{SerialNumber: big.NewInt(1)}, key.Public(), key)
+	require.NoError(t, err)
+	return &tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}, key
+}
+
+func TestPresenceSigner(t *testing.T) {
+	digest := sha256.Sum256([]byte("handshake"))
+
+	t.Run("approved", func(t *testing.T) {
+		cert, key := newPresenceTestCert(t, "alice")
+		prompter := &fakePrompter{}
+		var prompts int
+		c, err := withUserPresence(cert, func() { prompts++ }, prompter.prompt)
+		require.NoError(t, err)
+		assert.Equal(t, cert.Certificate, c.Certificate)
+		assert.Same(t, key, cert.PrivateKey, "the certificate should not be modified")
+
+		signer := c.PrivateKey.(crypto.Signer)
+		assert.Equal(t, key.Public(), signer.Public())
+		sig, err := signer.Sign(rand.Reader, digest[:], crypto.SHA256)
+		require.NoError(t, err)
+		assert.True(t, ecdsa.VerifyASN1(&key.PublicKey, digest[:], sig))
+		assert.Equal(t, []string{`pomerium-cli wants to use the client certificate "alice" to connect`}, prompter.reasons)
+		assert.Equal(t, 1, prompts)
+
+		_, err = signer.Sign(rand.Reader, digest[:], crypto.SHA256)
+		require.NoError(t, err)
+		assert.Len(t, prompter.reasons, 2, "each signature should be approved")
+		assert.Equal(t, 2, prompts)
+	})
+
+	t.Run("denied", func(t *testing.T) {
+		cert, _ := newPresenceTestCert(t, "alice")
+		denied := errors.New("denied by the user")
+		c, err := withUserPresence(cert, nil, (&fakePrompter{err: denied}).prompt)
+		require.NoError(t, err)
+
+		sig, err := c.PrivateKey.(crypto.Signer).Sign(rand.Reader, digest[:], crypto.SHA256)
+		assert.ErrorIs(t, err, denied)
+		assert.Nil(t, sig)
+	})
+
+	t.Run("no common name", func(t *testing.T) {
+		cert, _ := newPresenceTestCert(t, "")
+		prompter := &fakePrompter{}
+		c, err := withUserPresence(cert, nil, prompter.prompt)
+		require.NoError(t, err)
+		_, err = c.PrivateKey.(crypto.Signer).Sign(rand.Reader, digest[:], crypto.SHA256)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"pomerium-cli wants to use a client certificate to connect"}, prompter.reasons)
+	})
+
+	t.Run("not a signer", func(t *testing.T) {
+		_, err := withUserPresence(&tls.Certificate{PrivateKey: "key"}, nil, (&fakePrompter{}).prompt)
+		assert.Error(t, err)
+	})
+
+	t.Run("one prompt at a time", func(t *testing.T) {
+		cert, _ := newPresenceTestCert(t, "alice")
+		var active, maxActive atomic.Int32
+		c, err := withUserPresence(cert, nil, func(string) error {
+			n := active.Add(1)
+			defer active.Add(-1)
+			for {
+				m := maxActive.Load()
+				if n <= m || maxActive.CompareAndSwap(m, n) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			return nil
+		})
+		require.NoError(t, err)
+
+		var wg sync.WaitGroup
+		for range 4 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := c.PrivateKey.(crypto.Signer).Sign(rand.Reader, digest[:], crypto.SHA256)
+				assert.NoError(t, err)
+			}()
+		}
+		wg.Wait()
+		assert.Equal(t, int32(1), maxActive.Load())
+	})
+}
@@ -0,0 +1,261 @@
+//go:build windows && cgo
+
+package certstore
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"runtime"
+	"syscall"
+	"time"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+var (
+	combase                    = windows.NewLazySystemDLL("combase.dll")
+	procRoInitialize           = combase.NewProc("RoInitialize")
+	procRoUninitialize         = combase.NewProc("RoUninitialize")
+	procRoGetActivationFactory = combase.NewProc("RoGetActivationFactory")
+	procWindowsCreateString    = combase.NewProc("WindowsCreateString")
+	procWindowsDeleteString    = combase.NewProc("WindowsDeleteString")
+
+	kernel32             = windows.NewLazySystemDLL("kernel32.dll")
+	procGetConsoleWindow = kernel32.NewProc("GetConsoleWindow")
+
+	user32                        = windows.NewLazySystemDLL("user32.dll")
+	procGetProcessWindowStation   = user32.NewProc("GetProcessWindowStation")
+	procGetUserObjectInformationW = user32.NewProc("GetUserObjectInformationW")
+)
+
+var (
+	// IUserConsentVerifierInterop, see UserConsentVerifierInterop.h
+	iidUserConsentVerifierInterop = windows.GUID{
+		Data1: 0x39e050c3, Data2: 0x4e74, Data3: 0x441a,
+		Data4: [8]byte{0x8d, 0xc0, 0xb8, 0x11, 0x04, 0xdf, 0x94, 0x9c},
+	}
+	// IAsyncOperation<UserConsentVerificationResult>, derived from the signature
+	// pinterface({9fc2b0bb-e446-44e2-aa61-9cab8f636af2};enum(Windows.Security.Credentials.UI.UserConsentVerificationResult;i4))
+	iidAsyncOperationUserConsentVerificationResult = windows.GUID{
+		Data1: 0xfd596ffd, Data2: 0x2318, Data3: 0x558f,
+		Data4: [8]byte{0x9d, 0xbe, 0xd2, 0x1d, 0xf4, 0x37, 0x64, 0xa5},
+	}
+	iidAsyncInfo = windows.GUID{
+		Data1: 0x00000036, Data2: 0x0000, Data3: 0x0000,
+		Data4: [8]byte{0xc0, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x46},
+	}
+)
+
+const (
+	roInitMultithreaded = 1
+	rpcEChangedMode     = 0x80010106
+
+	uoiFlags   = 1
+	wsfVisible = 1
+
+	// vtable slots, the IInspectable methods take the first six
+	slotQueryInterface                    = 0
+	slotRelease                           = 2
+	slotRequestVerificationForWindowAsync = 6 // IUserConsentVerifierInterop
+	slotAsyncInfoStatus                   = 7 // IAsyncInfo.get_Status
+	slotAsyncInfoErrorCode                = 8 // IAsyncInfo.get_ErrorCode
+	slotAsyncOperationGetResults          = 8 // IAsyncOperation.GetResults
+
+	asyncStatusStarted   = 0
+	asyncStatusCompleted = 1
+	asyncStatusCanceled  = 2
+
+	asyncPollInterval = 100 * time.Millisecond
+)
+
+// errNoInteractiveDesktop is returned instead of prompting in a service: no
+// one would see the prompt, which would block forever.
+var errNoInteractiveDesktop = errors.New("not running on an interactive desktop, e.g. in a service")
+
+// UserConsentVerificationResult values other than Verified
+var userConsentVerificationErrors = map[int32]error{
+	1: errors.New("no Windows Hello device is available"),
+	2: errors.New("Windows Hello is not set up for the user"),
+	3: errors.New("Windows Hello is disabled by policy"),
+	4: errors.New("the Windows Hello device is busy"),
+	5: errors.New("too many failed attempts"),
+	6: errors.New("denied by the user"),
+}
+
+func requireUserPresence(cert *tls.Certificate, onPrompt func()) (*tls.Certificate, error) {
+	return withUserPresence(cert, onPrompt, confirmUserPresence)
+}
+
+// confirmUserPresence asks the user to verify their identity with Windows
+// Hello: face, fingerprint or PIN. Unlike a dialog, which any process of the
+// user could dismiss, this requires the user to be present.
+func confirmUserPresence(reason string) error {
+	if !interactiveDesktop() {
+		return errNoInteractiveDesktop
+	}
+
+	// the Windows Runtime is initialized per thread
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+	hr, _, _ := procRoInitialize.Call(roInitMultithreaded)
+	switch {
+	case uint32(hr) == rpcEChangedMode:
+		// the thread is already initialized as a single-threaded apartment
+	case failed(hr):
+		return hresultError("RoInitialize", hr)
+	default:
+		defer procRoUninitialize.Call()
+	}
+
+	className, err := newHString("Windows.Security.Credentials.UI.UserConsentVerifier")
+	if err != nil {
+		return err
+	}
+	defer className.free()
+	var verifier *comObject
+	hr, _, _ = procRoGetActivationFactory.Call(uintptr(className),
+		uintptr(unsafe.Pointer(&iidUserConsentVerifierInterop)), uintptr(unsafe.Pointer(&verifier)))
+	if failed(hr) {
+		return hresultError("RoGetActivationFactory", hr)
+	}
+	defer verifier.release()
+
+	message, err := newHString(reason)
+	if err != nil {
+		return err
+	}
+	defer message.free()
+	var op *comObject
+	hr = verifier.call(slotRequestVerificationForWindowAsync, promptWindow(), uintptr(message),
+		uintptr(unsafe.Pointer(&iidAsyncOperationUserConsentVerificationResult)), uintptr(unsafe.Pointer(&op)))
+	if failed(hr) {
+		return hresultError("RequestVerificationForWindowAsync", hr)
+	}
+	defer op.release()
+
+	result, err := waitForResult(op)
+	if err != nil {
+		return err
+	}
+	if err, ok := userConsentVerificationErrors[result]; ok {
+		return err
+	} else if result != 0 {
+		return fmt.Errorf("unexpected verification result %d", result)
+	}
+	return nil
+}
+
+// interactiveDesktop reports whether the process runs on a desktop the user
+// sees: services run in session 0, on a window station that is not visible.
+func interactiveDesktop() bool {
+	var session uint32
+	if err := windows.ProcessIdToSessionId(windows.GetCurrentProcessId(), &session); err != nil || session == 0 {
+		return false
+	}
+
+	winsta, _, _ := procGetProcessWindowStation.Call()
+	if winsta == 0 {
+		return false
+	}
+	// USEROBJECTFLAGS
+	var flags struct {
+		inherit  int32
+		reserved int32
+		flags    uint32
+	}
+	var n uint32
+	ok, _, _ := procGetUserObjectInformationW.Call(winsta, uoiFlags,
+		uintptr(unsafe.Pointer(&flags)), unsafe.Sizeof(flags), uintptr(unsafe.Pointer(&n)))
+	return ok != 0 && flags.flags&wsfVisible != 0
+}
+
+// promptWindow returns the window the prompt is shown over: the console, or
+// the foreground window when there is none, e.g. when started by Pomerium
+// Desktop.
+func promptWindow() uintptr {
+	if hwnd, _, _ := procGetConsoleWindow.Call(); hwnd != 0 {
+		return hwnd
+	}
+	return uintptr(windows.GetForegroundWindow())
+}
+
+// waitForResult polls the asynchronous operation until it completes, and
+// returns its result.
+func waitForResult(op *comObject) (int32, error) {
+	var info *comObject
+	hr := op.call(slotQueryInterface, uintptr(unsafe.Pointer(&iidAsyncInfo)), uintptr(unsafe.Pointer(&info)))
+	if failed(hr) {
+		return 0, hresultError("IAsyncInfo", hr)
+	}
+	defer info.release()
+
+	for {
+		var status int32
+		if hr := info.call(slotAsyncInfoStatus, uintptr(unsafe.Pointer(&status))); failed(hr) {
+			return 0, hresultError("IAsyncInfo.Status", hr)
+		}
+		switch status {
+		case asyncStatusStarted:
+			time.Sleep(asyncPollInterval)
+		case asyncStatusCompleted:
+			var result int32
+			if hr := op.call(slotAsyncOperationGetResults, uintptr(unsafe.Pointer(&result))); failed(hr) {
+				return 0, hresultError("IAsyncOperation.GetResults", hr)
+			}
+			return result, nil
+		case asyncStatusCanceled:
+			return 0, errors.New("verification canceled")
+		default:
+			var code int32
+			if hr := info.call(slotAsyncInfoErrorCode, uintptr(unsafe.Pointer(&code))); failed(hr) {
+				return 0, hresultError("IAsyncInfo.ErrorCode", hr)
+			}
+			return 0, hresultError("UserConsentVerifier", uintptr(uint32(code)))
+		}
+	}
+}
+
+// A comObject is a COM interface, which starts with a pointer to its vtable.
+type comObject struct {
+	vtbl *[16]uintptr
+}
+
+func (o *comObject) call(slot int, args ...uintptr) uintptr {
+	hr, _, _ := syscall.SyscallN(o.vtbl[slot], append([]uintptr{uintptr(unsafe.Pointer(o))}, args...)...)
+	return hr
+}
+
+func (o *comObject) release() {
+	o.call(slotRelease)
+}
+
+// An hstring is a Windows Runtime string.
+type hstring uintptr
+
+func newHString(s string) (hstring, error) {
+	u, err := windows.UTF16FromString(s)
+	if err != nil {
+		return 0, err
+	}
+	var h hstring
+	hr, _, _ := procWindowsCreateString.Call(uintptr(unsafe.Pointer(&u[0])), uintptr(len(u)-1),
+		uintptr(unsafe.Pointer(&h)))
+	if failed(hr) {
+		return 0, hresultError("WindowsCreateString", hr)
+	}
+	return h, nil
+}
+
+func (h hstring) free() {
+	_, _, _ = procWindowsDeleteString.Call(uintptr(h))
+}
+
+func failed(hr uintptr) bool {
+	return int32(uint32(hr)) < 0
+}
+
+func hresultError(op string, hr uintptr) error {
+	return fmt.Errorf("%s: %w", op, windows.Errno(uint32(hr)))
+}
@@ -0,0 +1,108 @@
+package certstore
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// A SelectPolicy chooses among several matching client certificates.
+type SelectPolicy string
+
+// Select policies
+const (
+	// SelectFirst uses the first certificate found in the store.
+	SelectFirst SelectPolicy = "first"
+	// SelectNewest uses the most recently issued certificate.
+	SelectNewest SelectPolicy = "newest"
+	// SelectPrompt asks the user.
+	SelectPrompt SelectPolicy = "prompt"
+)
+
+// ParseSelectPolicy parses a select policy, the empty string is SelectFirst.
+func ParseSelectPolicy(s string) (SelectPolicy, error) {
+	switch p := SelectPolicy(s); p {
+	case "":
+		return SelectFirst, nil
+	case SelectFirst, SelectNewest, SelectPrompt:
+		return p, nil
+	}
+	return "", fmt.Errorf("unknown client certificate select policy %q, expected first, newest or prompt", s)
+}
+
+// A selector chooses a certificate by searching the store twice: first for
+// all matching certificates, then for the chosen one. So the stores only need
+// to support a filter.
+type selector struct {
+	policy SelectPolicy
+	prompt func([]*x509.Certificate) (int, error)
+
+	// prompts are shown one at a time, and the answer is remembered
+	mu     sync.Mutex
+	chosen []byte
+}
+
+func (s *selector) selectCert(
+	load func(filter func(*x509.Certificate) bool) (*tls.Certificate, error),
+	filter func(*x509.Certificate) bool,
+) (*tls.Certificate, error) {
+	if s.policy == "" || s.policy == SelectFirst {
+		return load(filter)
+	}
+
+	var matches []*x509.Certificate
+	_, _ = load(func(cert *x509.Certificate) bool {
+		if filter(cert) && !slices.ContainsFunc(matches, cert.Equal) {
+			matches = append(matches, cert)
+		}
+		return false
+	})
+	if len(matches) <= 1 {
+		return load(filter)
+	}
+
+	chosen, err := s.choose(matches)
+	if err != nil {
+		return nil, err
+	}
+	return load(func(cert *x509.Certificate) bool {
+		return bytes.Equal(cert.Raw, chosen.Raw)
+	})
+}
+
+func (s *selector) choose(matches []*x509.Certificate) (*x509.Certificate, error) {
+	switch s.policy {
+	case SelectNewest:
+		return slices.MaxFunc(matches, func(a, b *x509.Certificate) int {
+			if c := a.NotBefore.Compare(b.NotBefore); c != 0 {
+				return c
+			}
+			return a.NotAfter.Compare(b.NotAfter)
+		}), nil
+	case SelectPrompt:
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if i := slices.IndexFunc(matches, func(cert *x509.Certificate) bool {
+			return bytes.Equal(cert.Raw, s.chosen)
+		}); i >= 0 {
+			return matches[i], nil
+		}
+		if s.prompt == nil {
+			return nil, fmt.Errorf("%d client certificates match, but there is no prompt", len(matches))
+		}
+		i, err := s.prompt(matches)
+		if err != nil {
+			return nil, err
+		}
+		if i < 0 || i >= len(matches) {
+			return nil, fmt.Errorf("invalid client certificate selection %d", i)
+		}
+		s.chosen = matches[i].Raw
+		return matches[i], nil
+	}
+	return nil, fmt.Errorf("unknown client certificate select policy %q", s.policy)
+}
@@ -0,0 +1,120 @@
+package certstore
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSelectPolicy(t *testing.T) {
+	for s, expected := range map[string]SelectPolicy{
+		"":       SelectFirst,
+		"first":  SelectFirst,
+		"newest": SelectNewest,
+		"prompt": SelectPrompt,
+	} {
+		p, err := ParseSelectPolicy(s)
+		require.NoError(t, err)
+		assert.Equal(t, expected, p)
+	}
+	_, err := ParseSelectPolicy("last")
+	assert.Error(t, err)
+}
+
+func TestSelector(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	now := time.Now()
+	var store []*x509.Certificate
+	for i, name := range []string{"old", "new", "other", "older"} {
+		tmpl := &x509.Certificate{
+			SerialNumber: big.NewInt(int64(i + 1)),
+			Subject:      pkix.Name{CommonName: name},
+			NotBefore:    now.Add(time.Duration(-i) * time.Hour),
+			NotAfter:     now.Add(time.Hour),
+		}
+		if name == "new" {
+			tmpl.NotBefore = now.Add(time.Hour)
+		}
+		der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+		require.NoError(t, err)
+		cert, err := x509.ParseCertificate(der)
+		require.NoError(t, err)
+		store = append(store, cert)
+	}
+
+	searches := 0
+	load := func(filter func(*x509.Certificate) bool) (*tls.Certificate, error) {
+		searches++
+		for _, cert := range store {
+			if filter(cert) {
+				return &tls.Certificate{Certificate: [][]byte{cert.Raw}}, nil
+			}
+		}
+		return nil, errors.New("no matching certificate found")
+	}
+	notOther := func(cert *x509.Certificate) bool { return cert.Subject.CommonName != "other" }
+	commonName := func(cert *tls.Certificate) string {
+		c, err := x509.ParseCertificate(cert.Certificate[0])
+		require.NoError(t, err)
+		return c.Subject.CommonName
+	}
+
+	t.Run("first", func(t *testing.T) {
+		searches = 0
+		cert, err := (&selector{policy: SelectFirst}).selectCert(load, notOther)
+		require.NoError(t, err)
+		assert.Equal(t, "old", commonName(cert))
+		assert.Equal(t, 1, searches)
+	})
+	t.Run("newest", func(t *testing.T) {
+		cert, err := (&selector{policy: SelectNewest}).selectCert(load, notOther)
+		require.NoError(t, err)
+		assert.Equal(t, "new", commonName(cert))
+	})
+	t.Run("prompt", func(t *testing.T) {
+		prompts := 0
+		s := &selector{policy: SelectPrompt, prompt: func(certs []*x509.Certificate) (int, error) {
+			prompts++
+			require.Len(t, certs, 3)
+			assert.Equal(t, "older", certs[2].Subject.CommonName)
+			return 2, nil
+		}}
+		for range 2 {
+			cert, err := s.selectCert(load, notOther)
+			require.NoError(t, err)
+			assert.Equal(t, "older", commonName(cert))
+		}
+		assert.Equal(t, 1, prompts, "should remember the selection")
+
+		s = &selector{policy: SelectPrompt, prompt: func([]*x509.Certificate) (int, error) {
+			return 3, nil
+		}}
+		_, err := s.selectCert(load, notOther)
+		assert.Error(t, err)
+	})
+	t.Run("single match", func(t *testing.T) {
+		s := &selector{policy: SelectPrompt, prompt: func([]*x509.Certificate) (int, error) {
+			t.Fatal("should not prompt")
+			return 0, nil
+		}}
+		cert, err := s.selectCert(load, func(cert *x509.Certificate) bool {
+			return cert.Subject.CommonName == "other"
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "other", commonName(cert))
+
+		_, err = s.selectCert(load, func(*x509.Certificate) bool { return false })
+		assert.ErrorContains(t, err, "no matching certificate found")
+	})
+}
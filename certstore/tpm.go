@@ -0,0 +1,368 @@
+package certstore
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"sync"
+
+	"github.com/google/go-tpm/tpm2"
+	"github.com/google/go-tpm/tpm2/transport"
+)
+
+// TPMPINEnv is the environment variable holding the password of the TPM key,
+// for keys which have one.
+const TPMPINEnv = "POMERIUM_CLI_TPM_PIN"
+
+// LoadTPMKeyPair reads a public/private key pair from a pair of files, like
+// [tls.LoadX509KeyPair], except that the private key is a TSS2 key file, as
+// created by tpm2-openssl or tpm2-tss-engine, which is used through the TPM.
+// The certificate file may contain intermediates after the certificate.
+func LoadTPMKeyPair(certFile, keyFile string) (tls.Certificate, error) {
+	if !IsTPMSupported {
+		return tls.Certificate{}, errNotSupported
+	}
+
+	certPEM, err := os.ReadFile(certFile)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	var cert tls.Certificate
+	for {
+		var block *pem.Block
+		block, certPEM = pem.Decode(certPEM)
+		if block == nil {
+			break
+		}
+		if block.Type == "CERTIFICATE" {
+			cert.Certificate = append(cert.Certificate, block.Bytes)
+		}
+	}
+	if len(cert.Certificate) == 0 {
+		return tls.Certificate{}, fmt.Errorf("tpm: no certificate found in %s", certFile)
+	}
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("tpm: %w", err)
+	}
+
+	keyPEM, err := os.ReadFile(keyFile)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	block, _ := pem.Decode(keyPEM)
+	if block == nil || block.Type != "TSS2 PRIVATE KEY" {
+		return tls.Certificate{}, fmt.Errorf("tpm: no TSS2 PRIVATE KEY found in %s", keyFile)
+	}
+	key, err := newTPMKey(block.Bytes, os.Getenv(TPMPINEnv), openTPM)
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("tpm: %w", err)
+	}
+	if !key.pub.(interface{ Equal(crypto.PublicKey) bool }).Equal(leaf.PublicKey) {
+		return tls.Certificate{}, errors.New("tpm: private key does not match public key")
+	}
+
+	cert.PrivateKey = key
+	cert.Leaf = leaf
+	return cert, nil
+}
+
+// A tss2Key is a loadable key from a TSS2 key file, see
+// https://www.hansenpartnership.com/draft-bottomley-tpm2-keys.html.
+type tss2Key struct {
+	Type       asn1.ObjectIdentifier
+	EmptyAuth  bool          `asn1:"optional,explicit,tag:0"`
+	Policy     asn1.RawValue `asn1:"optional,explicit,tag:1"`
+	Secret     asn1.RawValue `asn1:"optional,explicit,tag:2"`
+	AuthPolicy asn1.RawValue `asn1:"optional,explicit,tag:3"`
+	Parent     int64
+	PublicKey  []byte // TPM2B_PUBLIC
+	PrivateKey []byte // TPM2B_PRIVATE
+}
+
+var oidLoadableKey = asn1.ObjectIdentifier{2, 23, 133, 10, 1, 3}
+
+var tpmHashAlgs = map[crypto.Hash]tpm2.TPMIAlgHash{
+	crypto.SHA1:   tpm2.TPMAlgSHA1,
+	crypto.SHA256: tpm2.TPMAlgSHA256,
+	crypto.SHA384: tpm2.TPMAlgSHA384,
+	crypto.SHA512: tpm2.TPMAlgSHA512,
+}
+
+// tpmSRKTemplate is the ECC P-256 storage root key, which is the parent of
+// keys created under a hierarchy, see TCG TPM v2.0 Provisioning Guidance
+// section 7.5.1. The unique field is empty, like in tpm2-openssl and
+// tpm2-tss-engine.
+var tpmSRKTemplate = func() tpm2.TPMTPublic {
+	t := tpm2.ECCSRKTemplate
+	t.Unique = tpm2.NewTPMUPublicID(tpm2.TPMAlgECC, &tpm2.TPMSECCPoint{})
+	return t
+}()
+
+// tpmError adds a hint to the errors the user can fix.
+func tpmError(err error) error {
+	switch {
+	case errors.Is(err, tpm2.TPMRCAuthFail), errors.Is(err, tpm2.TPMRCBadAuth):
+		return fmt.Errorf("wrong password, set %s: %w", TPMPINEnv, err)
+	case errors.Is(err, tpm2.TPMRCLockout):
+		return fmt.Errorf("the TPM is in dictionary attack lockout: %w", err)
+	}
+	return err
+}
+
+// A tpmKey is a private key loaded from a TSS2 key file. The TPM is opened,
+// and the parent and the key are loaded, on first use. They are kept for the
+// lifetime of the key, or until a command fails, after which the next
+// signature loads them again.
+type tpmKey struct {
+	open    func() (transport.TPMCloser, error)
+	parent  tpm2.TPMHandle
+	public  tpm2.TPM2BPublic
+	private tpm2.TPM2BPrivate
+	auth    []byte
+	pub     crypto.PublicKey
+
+	mu      sync.Mutex
+	tpm     transport.TPMCloser
+	primary tpm2.TPMHandle // the parent created under a hierarchy, if any
+	key     tpm2.NamedHandle
+}
+
+// newTPMKey parses the key file and loads the key, to report errors early.
+func newTPMKey(der []byte, password string, open func() (transport.TPMCloser, error)) (*tpmKey, error) {
+	var k tss2Key
+	if rest, err := asn1.Unmarshal(der, &k); err != nil {
+		return nil, fmt.Errorf("parsing key file: %w", err)
+	} else if len(rest) > 0 {
+		return nil, errors.New("parsing key file: trailing data")
+	}
+	if !k.Type.Equal(oidLoadableKey) {
+		return nil, fmt.Errorf("unsupported key type %s", k.Type)
+	}
+	if len(k.Policy.FullBytes) > 0 || len(k.AuthPolicy.FullBytes) > 0 {
+		return nil, errors.New("keys with policies are not supported")
+	}
+	if k.Parent < 0 || k.Parent > 0xffffffff {
+		return nil, fmt.Errorf("invalid parent 0x%x", k.Parent)
+	}
+
+	public, err := tpm2.Unmarshal[tpm2.TPM2BPublic](k.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("parsing public key: %w", err)
+	}
+	pub, err := tpmPublicKey(public)
+	if err != nil {
+		return nil, fmt.Errorf("parsing public key: %w", err)
+	}
+	private, err := tpm2.Unmarshal[tpm2.TPM2BPrivate](k.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("parsing private key: %w", err)
+	}
+	key := &tpmKey{
+		open:    open,
+		parent:  tpm2.TPMHandle(k.Parent),
+		public:  *public,
+		private: *private,
+		pub:     pub,
+	}
+	if !k.EmptyAuth {
+		key.auth = []byte(password)
+	}
+	if err := key.withKey(func(transport.TPM, tpm2.NamedHandle) error { return nil }); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+func (k *tpmKey) Public() crypto.PublicKey {
+	return k.pub
+}
+
+func (k *tpmKey) Sign(_ io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	hashAlg, ok := tpmHashAlgs[opts.HashFunc()]
+	if !ok {
+		return nil, fmt.Errorf("tpm: unsupported hash %v", opts.HashFunc())
+	}
+	var scheme tpm2.TPMAlgID
+	switch k.pub.(type) {
+	case *ecdsa.PublicKey:
+		scheme = tpm2.TPMAlgECDSA
+	case *rsa.PublicKey:
+		scheme = tpm2.TPMAlgRSASSA
+		if _, ok := opts.(*rsa.PSSOptions); ok {
+			// the TPM chooses the salt length, which is the hash length
+			// unless the key is too small for it
+			scheme = tpm2.TPMAlgRSAPSS
+		}
+	}
+
+	var sig []byte
+	err := k.withKey(func(tpm transport.TPM, key tpm2.NamedHandle) error {
+		rsp, err := tpm2.Sign{
+			KeyHandle: tpm2.AuthHandle{Handle: key.Handle, Name: key.Name, Auth: tpm2.PasswordAuth(k.auth)},
+			Digest:    tpm2.TPM2BDigest{Buffer: digest},
+			InScheme: tpm2.TPMTSigScheme{
+				Scheme:  scheme,
+				Details: tpm2.NewTPMUSigScheme(scheme, &tpm2.TPMSSchemeHash{HashAlg: hashAlg}),
+			},
+			Validation: tpm2.TPMTTKHashCheck{Tag: tpm2.TPMSTHashCheck, Hierarchy: tpm2.TPMRHNull},
+		}.Execute(tpm)
+		if err != nil {
+			return tpmError(err)
+		}
+		sig, err = tpmSignature(&rsp.Signature)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("tpm: signing: %w", err)
+	}
+	return sig, nil
+}
+
+// withKey calls f with the loaded key, loading it first if needed. If f
+// fails, the TPM resources are released, so a key lost to a TPM reset is
+// loaded again on the next call.
+func (k *tpmKey) withKey(f func(tpm transport.TPM, key tpm2.NamedHandle) error) error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if k.tpm == nil {
+		if err := k.load(); err != nil {
+			k.release()
+			return err
+		}
+	}
+	if err := f(k.tpm, k.key); err != nil {
+		k.release()
+		return err
+	}
+	return nil
+}
+
+// load opens the TPM and loads the key, creating its parent first if the key
+// was created under a hierarchy.
+func (k *tpmKey) load() error {
+	tpm, err := k.open()
+	if err != nil {
+		return err
+	}
+	k.tpm = tpm
+
+	parent := tpm2.AuthHandle{Handle: k.parent, Auth: tpm2.PasswordAuth(nil)}
+	switch k.parent {
+	case tpm2.TPMRHOwner, tpm2.TPMRHEndorsement, tpm2.TPMRHPlatform, tpm2.TPMRHNull:
+		rsp, err := tpm2.CreatePrimary{
+			PrimaryHandle: parent,
+			InPublic:      tpm2.New2B(tpmSRKTemplate),
+		}.Execute(tpm)
+		if err != nil {
+			return fmt.Errorf("creating the parent key: %w", err)
+		}
+		k.primary = rsp.ObjectHandle
+		parent.Handle, parent.Name = rsp.ObjectHandle, rsp.Name
+	default:
+		// the Name of a persistent parent is not known in advance
+		rsp, err := tpm2.ReadPublic{ObjectHandle: k.parent}.Execute(tpm)
+		if err != nil {
+			return fmt.Errorf("reading the parent key: %w", err)
+		}
+		parent.Name = rsp.Name
+	}
+
+	rsp, err := tpm2.Load{
+		ParentHandle: parent,
+		InPrivate:    k.private,
+		InPublic:     k.public,
+	}.Execute(tpm)
+	if err != nil {
+		return fmt.Errorf("loading the key: %w", err)
+	}
+	k.key = tpm2.NamedHandle{Handle: rsp.ObjectHandle, Name: rsp.Name}
+	return nil
+}
+
+// release flushes the loaded objects and closes the TPM.
+func (k *tpmKey) release() {
+	if k.tpm == nil {
+		return
+	}
+	for _, h := range []tpm2.TPMHandle{k.key.Handle, k.primary} {
+		if h != 0 {
+			_, _ = tpm2.FlushContext{FlushHandle: h}.Execute(k.tpm)
+		}
+	}
+	_ = k.tpm.Close()
+	k.tpm, k.primary, k.key = nil, 0, tpm2.NamedHandle{}
+}
+
+// tpmPublicKey returns the public key of a TPM2B_PUBLIC.
+func tpmPublicKey(public *tpm2.TPM2BPublic) (crypto.PublicKey, error) {
+	pub, err := public.Contents()
+	if err != nil {
+		return nil, err
+	}
+	switch pub.Type {
+	case tpm2.TPMAlgRSA:
+		params, err := pub.Parameters.RSADetail()
+		if err != nil {
+			return nil, err
+		}
+		n, err := pub.Unique.RSA()
+		if err != nil {
+			return nil, err
+		}
+		return tpm2.RSAPub(params, n)
+	case tpm2.TPMAlgECC:
+		params, err := pub.Parameters.ECCDetail()
+		if err != nil {
+			return nil, err
+		}
+		point, err := pub.Unique.ECC()
+		if err != nil {
+			return nil, err
+		}
+		p, err := tpm2.ECCPub(params, point)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: p.Curve, X: p.X, Y: p.Y}, nil
+	}
+	return nil, fmt.Errorf("unsupported key type 0x%x", pub.Type)
+}
+
+// tpmSignature converts a TPMT_SIGNATURE to the encoding used by Go.
+func tpmSignature(sig *tpm2.TPMTSignature) ([]byte, error) {
+	switch sig.SigAlg {
+	case tpm2.TPMAlgRSASSA:
+		rsaSig, err := sig.Signature.RSASSA()
+		if err != nil {
+			return nil, err
+		}
+		return rsaSig.Sig.Buffer, nil
+	case tpm2.TPMAlgRSAPSS:
+		rsaSig, err := sig.Signature.RSAPSS()
+		if err != nil {
+			return nil, err
+		}
+		return rsaSig.Sig.Buffer, nil
+	case tpm2.TPMAlgECDSA:
+		eccSig, err := sig.Signature.ECDSA()
+		if err != nil {
+			return nil, err
+		}
+		return asn1.Marshal(struct{ R, S *big.Int }{
+			R: new(big.Int).SetBytes(eccSig.SignatureR.Buffer),
+			S: new(big.Int).SetBytes(eccSig.SignatureS.Buffer),
+		})
+	}
+	return nil, fmt.Errorf("unsupported signature algorithm 0x%x", sig.SigAlg)
+}
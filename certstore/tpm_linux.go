@@ -0,0 +1,19 @@
+package certstore
+
+import (
+	"github.com/google/go-tpm/tpm2/transport"
+
+	"github.com/pomerium/cli/version"
+)
+
+var IsTPMSupported = true
+
+func init() {
+	version.Features = append(version.Features, "tpm")
+}
+
+// openTPM opens the TPM through the kernel resource manager, which flushes
+// the objects of the process when it is closed.
+func openTPM() (transport.TPMCloser, error) {
+	return transport.OpenTPM("/dev/tpmrm0")
+}
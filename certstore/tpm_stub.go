@@ -0,0 +1,13 @@
+//go:build !linux && !(windows && cgo)
+
+package certstore
+
+import "github.com/google/go-tpm/tpm2/transport"
+
+var IsTPMSupported = false
+
+// openTPM is a stub that always returns an error, for builds where this
+// feature is not supported.
+func openTPM() (transport.TPMCloser, error) {
+	return nil, errNotSupported
+}
@@ -0,0 +1,386 @@
+package certstore
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/asn1"
+	"encoding/binary"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/google/go-tpm/tpm2"
+	"github.com/google/go-tpm/tpm2/transport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTPMPublicKey(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	public, err := tpm2.Unmarshal[tpm2.TPM2BPublic](marshalTPMPublic(&ecKey.PublicKey))
+	require.NoError(t, err)
+	pub, err := tpmPublicKey(public)
+	require.NoError(t, err)
+	assert.True(t, ecKey.PublicKey.Equal(pub))
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	public, err = tpm2.Unmarshal[tpm2.TPM2BPublic](marshalTPMPublic(&rsaKey.PublicKey))
+	require.NoError(t, err)
+	pub, err = tpmPublicKey(public)
+	require.NoError(t, err)
+	assert.True(t, rsaKey.PublicKey.Equal(pub))
+
+	_, err = tpm2.Unmarshal[tpm2.TPM2BPublic](marshalTPMPublic(&rsaKey.PublicKey)[:100])
+	assert.Error(t, err)
+}
+
+func TestTPMSRKTemplate(t *testing.T) {
+	// the template of tpm2-openssl and tpm2-tss-engine, a different one
+	// creates a different parent, which cannot load their keys
+	assert.Equal(t, []byte{
+		0x00, 0x23, // type: TPM_ALG_ECC
+		0x00, 0x0b, // nameAlg: TPM_ALG_SHA256
+		0x00, 0x03, 0x04, 0x72, // fixedTPM, fixedParent, sensitiveDataOrigin, userWithAuth, noDA, restricted, decrypt
+		0x00, 0x00, // authPolicy
+		0x00, 0x06, 0x00, 0x80, 0x00, 0x43, // symmetric: AES-128-CFB
+		0x00, 0x10, // scheme: TPM_ALG_NULL
+		0x00, 0x03, // curveID: TPM_ECC_NIST_P256
+		0x00, 0x10, // kdf: TPM_ALG_NULL
+		0x00, 0x00, 0x00, 0x00, // unique
+	}, tpm2.Marshal(tpmSRKTemplate))
+}
+
+func TestTPMKey(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	digest := sha256.Sum256([]byte("data"))
+
+	t.Run("ecdsa", func(t *testing.T) {
+		tpm := &fakeTPM{t: t, key: ecKey}
+		key, err := newTPMKey(marshalTSS2Key(t, ecKey.Public(), int64(tpm2.TPMRHOwner), true), "", tpm.open)
+		require.NoError(t, err)
+
+		for range 2 {
+			sig, err := key.Sign(rand.Reader, digest[:], crypto.SHA256)
+			require.NoError(t, err)
+			assert.True(t, ecdsa.VerifyASN1(&ecKey.PublicKey, digest[:], sig))
+		}
+		assert.Equal(t, 1, tpm.opens, "should keep the TPM open")
+		assert.Equal(t, 1, tpm.primaries, "should create the parent once")
+		assert.Len(t, tpm.loaded, 2, "should keep the parent and the key loaded")
+	})
+	t.Run("rsa", func(t *testing.T) {
+		tpm := &fakeTPM{t: t, key: rsaKey, auth: "secret"}
+		key, err := newTPMKey(marshalTSS2Key(t, rsaKey.Public(), 0x81000001, false), "secret", tpm.open)
+		require.NoError(t, err)
+
+		sig, err := key.Sign(rand.Reader, digest[:], crypto.SHA256)
+		require.NoError(t, err)
+		assert.NoError(t, rsa.VerifyPKCS1v15(&rsaKey.PublicKey, crypto.SHA256, digest[:], sig))
+
+		sig, err = key.Sign(rand.Reader, digest[:], &rsa.PSSOptions{
+			SaltLength: rsa.PSSSaltLengthEqualsHash,
+			Hash:       crypto.SHA256,
+		})
+		require.NoError(t, err)
+		assert.NoError(t, rsa.VerifyPSS(&rsaKey.PublicKey, crypto.SHA256, digest[:], sig, nil))
+		assert.Equal(t, 0, tpm.primaries, "should use the persistent parent")
+		assert.Len(t, tpm.loaded, 1, "should keep the key loaded")
+	})
+	t.Run("wrong password", func(t *testing.T) {
+		tpm := &fakeTPM{t: t, key: ecKey, auth: "secret"}
+		key, err := newTPMKey(marshalTSS2Key(t, ecKey.Public(), int64(tpm2.TPMRHOwner), false), "wrong", tpm.open)
+		require.NoError(t, err)
+		_, err = key.Sign(rand.Reader, digest[:], crypto.SHA256)
+		assert.ErrorContains(t, err, TPMPINEnv)
+		assert.Empty(t, tpm.loaded, "should flush all objects after an error")
+		assert.Equal(t, tpm.opens, tpm.closes, "should close the TPM after an error")
+	})
+	t.Run("reload", func(t *testing.T) {
+		tpm := &fakeTPM{t: t, key: ecKey}
+		key, err := newTPMKey(marshalTSS2Key(t, ecKey.Public(), int64(tpm2.TPMRHOwner), true), "", tpm.open)
+		require.NoError(t, err)
+
+		// like a TPM reset, which loses the objects
+		tpm.loaded = nil
+		_, err = key.Sign(rand.Reader, digest[:], crypto.SHA256)
+		assert.Error(t, err)
+
+		sig, err := key.Sign(rand.Reader, digest[:], crypto.SHA256)
+		require.NoError(t, err)
+		assert.True(t, ecdsa.VerifyASN1(&ecKey.PublicKey, digest[:], sig))
+		assert.Equal(t, 2, tpm.primaries, "should create the parent again")
+	})
+	t.Run("invalid", func(t *testing.T) {
+		tpm := &fakeTPM{t: t, key: ecKey}
+		_, err := newTPMKey([]byte("invalid"), "", tpm.open)
+		assert.Error(t, err)
+
+		der, err := asn1.Marshal(tss2Key{
+			Type:      asn1.ObjectIdentifier{2, 23, 133, 10, 1, 5},
+			Parent:    int64(tpm2.TPMRHOwner),
+			PublicKey: marshalTPMPublic(ecKey.Public()),
+		})
+		require.NoError(t, err)
+		_, err = newTPMKey(der, "", tpm.open)
+		assert.ErrorContains(t, err, "unsupported key type")
+	})
+}
+
+func marshalTSS2Key(t *testing.T, pub crypto.PublicKey, parent int64, emptyAuth bool) []byte {
+	der, err := asn1.Marshal(tss2Key{
+		Type:       oidLoadableKey,
+		EmptyAuth:  emptyAuth,
+		Parent:     parent,
+		PublicKey:  marshalTPMPublic(pub),
+		PrivateKey: tpm2.Marshal(tpm2.TPM2BPrivate{Buffer: []byte("sealed private key")}),
+	})
+	require.NoError(t, err)
+	return der
+}
+
+// marshalTPMPublic returns the TPM2B_PUBLIC of an unrestricted signing key.
+func marshalTPMPublic(pub crypto.PublicKey) []byte {
+	public := tpm2.TPMTPublic{
+		NameAlg: tpm2.TPMAlgSHA256,
+		ObjectAttributes: tpm2.TPMAObject{
+			SignEncrypt:         true,
+			UserWithAuth:        true,
+			SensitiveDataOrigin: true,
+			FixedParent:         true,
+			FixedTPM:            true,
+		},
+	}
+	switch pub := pub.(type) {
+	case *ecdsa.PublicKey:
+		public.Type = tpm2.TPMAlgECC
+		public.Parameters = tpm2.NewTPMUPublicParms(tpm2.TPMAlgECC, &tpm2.TPMSECCParms{
+			CurveID: tpm2.TPMECCNistP256,
+		})
+		public.Unique = tpm2.NewTPMUPublicID(tpm2.TPMAlgECC, &tpm2.TPMSECCPoint{
+			X: tpm2.TPM2BECCParameter{Buffer: pub.X.FillBytes(make([]byte, 32))},
+			Y: tpm2.TPM2BECCParameter{Buffer: pub.Y.FillBytes(make([]byte, 32))},
+		})
+	case *rsa.PublicKey:
+		public.Type = tpm2.TPMAlgRSA
+		public.Parameters = tpm2.NewTPMUPublicParms(tpm2.TPMAlgRSA, &tpm2.TPMSRSAParms{
+			KeyBits: tpm2.TPMKeyBits(pub.N.BitLen()),
+		})
+		public.Unique = tpm2.NewTPMUPublicID(tpm2.TPMAlgRSA, &tpm2.TPM2BPublicKeyRSA{
+			Buffer: pub.N.Bytes(),
+		})
+	}
+	return tpm2.Marshal(tpm2.New2B(public))
+}
+
+// A fakeTPM implements the commands used, signing with a software key.
+type fakeTPM struct {
+	t         *testing.T
+	key       crypto.Signer
+	auth      string
+	opens     int
+	closes    int
+	primaries int
+	loaded    map[uint32]bool
+	next      uint32
+}
+
+func (tpm *fakeTPM) open() (transport.TPMCloser, error) {
+	tpm.opens++
+	return tpm, nil
+}
+
+func (tpm *fakeTPM) Close() error {
+	tpm.closes++
+	return nil
+}
+
+// TPM 2.0 response codes, see TPM 2.0 Library Part 2 section 6.6.
+const (
+	fakeTPMRCHandle   = 0x18b // TPM_RC_HANDLE, handle 1
+	fakeTPMRCAuthFail = 0x98e // TPM_RC_AUTH_FAIL, session 1
+)
+
+func (tpm *fakeTPM) Send(cmd []byte) ([]byte, error) {
+	r := &tpmReader{b: cmd}
+	tag, size, cc := r.uint16(), r.uint32(), tpm2.TPMCC(r.uint32())
+	require.NoError(tpm.t, r.err)
+	require.Equal(tpm.t, len(cmd), int(size))
+	if tpm.loaded == nil {
+		tpm.loaded = make(map[uint32]bool)
+	}
+
+	switch cc {
+	case tpm2.TPMCCFlushContext:
+		require.Equal(tpm.t, uint16(tpm2.TPMSTNoSessions), tag)
+		handle := r.uint32()
+		if !tpm.loaded[handle] {
+			return tpm.fail(fakeTPMRCHandle), nil
+		}
+		delete(tpm.loaded, handle)
+		return tpm.respond(nil, nil, false), nil
+	case tpm2.TPMCCReadPublic:
+		require.Equal(tpm.t, uint16(tpm2.TPMSTNoSessions), tag)
+		require.Equal(tpm.t, uint32(0x81000001), r.uint32(), "unknown persistent handle")
+		var params []byte
+		params = binary.BigEndian.AppendUint16(params, 0) // outPublic
+		params = appendFakeTPM2B(params, []byte("persistent parent"))
+		params = binary.BigEndian.AppendUint16(params, 0) // qualifiedName
+		return tpm.respond(nil, params, false), nil
+	}
+
+	require.Equal(tpm.t, uint16(tpm2.TPMSTSessions), tag)
+	handle := r.uint32()
+	session := &tpmReader{b: r.bytes(int(r.uint32()))}
+	require.Equal(tpm.t, uint32(tpm2.TPMRSPW), session.uint32())
+	session.tpm2b() // nonce
+	session.bytes(1)
+	auth := session.tpm2b()
+	require.NoError(tpm.t, session.err)
+
+	switch cc {
+	case tpm2.TPMCCCreatePrimary:
+		require.Equal(tpm.t, uint32(tpm2.TPMRHOwner), handle)
+		r.tpm2b() // inSensitive
+		require.Equal(tpm.t, tpm2.Marshal(tpmSRKTemplate), r.tpm2b())
+		tpm.primaries++
+		h := tpm.newHandle()
+		var params []byte
+		params = binary.BigEndian.AppendUint16(params, 0)              // outPublic
+		params = binary.BigEndian.AppendUint16(params, 0)              // creationData
+		params = binary.BigEndian.AppendUint16(params, 0)              // creationHash
+		params = binary.BigEndian.AppendUint16(params, 0x8021)         // creationTicket: TPM_ST_CREATION
+		params = binary.BigEndian.AppendUint32(params, uint32(handle)) // creationTicket: hierarchy
+		params = binary.BigEndian.AppendUint16(params, 0)              // creationTicket: digest
+		params = appendFakeTPM2B(params, h)                            // name
+		return tpm.respond(h, params, true), nil
+	case tpm2.TPMCCLoad:
+		if !tpm.loaded[handle] && handle != 0x81000001 {
+			return tpm.fail(fakeTPMRCHandle), nil
+		}
+		require.Equal(tpm.t, []byte("sealed private key"), r.tpm2b())
+		h := tpm.newHandle()
+		return tpm.respond(h, appendFakeTPM2B(nil, h), true), nil
+	case tpm2.TPMCCSign:
+		if !tpm.loaded[handle] {
+			return tpm.fail(fakeTPMRCHandle), nil
+		}
+		if string(auth) != tpm.auth {
+			return tpm.fail(fakeTPMRCAuthFail), nil
+		}
+		digest, scheme, hashAlg := r.tpm2b(), tpm2.TPMAlgID(r.uint16()), tpm2.TPMIAlgHash(r.uint16())
+		require.NoError(tpm.t, r.err)
+		require.Equal(tpm.t, tpm2.TPMAlgSHA256, hashAlg)
+
+		sig := tpm2.TPMTSignature{SigAlg: scheme}
+		switch scheme {
+		case tpm2.TPMAlgECDSA:
+			var rs struct{ R, S *big.Int }
+			der, err := tpm.key.Sign(rand.Reader, digest, crypto.SHA256)
+			require.NoError(tpm.t, err)
+			_, err = asn1.Unmarshal(der, &rs)
+			require.NoError(tpm.t, err)
+			sig.Signature = tpm2.NewTPMUSignature(scheme, &tpm2.TPMSSignatureECC{
+				Hash:       hashAlg,
+				SignatureR: tpm2.TPM2BECCParameter{Buffer: rs.R.Bytes()},
+				SignatureS: tpm2.TPM2BECCParameter{Buffer: rs.S.Bytes()},
+			})
+		case tpm2.TPMAlgRSASSA, tpm2.TPMAlgRSAPSS:
+			var opts crypto.SignerOpts = crypto.SHA256
+			if scheme == tpm2.TPMAlgRSAPSS {
+				opts = &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash, Hash: crypto.SHA256}
+			}
+			s, err := tpm.key.Sign(rand.Reader, digest, opts)
+			require.NoError(tpm.t, err)
+			sig.Signature = tpm2.NewTPMUSignature(scheme, &tpm2.TPMSSignatureRSA{
+				Hash: hashAlg,
+				Sig:  tpm2.TPM2BPublicKeyRSA{Buffer: s},
+			})
+		default:
+			tpm.t.Fatalf("unexpected scheme 0x%x", scheme)
+		}
+		return tpm.respond(nil, tpm2.Marshal(sig), true), nil
+	}
+	tpm.t.Fatalf("unexpected command 0x%x", cc)
+	return nil, errors.New("unreachable")
+}
+
+func (tpm *fakeTPM) newHandle() []byte {
+	tpm.next++
+	handle := 0x80000000 + tpm.next
+	tpm.loaded[handle] = true
+	return binary.BigEndian.AppendUint32(nil, handle)
+}
+
+// respond returns a response with the handles and parameters, followed by an
+// empty password session for commands with sessions.
+func (tpm *fakeTPM) respond(handles, params []byte, sessions bool) []byte {
+	body := append([]byte{}, handles...)
+	tag := uint16(tpm2.TPMSTNoSessions)
+	if sessions {
+		tag = uint16(tpm2.TPMSTSessions)
+		body = binary.BigEndian.AppendUint32(body, uint32(len(params)))
+	}
+	body = append(body, params...)
+	if sessions {
+		body = append(body, 0x00, 0x00, 0x01, 0x00, 0x00) // nonce, continueSession, hmac
+	}
+	resp := binary.BigEndian.AppendUint16(nil, tag)
+	resp = binary.BigEndian.AppendUint32(resp, uint32(10+len(body)))
+	resp = binary.BigEndian.AppendUint32(resp, 0)
+	return append(resp, body...)
+}
+
+// fail returns a response with the error code.
+func (tpm *fakeTPM) fail(rc uint32) []byte {
+	resp := binary.BigEndian.AppendUint16(nil, uint16(tpm2.TPMSTNoSessions))
+	resp = binary.BigEndian.AppendUint32(resp, 10)
+	return binary.BigEndian.AppendUint32(resp, rc)
+}
+
+func appendFakeTPM2B(b, value []byte) []byte {
+	b = binary.BigEndian.AppendUint16(b, uint16(len(value)))
+	return append(b, value...)
+}
+
+// A tpmReader reads TPM structures, recording the first error.
+type tpmReader struct {
+	b   []byte
+	err error
+}
+
+func (r *tpmReader) bytes(n int) []byte {
+	if r.err != nil || len(r.b) < n {
+		r.err = errors.New("truncated TPM structure")
+		return nil
+	}
+	v := r.b[:n]
+	r.b = r.b[n:]
+	return v
+}
+
+func (r *tpmReader) uint16() uint16 {
+	if b := r.bytes(2); b != nil {
+		return binary.BigEndian.Uint16(b)
+	}
+	return 0
+}
+
+func (r *tpmReader) uint32() uint32 {
+	if b := r.bytes(4); b != nil {
+		return binary.BigEndian.Uint32(b)
+	}
+	return 0
+}
+
+func (r *tpmReader) tpm2b() []byte {
+	return r.bytes(int(r.uint16()))
+}
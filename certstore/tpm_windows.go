@@ -0,0 +1,24 @@
+//go:build windows && cgo
+
+package certstore
+
+import (
+	"errors"
+
+	"github.com/google/go-tpm/tpm2/transport"
+
+	"github.com/pomerium/cli/version"
+)
+
+var IsTPMSupported = true
+
+func init() {
+	version.Features = append(version.Features, "tpm")
+}
+
+// openTPM returns an error, as on Windows TPM keys are used through the
+// Microsoft Platform Crypto Provider, see [WithTPM].
+func openTPM() (transport.TPMCloser, error) {
+	return nil, errors.New("TSS2 key files are not supported on Windows, " +
+		"use a certificate from the system trust store instead")
+}
@@ -1,8 +1,11 @@
 package main
 
 import (
+	"context"
+	"crypto/tls"
+	"errors"
 	"fmt"
-	"net"
+	"net/http"
 	"os"
 	"path"
 	"time"
@@ -11,9 +14,18 @@ import (
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/cobra"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 
 	"github.com/pomerium/cli/api"
+	"github.com/pomerium/cli/internal/apiauth"
+	"github.com/pomerium/cli/internal/gateway"
+	"github.com/pomerium/cli/internal/ipc"
+	"github.com/pomerium/cli/internal/jsonrpc"
+	"github.com/pomerium/cli/internal/metrics"
+	"github.com/pomerium/cli/internal/tlsutil"
 	pb "github.com/pomerium/cli/proto"
 )
 
@@ -22,11 +34,26 @@ func init() {
 }
 
 type apiCmd struct {
-	jsonRPCAddr string
-	grpcAddr    string
-	configPath  string
-	browserCmd  string
-	sentryDSN   string
+	jsonRPCAddr      string
+	grpcAddr         string
+	configPath       string
+	configEncryption string
+	eventLogDir      string
+	auditLogPath     string
+	statsPath        string
+	browserCmd       string
+	sentryDSN        string
+
+	listenersPath    string
+	restoreListeners bool
+
+	clientCertExpiryWarning time.Duration
+	clientCertRenewHook     string
+
+	authTokenFile   string
+	tlsCertFile     string
+	tlsKeyFile      string
+	tlsClientCAFile string
 
 	cobra.Command
 }
@@ -37,21 +64,60 @@ func apiCommand() *cobra.Command {
 			Use:    "api",
 			Short:  "run api server",
 			Hidden: true,
+
+			Annotations: map[string]string{noVersionCheckAnnotation: "true"},
 		},
 	}
 	cmd.RunE = cmd.exec
 
 	cfgDir, err := os.UserConfigDir()
+	var eventLogDir, auditLogPath, statsPath, listenersPath string
 	if err == nil {
+		eventLogDir = path.Join(cfgDir, "PomeriumDesktop", "events")
+		auditLogPath = path.Join(cfgDir, "PomeriumDesktop", "audit.log")
+		statsPath = path.Join(cfgDir, "PomeriumDesktop", "stats.json")
+		listenersPath = path.Join(cfgDir, "PomeriumDesktop", "listeners.json")
 		cfgDir = path.Join(cfgDir, "PomeriumDesktop", "config.json")
 	}
 	addServiceAccountFlags(&cmd.Command)
+	addMetricsFlags(&cmd.Command)
 	flags := cmd.Flags()
-	flags.StringVar(&cmd.jsonRPCAddr, "json-addr", "127.0.0.1:8900", "address json api server should listen to")
-	flags.StringVar(&cmd.grpcAddr, "grpc-addr", "127.0.0.1:8800", "address json api server should listen to")
+	flags.StringVar(&cmd.jsonRPCAddr, "json-addr", "127.0.0.1:8900",
+		"address JSON-RPC and REST api server should listen to, in the same forms as --grpc-addr, disabled if empty; "+
+			"the REST api is described at "+gateway.OpenAPIPath+" and the health of the server is served at /healthz")
+	flags.StringVar(&cmd.grpcAddr, "grpc-addr", "127.0.0.1:8800",
+		"address gRPC api server should listen to: host:port, unix:/path/to/socket "+
+			"or, on Windows, npipe:name, the latter two only accessible to the current user")
 	flags.StringVar(&cmd.configPath, "config-path", cfgDir, "path to config file")
+	flags.StringVar(&cmd.configEncryption, "config-encryption", "off",
+		"on to encrypt the config file with a key kept in the keyring of the operating system, "+
+			"the file is migrated when this is changed")
+	flags.StringVar(&cmd.eventLogDir, "event-log-dir", eventLogDir,
+		"directory to keep connection event history in, history is kept in memory only if empty")
+	flags.StringVar(&cmd.auditLogPath, "audit-log", auditLogPath,
+		"path to the file the changes to the connections and listeners are appended to, "+
+			"the audit log is kept in memory only if empty")
+	flags.StringVar(&cmd.statsPath, "stats-path", statsPath,
+		"path to connection usage statistics file, statistics are kept in memory only if empty")
+	flags.StringVar(&cmd.listenersPath, "listeners-path", listenersPath,
+		"path to the file recording which connections are listening, state is kept in memory only if empty")
+	flags.BoolVar(&cmd.restoreListeners, "restore-listeners", false,
+		"restore the connections that were listening when the api server was last stopped")
 	flags.StringVar(&cmd.browserCmd, "browser-cmd", "", "use specific browser app")
 	flags.StringVar(&cmd.sentryDSN, "sentry-dsn", "", "if provided, report errors to Sentry")
+	flags.DurationVar(&cmd.clientCertExpiryWarning, "client-cert-expiry-warning", tlsutil.DefaultClientCertExpiryWarning,
+		"warn when a connection's client certificate expires within this duration, 0 to disable")
+	flags.StringVar(&cmd.clientCertRenewHook, "client-cert-renew-hook", "",
+		"(optional) command to run when a connection's client certificate expires soon, with its subject, "+
+			"serial number and expiry in the "+tlsutil.RenewHookSubjectEnv+", "+tlsutil.RenewHookSerialEnv+
+			" and "+tlsutil.RenewHookNotAfterEnv+" environment variables")
+	flags.StringVar(&cmd.authTokenFile, "auth-token-file", "",
+		"(optional) require clients to authenticate with a token generated at startup "+
+			"and written to this file, which only the current user can read")
+	flags.StringVar(&cmd.tlsCertFile, "tls-cert", "", "(optional) serve the gRPC api over TLS with this certificate")
+	flags.StringVar(&cmd.tlsKeyFile, "tls-key", "", "(optional) private key of the TLS certificate")
+	flags.StringVar(&cmd.tlsClientCAFile, "tls-client-ca", "",
+		"(optional) require clients to present a TLS certificate issued by this CA")
 	return &cmd.Command
 }
 
@@ -63,6 +129,19 @@ func (cmd *apiCmd) makeConfigPath() error {
 	return os.MkdirAll(path.Dir(cmd.configPath), 0o700)
 }
 
+// authenticated reports whether clients must present the api token or a TLS
+// client certificate
+func (cmd *apiCmd) authenticated() bool {
+	return cmd.authTokenFile != "" || cmd.tlsClientCAFile != ""
+}
+
+// trustedClients reports whether only the current user can call the api: the
+// clients authenticate, or all api addresses are only accessible to the user
+func (cmd *apiCmd) trustedClients() bool {
+	return cmd.authenticated() ||
+		(ipc.IsLocal(cmd.grpcAddr) && (cmd.jsonRPCAddr == "" || ipc.IsLocal(cmd.jsonRPCAddr)))
+}
+
 func (cmd *apiCmd) exec(c *cobra.Command, args []string) error {
 	var err error
 	if err = cmd.makeConfigPath(); err != nil {
@@ -84,39 +163,142 @@ func (cmd *apiCmd) exec(c *cobra.Command, args []string) error {
 		}
 	}
 
-	ctx := c.Context()
-	srv, err := api.NewServer(ctx,
-		api.WithConfigProvider(api.FileConfigProvider(cmd.configPath)),
+	var encryptConfig bool
+	switch cmd.configEncryption {
+	case "on":
+		encryptConfig = true
+	case "off":
+	default:
+		return fmt.Errorf("--config-encryption: expected on or off, got %q", cmd.configEncryption)
+	}
+
+	srvOpts := []api.ServerOption{
+		api.WithConfigProvider(api.NewEncryptedConfigProvider(api.FileConfigProvider(cmd.configPath), encryptConfig)),
 		api.WithBrowserCommand(cmd.browserCmd),
 		api.WithServiceAccount(serviceAccountOptions.serviceAccount),
 		api.WithServiceAccountFile(serviceAccountOptions.serviceAccountFile),
-	)
+		api.WithClientCertExpiry(cmd.clientCertExpiryWarning, cmd.clientCertRenewHook),
+		api.WithTrustedClients(cmd.trustedClients()),
+	}
+	if cmd.statsPath != "" {
+		srvOpts = append(srvOpts, api.WithUsageStatsProvider(api.FileConfigProvider(cmd.statsPath)))
+	}
+	if cmd.listenersPath != "" {
+		srvOpts = append(srvOpts, api.WithListenerStateProvider(api.FileConfigProvider(cmd.listenersPath)))
+	}
+	srvOpts = append(srvOpts, api.WithRestoreListeners(cmd.restoreListeners))
+	if cmd.eventLogDir != "" {
+		eventLog, err := api.NewFileEventLog(cmd.eventLogDir, api.DefaultEventLogSize)
+		if err != nil {
+			return err
+		}
+		srvOpts = append(srvOpts, api.WithEventLog(eventLog))
+	}
+	if cmd.auditLogPath != "" {
+		auditLog, err := api.NewFileAuditLog(cmd.auditLogPath)
+		if err != nil {
+			return err
+		}
+		srvOpts = append(srvOpts, api.WithAuditLog(auditLog))
+	}
+
+	ctx := c.Context()
+	err = startMetricsServer(ctx)
 	if err != nil {
 		return err
 	}
 
-	lCfg := new(net.ListenConfig)
-	lis, err := lCfg.Listen(ctx, "tcp", cmd.grpcAddr)
+	srv, err := api.NewServer(ctx, srvOpts...)
+	if err != nil {
+		return err
+	}
+
+	lis, err := ipc.Listen(ctx, cmd.grpcAddr)
 	if err != nil {
 		return err
 	}
 	log.Info().Str("address", lis.Addr().String()).Msg("starting gRPC server")
 
-	interceptors := []grpc.UnaryServerInterceptor{pb.UnaryLog}
+	interceptors := []grpc.UnaryServerInterceptor{pb.UnaryLog, metrics.UnaryServerInterceptor}
+	var streamInterceptors []grpc.StreamServerInterceptor
+	if cmd.authTokenFile != "" {
+		token, err := apiauth.GenerateToken(cmd.authTokenFile)
+		if err != nil {
+			return err
+		}
+		log.Info().Str("path", cmd.authTokenFile).Msg("api token written")
+		interceptors = append(interceptors, apiauth.UnaryServerInterceptor(token))
+		streamInterceptors = append(streamInterceptors, apiauth.StreamServerInterceptor(token))
+	}
 	if sentryClient != nil {
 		interceptors = append(interceptors, pb.SentryErrorLog(sentryClient))
 	}
 	opts := []grpc.ServerOption{
 		grpc.ChainUnaryInterceptor(interceptors...),
+		grpc.ChainStreamInterceptor(streamInterceptors...),
+	}
+	var tlsCfg *tls.Config
+	if cmd.tlsCertFile != "" || cmd.tlsKeyFile != "" || cmd.tlsClientCAFile != "" {
+		if tlsCfg, err = apiauth.ServerTLSConfig(cmd.tlsCertFile, cmd.tlsKeyFile, cmd.tlsClientCAFile); err != nil {
+			return err
+		}
+		opts = append(opts, grpc.Creds(credentials.NewTLS(tlsCfg)))
 	}
 	grpcSrv := grpc.NewServer(opts...)
 	pb.RegisterConfigServer(grpcSrv, srv)
 	pb.RegisterListenerServer(grpcSrv, srv)
+	if !api.RegisterJWTCacheServer(grpcSrv, srv, cmd.grpcAddr, cmd.authenticated()) {
+		log.Warn().Msg("JWT cache not shared: requires --auth-token-file, --tls-client-ca " +
+			"or a unix socket or named pipe --grpc-addr")
+	}
+	healthSrv := health.NewServer()
+	healthpb.RegisterHealthServer(grpcSrv, healthSrv)
+	go api.WatchHealth(ctx, srv, healthSrv, api.HealthCheckInterval)
 	reflection.Register(grpcSrv)
 
+	if cmd.jsonRPCAddr != "" {
+		jsonSrv := jsonrpc.NewServer(interceptors...)
+		restSrv := gateway.NewServer(interceptors, streamInterceptors)
+		for _, r := range []grpc.ServiceRegistrar{jsonSrv, restSrv} {
+			pb.RegisterConfigServer(r, srv)
+			pb.RegisterListenerServer(r, srv)
+		}
+		mux := http.NewServeMux()
+		mux.Handle("/healthz", api.HealthHandler(healthSrv))
+		mux.Handle("/v1/", restSrv)
+		mux.Handle("/", jsonSrv)
+		if err := cmd.startHTTPServer(ctx, mux, tlsCfg); err != nil {
+			return err
+		}
+	}
+
 	go func() {
 		<-ctx.Done()
 		grpcSrv.Stop()
 	}()
 	return grpcSrv.Serve(lis)
 }
+
+func (cmd *apiCmd) startHTTPServer(ctx context.Context, handler http.Handler, tlsCfg *tls.Config) error {
+	li, err := ipc.Listen(ctx, cmd.jsonRPCAddr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on JSON-RPC and REST api address: %w", err)
+	}
+	if tlsCfg != nil {
+		li = tls.NewListener(li, tlsCfg)
+	}
+	log.Info().Str("address", li.Addr().String()).Msg("starting JSON-RPC and REST api server")
+
+	srv := &http.Server{
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	context.AfterFunc(ctx, func() { _ = srv.Close() })
+	go func() {
+		err := srv.Serve(li)
+		if !errors.Is(err, http.ErrServerClosed) {
+			log.Error().Err(err).Msg("error serving JSON-RPC and REST api")
+		}
+	}()
+	return nil
+}
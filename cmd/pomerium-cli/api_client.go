@@ -0,0 +1,149 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/pomerium/cli/api"
+	"github.com/pomerium/cli/internal/apiauth"
+	"github.com/pomerium/cli/internal/ipc"
+	"github.com/pomerium/cli/jwt"
+	pb "github.com/pomerium/cli/proto"
+)
+
+var apiClientOptions struct {
+	addr string
+}
+
+func addAPIClientFlags(cmd *cobra.Command) {
+	flags := cmd.Flags()
+	flags.StringVar(&apiClientOptions.addr, "api-addr", "127.0.0.1:8800",
+		"gRPC address of a running api server: host:port, unix:/path/to/socket or, on Windows, npipe:name")
+	addAPIAuthFlags(cmd)
+}
+
+var apiAuthOptions struct {
+	tokenFile      string
+	caCert         string
+	clientCertPath string
+	clientKeyPath  string
+}
+
+func addAPIAuthFlags(cmd *cobra.Command) {
+	flags := cmd.Flags()
+	flags.StringVar(&apiAuthOptions.tokenFile, "api-token-file", "",
+		"(optional) file with the token to authenticate to the api server with, see its --auth-token-file")
+	flags.StringVar(&apiAuthOptions.caCert, "api-ca-cert", "",
+		"(optional) connect to the api server over TLS, verifying its certificate with this CA")
+	flags.StringVar(&apiAuthOptions.clientCertPath, "api-client-cert", "",
+		"(optional) connect to the api server over TLS with this client certificate")
+	flags.StringVar(&apiAuthOptions.clientKeyPath, "api-client-key", "",
+		"(optional) private key of the api client certificate")
+}
+
+// apiClient talks to a running api server, i.e. the one managed by Pomerium Desktop
+type apiClient struct {
+	pb.ConfigClient
+	pb.ListenerClient
+	conn *grpc.ClientConn
+}
+
+func newAPIClient() (*apiClient, error) {
+	conn, err := dialAPIServer(apiClientOptions.addr)
+	if err != nil {
+		return nil, fmt.Errorf("api server %s: %w", apiClientOptions.addr, err)
+	}
+	return &apiClient{
+		ConfigClient:   pb.NewConfigClient(conn),
+		ListenerClient: pb.NewListenerClient(conn),
+		conn:           conn,
+	}, nil
+}
+
+// dialAPIServer creates a client connection to the api server at addr, which
+// may also be a Unix domain socket or named pipe, see [ipc.Dial]. The api auth
+// flags set up TLS and the token to authenticate with.
+func dialAPIServer(addr string) (*grpc.ClientConn, error) {
+	creds := insecure.NewCredentials()
+	if apiAuthOptions.caCert != "" || apiAuthOptions.clientCertPath != "" || apiAuthOptions.clientKeyPath != "" {
+		tlsCfg, err := apiauth.ClientTLSConfig(apiAuthOptions.caCert,
+			apiAuthOptions.clientCertPath, apiAuthOptions.clientKeyPath)
+		if err != nil {
+			return nil, err
+		}
+		creds = credentials.NewTLS(tlsCfg)
+	}
+	opts := []grpc.DialOption{
+		grpc.WithTransportCredentials(creds),
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return ipc.Dial(ctx, addr)
+		}),
+	}
+	if ipc.IsLocal(addr) {
+		opts = append(opts, grpc.WithAuthority("localhost"))
+	}
+	if apiAuthOptions.tokenFile != "" {
+		token, err := apiauth.ReadToken(apiAuthOptions.tokenFile)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, grpc.WithPerRPCCredentials(apiauth.TokenCredentials(token)))
+	}
+	return grpc.NewClient("passthrough:///"+addr, opts...)
+}
+
+func (c *apiClient) Close() error {
+	return c.conn.Close()
+}
+
+// selectorFromArgs selects records by ids and tags, or all records if neither
+// is given, excluding records that have any of notTags
+func selectorFromArgs(ids, tags, notTags []string) *pb.Selector {
+	if len(ids) == 0 && len(tags) == 0 {
+		return &pb.Selector{All: true, NotTags: notTags}
+	}
+	return &pb.Selector{Ids: ids, Tags: tags, NotTags: notTags}
+}
+
+var jwtCacheOptions struct {
+	apiAddr string
+
+	once  sync.Once
+	cache jwt.Cache
+}
+
+func addJWTCacheFlags(cmd *cobra.Command) {
+	flags := cmd.Flags()
+	flags.StringVar(&jwtCacheOptions.apiAddr, "jwt-cache-api-addr", "",
+		"(optional) gRPC address of a running api server to share cached JWTs with, "+
+			"so that terminal and desktop tunnels use the same session; the api server only shares "+
+			"its cache when authenticated or listening on a unix socket or named pipe")
+	addAPIAuthFlags(cmd)
+}
+
+// getJWTCache returns the JWT cache of the api server if requested, or the local cache otherwise
+func getJWTCache() jwt.Cache {
+	jwtCacheOptions.once.Do(func() {
+		jwtCacheOptions.cache = jwt.GetCache()
+		if jwtCacheOptions.apiAddr == "" {
+			return
+		}
+
+		conn, err := dialAPIServer(jwtCacheOptions.apiAddr)
+		if err != nil {
+			log.Error().Err(err).Str("addr", jwtCacheOptions.apiAddr).
+				Msg("error connecting to api server, using local JWT cache")
+			return
+		}
+		jwtCacheOptions.cache = api.NewRemoteJWTCache(conn, jwtCacheOptions.cache)
+	})
+	return jwtCacheOptions.cache
+}
@@ -0,0 +1,401 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pomerium/cli/tunnel"
+)
+
+const (
+	benchTimeout        = 10 * time.Second
+	benchRTTMessageSize = 64
+)
+
+var benchCmdOptions struct {
+	pomeriumURL string
+	udp         bool
+	protocols   []string
+	duration    time.Duration
+	samples     int
+	size        int
+}
+
+func init() {
+	addBrowserFlags(benchCmd)
+	addServiceAccountFlags(benchCmd)
+	addTLSFlags(benchCmd)
+	addOutboundProxyFlags(benchCmd)
+	addProxyChainFlags(benchCmd)
+	addJWTCacheFlags(benchCmd)
+	addContextFlags(benchCmd)
+	flags := benchCmd.Flags()
+	flags.StringVar(&benchCmdOptions.pomeriumURL, "pomerium-url", "",
+		"the URL of the pomerium server to connect to")
+	flags.BoolVar(&benchCmdOptions.udp, "udp", false,
+		"benchmark a UDP route instead of a TCP route")
+	flags.StringSliceVar(&benchCmdOptions.protocols, "protocols", nil,
+		"protocols to benchmark, defaults to h1, h2 and h3")
+	flags.DurationVar(&benchCmdOptions.duration, "duration", 10*time.Second,
+		"how long to measure throughput for each protocol")
+	flags.IntVar(&benchCmdOptions.samples, "samples", 100,
+		"number of round trips to measure for each protocol")
+	flags.IntVar(&benchCmdOptions.size, "size", 0,
+		"size of the written chunks or datagrams, defaults to 32768 for TCP and 1200 for UDP")
+	rootCmd.AddCommand(benchCmd)
+}
+
+var benchCmd = &cobra.Command{
+	Use:   "bench destination",
+	Short: "measures throughput and latency of a tunnel to an echo server",
+	Long: "Measures throughput, round trip times and, for UDP, datagram loss of a tunnel " +
+		"through Pomerium for each protocol. The destination must echo all data it receives.",
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		destinationAddr, proxyURL, err := tunnel.ParseURLs(args[0], benchCmdOptions.pomeriumURL)
+		if err != nil {
+			return newConfigError(err)
+		}
+
+		var tlsConfig *tls.Config
+		if proxyURL.Scheme == "https" {
+			tlsConfig, err = getTLSConfig()
+			if err != nil {
+				return err
+			}
+		}
+
+		protocols, err := getBenchProtocols(tlsConfig != nil)
+		if err != nil {
+			return err
+		}
+		size := benchCmdOptions.size
+		if size <= 0 {
+			size = 32 * 1024
+			if benchCmdOptions.udp {
+				size = 1200
+			}
+		}
+		if size < benchRTTMessageSize {
+			return newConfigError(fmt.Errorf("size must be at least %d", benchRTTMessageSize))
+		}
+
+		outboundProxies, err := getOutboundProxyURLs()
+		if err != nil {
+			return err
+		}
+		proxyChain, err := getProxyChain()
+		if err != nil {
+			return err
+		}
+		callbackPorts, err := getCallbackPortRange()
+		if err != nil {
+			return err
+		}
+
+		options := []tunnel.Option{
+			tunnel.WithBrowserCommand(browserOptions.command),
+			tunnel.WithCallbackPath(browserOptions.callbackPath),
+			tunnel.WithCallbackPortRange(callbackPorts),
+			tunnel.WithRedirectAddress(browserOptions.callbackAddr),
+			tunnel.WithQRCode(browserOptions.qrCode),
+			tunnel.WithDestinationHost(destinationAddr),
+			tunnel.WithOutboundProxy(outboundProxies...),
+			tunnel.WithProxyChain(proxyChain...),
+			tunnel.WithProxyHost(proxyURL.Host),
+			tunnel.WithServiceAccount(serviceAccountOptions.serviceAccount),
+			tunnel.WithServiceAccountFile(serviceAccountOptions.serviceAccountFile),
+			tunnel.WithTLSConfig(tlsConfig),
+			tunnel.WithJWTCache(getJWTCache()),
+		}
+
+		var results []*benchResult
+		for _, protocol := range protocols {
+			_, _ = fmt.Fprintf(os.Stderr, "benchmarking %s...\n", protocol)
+			tun := tunnel.New(append(options, tunnel.WithProtocol(protocol))...)
+			var res *benchResult
+			if benchCmdOptions.udp {
+				res = benchUDP(cmd.Context(), tun, size)
+			} else {
+				res = benchTCP(cmd.Context(), tun, size)
+			}
+			res.protocol = protocol
+			results = append(results, res)
+		}
+		return printBenchResults(results, benchCmdOptions.udp)
+	},
+}
+
+func getBenchProtocols(useTLS bool) ([]tunnel.Protocol, error) {
+	names := benchCmdOptions.protocols
+	if len(names) == 0 {
+		names = []string{"h1", "h2", "h3"}
+		if !useTLS {
+			names = []string{"h1"}
+		}
+	}
+
+	protocols := make([]tunnel.Protocol, 0, len(names))
+	for _, name := range names {
+		protocol, err := tunnel.ParseProtocol(name)
+		if err != nil {
+			return nil, newConfigError(err)
+		}
+		protocols = append(protocols, protocol)
+	}
+	return protocols, nil
+}
+
+type benchResult struct {
+	protocol tunnel.Protocol
+	err      error
+
+	rtts     []time.Duration
+	bytes    int64
+	elapsed  time.Duration
+	sent     int64
+	received int64
+}
+
+// benchTCP measures round trip times and then the throughput of a TCP tunnel
+func benchTCP(ctx context.Context, tun *tunnel.Tunnel, size int) *benchResult {
+	res := new(benchResult)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	local, remote := net.Pipe()
+	defer func() { _ = local.Close() }()
+	errc := make(chan error, 1)
+	go func() {
+		errc <- tun.Run(ctx, remote, tunnel.DiscardEvents())
+		_ = remote.Close()
+	}()
+
+	msg, buf := make([]byte, benchRTTMessageSize), make([]byte, benchRTTMessageSize)
+	roundTrip := func(timeout time.Duration) error {
+		if timeout > 0 {
+			_ = local.SetDeadline(time.Now().Add(timeout))
+		}
+		if _, err := local.Write(msg); err != nil {
+			return err
+		}
+		_, err := io.ReadFull(local, buf)
+		return err
+	}
+
+	// the first round trip connects, which may require a login
+	if err := roundTrip(0); err != nil {
+		res.err = benchError(err, errc)
+		return res
+	}
+	for i := 0; i < benchCmdOptions.samples; i++ {
+		start := time.Now()
+		if err := roundTrip(benchTimeout); err != nil {
+			res.err = benchError(err, errc)
+			return res
+		}
+		res.rtts = append(res.rtts, time.Since(start))
+	}
+
+	_ = local.SetDeadline(time.Time{})
+	var received atomic.Int64
+	go func() {
+		buf := make([]byte, 64*1024)
+		for {
+			n, err := local.Read(buf)
+			received.Add(int64(n))
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	payload := make([]byte, size)
+	start := time.Now()
+	deadline := start.Add(benchCmdOptions.duration)
+	_ = local.SetWriteDeadline(deadline)
+	for time.Now().Before(deadline) {
+		if _, err := local.Write(payload); err != nil {
+			if !errors.Is(err, os.ErrDeadlineExceeded) {
+				res.err = benchError(err, errc)
+			}
+			break
+		}
+	}
+	res.elapsed = time.Since(start)
+	res.bytes = received.Load()
+	return res
+}
+
+// benchUDP measures round trip times and then the throughput and datagram loss of a UDP tunnel
+func benchUDP(ctx context.Context, tun *tunnel.Tunnel, size int) *benchResult {
+	res := new(benchResult)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	listener, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	if err != nil {
+		res.err = err
+		return res
+	}
+	defer func() { _ = listener.Close() }()
+	errc := make(chan error, 1)
+	go func() { errc <- tun.RunUDPSessionManager(ctx, listener, tunnel.DiscardEvents()) }()
+
+	conn, err := net.DialUDP("udp", nil, listener.LocalAddr().(*net.UDPAddr))
+	if err != nil {
+		res.err = err
+		return res
+	}
+	defer func() { _ = conn.Close() }()
+
+	msg, buf := make([]byte, benchRTTMessageSize), make([]byte, size)
+	var seq uint64
+	// roundTrip sends a datagram and waits for its echo, ignoring late echoes of earlier ones
+	roundTrip := func(timeout time.Duration) error {
+		seq++
+		binary.BigEndian.PutUint64(msg, seq)
+		if _, err := conn.Write(msg); err != nil {
+			return err
+		}
+		_ = conn.SetReadDeadline(time.Now().Add(timeout))
+		for {
+			n, err := conn.Read(buf)
+			if err != nil {
+				return err
+			}
+			if n >= 8 && binary.BigEndian.Uint64(buf) == seq {
+				return nil
+			}
+		}
+	}
+
+	// datagrams are dropped until the tunnel is connected, which may require a login
+	for {
+		err := roundTrip(time.Second)
+		if err == nil {
+			break
+		} else if !errors.Is(err, os.ErrDeadlineExceeded) {
+			res.err = benchError(err, errc)
+			return res
+		}
+		select {
+		case err := <-errc:
+			res.err = err
+			return res
+		case <-ctx.Done():
+			res.err = ctx.Err()
+			return res
+		default:
+		}
+	}
+	for i := 0; i < benchCmdOptions.samples; i++ {
+		start := time.Now()
+		err := roundTrip(time.Second)
+		if errors.Is(err, os.ErrDeadlineExceeded) {
+			continue
+		} else if err != nil {
+			res.err = benchError(err, errc)
+			return res
+		}
+		res.rtts = append(res.rtts, time.Since(start))
+	}
+
+	var received atomic.Int64
+	var receivedBytes atomic.Int64
+	_ = conn.SetReadDeadline(time.Time{})
+	go func() {
+		buf := make([]byte, size)
+		for {
+			n, err := conn.Read(buf)
+			if err != nil {
+				return
+			}
+			received.Add(1)
+			receivedBytes.Add(int64(n))
+		}
+	}()
+
+	payload := make([]byte, size)
+	start := time.Now()
+	for time.Since(start) < benchCmdOptions.duration {
+		if _, err := conn.Write(payload); err != nil {
+			res.err = benchError(err, errc)
+			break
+		}
+		res.sent++
+	}
+	res.elapsed = time.Since(start)
+	// wait for datagrams still in flight
+	time.Sleep(time.Second)
+	res.received = received.Load()
+	res.bytes = receivedBytes.Load()
+	return res
+}
+
+// benchError returns the tunnel error that caused err, if there is one
+func benchError(err error, errc <-chan error) error {
+	select {
+	case tunErr := <-errc:
+		if tunErr != nil {
+			return tunErr
+		}
+	case <-time.After(100 * time.Millisecond):
+	}
+	return err
+}
+
+func printBenchResults(results []*benchResult, udp bool) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	hdr := []string{"PROTOCOL", "THROUGHPUT", "RTT P50", "RTT P90", "RTT P99"}
+	if udp {
+		hdr = append(hdr, "LOSS")
+	}
+	hdr = append(hdr, "ERROR")
+	_, _ = fmt.Fprintln(w, strings.Join(hdr, "\t"))
+
+	for _, res := range results {
+		row := []string{string(res.protocol), "-", "-", "-", "-"}
+		if res.elapsed > 0 {
+			row[1] = fmt.Sprintf("%.1f Mbit/s", float64(res.bytes)*8/res.elapsed.Seconds()/1e6)
+		}
+		slices.Sort(res.rtts)
+		if len(res.rtts) > 0 {
+			row[2] = percentile(res.rtts, 0.5).String()
+			row[3] = percentile(res.rtts, 0.9).String()
+			row[4] = percentile(res.rtts, 0.99).String()
+		}
+		if udp {
+			loss := "-"
+			if res.sent > 0 {
+				loss = fmt.Sprintf("%.2f%%", 100*float64(res.sent-res.received)/float64(res.sent))
+			}
+			row = append(row, loss)
+		}
+		errText := ""
+		if res.err != nil {
+			errText = res.err.Error()
+		}
+		row = append(row, errText)
+		_, _ = fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+	return w.Flush()
+}
+
+// percentile returns the q-th percentile of the sorted durations
+func percentile(sorted []time.Duration, q float64) time.Duration {
+	return sorted[int(q*float64(len(sorted)-1))].Round(time.Microsecond)
+}
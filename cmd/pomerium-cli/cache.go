@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
@@ -11,11 +12,16 @@ import (
 	"path/filepath"
 	"time"
 
+	"github.com/rs/zerolog/log"
 	"github.com/spf13/cobra"
 
 	"github.com/pomerium/cli/internal/cache"
+	"github.com/pomerium/cli/internal/filelock"
+	"github.com/pomerium/cli/jwt"
 )
 
+const execCredentialLockTimeout = 5 * time.Minute
+
 var cacheCmd = &cobra.Command{
 	Use:   "cache",
 	Short: "commands for working with the cache",
@@ -25,6 +31,12 @@ var cacheClearCmd = &cobra.Command{
 	Use:   "clear",
 	Short: "clear the cache",
 	RunE: func(_ *cobra.Command, _ []string) error {
+		// JWTs in the keyring are not removed with the cache directory
+		if c, err := jwt.NewKeyringCache(); err == nil {
+			if err := c.Clear(); err != nil {
+				return err
+			}
+		}
 		return cache.Clear()
 	},
 }
@@ -42,10 +54,32 @@ var cacheLocationCmd = &cobra.Command{
 	},
 }
 
+// cacheEncryption is the value of the --cache-encryption flag
+type cacheEncryption string
+
+func (e *cacheEncryption) String() string { return string(*e) }
+func (e *cacheEncryption) Type() string   { return "mode" }
+func (e *cacheEncryption) Set(v string) error {
+	if err := cache.SetEncryption(v); err != nil {
+		return err
+	}
+	*e = cacheEncryption(v)
+	return nil
+}
+
+var cacheEncryptionOptions = struct {
+	mode cacheEncryption
+}{mode: cache.EncryptionNone}
+
 func init() {
 	cacheCmd.AddCommand(cacheClearCmd)
 	cacheCmd.AddCommand(cacheLocationCmd)
 	rootCmd.AddCommand(cacheCmd)
+
+	rootCmd.PersistentFlags().Var(&cacheEncryptionOptions.mode, "cache-encryption",
+		"encryption of the cached JWTs and Kubernetes credentials: none, keyring, with a key kept "+
+			"in the keyring of the operating system, or passphrase, with a key derived from "+
+			"the "+cache.PassphraseEnv+" environment variable")
 }
 
 func cachedCredentialPath(serverURL string) (string, error) {
@@ -91,14 +125,20 @@ func loadCachedCredential(serverURL string) (*ExecCredential, error) {
 		return nil, err
 	}
 
-	f, err := os.Open(fn)
+	data, err := os.ReadFile(fn)
 	if err != nil {
 		return nil, err
 	}
-	defer func() { _ = f.Close() }()
+	data, migrate, err := cache.Open(filepath.Base(fn), data)
+	if err != nil {
+		if errors.Is(err, cache.ErrUndecryptable) {
+			_ = os.Remove(fn)
+		}
+		return nil, err
+	}
 
 	var creds ExecCredential
-	err = json.NewDecoder(f).Decode(&creds)
+	err = json.Unmarshal(data, &creds)
 	if err != nil {
 		_ = os.Remove(fn)
 		return nil, err
@@ -115,6 +155,13 @@ func loadCachedCredential(serverURL string) (*ExecCredential, error) {
 		return nil, errors.New("expired")
 	}
 
+	if migrate {
+		// encrypt the credential cached before cache encryption was enabled
+		if err := saveCachedCredential(serverURL, &creds); err != nil {
+			log.Error().Err(err).Msg("failed to encrypt cached credential")
+		}
+	}
+
 	return &creds, nil
 }
 
@@ -124,20 +171,31 @@ func saveCachedCredential(serverURL string, creds *ExecCredential) error {
 		return err
 	}
 
+	data, err := json.Marshal(creds)
+	if err != nil {
+		return fmt.Errorf("failed to encode credentials: %w", err)
+	}
+	data, err = cache.Seal(filepath.Base(fn), data)
+	if err != nil {
+		return err
+	}
+
 	err = os.MkdirAll(filepath.Dir(fn), 0o755)
 	if err != nil {
 		return fmt.Errorf("failed to create cache directory: %w", err)
 	}
 
-	f, err := os.Create(fn)
+	// write to a temporary file first, so concurrent readers never see a partial file
+	f, err := os.CreateTemp(filepath.Dir(fn), filepath.Base(fn)+".*.tmp")
 	if err != nil {
 		return fmt.Errorf("failed to create cache file: %w", err)
 	}
+	defer func() { _ = os.Remove(f.Name()) }()
 
-	err = json.NewEncoder(f).Encode(creds)
+	_, err = f.Write(data)
 	if err != nil {
 		_ = f.Close()
-		return fmt.Errorf("failed to encode credentials to cache file: %w", err)
+		return fmt.Errorf("failed to write credentials to cache file: %w", err)
 	}
 
 	err = f.Close()
@@ -145,9 +203,32 @@ func saveCachedCredential(serverURL string, creds *ExecCredential) error {
 		return fmt.Errorf("failed to close cache file: %w", err)
 	}
 
+	err = os.Rename(f.Name(), fn)
+	if err != nil {
+		return fmt.Errorf("failed to replace cache file: %w", err)
+	}
+
 	return nil
 }
 
+// lockCachedCredential locks the cached credential of the server across
+// processes, waiting at most execCredentialLockTimeout for another login.
+func lockCachedCredential(ctx context.Context, serverURL string) (*filelock.Lock, error) {
+	fn, err := cachedCredentialPath(serverURL)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, execCredentialLockTimeout)
+	defer cancel()
+
+	lock, err := filelock.Acquire(ctx, fn+".lock")
+	if err != nil {
+		return nil, fmt.Errorf("failed to wait for concurrent login: %w", err)
+	}
+	return lock, nil
+}
+
 func loadLastURL() string {
 	fn, err := cache.LastURLPath()
 	if err != nil {
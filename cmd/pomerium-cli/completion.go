@@ -0,0 +1,180 @@
+package main
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pomerium/cli/internal/cache"
+	"github.com/pomerium/cli/internal/contexts"
+	"github.com/pomerium/cli/internal/portal"
+	pb "github.com/pomerium/cli/proto"
+	"github.com/pomerium/cli/tunnel"
+)
+
+// completionTimeout bounds the requests made to complete an argument
+const completionTimeout = 2 * time.Second
+
+type completionFunc = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective)
+
+// completeOneArg completes only the first argument with fn
+func completeOneArg(fn completionFunc) completionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return fn(cmd, args, toComplete)
+	}
+}
+
+// completeRouteDestinations completes the destination argument of a tunnel
+// with the routes of the type that were recently used in the context, or are
+// cached for the server of the context or the last used server. The routes
+// are not fetched, run pomerium-cli routes list to cache them.
+func completeRouteDestinations(routeType string) completionFunc {
+	return completeOneArg(func(cmd *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		pomeriumURL, _ := cmd.Flags().GetString("pomerium-url")
+		serverURLs := []string{pomeriumURL, loadLastURL()}
+		var lastRoutes []string
+		if c := completionContext(cmd); c != nil {
+			if pomeriumURL == "" {
+				pomeriumURL = c.ServerURL
+			}
+			serverURLs = append(serverURLs, c.ServerURL)
+			lastRoutes = c.LastRoutes
+		}
+
+		var completions []string
+		seen := make(map[string]bool)
+		add := func(destination, description string) {
+			if destination == "" || seen[destination] || !strings.HasPrefix(destination, toComplete) {
+				return
+			}
+			seen[destination] = true
+			completions = append(completions, destination+"\t"+description)
+		}
+		for _, route := range lastRoutes {
+			add(route, "recently used")
+		}
+
+		dir, err := cache.RoutesPath()
+		if err != nil {
+			return completions, cobra.ShellCompDirectiveNoFileComp
+		}
+		for _, serverURL := range serverURLs {
+			if serverURL == "" {
+				continue
+			}
+			routes, err := portal.CachedRoutes(dir, serverURL)
+			if err != nil {
+				continue
+			}
+			for _, route := range routes {
+				if route.Type == routeType {
+					add(routeDestination(route.From, pomeriumURL), route.Name)
+				}
+			}
+		}
+		return completions, cobra.ShellCompDirectiveNoFileComp
+	})
+}
+
+// routeDestination returns the host:port of the route if it is reached
+// through the pomerium URL, or the URL of the route otherwise
+func routeDestination(from, pomeriumURL string) string {
+	destinationAddr, proxyURL, err := tunnel.ParseURLs(from, "")
+	if err != nil {
+		return ""
+	}
+	_, viaURL, err := tunnel.ParseURLs(destinationAddr, pomeriumURL)
+	if err != nil || viaURL.String() != proxyURL.String() {
+		return from
+	}
+	return destinationAddr
+}
+
+// completionContext returns the context selected by the --context flag, or
+// the current context
+func completionContext(cmd *cobra.Command) *contexts.Context {
+	_, cfg, err := loadContexts()
+	if err != nil {
+		return nil
+	}
+	name, _ := cmd.Flags().GetString("context")
+	c, err := cfg.Get(name)
+	if err != nil {
+		return nil
+	}
+	return c
+}
+
+// completeContextNames completes the names of the contexts
+func completeContextNames(_ *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	_, cfg, err := loadContexts()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	var names []string
+	for _, name := range cfg.Names() {
+		if strings.HasPrefix(name, toComplete) {
+			names = append(names, name+"\t"+cfg.Contexts[name].ServerURL)
+		}
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeConnectionIDs completes the ids of the connections of the api
+// server, which are not already given
+func completeConnectionIDs(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	var ids []string
+	for _, rec := range completionRecords() {
+		id := rec.GetId()
+		if !strings.HasPrefix(id, toComplete) || slices.Contains(args, id) {
+			continue
+		}
+		ids = append(ids, id+"\t"+rec.GetConn().GetName())
+	}
+	return ids, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeTags completes the last of the comma separated tags with the tags
+// of the connections of the api server
+func completeTags(_ *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	prefix, last := "", toComplete
+	if i := strings.LastIndex(toComplete, ","); i >= 0 {
+		prefix, last = toComplete[:i+1], toComplete[i+1:]
+	}
+	given := strings.Split(prefix, ",")
+
+	var tags []string
+	for _, rec := range completionRecords() {
+		for _, tag := range rec.GetTags() {
+			if strings.HasPrefix(tag, last) && !slices.Contains(given, tag) && !slices.Contains(tags, prefix+tag) {
+				tags = append(tags, prefix+tag)
+			}
+		}
+	}
+	slices.Sort(tags)
+	return tags, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace
+}
+
+// completionRecords returns the connections of the api server, or nil if it
+// is not running
+func completionRecords() []*pb.Record {
+	client, err := newAPIClient()
+	if err != nil {
+		return nil
+	}
+	defer func() { _ = client.Close() }()
+
+	ctx, cancel := context.WithTimeout(context.Background(), completionTimeout)
+	defer cancel()
+	recs, err := client.List(ctx, &pb.Selector{All: true})
+	if err != nil {
+		return nil
+	}
+	return recs.GetRecords()
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pomerium/cli/api"
+	pb "github.com/pomerium/cli/proto"
+)
+
+var configCmdOptions struct {
+	configPath string
+	noSecrets  bool
+}
+
+func init() {
+	for _, cmd := range []*cobra.Command{configBackupCmd, configRestoreCmd} {
+		addAPIClientFlags(cmd)
+		cmd.Flags().StringVar(&configCmdOptions.configPath, "config-path", "",
+			"(optional) use the config file at this path directly instead of a running api server, "+
+				"which should not be running at the same time")
+		configCmd.AddCommand(cmd)
+	}
+	configBackupCmd.Flags().BoolVar(&configCmdOptions.noSecrets, "no-secrets", false,
+		"exclude the inline client certificate keys and the service accounts from the backup")
+	rootCmd.AddCommand(configCmd)
+}
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "back up and restore the connections of the api server",
+}
+
+var configBackupCmd = &cobra.Command{
+	Use:   "backup file",
+	Short: "write all connections to a file, or stdout if file is -",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		store, err := openConfigStore(cmd.Context(), true)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = store.Close() }()
+
+		data, err := store.Export(cmd.Context(), &pb.ExportRequest{
+			Selector:      &pb.Selector{All: true},
+			Format:        pb.ExportRequest_EXPORT_FORMAT_JSON_PRETTY,
+			RemoveSecrets: configCmdOptions.noSecrets,
+		})
+		if err != nil {
+			return err
+		}
+
+		if args[0] == "-" {
+			_, err = os.Stdout.Write(data.GetData())
+			return err
+		}
+		return os.WriteFile(args[0], data.GetData(), 0o600)
+	},
+}
+
+var configRestoreCmd = &cobra.Command{
+	Use:   "restore file",
+	Short: "restore the connections from a backup file, or stdin if file is -",
+	Long: "Restores the connections from a backup file. Connections with the same id are replaced, " +
+		"other connections are kept. Secrets excluded from the backup are not restored.",
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var data []byte
+		var err error
+		if args[0] == "-" {
+			data, err = io.ReadAll(os.Stdin)
+		} else {
+			data, err = os.ReadFile(args[0])
+		}
+		if err != nil {
+			return err
+		}
+
+		store, err := openConfigStore(cmd.Context(), false)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = store.Close() }()
+
+		_, err = store.Import(cmd.Context(), &pb.ImportRequest{
+			Data:   data,
+			Format: pb.ImportRequest_IMPORT_FORMAT_JSON,
+		})
+		return err
+	},
+}
+
+// configStore exports and imports the records of either a running api server
+// or a config file
+type configStore interface {
+	Export(ctx context.Context, req *pb.ExportRequest) (*pb.ConfigData, error)
+	Import(ctx context.Context, req *pb.ImportRequest) (*pb.ImportResponse, error)
+	Close() error
+}
+
+func openConfigStore(ctx context.Context, readOnly bool) (configStore, error) {
+	if configCmdOptions.configPath == "" {
+		client, err := newAPIClient()
+		if err != nil {
+			return nil, err
+		}
+		return &apiConfigStore{client}, nil
+	}
+
+	path := configCmdOptions.configPath
+	data, err := api.FileConfigProvider(path).Load()
+	if err != nil {
+		return nil, fmt.Errorf("config %s: %w", path, err)
+	}
+	var cp api.ConfigProvider = api.FileConfigProvider(path)
+	if readOnly {
+		cp = readOnlyConfigProvider{cp}
+	}
+	// keep the file encrypted, or not, as it is
+	cp = api.NewEncryptedConfigProvider(cp, api.IsEncryptedConfig(data))
+
+	srv, err := api.NewServer(ctx,
+		api.WithConfigProvider(cp),
+		api.WithAutostartListeners(false),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("config %s: %w", path, err)
+	}
+	return fileConfigStore{srv}, nil
+}
+
+type apiConfigStore struct {
+	*apiClient
+}
+
+func (s *apiConfigStore) Export(ctx context.Context, req *pb.ExportRequest) (*pb.ConfigData, error) {
+	return s.apiClient.Export(ctx, req)
+}
+
+func (s *apiConfigStore) Import(ctx context.Context, req *pb.ImportRequest) (*pb.ImportResponse, error) {
+	return s.apiClient.Import(ctx, req)
+}
+
+type fileConfigStore struct {
+	api.Server
+}
+
+func (fileConfigStore) Close() error { return nil }
+
+// readOnlyConfigProvider does not write the config file when backing it up,
+// i.e. after migrating it to a newer schema version
+type readOnlyConfigProvider struct {
+	api.ConfigProvider
+}
+
+func (readOnlyConfigProvider) Save([]byte) error { return nil }
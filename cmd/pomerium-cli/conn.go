@@ -0,0 +1,434 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/pomerium/cli/proto"
+)
+
+var connCmdOptions struct {
+	id          string
+	name        string
+	listen      string
+	pomeriumURL string
+	protocol    string
+	tags        []string
+	notTags     []string
+	format      string
+	overrideTag string
+
+	browserCmd         string
+	serviceAccount     string
+	serviceAccountFile string
+
+	portalTag              string
+	portalCACert           string
+	portalDisableTLSVerify bool
+}
+
+func init() {
+	for _, cmd := range []*cobra.Command{
+		connAddCmd, connListCmd, connEditCmd, connRemoveCmd, connConnectCmd, connDisconnectCmd, connImportCmd,
+		connImportPortalCmd,
+	} {
+		addAPIClientFlags(cmd)
+		connCmd.AddCommand(cmd)
+	}
+	for _, cmd := range []*cobra.Command{connAddCmd, connEditCmd} {
+		flags := cmd.Flags()
+		flags.StringVar(&connCmdOptions.name, "name", "",
+			"user friendly connection name")
+		flags.StringVar(&connCmdOptions.listen, "listen", "",
+			"local address to start a listener on, a random port is used if empty, "+
+				"or unix:/path/to.sock for a Unix domain socket")
+		flags.StringVar(&connCmdOptions.pomeriumURL, "pomerium-url", "",
+			"the URL of the pomerium server to connect to")
+		flags.StringVar(&connCmdOptions.protocol, "protocol", "tcp",
+			"the protocol to use for the connection (tcp or udp)")
+		flags.StringSliceVar(&connCmdOptions.tags, "tags", nil,
+			"tags to assign to the connection")
+		_ = cmd.RegisterFlagCompletionFunc("tags", completeTags)
+		flags.StringVar(&connCmdOptions.browserCmd, "browser-cmd", "",
+			"(optional) browser app to authenticate with instead of the api server's, "+
+				"only accepted by an authenticated api server")
+		flags.StringVar(&connCmdOptions.serviceAccount, "service-account", "",
+			"(optional) service account JWT to authenticate with instead of the api server's, "+
+				"empty to authenticate interactively")
+		flags.StringVar(&connCmdOptions.serviceAccountFile, "service-account-file", "",
+			"(optional) file with the service account JWT to authenticate with instead of the api server's, "+
+				"only accepted by an authenticated api server")
+	}
+	connAddCmd.Flags().StringVar(&connCmdOptions.id, "id", "",
+		"(optional) a stable id for the connection instead of a generated one, "+
+			"lowercase letters, digits, '.', '_' or '-'")
+	connImportCmd.Flags().StringVar(&connCmdOptions.format, "format", "",
+		"format of the file: json or csv, defaults to csv for files ending in .csv and json otherwise")
+	connImportCmd.Flags().StringVar(&connCmdOptions.overrideTag, "tag", "",
+		"(optional) assign this tag to all imported connections instead of their own tags")
+	flags := connImportPortalCmd.Flags()
+	flags.StringVar(&connCmdOptions.portalTag, "tag", "",
+		"(optional) tag to assign to the imported connections, defaults to the host of the server url")
+	flags.StringVar(&connCmdOptions.portalCACert, "ca-cert", "",
+		"(optional) file with a PEM-encoded CA certificate to verify the server with")
+	flags.BoolVar(&connCmdOptions.portalDisableTLSVerify, "disable-tls-verification", false,
+		"disables TLS verification of the server")
+	for _, cmd := range []*cobra.Command{connListCmd, connConnectCmd, connDisconnectCmd} {
+		flags := cmd.Flags()
+		flags.StringSliceVar(&connCmdOptions.tags, "tags", nil,
+			"only select connections with the given tags")
+		flags.StringSliceVar(&connCmdOptions.notTags, "not-tags", nil,
+			"do not select connections with the given tags")
+		_ = cmd.RegisterFlagCompletionFunc("tags", completeTags)
+		_ = cmd.RegisterFlagCompletionFunc("not-tags", completeTags)
+	}
+	rootCmd.AddCommand(connCmd)
+}
+
+var connCmd = &cobra.Command{
+	Use:   "conn",
+	Short: "manage connections of a running api server",
+}
+
+var connAddCmd = &cobra.Command{
+	Use:   "add destination",
+	Short: "add a connection",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		rec := &pb.Record{Conn: &pb.Connection{RemoteAddr: args[0]}}
+		if err := applyConnFlags(cmd, rec); err != nil {
+			return err
+		}
+		if connCmdOptions.id != "" {
+			if err := checkConnIDUnused(cmd, connCmdOptions.id); err != nil {
+				return err
+			}
+			rec.Id = proto.String(connCmdOptions.id)
+		}
+		return upsertConn(cmd, rec)
+	},
+}
+
+var connEditCmd = &cobra.Command{
+	Use:   "edit connection-id",
+	Short: "edit a connection",
+	Args:  cobra.ExactArgs(1),
+
+	ValidArgsFunction: completeOneArg(completeConnectionIDs),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := newAPIClient()
+		if err != nil {
+			return err
+		}
+		defer func() { _ = client.Close() }()
+
+		recs, err := client.List(cmd.Context(), &pb.Selector{Ids: args})
+		if err != nil {
+			return err
+		}
+		if len(recs.GetRecords()) != 1 {
+			return fmt.Errorf("connection %s not found", args[0])
+		}
+		rec := recs.GetRecords()[0]
+		if rec.Conn == nil {
+			rec.Conn = new(pb.Connection)
+		}
+		if err := applyConnFlags(cmd, rec); err != nil {
+			return err
+		}
+		return upsertConn(cmd, rec)
+	},
+}
+
+var connListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "list connections",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		client, err := newAPIClient()
+		if err != nil {
+			return err
+		}
+		defer func() { _ = client.Close() }()
+
+		recs, err := client.List(cmd.Context(), selectorFromArgs(nil, connCmdOptions.tags, connCmdOptions.notTags))
+		if err != nil {
+			return err
+		}
+		printConns(os.Stdout, recs.GetRecords())
+		return nil
+	},
+}
+
+var connRemoveCmd = &cobra.Command{
+	Use:   "rm connection-id...",
+	Short: "remove connections",
+	Args:  cobra.MinimumNArgs(1),
+
+	ValidArgsFunction: completeConnectionIDs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := newAPIClient()
+		if err != nil {
+			return err
+		}
+		defer func() { _ = client.Close() }()
+
+		_, err = client.Delete(cmd.Context(), &pb.Selector{Ids: args})
+		return err
+	},
+}
+
+var connConnectCmd = &cobra.Command{
+	Use:   "connect [connection-id...]",
+	Short: "start listening for the given connections",
+
+	ValidArgsFunction: completeConnectionIDs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return updateListeners(cmd, args, true)
+	},
+}
+
+var connDisconnectCmd = &cobra.Command{
+	Use:   "disconnect [connection-id...]",
+	Short: "stop listening for the given connections",
+
+	ValidArgsFunction: completeConnectionIDs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return updateListeners(cmd, args, false)
+	},
+}
+
+var connImportCmd = &cobra.Command{
+	Use:   "import file",
+	Short: "import connections from a file, or stdin if file is -",
+	Long: "Imports connections exported by the api server, or listed in a CSV file with the columns " +
+		"name, remote addr, listen addr, pomerium url and tags. Only remote addr is required, " +
+		"tags are separated by commas or semicolons and a header row is skipped.",
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		req := &pb.ImportRequest{}
+		switch format := connCmdOptions.format; {
+		case format == "csv", format == "" && strings.EqualFold(filepath.Ext(args[0]), ".csv"):
+			req.Format = pb.ImportRequest_IMPORT_FORMAT_CSV
+		case format == "json", format == "":
+			req.Format = pb.ImportRequest_IMPORT_FORMAT_JSON
+		default:
+			return newConfigError(fmt.Errorf("unsupported format %q, expected json or csv", format))
+		}
+		if connCmdOptions.overrideTag != "" {
+			req.OverrideTag = proto.String(connCmdOptions.overrideTag)
+		}
+
+		var err error
+		if args[0] == "-" {
+			req.Data, err = io.ReadAll(os.Stdin)
+		} else {
+			req.Data, err = os.ReadFile(args[0])
+		}
+		if err != nil {
+			return err
+		}
+
+		client, err := newAPIClient()
+		if err != nil {
+			return err
+		}
+		defer func() { _ = client.Close() }()
+
+		_, err = client.Import(cmd.Context(), req)
+		return err
+	},
+}
+
+var connImportPortalCmd = &cobra.Command{
+	Use:   "import-portal [server-url]",
+	Short: "import the TCP and UDP routes of the routes portal as connections",
+	Long: "Creates a connection for each TCP and UDP route of the routes portal of the server, or the last " +
+		"used server if not given. Importing again updates the same connections, keeping their local settings.",
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		rawServerURL, err := routesServerURL(args)
+		if err != nil {
+			return err
+		}
+
+		req := &pb.ImportFromPortalRequest{Routes: &pb.FetchRoutesRequest{ServerUrl: rawServerURL}}
+		switch {
+		case connCmdOptions.portalDisableTLSVerify:
+			req.Routes.TlsOptions = &pb.FetchRoutesRequest_DisableTlsVerification{DisableTlsVerification: true}
+		case connCmdOptions.portalCACert != "":
+			caCert, err := os.ReadFile(connCmdOptions.portalCACert)
+			if err != nil {
+				return newConfigError(fmt.Errorf("ca cert: %w", err))
+			}
+			req.Routes.TlsOptions = &pb.FetchRoutesRequest_CaCert{CaCert: caCert}
+		}
+		if cmd.Flags().Changed("tag") {
+			req.Tag = proto.String(connCmdOptions.portalTag)
+		}
+
+		client, err := newAPIClient()
+		if err != nil {
+			return err
+		}
+		defer func() { _ = client.Close() }()
+
+		recs, err := client.ImportFromPortal(cmd.Context(), req)
+		if err != nil {
+			return err
+		}
+		printConns(os.Stdout, recs.GetRecords())
+		return nil
+	},
+}
+
+// applyConnFlags updates the record with the flags that were explicitly set
+func applyConnFlags(cmd *cobra.Command, rec *pb.Record) error {
+	flags := cmd.Flags()
+	if flags.Changed("name") {
+		rec.Conn.Name = proto.String(connCmdOptions.name)
+	}
+	if flags.Changed("listen") {
+		rec.Conn.ListenAddr = proto.String(connCmdOptions.listen)
+	}
+	if flags.Changed("pomerium-url") {
+		rec.Conn.PomeriumUrl = proto.String(connCmdOptions.pomeriumURL)
+	}
+	if flags.Changed("protocol") {
+		switch strings.ToLower(connCmdOptions.protocol) {
+		case "tcp":
+			rec.Conn.Protocol = pb.Protocol_TCP.Enum()
+		case "udp":
+			rec.Conn.Protocol = pb.Protocol_UDP.Enum()
+		default:
+			return fmt.Errorf("unsupported protocol %q", connCmdOptions.protocol)
+		}
+	}
+	if flags.Changed("tags") {
+		rec.Tags = connCmdOptions.tags
+	}
+	if flags.Changed("browser-cmd") {
+		rec.Conn.BrowserCmd = proto.String(connCmdOptions.browserCmd)
+	}
+	if flags.Changed("service-account") {
+		rec.Conn.ServiceAccount = proto.String(connCmdOptions.serviceAccount)
+	}
+	if flags.Changed("service-account-file") {
+		rec.Conn.ServiceAccountFile = proto.String(connCmdOptions.serviceAccountFile)
+	}
+	return nil
+}
+
+// checkConnIDUnused returns an error if a connection with the id exists, as
+// upserting it would replace that connection
+func checkConnIDUnused(cmd *cobra.Command, id string) error {
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = client.Close() }()
+
+	_, err = client.List(cmd.Context(), &pb.Selector{Ids: []string{id}})
+	if status.Code(err) == codes.NotFound {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	return newConfigError(fmt.Errorf("connection %s already exists", id))
+}
+
+func upsertConn(cmd *cobra.Command, rec *pb.Record) error {
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = client.Close() }()
+
+	rec, err = client.Upsert(cmd.Context(), rec)
+	if err != nil {
+		return err
+	}
+	fmt.Println(rec.GetId())
+	return nil
+}
+
+func updateListeners(cmd *cobra.Command, ids []string, connected bool) error {
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = client.Close() }()
+
+	ctx := cmd.Context()
+	if len(ids) == 0 && len(connCmdOptions.tags) == 0 && len(connCmdOptions.notTags) == 0 {
+		return fmt.Errorf("either connection ids, --tags or --not-tags are required")
+	}
+	recs, err := client.List(ctx, selectorFromArgs(ids, connCmdOptions.tags, connCmdOptions.notTags))
+	if err != nil {
+		return err
+	}
+	ids = ids[:0]
+	for _, r := range recs.GetRecords() {
+		ids = append(ids, r.GetId())
+	}
+	if len(ids) == 0 {
+		return fmt.Errorf("no connections match the given selection")
+	}
+
+	res, err := client.Update(ctx, &pb.ListenerUpdateRequest{
+		ConnectionIds: ids,
+		Connected:     connected,
+	})
+	if err != nil {
+		return err
+	}
+
+	ids = ids[:0]
+	for id := range res.GetListeners() {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	var failed bool
+	for _, id := range ids {
+		l := res.GetListeners()[id]
+		switch {
+		case l.GetLastError() != "":
+			failed = true
+			fmt.Fprintf(os.Stderr, "%s: %s\n", id, l.GetLastError())
+		case l.GetListening():
+			fmt.Printf("%s: listening on %s\n", id, l.GetListenAddr())
+		default:
+			fmt.Printf("%s: stopped\n", id)
+		}
+	}
+	if failed {
+		return fmt.Errorf("some connections could not be updated")
+	}
+	return nil
+}
+
+func printConns(w io.Writer, recs []*pb.Record) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tNAME\tPROTOCOL\tREMOTE\tLISTEN\tTAGS")
+	for _, r := range recs {
+		protocol := "tcp"
+		if r.GetConn().GetProtocol() == pb.Protocol_UDP {
+			protocol = "udp"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			r.GetId(), r.GetConn().GetName(), protocol, r.GetConn().GetRemoteAddr(),
+			r.GetConn().GetListenAddr(), strings.Join(r.GetTags(), ","))
+	}
+	_ = tw.Flush()
+}
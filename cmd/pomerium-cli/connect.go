@@ -0,0 +1,208 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pomerium/cli/internal/portal"
+	"github.com/pomerium/cli/tunnel"
+)
+
+// maxPickerRoutes is the number of routes the picker shows at once
+const maxPickerRoutes = 20
+
+var connectCmdOptions struct {
+	listen string
+	types  []string
+}
+
+func init() {
+	addBrowserFlags(connectCmd)
+	addServiceAccountFlags(connectCmd)
+	addExpectedUserFlags(connectCmd)
+	addTLSFlags(connectCmd)
+	addOutboundProxyFlags(connectCmd)
+	addProxyChainFlags(connectCmd)
+	addKeepAliveFlags(connectCmd)
+	addIPPreferenceFlags(connectCmd)
+	addReconnectFlags(connectCmd)
+	addMetricsFlags(connectCmd)
+	addProtocolFlags(connectCmd)
+	addProxyProtocolFlags(connectCmd)
+	addJWTCacheFlags(connectCmd)
+	addContextFlags(connectCmd)
+	flags := connectCmd.Flags()
+	flags.StringVar(&connectCmdOptions.listen, "listen", "127.0.0.1:0",
+		"local address to start a listener on")
+	flags.StringSliceVar(&connectCmdOptions.types, "type", []string{"tcp", "udp"},
+		"only offer routes of the given types (tcp, udp)")
+	rootCmd.AddCommand(connectCmd)
+}
+
+var connectCmd = &cobra.Command{
+	Use:   "connect [server-url]",
+	Short: "interactively picks a route of the server and creates a tunnel to it",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		rawServerURL, err := routesServerURL(args)
+		if err != nil {
+			return err
+		}
+
+		p, err := newPortal()
+		if err != nil {
+			return err
+		}
+		routes, err := p.ListRoutes(cmd.Context(), rawServerURL)
+		if err != nil {
+			return err
+		}
+		routes, err = portal.FilterRoutes(routes, portal.Filter{Types: connectCmdOptions.types})
+		if err != nil {
+			return err
+		}
+		if len(routes) == 0 {
+			return fmt.Errorf("no %s routes available", strings.Join(connectCmdOptions.types, " or "))
+		}
+
+		route, err := pickRoute(os.Stdin, os.Stderr, routes)
+		if err != nil {
+			return err
+		}
+		rememberContextRoute(route.From)
+
+		c := make(chan os.Signal, 1)
+		signal.Notify(c, os.Interrupt, syscall.SIGTERM, syscall.SIGHUP)
+		ctx, cancel := context.WithCancel(context.Background())
+		go func() {
+			<-c
+			cancel()
+		}()
+
+		err = startMetricsServer(ctx)
+		if err != nil {
+			return err
+		}
+
+		err = runRouteTunnel(ctx, route)
+		if err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "%s\n", err.Error())
+			exitWithError(err)
+		}
+		return nil
+	},
+}
+
+// pickRoute prompts until a route is selected by its number. Any other
+// input searches the routes.
+func pickRoute(r io.Reader, w io.Writer, routes []portal.Route) (portal.Route, error) {
+	scanner := bufio.NewScanner(r)
+	matches := routes
+	for {
+		if len(matches) == 0 {
+			fmt.Fprintln(w, "No matching routes.")
+			matches = routes
+		}
+		shown := matches[:min(len(matches), maxPickerRoutes)]
+
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		for i, route := range shown {
+			fmt.Fprintf(tw, "%d\t%s\t%s\t%s\n", i+1, route.Name, route.Type, route.From)
+		}
+		_ = tw.Flush()
+		if len(matches) > len(shown) {
+			fmt.Fprintf(w, "... and %d more\n", len(matches)-len(shown))
+		}
+		fmt.Fprint(w, "Select a route by number, or type to search: ")
+
+		if !scanner.Scan() {
+			if err := scanner.Err(); err != nil {
+				return portal.Route{}, fmt.Errorf("error reading selection: %w", err)
+			}
+			return portal.Route{}, fmt.Errorf("no route selected")
+		}
+		input := strings.TrimSpace(scanner.Text())
+		if n, err := strconv.Atoi(input); err == nil && n >= 1 && n <= len(shown) {
+			return shown[n-1], nil
+		}
+		if input == "" && len(matches) == 1 {
+			return matches[0], nil
+		}
+		matches = portal.SearchRoutes(routes, input)
+	}
+}
+
+// runRouteTunnel runs a TCP or UDP listener for the route, depending on its type.
+func runRouteTunnel(ctx context.Context, route portal.Route) error {
+	destinationAddr, proxyURL, err := tunnel.ParseURLs(route.From, "")
+	if err != nil {
+		return newConfigError(err)
+	}
+
+	var tlsConfig *tls.Config
+	if proxyURL.Scheme == "https" {
+		tlsConfig, err = getTLSConfig()
+		if err != nil {
+			return err
+		}
+	}
+
+	outboundProxies, err := getOutboundProxyURLs()
+	if err != nil {
+		return err
+	}
+	proxyChain, err := getProxyChain()
+	if err != nil {
+		return err
+	}
+	callbackPorts, err := getCallbackPortRange()
+	if err != nil {
+		return err
+	}
+	protocol, err := getProtocol()
+	if err != nil {
+		return err
+	}
+	proxyProtocol, err := getProxyProtocolVersion()
+	if err != nil {
+		return err
+	}
+
+	tun := tunnel.New(
+		tunnel.WithBrowserCommand(browserOptions.command),
+		tunnel.WithCallbackPath(browserOptions.callbackPath),
+		tunnel.WithCallbackPortRange(callbackPorts),
+		tunnel.WithRedirectAddress(browserOptions.callbackAddr),
+		tunnel.WithQRCode(browserOptions.qrCode),
+		tunnel.WithDestinationHost(destinationAddr),
+		tunnel.WithExpectedUser(expectedUserOptions.user),
+		tunnel.WithKeepAlive(keepAliveOptions.interval),
+		tunnel.WithIPPreference(getIPPreference()),
+		tunnel.WithOutboundProxy(outboundProxies...),
+		tunnel.WithProtocol(protocol),
+		tunnel.WithProxyChain(proxyChain...),
+		tunnel.WithProxyHost(proxyURL.Host),
+		tunnel.WithProxyProtocol(proxyProtocol),
+		tunnel.WithReconnect(getReconnectSettings()),
+		tunnel.WithServiceAccount(serviceAccountOptions.serviceAccount),
+		tunnel.WithServiceAccountFile(serviceAccountOptions.serviceAccountFile),
+		tunnel.WithTLSConfig(tlsConfig),
+		tunnel.WithJWTCache(getJWTCache()),
+	)
+
+	if strings.EqualFold(route.Type, "udp") {
+		return tun.RunUDPListener(ctx, connectCmdOptions.listen)
+	}
+	return tun.RunListener(ctx, connectCmdOptions.listen)
+}
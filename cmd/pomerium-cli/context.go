@@ -0,0 +1,276 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/pomerium/cli/internal/contexts"
+)
+
+var contextOptions struct {
+	name string
+
+	// set once the context is applied
+	current   string
+	serverURL string
+}
+
+// addContextFlags adds the --context flag. The selected context, or the
+// current context, provides the defaults of flags not given on the command line.
+func addContextFlags(cmd *cobra.Command) {
+	flags := cmd.Flags()
+	flags.StringVar(&contextOptions.name, "context", "",
+		"(optional) name of the context to use instead of the current context")
+	_ = cmd.RegisterFlagCompletionFunc("context", completeContextNames)
+	cmd.PreRunE = func(cmd *cobra.Command, _ []string) error {
+		return applyContext(cmd)
+	}
+}
+
+var contextAddCmdOptions struct {
+	serverURL          string
+	authMethod         string
+	serviceAccountFile string
+	use                bool
+}
+
+func init() {
+	flags := contextAddCmd.Flags()
+	flags.StringVar(&contextAddCmdOptions.serverURL, "server-url", "",
+		"the URL of the pomerium server")
+	flags.StringVar(&contextAddCmdOptions.authMethod, "auth-method", contexts.AuthMethodBrowser,
+		"default auth method: "+strings.Join(contexts.AuthMethods, " or "))
+	flags.StringVar(&contextAddCmdOptions.serviceAccountFile, "service-account-file", "",
+		"a file containing the service account JWT to use for the service-account auth method")
+	flags.BoolVar(&contextAddCmdOptions.use, "use", false,
+		"make the context the current context")
+	_ = contextAddCmd.MarkFlagRequired("server-url")
+	addTLSFlags(contextAddCmd)
+
+	contextCmd.AddCommand(contextAddCmd)
+	contextCmd.AddCommand(contextUseCmd)
+	contextCmd.AddCommand(contextListCmd)
+	rootCmd.AddCommand(contextCmd)
+}
+
+var contextCmd = &cobra.Command{
+	Use:   "context",
+	Short: "commands for cluster contexts",
+}
+
+var contextAddCmd = &cobra.Command{
+	Use:   "add name",
+	Short: "add or replace a context",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(_ *cobra.Command, args []string) error {
+		c := &contexts.Context{
+			ServerURL:          contextAddCmdOptions.serverURL,
+			AuthMethod:         contextAddCmdOptions.authMethod,
+			ServiceAccountFile: contextAddCmdOptions.serviceAccountFile,
+			TLS: contexts.TLS{
+				DisableTLSVerification:  tlsOptions.disableTLSVerification,
+				CAFile:                  tlsOptions.alternateCAPath,
+				CACert:                  tlsOptions.caCert,
+				CADir:                   tlsOptions.caDir,
+				PinSHA256:               tlsOptions.pinSHA256,
+				CheckRevocation:         tlsOptions.checkRevocation,
+				ServerName:              tlsOptions.serverName,
+				MinVersion:              tlsOptions.minVersion,
+				ClientCertFile:          tlsOptions.clientCertPath,
+				ClientKeyFile:           tlsOptions.clientKeyPath,
+				ClientCertChainFile:     tlsOptions.clientCertChainPath,
+				ClientKeyPassphraseFile: tlsOptions.clientKeyPassphraseFile,
+				ClientCertFromStore:     tlsOptions.clientCertFromStore,
+				ClientCertIssuer:        tlsOptions.clientCertIssuer,
+				ClientCertSubject:       tlsOptions.clientCertSubject,
+				RequireUserPresence:     tlsOptions.requireUserPresence,
+				ClientCertSelect:        tlsOptions.clientCertSelect,
+				ClientCertPKCS11:        tlsOptions.clientCertPKCS11,
+				ClientCertPIV:           tlsOptions.clientCertPIV,
+				ClientCertTPM:           tlsOptions.clientCertTPM,
+				ClientCertExpiryWarning: tlsOptions.clientCertExpiryWarning.String(),
+				ClientCertRenewHook:     tlsOptions.clientCertRenewHook,
+			},
+		}
+		if err := c.Validate(); err != nil {
+			return newConfigError(err)
+		}
+
+		path, cfg, err := loadContexts()
+		if err != nil {
+			return err
+		}
+		if old, ok := cfg.Contexts[args[0]]; ok && old.ServerURL == c.ServerURL {
+			c.LastRoutes = old.LastRoutes
+		}
+		cfg.Contexts[args[0]] = c
+		if contextAddCmdOptions.use || cfg.CurrentContext == "" {
+			cfg.CurrentContext = args[0]
+		}
+		return cfg.Save(path)
+	},
+}
+
+var contextUseCmd = &cobra.Command{
+	Use:   "use name",
+	Short: "set the current context",
+	Args:  cobra.ExactArgs(1),
+
+	ValidArgsFunction: completeOneArg(completeContextNames),
+	RunE: func(_ *cobra.Command, args []string) error {
+		path, cfg, err := loadContexts()
+		if err != nil {
+			return err
+		}
+		if _, err := cfg.Get(args[0]); err != nil {
+			return err
+		}
+		cfg.CurrentContext = args[0]
+		return cfg.Save(path)
+	},
+}
+
+var contextListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "list contexts",
+	Args:  cobra.NoArgs,
+	RunE: func(_ *cobra.Command, _ []string) error {
+		_, cfg, err := loadContexts()
+		if err != nil {
+			return err
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		_, _ = fmt.Fprintln(w, "CURRENT\tNAME\tSERVER\tAUTH\tLAST ROUTES")
+		for _, name := range cfg.Names() {
+			c := cfg.Contexts[name]
+			current := ""
+			if name == cfg.CurrentContext {
+				current = "*"
+			}
+			authMethod := c.AuthMethod
+			if authMethod == "" {
+				authMethod = contexts.AuthMethodBrowser
+			}
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+				current, name, c.ServerURL, authMethod, strings.Join(c.LastRoutes, ","))
+		}
+		return w.Flush()
+	},
+}
+
+func loadContexts() (string, *contexts.Config, error) {
+	path, err := contexts.Path()
+	if err != nil {
+		return "", nil, err
+	}
+	cfg, err := contexts.Load(path)
+	if err != nil {
+		return "", nil, newConfigError(err)
+	}
+	return path, cfg, nil
+}
+
+type flagDefault struct {
+	name   string
+	values []string
+}
+
+// applyContext sets the flags of the command that were not given on the
+// command line to the settings of the selected context.
+func applyContext(cmd *cobra.Command) error {
+	_, cfg, err := loadContexts()
+	if err != nil {
+		return err
+	}
+	c, err := cfg.Get(contextOptions.name)
+	if err != nil || c == nil {
+		return newConfigError(err)
+	}
+
+	contextOptions.current = contextOptions.name
+	if contextOptions.current == "" {
+		contextOptions.current = cfg.CurrentContext
+	}
+	contextOptions.serverURL = c.ServerURL
+
+	defaults := []flagDefault{
+		{"pomerium-url", []string{c.ServerURL}},
+		{"disable-tls-verification", []string{formatBoolFlag(c.TLS.DisableTLSVerification)}},
+		{"alternate-ca-path", []string{c.TLS.CAFile}},
+		{"ca-cert", []string{c.TLS.CACert}},
+		{"ca-dir", []string{c.TLS.CADir}},
+		{"pin-sha256", c.TLS.PinSHA256},
+		{"check-revocation", []string{formatBoolFlag(c.TLS.CheckRevocation)}},
+		{"tls-server-name", []string{c.TLS.ServerName}},
+		{"tls-min-version", []string{c.TLS.MinVersion}},
+		{"client-cert", []string{c.TLS.ClientCertFile}},
+		{"client-key", []string{c.TLS.ClientKeyFile}},
+		{"client-cert-chain", []string{c.TLS.ClientCertChainFile}},
+		{"client-key-passphrase-file", []string{c.TLS.ClientKeyPassphraseFile}},
+		{"client-cert-from-store", []string{formatBoolFlag(c.TLS.ClientCertFromStore)}},
+		{"client-cert-issuer", []string{c.TLS.ClientCertIssuer}},
+		{"client-cert-subject", []string{c.TLS.ClientCertSubject}},
+		{"require-user-presence", []string{formatBoolFlag(c.TLS.RequireUserPresence)}},
+		{"client-cert-select", []string{c.TLS.ClientCertSelect}},
+		{"client-cert-pkcs11", []string{c.TLS.ClientCertPKCS11}},
+		{"client-cert-piv", []string{c.TLS.ClientCertPIV}},
+		{"client-cert-tpm", []string{formatBoolFlag(c.TLS.ClientCertTPM)}},
+		{"client-cert-expiry-warning", []string{c.TLS.ClientCertExpiryWarning}},
+		{"client-cert-renew-hook", []string{c.TLS.ClientCertRenewHook}},
+	}
+	if c.AuthMethod == contexts.AuthMethodServiceAccount {
+		defaults = append(defaults, flagDefault{"service-account-file", []string{c.ServiceAccountFile}})
+	}
+
+	flags := cmd.Flags()
+	for _, d := range defaults {
+		f := flags.Lookup(d.name)
+		if f == nil || f.Changed {
+			continue
+		}
+		for _, v := range d.values {
+			if v == "" {
+				continue
+			}
+			if err := flags.Set(d.name, v); err != nil {
+				return newConfigError(fmt.Errorf("context %s: %s: %w", contextOptions.current, d.name, err))
+			}
+		}
+	}
+	return nil
+}
+
+func formatBoolFlag(b bool) string {
+	if !b {
+		return ""
+	}
+	return strconv.FormatBool(b)
+}
+
+// rememberContextRoute records the route as last used in the applied context
+func rememberContextRoute(route string) {
+	if contextOptions.current == "" {
+		return
+	}
+
+	path, cfg, err := loadContexts()
+	if err != nil {
+		log.Debug().Err(err).Msg("failed to load contexts")
+		return
+	}
+	c, ok := cfg.Contexts[contextOptions.current]
+	if !ok {
+		return
+	}
+	c.AddLastRoute(route)
+	if err := cfg.Save(path); err != nil {
+		log.Debug().Err(err).Msg("failed to save contexts")
+	}
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pomerium/cli/internal/cache"
+	"github.com/pomerium/cli/internal/doctor"
+)
+
+var doctorCmdOptions struct {
+	timeout time.Duration
+}
+
+func init() {
+	flags := doctorCmd.Flags()
+	flags.DurationVar(&doctorCmdOptions.timeout, "timeout", 10*time.Second,
+		"timeout of each check")
+	addTLSFlags(doctorCmd)
+	addOutboundProxyFlags(doctorCmd)
+	addContextFlags(doctorCmd)
+	rootCmd.AddCommand(doctorCmd)
+}
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor [server-url]",
+	Short: "diagnose the connection to a Pomerium server",
+	Long: "Checks that the Pomerium server resolves and is reachable, that its certificate is trusted, " +
+		"whether HTTP/2 and HTTP/3 over UDP are usable, that the login flow is reachable, that the " +
+		"local clock is in sync with the server, and the permissions of the cache directory.",
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		rawServerURL, err := routesServerURL(args)
+		if err != nil {
+			return newConfigError(err)
+		}
+		serverURL, err := url.Parse(rawServerURL)
+		if err != nil || serverURL.Host == "" {
+			return newConfigError(fmt.Errorf("invalid server-url: %s", rawServerURL))
+		}
+
+		tlsConfig, err := getTLSConfig()
+		if err != nil {
+			return err
+		}
+		outboundProxy, err := getOutboundProxyURL()
+		if err != nil {
+			return err
+		}
+		opts := []doctor.Option{
+			doctor.WithTLSConfig(tlsConfig),
+			doctor.WithOutboundProxy(outboundProxy),
+			doctor.WithTimeout(doctorCmdOptions.timeout),
+		}
+		if dir, err := cache.RootPath(); err == nil {
+			opts = append(opts, doctor.WithCacheDir(dir))
+		}
+
+		results := doctor.New(opts...).Run(cmd.Context(), serverURL)
+		tw := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 4, 2, ' ', 0)
+		failed := false
+		for _, r := range results {
+			fmt.Fprintf(tw, "%s\t%s\t%s\n", r.Status, r.Check, r.Detail)
+			failed = failed || r.Status == doctor.StatusFailed
+		}
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+		if failed {
+			return errors.New("some checks failed")
+		}
+		return nil
+	},
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pomerium/cli/internal/httputil"
+	"github.com/pomerium/cli/internal/portal"
+	"github.com/pomerium/cli/internal/tlsutil"
+	"github.com/pomerium/cli/jwt"
+	"github.com/pomerium/cli/tunnel"
+)
+
+// Exit statuses. These are stable, scripts may rely on them.
+const (
+	exitCodeError            = 1
+	exitCodeConfig           = 2
+	exitCodeAuthRequired     = 3
+	exitCodeUnauthorized     = 4
+	exitCodeProxyUnreachable = 5
+	exitCodeTLS              = 6
+)
+
+var errorClasses = map[int]string{
+	exitCodeError:            "error",
+	exitCodeConfig:           "config_error",
+	exitCodeAuthRequired:     "auth_required",
+	exitCodeUnauthorized:     "unauthorized",
+	exitCodeProxyUnreachable: "proxy_unreachable",
+	exitCodeTLS:              "tls_error",
+}
+
+// errorFormat is the value of the --error-format flag
+type errorFormat string
+
+func (f *errorFormat) String() string { return string(*f) }
+func (f *errorFormat) Type() string   { return "format" }
+func (f *errorFormat) Set(v string) error {
+	switch v {
+	case "text", "json":
+		*f = errorFormat(v)
+		return nil
+	}
+	return fmt.Errorf("unknown error format %q, expected text or json", v)
+}
+
+var errorOptions = struct {
+	format errorFormat
+}{format: "text"}
+
+func init() {
+	rootCmd.PersistentFlags().Var(&errorOptions.format, "error-format",
+		"format of the final error line on stderr: text or json")
+	rootCmd.SetFlagErrorFunc(func(_ *cobra.Command, err error) error {
+		return configError{err}
+	})
+}
+
+// a configError is caused by invalid flags, arguments or configuration
+type configError struct {
+	err error
+}
+
+func (e configError) Error() string { return e.err.Error() }
+func (e configError) Unwrap() error { return e.err }
+
+func newConfigError(err error) error {
+	if err == nil {
+		return nil
+	}
+	return configError{err}
+}
+
+// exitWithError exits with the exit status for the error. With the json error
+// format, a final line describing the error is written to stderr.
+func exitWithError(err error) {
+	code := exitCode(err)
+	if errorOptions.format == "json" {
+		bs, _ := json.Marshal(struct {
+			Error    string `json:"error"`
+			Class    string `json:"class"`
+			ExitCode int    `json:"exit_code"`
+		}{err.Error(), errorClasses[code], code})
+		_, _ = fmt.Fprintln(os.Stderr, string(bs))
+	}
+	os.Exit(code)
+}
+
+func exitCode(err error) int {
+	var (
+		cfgErr       configError
+		verifyErr    *tls.CertificateVerificationError
+		authorityErr x509.UnknownAuthorityError
+		hostnameErr  x509.HostnameError
+		invalidErr   x509.CertificateInvalidError
+		headerErr    tls.RecordHeaderError
+		alertErr     tls.AlertError
+		opErr        *net.OpError
+		dnsErr       *net.DNSError
+	)
+	switch {
+	case errors.As(err, &cfgErr):
+		return exitCodeConfig
+	case errors.Is(err, httputil.ErrUnauthenticated),
+		errors.Is(err, tunnel.ErrUnauthenticated):
+		return exitCodeAuthRequired
+	case errors.Is(err, tunnel.ErrUnauthorized),
+		errors.Is(err, portal.ErrForbidden),
+		errors.Is(err, jwt.ErrUnexpectedUser):
+		return exitCodeUnauthorized
+	case errors.Is(err, tlsutil.ErrPinMismatch),
+		errors.As(err, &verifyErr),
+		errors.As(err, &authorityErr),
+		errors.As(err, &hostnameErr),
+		errors.As(err, &invalidErr),
+		errors.As(err, &headerErr),
+		errors.As(err, &alertErr):
+		return exitCodeTLS
+	case errors.Is(err, tunnel.ErrUnavailable),
+		errors.Is(err, portal.ErrUnavailable),
+		errors.Is(err, httputil.ErrProxyAuthRequired),
+		errors.As(err, &opErr),
+		errors.As(err, &dnsErr):
+		return exitCodeProxyUnreachable
+	default:
+		return exitCodeError
+	}
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/pomerium/cli/internal/tun"
+	"github.com/pomerium/cli/tunnel"
+)
+
+var ipCmdOptions struct {
+	name string
+	mtu  int
+}
+
+func init() {
+	addBrowserFlags(ipCmd)
+	addServiceAccountFlags(ipCmd)
+	addExpectedUserFlags(ipCmd)
+	addTLSFlags(ipCmd)
+	addKeepAliveFlags(ipCmd)
+	addIPPreferenceFlags(ipCmd)
+	addJWTCacheFlags(ipCmd)
+	addContextFlags(ipCmd)
+	flags := ipCmd.Flags()
+	flags.StringVar(&ipCmdOptions.name, "interface", "pomerium%d",
+		"name of the TUN interface to create, %d is replaced by the first free number")
+	flags.IntVar(&ipCmdOptions.mtu, "mtu", 1280,
+		"MTU of the TUN interface, packets must fit into a single QUIC datagram")
+	rootCmd.AddCommand(ipCmd)
+}
+
+var ipCmd = &cobra.Command{
+	Use:   "ip pomerium-url",
+	Short: "creates a TUN interface that tunnels IP packets through Pomerium using CONNECT-IP",
+	Long: `Creates a TUN interface that tunnels IP packets through Pomerium using CONNECT-IP over HTTP/3.
+The addresses and routes advertised by the proxy are added to the interface.
+Only Linux is supported and creating the interface requires elevated privileges.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		proxyURL, err := url.Parse(args[0])
+		if err != nil || proxyURL.Host == "" {
+			return newConfigError(fmt.Errorf("invalid pomerium url"))
+		}
+		if proxyURL.Scheme != "https" {
+			return newConfigError(fmt.Errorf("invalid pomerium url: CONNECT-IP requires https"))
+		}
+		if proxyURL.Port() == "" {
+			proxyURL.Host = net.JoinHostPort(proxyURL.Hostname(), "443")
+		}
+		cacheLastURL(proxyURL.String())
+
+		tlsConfig, err := getTLSConfig()
+		if err != nil {
+			return err
+		}
+		callbackPorts, err := getCallbackPortRange()
+		if err != nil {
+			return err
+		}
+
+		dev, err := tun.Open(ipCmdOptions.name, ipCmdOptions.mtu)
+		if err != nil {
+			return err
+		}
+		defer dev.Close()
+		log.Info().Str("interface", dev.Name()).Msg("created TUN interface")
+
+		c := make(chan os.Signal, 1)
+		signal.Notify(c, os.Interrupt, syscall.SIGTERM, syscall.SIGHUP)
+		ctx, cancel := context.WithCancel(context.Background())
+		go func() {
+			<-c
+			cancel()
+		}()
+
+		ipTunnel := tunnel.New(
+			tunnel.WithBrowserCommand(browserOptions.command),
+			tunnel.WithCallbackPath(browserOptions.callbackPath),
+			tunnel.WithCallbackPortRange(callbackPorts),
+			tunnel.WithRedirectAddress(browserOptions.callbackAddr),
+			tunnel.WithQRCode(browserOptions.qrCode),
+			tunnel.WithExpectedUser(expectedUserOptions.user),
+			tunnel.WithKeepAlive(keepAliveOptions.interval),
+			tunnel.WithIPPreference(getIPPreference()),
+			tunnel.WithProtocol(tunnel.ProtocolHTTP3),
+			tunnel.WithProxyHost(proxyURL.Host),
+			tunnel.WithServiceAccount(serviceAccountOptions.serviceAccount),
+			tunnel.WithServiceAccountFile(serviceAccountOptions.serviceAccountFile),
+			tunnel.WithTLSConfig(tlsConfig),
+			tunnel.WithJWTCache(getJWTCache()),
+		)
+
+		err = ipTunnel.RunIP(ctx, dev, tunnel.LogEvents())
+		if err != nil && ctx.Err() == nil {
+			_, _ = fmt.Fprintf(os.Stderr, "%s\n", err.Error())
+			exitWithError(err)
+		}
+		return nil
+	},
+}
@@ -0,0 +1,188 @@
+package main
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pomerium/cli/jwt"
+)
+
+var jwtCmd = &cobra.Command{
+	Use:   "jwt",
+	Short: "commands for working with cached JWTs",
+}
+
+var jwtListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "list cached JWTs",
+	Args:  cobra.NoArgs,
+	RunE:  runJWTList,
+}
+
+var jwtDeleteCmd = &cobra.Command{
+	Use:   "delete proxy-host",
+	Short: "delete the cached JWT for a proxy host, forcing re-authentication",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runJWTDelete,
+}
+
+// the jwt commands are also available next to cache clear
+var cacheListJWTsCmd = &cobra.Command{
+	Use:   "list-jwts",
+	Short: jwtListCmd.Short,
+	Args:  cobra.NoArgs,
+	RunE:  runJWTList,
+}
+
+var cacheDeleteJWTCmd = &cobra.Command{
+	Use:   "delete-jwt proxy-host",
+	Short: jwtDeleteCmd.Short,
+	Args:  cobra.ExactArgs(1),
+	RunE:  runJWTDelete,
+}
+
+func runJWTList(_ *cobra.Command, _ []string) error {
+	lister, ok := jwt.GetCache().(jwt.Lister)
+	if !ok {
+		return errors.New("the JWT cache does not support listing")
+	}
+	entries, err := lister.ListJWTs()
+	if err != nil {
+		return err
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Key < entries[j].Key })
+	printJWTs(os.Stdout, entries)
+	return nil
+}
+
+func runJWTDelete(_ *cobra.Command, args []string) error {
+	deleted, err := deleteCachedJWTs(os.Stdout, jwt.GetCache(), args[0])
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return fmt.Errorf("no cached JWT found for %s", args[0])
+	}
+	return nil
+}
+
+// jwtStore is the value of the --jwt-store flag
+type jwtStore string
+
+func (s *jwtStore) String() string { return string(*s) }
+func (s *jwtStore) Type() string   { return "store" }
+func (s *jwtStore) Set(v string) error {
+	if err := jwt.SetStore(v); err != nil {
+		return err
+	}
+	*s = jwtStore(v)
+	return nil
+}
+
+var jwtStoreOptions = struct {
+	store jwtStore
+}{store: jwt.StoreFile}
+
+func init() {
+	jwtCmd.AddCommand(jwtListCmd)
+	jwtCmd.AddCommand(jwtDeleteCmd)
+	rootCmd.AddCommand(jwtCmd)
+	cacheCmd.AddCommand(cacheListJWTsCmd)
+	cacheCmd.AddCommand(cacheDeleteJWTCmd)
+
+	rootCmd.PersistentFlags().Var(&jwtStoreOptions.store, "jwt-store",
+		"where to cache JWTs: file, in the user's cache directory, or keyring, "+
+			"in the macOS Keychain, Windows Credential Manager or Secret Service")
+}
+
+func printJWTs(w io.Writer, entries []jwt.Entry) {
+	formatTime := func(t time.Time) string {
+		if t.IsZero() {
+			return "-"
+		}
+		return t.Local().Format(time.RFC3339)
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "KEY\tSUBJECT\tEMAIL\tISSUED\tEXPIRES\tSTATUS\tPATH")
+	for _, e := range entries {
+		key := e.Key
+		if key == "" {
+			key = "(unknown)"
+		}
+		state := "valid"
+		if e.Err != nil {
+			state = e.Err.Error()
+		}
+		path := e.Path
+		if path == "" {
+			path = "-"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			key, e.Claims.Subject, e.Claims.Email,
+			formatTime(e.Claims.IssuedAt), formatTime(e.Claims.Expiry), state, path)
+	}
+	_ = tw.Flush()
+}
+
+// deleteCachedJWTs deletes the cached JWTs for a proxy host, see jwtCacheKeys,
+// and returns the number of deleted JWTs.
+func deleteCachedJWTs(w io.Writer, cache jwt.Cache, proxyHost string) (int, error) {
+	keys, err := jwtCacheKeys(proxyHost)
+	if err != nil {
+		return 0, err
+	}
+
+	deleted := 0
+	for _, key := range keys {
+		if _, err := cache.LoadJWT(key); errors.Is(err, jwt.ErrNotFound) {
+			continue
+		}
+		if err := cache.DeleteJWT(key); err != nil {
+			return deleted, fmt.Errorf("delete %s: %w", key, err)
+		}
+		fmt.Fprintln(w, "deleted", key)
+		deleted++
+	}
+	return deleted, nil
+}
+
+// jwtCacheKeys returns the possible cache keys for a proxy host, which may
+// also be given as a URL. Without a port, both default ports are considered,
+// as are keys for connections with and without TLS.
+func jwtCacheKeys(proxyHost string) ([]string, error) {
+	if strings.Contains(proxyHost, "://") {
+		u, err := url.Parse(proxyHost)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy host: %w", err)
+		}
+		proxyHost = u.Host
+	}
+	if proxyHost == "" {
+		return nil, fmt.Errorf("proxy host is required")
+	}
+
+	hosts := []string{proxyHost}
+	if _, _, err := net.SplitHostPort(proxyHost); err != nil {
+		hosts = append(hosts, net.JoinHostPort(proxyHost, "443"), net.JoinHostPort(proxyHost, "80"))
+	}
+
+	var keys []string
+	for _, h := range hosts {
+		keys = append(keys,
+			jwt.CacheKeyForHost(h, &tls.Config{}), //nolint: gosec
+			jwt.CacheKeyForHost(h, nil))
+	}
+	return keys, nil
+}
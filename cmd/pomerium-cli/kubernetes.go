@@ -7,18 +7,33 @@ import (
 	"fmt"
 	"net/url"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/go-jose/go-jose/v3"
 	"github.com/spf13/cobra"
 
 	"github.com/pomerium/cli/authclient"
+	"github.com/pomerium/cli/jwt"
 )
 
+var kubernetesExecCredentialOptions struct {
+	clientCertPath string
+	clientKeyPath  string
+}
+
 func init() {
+	flags := kubernetesExecCredentialCmd.Flags()
+	flags.StringVar(&kubernetesExecCredentialOptions.clientCertPath, "credential-client-cert", "",
+		"(optional) PEM-encoded client certificate to return to kubectl for mTLS to the API server")
+	flags.StringVar(&kubernetesExecCredentialOptions.clientKeyPath, "credential-client-key", "",
+		"(optional) PEM-encoded private key of the certificate given by --credential-client-cert")
 	addBrowserFlags(kubernetesExecCredentialCmd)
+	addNoBrowserFlags(kubernetesExecCredentialCmd)
 	addServiceAccountFlags(kubernetesExecCredentialCmd)
+	addExpectedUserFlags(kubernetesExecCredentialCmd)
 	addTLSFlags(kubernetesExecCredentialCmd)
+	addOutboundProxyFlags(kubernetesExecCredentialCmd)
 	kubernetesCmd.AddCommand(kubernetesExecCredentialCmd)
 	kubernetesCmd.AddCommand(kubernetesFlushCredentialsCmd)
 	rootCmd.AddCommand(kubernetesCmd)
@@ -44,11 +59,18 @@ var kubernetesFlushCredentialsCmd = &cobra.Command{
 var kubernetesExecCredentialCmd = &cobra.Command{
 	Use:   "exec-credential",
 	Short: "run the kubernetes credential plugin for use with kubectl",
+
+	Annotations: map[string]string{noVersionCheckAnnotation: "true"},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if len(args) < 1 {
 			return fmt.Errorf("server url is required")
 		}
 
+		apiVersion, err := execCredentialAPIVersion()
+		if err != nil {
+			return err
+		}
+
 		cacheLastURL(args[0])
 
 		serverURL, err := url.Parse(args[0])
@@ -64,24 +86,57 @@ var kubernetesExecCredentialCmd = &cobra.Command{
 			}
 		}
 
+		outboundProxy, err := getOutboundProxyURL()
+		if err != nil {
+			return err
+		}
+		callbackPorts, err := getCallbackPortRange()
+		if err != nil {
+			return err
+		}
+
 		ac := authclient.New(
 			authclient.WithBrowserCommand(browserOptions.command),
+			authclient.WithCallbackPath(browserOptions.callbackPath),
+			authclient.WithCallbackPortRange(callbackPorts),
+			authclient.WithRedirectAddress(browserOptions.callbackAddr),
+			authclient.WithQRCode(browserOptions.qrCode),
+			authclient.WithNoBrowser(noBrowserOptions.enabled),
+			authclient.WithExpectedUser(expectedUserOptions.user),
+			authclient.WithOutboundProxy(outboundProxy),
 			authclient.WithServiceAccount(serviceAccountOptions.serviceAccount),
 			authclient.WithServiceAccountFile(serviceAccountOptions.serviceAccountFile),
 			authclient.WithTLSConfig(tlsConfig))
 
-		creds, err := loadCachedCredential(serverURL.String())
-		if err == nil && ac.CheckBearerToken(context.Background(), serverURL, creds.Status.Token) == nil {
-			printCreds(creds)
-			return nil
+		loadValidCachedCredential := func() (*ExecCredential, bool) {
+			creds, err := loadCachedCredential(serverURL.String())
+			return creds, err == nil &&
+				jwt.CheckUser(strings.TrimPrefix(creds.Status.Token, "Pomerium-"), expectedUserOptions.user) == nil &&
+				ac.CheckBearerToken(context.Background(), serverURL, creds.Status.Token) == nil
+		}
+
+		if creds, ok := loadValidCachedCredential(); ok {
+			return printCredsWithClientCert(creds, apiVersion)
+		}
+
+		// only one concurrent invocation logs in, the others wait for its credential
+		lock, err := lockCachedCredential(context.Background(), serverURL.String())
+		if err != nil {
+			return err
+		}
+		defer func() { _ = lock.Unlock() }()
+
+		if creds, ok := loadValidCachedCredential(); ok {
+			return printCredsWithClientCert(creds, apiVersion)
 		}
 
 		rawJWT, err := ac.GetJWT(context.Background(), serverURL, func(s string) {})
 		if err != nil {
-			fatalf("%s", err)
+			_, _ = fmt.Fprintln(os.Stderr, err)
+			exitWithError(err)
 		}
 
-		creds, err = parseToken(rawJWT)
+		creds, err := parseToken(rawJWT)
 		if err != nil {
 			return err
 		}
@@ -89,10 +144,76 @@ var kubernetesExecCredentialCmd = &cobra.Command{
 		if err = saveCachedCredential(serverURL.String(), creds); err != nil {
 			return err
 		}
-		printCreds(creds)
+		return printCredsWithClientCert(creds, apiVersion)
+	},
+}
+
+const (
+	execCredentialAPIVersionV1      = "client.authentication.k8s.io/v1"
+	execCredentialAPIVersionV1Beta1 = "client.authentication.k8s.io/v1beta1"
+)
+
+// execCredentialAPIVersion returns the API version of the ExecCredential
+// requested by kubectl in the KUBERNETES_EXEC_INFO environment variable, which
+// is the apiVersion of the exec section of the kubeconfig. Older clients that
+// do not set it get v1beta1.
+func execCredentialAPIVersion() (string, error) {
+	info := os.Getenv("KUBERNETES_EXEC_INFO")
+	if info == "" {
+		return execCredentialAPIVersionV1Beta1, nil
+	}
+	var req TypeMeta
+	if err := json.Unmarshal([]byte(info), &req); err != nil {
+		return "", fmt.Errorf("invalid KUBERNETES_EXEC_INFO: %w", err)
+	}
+	switch req.APIVersion {
+	case "":
+		return execCredentialAPIVersionV1Beta1, nil
+	case execCredentialAPIVersionV1, execCredentialAPIVersionV1Beta1:
+		return req.APIVersion, nil
+	}
+	return "", fmt.Errorf("unsupported ExecCredential apiVersion %q, use %s in the kubeconfig",
+		req.APIVersion, execCredentialAPIVersionV1)
+}
+
+// printCredsWithClientCert prints the credentials in the API version with the
+// configured client certificate. The certificate is read on every invocation
+// rather than cached with the token, so that renewed certificates are picked
+// up immediately.
+func printCredsWithClientCert(creds *ExecCredential, apiVersion string) error {
+	// the cached credentials may have been requested in another version
+	creds = &ExecCredential{
+		TypeMeta: TypeMeta{APIVersion: apiVersion, Kind: creds.Kind},
+		Status:   creds.Status,
+	}
 
+	certPath := kubernetesExecCredentialOptions.clientCertPath
+	keyPath := kubernetesExecCredentialOptions.clientKeyPath
+	switch {
+	case certPath == "" && keyPath == "":
+		printCreds(creds)
 		return nil
-	},
+	case certPath == "" || keyPath == "":
+		return fmt.Errorf("both --credential-client-cert and --credential-client-key are required")
+	}
+
+	certPEM, err := os.ReadFile(certPath)
+	if err != nil {
+		return fmt.Errorf("read client certificate: %w", err)
+	}
+	keyPEM, err := os.ReadFile(keyPath)
+	if err != nil {
+		return fmt.Errorf("read client key: %w", err)
+	}
+	if _, err := tls.X509KeyPair(certPEM, keyPEM); err != nil {
+		return fmt.Errorf("invalid client certificate: %w", err)
+	}
+
+	status := *creds.Status
+	status.ClientCertificateData = string(certPEM)
+	status.ClientKeyData = string(keyPEM)
+	printCreds(&ExecCredential{TypeMeta: creds.TypeMeta, Status: &status})
+	return nil
 }
 
 func parseToken(rawjwt string) (*ExecCredential, error) {
@@ -116,7 +237,7 @@ func parseToken(rawjwt string) (*ExecCredential, error) {
 
 	return &ExecCredential{
 		TypeMeta: TypeMeta{
-			APIVersion: "client.authentication.k8s.io/v1beta1",
+			APIVersion: execCredentialAPIVersionV1Beta1,
 			Kind:       "ExecCredential",
 		},
 		Status: &ExecCredentialStatus{
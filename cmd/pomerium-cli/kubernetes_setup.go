@@ -0,0 +1,144 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pomerium/cli/internal/kubeconfig"
+	"github.com/pomerium/cli/internal/profile"
+)
+
+var kubernetesSetupCmdOptions struct {
+	cluster              string
+	server               string
+	kubeconfig           string
+	certificateAuthority string
+	command              string
+	execArgs             []string
+	execEnv              []string
+	use                  bool
+}
+
+func init() {
+	flags := kubernetesSetupCmd.Flags()
+	flags.StringVar(&kubernetesSetupCmdOptions.cluster, "cluster", "",
+		"name of the cluster, user and context to write")
+	flags.StringVar(&kubernetesSetupCmdOptions.server, "server", "",
+		"URL of the Kubernetes API server route")
+	flags.StringVar(&kubernetesSetupCmdOptions.kubeconfig, "kubeconfig", "",
+		"(optional) kubeconfig file to write, defaults to the first of KUBECONFIG or ~/.kube/config")
+	flags.StringVar(&kubernetesSetupCmdOptions.certificateAuthority, "certificate-authority", "",
+		"(optional) CA of the API server route, if not trusted by the system")
+	flags.StringVar(&kubernetesSetupCmdOptions.command, "command", "",
+		"(optional) command kubectl runs to get credentials, defaults to this executable")
+	flags.StringArrayVar(&kubernetesSetupCmdOptions.execArgs, "exec-arg", nil,
+		"(optional) flag to pass to exec-credential, i.e. --exec-arg=--disable-tls-verification, may be repeated")
+	flags.StringArrayVar(&kubernetesSetupCmdOptions.execEnv, "exec-env", nil,
+		"(optional) NAME=VALUE environment variable to set for exec-credential, may be repeated")
+	flags.BoolVar(&kubernetesSetupCmdOptions.use, "use", false,
+		"make the context the current context")
+	_ = kubernetesSetupCmd.MarkFlagRequired("cluster")
+	_ = kubernetesSetupCmd.MarkFlagRequired("server")
+	kubernetesCmd.AddCommand(kubernetesSetupCmd)
+}
+
+var kubernetesSetupCmd = &cobra.Command{
+	Use:   "setup",
+	Short: "add a cluster using the credential plugin to the kubeconfig",
+	Long: "Writes a cluster, a user and a context of the given name to the kubeconfig, " +
+		"with the user getting its credentials from the exec-credential command. " +
+		"Existing entries of the same name are updated, other entries are kept.",
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		opts := kubernetesSetupCmdOptions
+		serverURL, err := url.Parse(opts.server)
+		if err != nil || serverURL.Host == "" || (serverURL.Scheme != "https" && serverURL.Scheme != "http") {
+			return newConfigError(fmt.Errorf("invalid --server: %q", opts.server))
+		}
+
+		env := make([]kubeconfig.EnvVar, 0, len(opts.execEnv))
+		for _, v := range opts.execEnv {
+			name, value, ok := strings.Cut(v, "=")
+			if !ok || name == "" {
+				return newConfigError(fmt.Errorf("invalid --exec-env %q, expected NAME=VALUE", v))
+			}
+			env = append(env, kubeconfig.EnvVar{Name: name, Value: value})
+		}
+
+		command := opts.command
+		if command == "" {
+			if command, err = pluginCommand(); err != nil {
+				return err
+			}
+		}
+		var args []string
+		// exec-credential caches credentials per profile
+		if name := profile.Name(); name != "" {
+			args = append(args, "--profile", name)
+		}
+		args = append(args, "k8s", "exec-credential")
+		args = append(args, opts.execArgs...)
+		args = append(args, serverURL.String())
+
+		caPath := opts.certificateAuthority
+		if caPath != "" {
+			if caPath, err = filepath.Abs(caPath); err != nil {
+				return err
+			}
+		}
+
+		path := opts.kubeconfig
+		if path == "" {
+			if path, err = kubeconfig.Path(); err != nil {
+				return err
+			}
+		}
+		cfg, err := kubeconfig.Load(path)
+		if err != nil {
+			return err
+		}
+		err = cfg.Set(kubeconfig.Entry{
+			Name:                 opts.cluster,
+			Server:               serverURL.String(),
+			CertificateAuthority: caPath,
+			Exec:                 kubeconfig.Exec{Command: command, Args: args, Env: env},
+		})
+		if err != nil {
+			return err
+		}
+		if opts.use {
+			cfg.SetCurrentContext(opts.cluster)
+		}
+		if err := cfg.Save(path); err != nil {
+			return fmt.Errorf("error saving kubeconfig: %w", err)
+		}
+
+		fmt.Fprintf(cmd.OutOrStdout(), "context %s written to %s\n", opts.cluster, path)
+		return nil
+	},
+}
+
+// pluginCommand returns the command kubectl runs to get credentials: the name
+// of this executable if it is the one found in the PATH, so that the
+// kubeconfig keeps working across upgrades, or its absolute path otherwise.
+func pluginCommand() (string, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return "", err
+	}
+	name := filepath.Base(exe)
+	if found, err := exec.LookPath(strings.TrimSuffix(name, filepath.Ext(name))); err == nil {
+		fi1, err1 := os.Stat(found)
+		fi2, err2 := os.Stat(exe)
+		if err1 == nil && err2 == nil && os.SameFile(fi1, fi2) {
+			return strings.TrimSuffix(name, filepath.Ext(name)), nil
+		}
+	}
+	return exe, nil
+}
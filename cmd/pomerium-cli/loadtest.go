@@ -0,0 +1,370 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"runtime"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pomerium/cli/tunnel"
+)
+
+// loadtestDrainTimeout is how long peers wait for echoes still in flight
+const loadtestDrainTimeout = 2 * time.Second
+
+var loadtestUDPCmdOptions struct {
+	pomeriumURL string
+	target      string
+	peers       int
+	rate        float64
+	size        int
+	duration    time.Duration
+	rampUp      time.Duration
+	settings    tunnel.UDPSettings
+}
+
+func init() {
+	addBrowserFlags(loadtestUDPCmd)
+	addServiceAccountFlags(loadtestUDPCmd)
+	addTLSFlags(loadtestUDPCmd)
+	addOutboundProxyFlags(loadtestUDPCmd)
+	addProxyChainFlags(loadtestUDPCmd)
+	addJWTCacheFlags(loadtestUDPCmd)
+	addContextFlags(loadtestUDPCmd)
+	flags := loadtestUDPCmd.Flags()
+	flags.StringVar(&loadtestUDPCmdOptions.pomeriumURL, "pomerium-url", "",
+		"the URL of the pomerium server to connect to")
+	flags.StringVar(&loadtestUDPCmdOptions.target, "target", "",
+		"address of an already running UDP listener to test instead of starting one for the destination")
+	flags.IntVar(&loadtestUDPCmdOptions.peers, "peers", 100,
+		"number of simulated local peers, each is a separate UDP session")
+	flags.Float64Var(&loadtestUDPCmdOptions.rate, "rate", 10,
+		"datagrams per second sent by each peer")
+	flags.IntVar(&loadtestUDPCmdOptions.size, "size", 200,
+		"size of the datagrams")
+	flags.DurationVar(&loadtestUDPCmdOptions.duration, "duration", 30*time.Second,
+		"how long to send datagrams for")
+	flags.DurationVar(&loadtestUDPCmdOptions.rampUp, "ramp-up", 0,
+		"spread the start of the peers over this duration instead of starting all at once")
+	flags.DurationVar(&loadtestUDPCmdOptions.settings.SessionTimeout, "session-timeout", 0,
+		"maximum duration of a single UDP session (default 10m)")
+	flags.IntVar(&loadtestUDPCmdOptions.settings.MaxSessions, "max-sessions", 0,
+		"maximum number of concurrent UDP sessions, unlimited if 0")
+	flags.IntVar(&loadtestUDPCmdOptions.settings.ReadBufferSize, "read-buffer-size", 0,
+		"listener socket read buffer size in bytes, system default if 0")
+	flags.IntVar(&loadtestUDPCmdOptions.settings.WriteBufferSize, "write-buffer-size", 0,
+		"listener socket write buffer size in bytes, system default if 0")
+	loadtestCmd.AddCommand(loadtestUDPCmd)
+	rootCmd.AddCommand(loadtestCmd)
+}
+
+var loadtestCmd = &cobra.Command{
+	Use:    "loadtest",
+	Short:  "load testing tools",
+	Hidden: true,
+}
+
+var loadtestUDPCmd = &cobra.Command{
+	Use:   "udp [destination]",
+	Short: "load tests the UDP session manager with many simulated local peers",
+	Long: "Starts a UDP listener for the destination, or uses the listener given with --target, " +
+		"and sends datagrams to it from many local peers. Reports the rate at which sessions are " +
+		"established, dropped datagrams and, for a listener started by this command, the memory " +
+		"usage of the process. " +
+		"The destination must echo all datagrams it receives.",
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		opts := &loadtestUDPCmdOptions
+		if (len(args) == 0) == (opts.target == "") {
+			return newConfigError(fmt.Errorf("either a destination or --target is required"))
+		}
+		if opts.peers <= 0 || opts.rate <= 0 {
+			return newConfigError(fmt.Errorf("--peers and --rate must be positive"))
+		}
+		if opts.size < 16 {
+			return newConfigError(fmt.Errorf("--size must be at least 16"))
+		}
+
+		c := make(chan os.Signal, 1)
+		signal.Notify(c, os.Interrupt, syscall.SIGTERM)
+		ctx, cancel := context.WithCancel(cmd.Context())
+		defer cancel()
+		go func() {
+			select {
+			case <-c:
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+
+		var target *net.UDPAddr
+		var err error
+		if opts.target != "" {
+			target, err = net.ResolveUDPAddr("udp", opts.target)
+			if err != nil {
+				return newConfigError(fmt.Errorf("invalid --target: %w", err))
+			}
+		} else {
+			target, err = startLoadtestListener(ctx, args[0])
+			if err != nil {
+				return err
+			}
+		}
+
+		stats := &loadtestStats{inProcess: opts.target == "", start: time.Now()}
+		stats.sample()
+		go stats.monitor(ctx)
+
+		sendCtx, stopSending := context.WithTimeout(ctx, opts.rampUp+opts.duration)
+		defer stopSending()
+		var wg sync.WaitGroup
+		for i := 0; i < opts.peers; i++ {
+			delay := time.Duration(0)
+			if opts.peers > 1 {
+				delay = opts.rampUp * time.Duration(i) / time.Duration(opts.peers-1)
+			}
+			wg.Add(1)
+			go func(id uint64) {
+				defer wg.Done()
+				select {
+				case <-sendCtx.Done():
+					return
+				case <-time.After(delay):
+				}
+				stats.runPeer(ctx, sendCtx, target, id)
+			}(uint64(i))
+		}
+		wg.Wait()
+		stats.sample()
+
+		return stats.print()
+	},
+}
+
+// startLoadtestListener starts the UDP session manager for the destination
+// on a local port and returns its address
+func startLoadtestListener(ctx context.Context, destination string) (*net.UDPAddr, error) {
+	destinationAddr, proxyURL, err := tunnel.ParseURLs(destination, loadtestUDPCmdOptions.pomeriumURL)
+	if err != nil {
+		return nil, newConfigError(err)
+	}
+
+	var tlsConfig *tls.Config
+	if proxyURL.Scheme == "https" {
+		tlsConfig, err = getTLSConfig()
+		if err != nil {
+			return nil, err
+		}
+	}
+	outboundProxies, err := getOutboundProxyURLs()
+	if err != nil {
+		return nil, err
+	}
+	proxyChain, err := getProxyChain()
+	if err != nil {
+		return nil, err
+	}
+	callbackPorts, err := getCallbackPortRange()
+	if err != nil {
+		return nil, err
+	}
+
+	tun := tunnel.New(
+		tunnel.WithBrowserCommand(browserOptions.command),
+		tunnel.WithCallbackPath(browserOptions.callbackPath),
+		tunnel.WithCallbackPortRange(callbackPorts),
+		tunnel.WithRedirectAddress(browserOptions.callbackAddr),
+		tunnel.WithQRCode(browserOptions.qrCode),
+		tunnel.WithDestinationHost(destinationAddr),
+		tunnel.WithOutboundProxy(outboundProxies...),
+		tunnel.WithProxyChain(proxyChain...),
+		tunnel.WithProxyHost(proxyURL.Host),
+		tunnel.WithServiceAccount(serviceAccountOptions.serviceAccount),
+		tunnel.WithServiceAccountFile(serviceAccountOptions.serviceAccountFile),
+		tunnel.WithTLSConfig(tlsConfig),
+		tunnel.WithJWTCache(getJWTCache()),
+		tunnel.WithUDPSettings(loadtestUDPCmdOptions.settings),
+	)
+
+	listener, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	if err != nil {
+		return nil, err
+	}
+	go func() {
+		defer func() { _ = listener.Close() }()
+		err := tun.RunUDPSessionManager(ctx, listener, tunnel.DiscardEvents())
+		if err != nil && ctx.Err() == nil {
+			_, _ = fmt.Fprintf(os.Stderr, "udp session manager stopped: %s\n", err)
+		}
+	}()
+	return listener.LocalAddr().(*net.UDPAddr), nil
+}
+
+type loadtestStats struct {
+	inProcess bool
+	start     time.Time
+
+	started, established atomic.Int64
+	sent, received       atomic.Int64
+
+	mu          sync.Mutex
+	setupTimes  []time.Duration
+	lastSetup   time.Duration // since start
+	peakHeap    uint64
+	peakRoutine int
+	heap        uint64
+	goroutines  int
+}
+
+// runPeer sends datagrams to the target from a new local socket until
+// sendCtx is done, counting their echoes
+func (stats *loadtestStats) runPeer(ctx, sendCtx context.Context, target *net.UDPAddr, id uint64) {
+	conn, err := net.DialUDP("udp", nil, target)
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "peer %d: %s\n", id, err)
+		return
+	}
+	defer func() { _ = conn.Close() }()
+	stats.started.Add(1)
+
+	var first atomic.Int64
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		buf := make([]byte, loadtestUDPCmdOptions.size)
+		var established bool
+		for {
+			n, err := conn.Read(buf)
+			if err != nil {
+				return
+			}
+			if n < 16 || binary.BigEndian.Uint64(buf) != id {
+				continue
+			}
+			stats.received.Add(1)
+			if !established {
+				established = true
+				stats.established.Add(1)
+				stats.mu.Lock()
+				stats.setupTimes = append(stats.setupTimes, time.Since(time.Unix(0, first.Load())))
+				stats.lastSetup = time.Since(stats.start)
+				stats.mu.Unlock()
+			}
+		}
+	}()
+
+	payload := make([]byte, loadtestUDPCmdOptions.size)
+	binary.BigEndian.PutUint64(payload, id)
+	ticker := time.NewTicker(time.Duration(float64(time.Second) / loadtestUDPCmdOptions.rate))
+	defer ticker.Stop()
+	for seq := uint64(0); ; seq++ {
+		binary.BigEndian.PutUint64(payload[8:], seq)
+		if seq == 0 {
+			first.Store(time.Now().UnixNano())
+		}
+		if _, err := conn.Write(payload); err == nil {
+			stats.sent.Add(1)
+		}
+
+		select {
+		case <-sendCtx.Done():
+			// wait for echoes still in flight
+			select {
+			case <-ctx.Done():
+			case <-time.After(loadtestDrainTimeout):
+			}
+			_ = conn.Close()
+			<-done
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// sample records the memory usage of this process
+func (stats *loadtestStats) sample() {
+	var m runtime.MemStats
+	runtime.ReadMemStats(&m)
+
+	stats.mu.Lock()
+	defer stats.mu.Unlock()
+	stats.heap = m.HeapInuse
+	stats.goroutines = runtime.NumGoroutine()
+	stats.peakHeap = max(stats.peakHeap, stats.heap)
+	stats.peakRoutine = max(stats.peakRoutine, stats.goroutines)
+}
+
+// monitor samples memory usage and reports progress every second
+func (stats *loadtestStats) monitor(ctx context.Context) {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		stats.sample()
+		stats.mu.Lock()
+		heap, goroutines := stats.heap, stats.goroutines
+		stats.mu.Unlock()
+		_, _ = fmt.Fprintf(os.Stderr, "%s: sessions=%d/%d sent=%d received=%d heap=%s goroutines=%d\n",
+			time.Since(stats.start).Round(time.Second), stats.established.Load(), stats.started.Load(),
+			stats.sent.Load(), stats.received.Load(), formatMiB(heap), goroutines)
+	}
+}
+
+func (stats *loadtestStats) print() error {
+	stats.mu.Lock()
+	defer stats.mu.Unlock()
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	row := func(name, format string, args ...any) {
+		_, _ = fmt.Fprintf(w, "%s\t"+format+"\n", append([]any{name}, args...)...)
+	}
+
+	established := stats.established.Load()
+	row("peers", "%d", stats.started.Load())
+	row("sessions established", "%d", established)
+	if stats.lastSetup > 0 {
+		row("session rate", "%.1f/s", float64(established)/stats.lastSetup.Seconds())
+	}
+	slices.Sort(stats.setupTimes)
+	if len(stats.setupTimes) > 0 {
+		row("session setup p50", "%s", percentile(stats.setupTimes, 0.5))
+		row("session setup p99", "%s", percentile(stats.setupTimes, 0.99))
+	}
+
+	sent, received := stats.sent.Load(), stats.received.Load()
+	row("datagrams sent", "%d", sent)
+	row("datagrams received", "%d", received)
+	if sent > 0 {
+		row("dropped", "%d (%.2f%%)", sent-received, 100*float64(sent-received)/float64(sent))
+	}
+
+	if stats.inProcess {
+		row("peak heap in use", "%s", formatMiB(stats.peakHeap))
+		row("peak goroutines", "%d", stats.peakRoutine)
+		row("heap in use at end", "%s", formatMiB(stats.heap))
+	} else {
+		row("memory", "%s", "not measured for a --target listener")
+	}
+	return w.Flush()
+}
+
+func formatMiB(n uint64) string {
+	return fmt.Sprintf("%.1f MiB", float64(n)/(1<<20))
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pomerium/cli/authclient"
+	"github.com/pomerium/cli/jwt"
+)
+
+func init() {
+	addBrowserFlags(loginCmd)
+	addServiceAccountFlags(loginCmd)
+	addExpectedUserFlags(loginCmd)
+	addTLSFlags(loginCmd)
+	addOutboundProxyFlags(loginCmd)
+	addJWTCacheFlags(loginCmd)
+	addContextFlags(loginCmd)
+	rootCmd.AddCommand(loginCmd)
+
+	addJWTCacheFlags(logoutCmd)
+	addContextFlags(logoutCmd)
+	rootCmd.AddCommand(logoutCmd)
+}
+
+var loginCmd = &cobra.Command{
+	Use:   "login [server-url]",
+	Short: "signs in to a Pomerium server and caches the JWT for later tunnels",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		rawServerURL, err := routesServerURL(args)
+		if err != nil {
+			return err
+		}
+		serverURL, err := url.Parse(rawServerURL)
+		if err != nil || serverURL.Host == "" {
+			return newConfigError(fmt.Errorf("invalid server url: %s", rawServerURL))
+		}
+
+		var tlsConfig *tls.Config
+		if serverURL.Scheme == "https" {
+			tlsConfig, err = getTLSConfig()
+			if err != nil {
+				return err
+			}
+		}
+
+		outboundProxy, err := getOutboundProxyURL()
+		if err != nil {
+			return err
+		}
+		callbackPorts, err := getCallbackPortRange()
+		if err != nil {
+			return err
+		}
+
+		ac := authclient.New(
+			authclient.WithBrowserCommand(browserOptions.command),
+			authclient.WithCallbackPath(browserOptions.callbackPath),
+			authclient.WithCallbackPortRange(callbackPorts),
+			authclient.WithRedirectAddress(browserOptions.callbackAddr),
+			authclient.WithQRCode(browserOptions.qrCode),
+			authclient.WithExpectedUser(expectedUserOptions.user),
+			authclient.WithOutboundProxy(outboundProxy),
+			authclient.WithServiceAccount(serviceAccountOptions.serviceAccount),
+			authclient.WithServiceAccountFile(serviceAccountOptions.serviceAccountFile),
+			authclient.WithTLSConfig(tlsConfig))
+
+		rawJWT, err := ac.GetJWT(context.Background(), serverURL, func(s string) {})
+		if err != nil {
+			_, _ = fmt.Fprintln(os.Stderr, err)
+			exitWithError(err)
+		}
+
+		cache := getJWTCache()
+		for _, key := range loginCacheKeys(serverURL, tlsConfig) {
+			if err := cache.StoreJWT(key, rawJWT); err != nil {
+				return fmt.Errorf("error storing JWT: %w", err)
+			}
+		}
+
+		printLogin(os.Stdout, serverURL.Host, rawJWT)
+		return nil
+	},
+}
+
+var logoutCmd = &cobra.Command{
+	Use:   "logout [server-url]",
+	Short: "removes the cached JWT of a Pomerium server",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		rawServerURL, err := routesServerURL(args)
+		if err != nil {
+			return err
+		}
+
+		deleted, err := deleteCachedJWTs(io.Discard, getJWTCache(), rawServerURL)
+		if err != nil {
+			return err
+		}
+		if deleted == 0 {
+			fmt.Println("not logged in to", rawServerURL)
+		} else {
+			fmt.Println("logged out of", rawServerURL)
+		}
+		return nil
+	},
+}
+
+// loginCacheKeys returns the cache keys to store a JWT for the server under.
+// Tunnels always include the port in the proxy host, while the routes portal
+// uses the host as given, so both are stored.
+func loginCacheKeys(serverURL *url.URL, tlsConfig *tls.Config) []string {
+	keys := []string{jwt.CacheKeyForHost(serverURL.Host, tlsConfig)}
+	if serverURL.Port() == "" {
+		port := "80"
+		if serverURL.Scheme == "https" {
+			port = "443"
+		}
+		keys = append(keys, jwt.CacheKeyForHost(net.JoinHostPort(serverURL.Hostname(), port), tlsConfig))
+	}
+	return keys
+}
+
+func printLogin(w io.Writer, host, rawJWT string) {
+	claims, err := jwt.ParseClaims(rawJWT)
+	if err != nil {
+		fmt.Fprintln(w, "logged in to", host)
+		return
+	}
+
+	user := claims.Email
+	if user == "" {
+		user = claims.Subject
+	}
+	fmt.Fprintf(w, "logged in to %s as %s", host, user)
+	if !claims.Expiry.IsZero() {
+		fmt.Fprintf(w, " until %s", claims.Expiry.Local().Format(time.RFC3339))
+	}
+	fmt.Fprintln(w)
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/pomerium/cli/internal/metrics"
+)
+
+var metricsOptions struct {
+	addr string
+}
+
+func addMetricsFlags(cmd *cobra.Command) {
+	flags := cmd.Flags()
+	flags.StringVar(&metricsOptions.addr, "metrics-addr", "",
+		"(optional) address to serve Prometheus metrics on at /metrics, e.g. 127.0.0.1:9090")
+}
+
+// startMetricsServer serves the metrics in the background until ctx is done,
+// if a metrics address is set.
+func startMetricsServer(ctx context.Context) error {
+	if metricsOptions.addr == "" {
+		return nil
+	}
+
+	li, err := net.Listen("tcp", metricsOptions.addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on metrics address: %w", err)
+	}
+	log.Info().Str("address", li.Addr().String()).Msg("serving metrics")
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", metrics.Handler())
+	srv := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	context.AfterFunc(ctx, func() { _ = srv.Close() })
+	go func() {
+		err := srv.Serve(li)
+		if !errors.Is(err, http.ErrServerClosed) {
+			log.Error().Err(err).Msg("error serving metrics")
+		}
+	}()
+	return nil
+}
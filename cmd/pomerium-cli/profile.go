@@ -0,0 +1,28 @@
+package main
+
+import (
+	"github.com/pomerium/cli/internal/profile"
+)
+
+// profileName is the value of the --profile flag
+type profileName string
+
+func (p *profileName) String() string { return string(*p) }
+func (p *profileName) Type() string   { return "name" }
+func (p *profileName) Set(v string) error {
+	if err := profile.Set(v); err != nil {
+		return err
+	}
+	*p = profileName(v)
+	return nil
+}
+
+var profileOptions struct {
+	name profileName
+}
+
+func init() {
+	rootCmd.PersistentFlags().Var(&profileOptions.name, "profile",
+		"(optional) name of the profile to use, each profile has its own contexts, "+
+			"cached JWTs and credentials, so that separate logins can be kept side by side")
+}
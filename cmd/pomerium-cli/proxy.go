@@ -28,7 +28,12 @@ var proxyCmdOptions struct {
 
 func init() {
 	addServiceAccountFlags(proxyCmd)
+	addExpectedUserFlags(proxyCmd)
 	addTLSFlags(proxyCmd)
+	addOutboundProxyFlags(proxyCmd)
+	addProxyChainFlags(proxyCmd)
+	addJWTCacheFlags(proxyCmd)
+	addContextFlags(proxyCmd)
 	flags := proxyCmd.Flags()
 	flags.StringVar(&proxyCmdOptions.listen, "listen", "127.0.0.1:3128",
 		"local address to start a listener on")
@@ -44,6 +49,8 @@ var proxyCmd = &cobra.Command{
 	Short:  "creates a https proxy that proxies certain domains via a TCP tunnel through Pomerium",
 	Args:   cobra.ExactArgs(0),
 	Hidden: true,
+
+	Annotations: map[string]string{noVersionCheckAnnotation: "true"},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		proxy := goproxy.NewProxyHttpServer()
 
@@ -138,12 +145,25 @@ func newTCPTunnel(dstHost string, specificPomeriumURL string) (*tunnel.Tunnel, e
 		}
 	}
 
+	outboundProxies, err := getOutboundProxyURLs()
+	if err != nil {
+		return nil, err
+	}
+	proxyChain, err := getProxyChain()
+	if err != nil {
+		return nil, err
+	}
+
 	return tunnel.New(
 		tunnel.WithDestinationHost(net.JoinHostPort(dstHostname, dstPort)),
+		tunnel.WithExpectedUser(expectedUserOptions.user),
+		tunnel.WithOutboundProxy(outboundProxies...),
+		tunnel.WithProxyChain(proxyChain...),
 		tunnel.WithProxyHost(pomeriumURL.Host),
 		tunnel.WithServiceAccount(serviceAccountOptions.serviceAccount),
 		tunnel.WithServiceAccountFile(serviceAccountOptions.serviceAccountFile),
 		tunnel.WithTLSConfig(tlsConfig),
+		tunnel.WithJWTCache(getJWTCache()),
 	), nil
 }
 
@@ -1,17 +1,48 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
 
 	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
 
+	"github.com/pomerium/cli/internal/cache"
 	"github.com/pomerium/cli/internal/portal"
 )
 
+var routesListCmdOptions struct {
+	cacheTTL time.Duration
+	refresh  bool
+	types    []string
+	name     string
+	output   string
+}
+
 func init() {
+	flags := routesListCmd.Flags()
+	flags.DurationVar(&routesListCmdOptions.cacheTTL, "cache-ttl", time.Minute,
+		"how long to cache the routes of a server, 0 to disable the cache")
+	flags.BoolVar(&routesListCmdOptions.refresh, "refresh", false,
+		"ignore cached routes and fetch them from the server")
+	flags.StringSliceVar(&routesListCmdOptions.types, "type", nil,
+		"only list routes of the given types (tcp, udp, http)")
+	flags.StringVar(&routesListCmdOptions.name, "name", "",
+		"only list routes with names matching the glob pattern, i.e. db-*")
+	flags.StringVarP(&routesListCmdOptions.output, "output", "o", "text",
+		"output format: text, table, wide, json or yaml")
 	addBrowserFlags(routesListCmd)
 	addServiceAccountFlags(routesListCmd)
 	addTLSFlags(routesListCmd)
+	addOutboundProxyFlags(routesListCmd)
+	addJWTCacheFlags(routesListCmd)
+	addContextFlags(routesListCmd)
 	routesCmd.AddCommand(routesListCmd)
 	rootCmd.AddCommand(routesCmd)
 }
@@ -26,46 +57,149 @@ var routesListCmd = &cobra.Command{
 	Short: "list routes",
 	Args:  cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		rawServerURL := ""
-		if len(args) > 0 {
-			rawServerURL = args[0]
-		} else {
-			rawServerURL = loadLastURL()
+		rawServerURL, err := routesServerURL(args)
+		if err != nil {
+			return err
 		}
-		if rawServerURL == "" {
-			return fmt.Errorf("server-url is required")
+
+		if !slices.Contains(routesOutputFormats, routesListCmdOptions.output) {
+			return fmt.Errorf("unknown output format %q, expected one of %s",
+				routesListCmdOptions.output, strings.Join(routesOutputFormats, ", "))
 		}
 
-		cacheLastURL(rawServerURL)
+		var routesCacheDir string
+		if routesListCmdOptions.cacheTTL > 0 {
+			routesCacheDir, err = cache.RoutesPath()
+			if err != nil {
+				return err
+			}
+		}
 
-		tlsConfig, err := getTLSConfig()
+		p, err := newPortal(
+			portal.WithRefresh(routesListCmdOptions.refresh),
+			portal.WithRoutesCache(routesCacheDir, routesListCmdOptions.cacheTTL),
+		)
 		if err != nil {
 			return err
 		}
-
-		p := portal.New(
-			portal.WithBrowserCommand(browserOptions.command),
-			portal.WithServiceAccount(serviceAccountOptions.serviceAccount),
-			portal.WithServiceAccountFile(serviceAccountOptions.serviceAccountFile),
-			portal.WithTLSConfig(tlsConfig),
-		)
 		routes, err := p.ListRoutes(cmd.Context(), rawServerURL)
 		if err != nil {
 			return err
 		}
 
+		routes, err = portal.FilterRoutes(routes, portal.Filter{
+			Types: routesListCmdOptions.types,
+			Name:  routesListCmdOptions.name,
+		})
+		if err != nil {
+			return err
+		}
+		return printRoutes(os.Stdout, routes, routesListCmdOptions.output)
+	},
+}
+
+// routesServerURL returns the server url argument, or the last used server url
+func routesServerURL(args []string) (string, error) {
+	rawServerURL := ""
+	switch {
+	case len(args) > 0:
+		rawServerURL = args[0]
+	case contextOptions.serverURL != "":
+		rawServerURL = contextOptions.serverURL
+	default:
+		rawServerURL = loadLastURL()
+	}
+	if rawServerURL == "" {
+		return "", fmt.Errorf("server-url is required")
+	}
+
+	cacheLastURL(rawServerURL)
+	return rawServerURL, nil
+}
+
+// newPortal creates a portal client configured by the login and TLS flags
+func newPortal(options ...portal.Option) (*portal.Portal, error) {
+	tlsConfig, err := getTLSConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	outboundProxy, err := getOutboundProxyURL()
+	if err != nil {
+		return nil, err
+	}
+	callbackPorts, err := getCallbackPortRange()
+	if err != nil {
+		return nil, err
+	}
+
+	return portal.New(append([]portal.Option{
+		portal.WithBrowserCommand(browserOptions.command),
+		portal.WithCallbackPath(browserOptions.callbackPath),
+		portal.WithCallbackPortRange(callbackPorts),
+		portal.WithRedirectAddress(browserOptions.callbackAddr),
+		portal.WithQRCode(browserOptions.qrCode),
+		portal.WithOutboundProxy(outboundProxy),
+		portal.WithServiceAccount(serviceAccountOptions.serviceAccount),
+		portal.WithServiceAccountFile(serviceAccountOptions.serviceAccountFile),
+		portal.WithTLSConfig(tlsConfig),
+		portal.WithJWTCache(getJWTCache()),
+	}, options...)...), nil
+}
+
+var routesOutputFormats = []string{"text", "table", "wide", "json", "yaml"}
+
+func printRoutes(w io.Writer, routes []portal.Route, output string) error {
+	switch output {
+	case "text":
 		for _, route := range routes {
-			fmt.Println("Route", route.Name)
-			fmt.Println("id:", route.ID)
-			fmt.Println("type:", route.Type)
-			fmt.Println("from:", route.From)
-			fmt.Println("description:", route.Description)
+			fmt.Fprintln(w, "Route", route.Name)
+			fmt.Fprintln(w, "id:", route.ID)
+			fmt.Fprintln(w, "type:", route.Type)
+			fmt.Fprintln(w, "from:", route.From)
+			fmt.Fprintln(w, "description:", route.Description)
 			if route.ConnectCommand != "" {
-				fmt.Println("connect_command:", route.ConnectCommand)
+				fmt.Fprintln(w, "connect_command:", route.ConnectCommand)
 			}
-			fmt.Println()
+			fmt.Fprintln(w)
+		}
+	case "table", "wide":
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		if output == "wide" {
+			fmt.Fprintln(tw, "NAME\tTYPE\tFROM\tID\tCONNECT COMMAND\tDESCRIPTION")
+		} else {
+			fmt.Fprintln(tw, "NAME\tTYPE\tFROM")
 		}
+		for _, route := range routes {
+			if output == "wide" {
+				fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", route.Name, route.Type, route.From,
+					route.ID, route.ConnectCommand, route.Description)
+			} else {
+				fmt.Fprintf(tw, "%s\t%s\t%s\n", route.Name, route.Type, route.From)
+			}
+		}
+		return tw.Flush()
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(nonNilRoutes(routes))
+	case "yaml":
+		bs, err := yaml.Marshal(nonNilRoutes(routes))
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(bs)
+		return err
+	default:
+		return fmt.Errorf("unknown output format %q", output)
+	}
+	return nil
+}
 
-		return nil
-	},
+// nonNilRoutes makes sure that no routes are encoded as an empty list rather than null
+func nonNilRoutes(routes []portal.Route) []portal.Route {
+	if routes == nil {
+		return []portal.Route{}
+	}
+	return routes
 }
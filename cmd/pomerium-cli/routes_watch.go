@@ -0,0 +1,131 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/pomerium/cli/internal/portal"
+)
+
+var routesWatchCmdOptions struct {
+	interval time.Duration
+	output   string
+}
+
+func init() {
+	flags := routesWatchCmd.Flags()
+	flags.DurationVar(&routesWatchCmdOptions.interval, "interval", 30*time.Second,
+		"how often to poll the server for routes")
+	flags.StringVarP(&routesWatchCmdOptions.output, "output", "o", "text",
+		"output format: text or json (one change per line)")
+	addBrowserFlags(routesWatchCmd)
+	addServiceAccountFlags(routesWatchCmd)
+	addTLSFlags(routesWatchCmd)
+	addOutboundProxyFlags(routesWatchCmd)
+	addJWTCacheFlags(routesWatchCmd)
+	addContextFlags(routesWatchCmd)
+	routesCmd.AddCommand(routesWatchCmd)
+}
+
+var routesWatchCmd = &cobra.Command{
+	Use:   "watch server-url",
+	Short: "print routes as they are added, removed or changed",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		rawServerURL, err := routesServerURL(args)
+		if err != nil {
+			return err
+		}
+
+		var printChange func(io.Writer, portal.RouteChange) error
+		switch routesWatchCmdOptions.output {
+		case "text":
+			printChange = printRouteChangeText
+		case "json":
+			printChange = printRouteChangeJSON
+		default:
+			return fmt.Errorf("unknown output format %q, expected text or json", routesWatchCmdOptions.output)
+		}
+		if routesWatchCmdOptions.interval <= 0 {
+			return fmt.Errorf("interval must be positive")
+		}
+
+		p, err := newPortal()
+		if err != nil {
+			return err
+		}
+		return watchRoutes(cmd.Context(), p, rawServerURL, func(change portal.RouteChange) error {
+			return printChange(os.Stdout, change)
+		})
+	},
+}
+
+// watchRoutes polls the routes and calls onChange for every change. The routes
+// of the first poll are reported as added.
+func watchRoutes(
+	ctx context.Context,
+	p *portal.Portal,
+	rawServerURL string,
+	onChange func(portal.RouteChange) error,
+) error {
+	ticker := time.NewTicker(routesWatchCmdOptions.interval)
+	defer ticker.Stop()
+
+	var previous []portal.Route
+	for {
+		routes, err := p.ListRoutes(ctx, rawServerURL)
+		switch {
+		case ctx.Err() != nil:
+			return nil
+		case errors.Is(err, portal.ErrServer), errors.Is(err, portal.ErrUnavailable):
+			log.Ctx(ctx).Warn().Err(err).Msg("failed to list routes, retrying")
+		case err != nil:
+			return err
+		default:
+			for _, change := range portal.DiffRoutes(previous, routes) {
+				if err := onChange(change); err != nil {
+					return err
+				}
+			}
+			previous = routes
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func printRouteChangeText(w io.Writer, change portal.RouteChange) error {
+	ts := time.Now().Format("2006-01-02 15:04:05")
+	var err error
+	switch change.Type {
+	case portal.RouteAdded:
+		_, err = fmt.Fprintf(w, "%s + %s %s %s\n", ts, change.Route.Name, change.Route.Type, change.Route.From)
+	case portal.RouteRemoved:
+		_, err = fmt.Fprintf(w, "%s - %s %s %s\n", ts, change.Route.Name, change.Route.Type, change.Route.From)
+	case portal.RouteChanged:
+		prev := change.Previous
+		_, err = fmt.Fprintf(w, "%s ~ %s %s %s (was %s %s %s)\n", ts,
+			change.Route.Name, change.Route.Type, change.Route.From,
+			prev.Name, prev.Type, prev.From)
+	}
+	return err
+}
+
+func printRouteChangeJSON(w io.Writer, change portal.RouteChange) error {
+	return json.NewEncoder(w).Encode(struct {
+		Time time.Time `json:"time"`
+		portal.RouteChange
+	}{time.Now(), change})
+}
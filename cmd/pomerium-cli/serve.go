@@ -0,0 +1,284 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	backoff "github.com/cenkalti/backoff/v4"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+
+	"github.com/pomerium/cli/internal/contexts"
+	"github.com/pomerium/cli/tunnel"
+)
+
+// maxTunnelRestartInterval is the longest delay before a failed tunnel is restarted.
+const maxTunnelRestartInterval = 30 * time.Second
+
+var serveCmdOptions struct {
+	config string
+}
+
+func init() {
+	addBrowserFlags(serveCmd)
+	addServiceAccountFlags(serveCmd)
+	addExpectedUserFlags(serveCmd)
+	addTLSFlags(serveCmd)
+	addOutboundProxyFlags(serveCmd)
+	addProxyChainFlags(serveCmd)
+	addKeepAliveFlags(serveCmd)
+	addIPPreferenceFlags(serveCmd)
+	addReconnectFlags(serveCmd)
+	addMetricsFlags(serveCmd)
+	addJWTCacheFlags(serveCmd)
+	addContextFlags(serveCmd)
+	flags := serveCmd.Flags()
+	flags.StringVar(&serveCmdOptions.config, "config", "",
+		"path to a YAML file with the tunnels to run")
+	_ = serveCmd.MarkFlagRequired("config")
+	rootCmd.AddCommand(serveCmd)
+}
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "runs all tunnels defined in a config file",
+	Long: `Runs all tunnels defined in a config file concurrently, restarting them when they fail.
+
+Example config:
+
+  tunnels:
+    - name: db
+      destination: tcp+https://db.example.com:5432
+      listen: 127.0.0.1:5432
+      proxy_protocol: 2
+      tags: [prod]
+    - name: dns
+      type: udp
+      destination: udp+https://dns.example.com:53
+      listen: 127.0.0.1:5353
+      protocol: h3
+      tls:
+        ca_file: /etc/ssl/corp-ca.pem
+
+Flags apply to all tunnels, the tls settings of a tunnel replace the TLS flags.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := loadTunnelsConfig(serveCmdOptions.config)
+		if err != nil {
+			return newConfigError(err)
+		}
+
+		tunnels := make([]*tunnel.Tunnel, len(cfg.Tunnels))
+		for i, tc := range cfg.Tunnels {
+			tunnels[i], err = newConfiguredTunnel(tc)
+			if err != nil {
+				return fmt.Errorf("tunnel %s: %w", tc.Name, err)
+			}
+		}
+
+		c := make(chan os.Signal, 1)
+		signal.Notify(c, os.Interrupt, syscall.SIGTERM, syscall.SIGHUP)
+		ctx, cancel := context.WithCancel(context.Background())
+		go func() {
+			<-c
+			cancel()
+		}()
+
+		err = startMetricsServer(ctx)
+		if err != nil {
+			return err
+		}
+
+		var wg sync.WaitGroup
+		for i, tc := range cfg.Tunnels {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				runConfiguredTunnel(ctx, tc, tunnels[i])
+			}()
+		}
+		wg.Wait()
+		return nil
+	},
+}
+
+// A tunnelsConfig is the config file of the serve command.
+type tunnelsConfig struct {
+	Tunnels []tunnelConfig `json:"tunnels"`
+}
+
+// A tunnelConfig defines a single tunnel of the serve command.
+type tunnelConfig struct {
+	// Name identifies the tunnel in logs, it defaults to the destination.
+	Name        string `json:"name,omitempty"`
+	Destination string `json:"destination"`
+	Listen      string `json:"listen"`
+	// Type is either tcp or udp, defaults to tcp.
+	Type        string   `json:"type,omitempty"`
+	PomeriumURL string   `json:"pomerium_url,omitempty"`
+	Protocol    string   `json:"protocol,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+	// ProxyProtocol is the version of the PROXY protocol header sent to the
+	// destination of tcp tunnels, if set.
+	ProxyProtocol int `json:"proxy_protocol,omitempty"`
+	// TLS replaces the TLS flags if set.
+	TLS *contexts.TLS `json:"tls,omitempty"`
+}
+
+func loadTunnelsConfig(path string) (*tunnelsConfig, error) {
+	bs, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading config: %w", err)
+	}
+
+	var cfg tunnelsConfig
+	if err := yaml.UnmarshalStrict(bs, &cfg); err != nil {
+		return nil, fmt.Errorf("error parsing config %s: %w", path, err)
+	}
+	if len(cfg.Tunnels) == 0 {
+		return nil, fmt.Errorf("no tunnels defined in %s", path)
+	}
+
+	names := make(map[string]bool)
+	for i := range cfg.Tunnels {
+		tc := &cfg.Tunnels[i]
+		if tc.Name == "" {
+			tc.Name = tc.Destination
+		}
+		if names[tc.Name] {
+			return nil, fmt.Errorf("duplicate tunnel name %q", tc.Name)
+		}
+		names[tc.Name] = true
+
+		switch {
+		case tc.Destination == "":
+			return nil, fmt.Errorf("tunnel %d: destination is required", i+1)
+		case tc.Listen == "":
+			return nil, fmt.Errorf("tunnel %s: listen is required", tc.Name)
+		}
+		switch strings.ToLower(tc.Type) {
+		case "":
+			tc.Type = "tcp"
+		case "tcp", "udp":
+			tc.Type = strings.ToLower(tc.Type)
+		default:
+			return nil, fmt.Errorf("tunnel %s: unknown type %q, expected tcp or udp", tc.Name, tc.Type)
+		}
+		if _, err := tunnel.ParseProtocol(tc.Protocol); err != nil {
+			return nil, fmt.Errorf("tunnel %s: %w", tc.Name, err)
+		}
+		if tc.ProxyProtocol < 0 || tc.ProxyProtocol > 2 {
+			return nil, fmt.Errorf("tunnel %s: invalid PROXY protocol version %d, expected 1 or 2",
+				tc.Name, tc.ProxyProtocol)
+		}
+	}
+	return &cfg, nil
+}
+
+func newConfiguredTunnel(tc tunnelConfig) (*tunnel.Tunnel, error) {
+	destinationAddr, proxyURL, err := tunnel.ParseURLs(tc.Destination, tc.PomeriumURL)
+	if err != nil {
+		return nil, newConfigError(err)
+	}
+	protocol, err := tunnel.ParseProtocol(tc.Protocol)
+	if err != nil {
+		return nil, newConfigError(err)
+	}
+
+	var tlsConfig *tls.Config
+	if proxyURL.Scheme == "https" {
+		if tc.TLS != nil {
+			tlsConfig, err = newTLSConfig(*tc.TLS)
+		} else {
+			tlsConfig, err = getTLSConfig()
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	outboundProxies, err := getOutboundProxyURLs()
+	if err != nil {
+		return nil, err
+	}
+	proxyChain, err := getProxyChain()
+	if err != nil {
+		return nil, err
+	}
+	callbackPorts, err := getCallbackPortRange()
+	if err != nil {
+		return nil, err
+	}
+
+	return tunnel.New(
+		tunnel.WithBrowserCommand(browserOptions.command),
+		tunnel.WithCallbackPath(browserOptions.callbackPath),
+		tunnel.WithCallbackPortRange(callbackPorts),
+		tunnel.WithRedirectAddress(browserOptions.callbackAddr),
+		tunnel.WithQRCode(browserOptions.qrCode),
+		tunnel.WithDestinationHost(destinationAddr),
+		tunnel.WithExpectedUser(expectedUserOptions.user),
+		tunnel.WithKeepAlive(keepAliveOptions.interval),
+		tunnel.WithIPPreference(getIPPreference()),
+		tunnel.WithOutboundProxy(outboundProxies...),
+		tunnel.WithProtocol(protocol),
+		tunnel.WithProxyChain(proxyChain...),
+		tunnel.WithProxyHost(proxyURL.Host),
+		tunnel.WithProxyProtocol(tc.ProxyProtocol),
+		tunnel.WithReconnect(getReconnectSettings()),
+		tunnel.WithServiceAccount(serviceAccountOptions.serviceAccount),
+		tunnel.WithServiceAccountFile(serviceAccountOptions.serviceAccountFile),
+		tunnel.WithTLSConfig(tlsConfig),
+		tunnel.WithJWTCache(getJWTCache()),
+	), nil
+}
+
+// runConfiguredTunnel runs the listener of a tunnel until ctx is done,
+// restarting it with an exponential backoff when it fails.
+func runConfiguredTunnel(ctx context.Context, tc tunnelConfig, tun *tunnel.Tunnel) {
+	ctx = log.With().Str("tunnel", tc.Name).Strs("tags", tc.Tags).Logger().WithContext(ctx)
+
+	bo := backoff.NewExponentialBackOff()
+	bo.MaxInterval = maxTunnelRestartInterval
+	bo.MaxElapsedTime = 0
+
+	for {
+		log.Ctx(ctx).Info().Str("type", tc.Type).Str("listen", tc.Listen).Msg("starting tunnel")
+		start := time.Now()
+
+		var err error
+		if tc.Type == "udp" {
+			err = tun.RunUDPListener(ctx, tc.Listen)
+		} else {
+			err = tun.RunListener(ctx, tc.Listen)
+		}
+		if ctx.Err() != nil {
+			log.Ctx(ctx).Info().Msg("stopped tunnel")
+			return
+		}
+		if err == nil {
+			err = errors.New("stopped unexpectedly")
+		}
+
+		// a tunnel that ran for a while failed for a new reason
+		if time.Since(start) > maxTunnelRestartInterval {
+			bo.Reset()
+		}
+		delay := bo.NextBackOff()
+		log.Ctx(ctx).Error().Err(err).Dur("restart-in", delay).Msg("tunnel failed")
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+	}
+}
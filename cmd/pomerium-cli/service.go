@@ -0,0 +1,211 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pomerium/cli/internal/service"
+	"github.com/pomerium/cli/internal/systemd"
+)
+
+var serviceCmdOptions struct {
+	name         string
+	displayName  string
+	systemd      bool
+	systemUnit   bool
+	socketListen string
+	launchd      bool
+	logFile      string
+}
+
+func init() {
+	for _, cmd := range []*cobra.Command{serviceInstallCmd, serviceUninstallCmd, serviceStartCmd, serviceStopCmd} {
+		flags := cmd.Flags()
+		flags.StringVar(&serviceCmdOptions.name, "name", "pomerium-cli",
+			"name of the service, to run several tunnels as services")
+		flags.BoolVar(&serviceCmdOptions.systemd, "systemd", false,
+			"manage a systemd unit of the current user, rather than a service of the Windows service control manager")
+		flags.BoolVar(&serviceCmdOptions.systemUnit, "system", false,
+			"with --systemd, manage a system unit rather than a unit of the current user")
+		flags.BoolVar(&serviceCmdOptions.launchd, "launchd", false,
+			"manage a launchd agent of the current user, which runs while the user is logged in")
+		cmd.MarkFlagsMutuallyExclusive("systemd", "launchd")
+		serviceCmd.AddCommand(cmd)
+	}
+	flags := serviceInstallCmd.Flags()
+	flags.StringVar(&serviceCmdOptions.displayName, "display-name", "",
+		"(optional) user friendly name of the service, defaults to the name")
+	flags.StringVar(&serviceCmdOptions.socketListen, "socket-listen", "",
+		"(optional) with --systemd, let systemd listen on this address, or unix:/path/to.sock, "+
+			"and start the tunnel when the first client connects")
+	flags.StringVar(&serviceCmdOptions.logFile, "log-file", "",
+		"(optional) with --launchd, the file the output of the service is appended to, "+
+			"defaults to ~/Library/Logs/pomerium-cli/<name>.log")
+	rootCmd.AddCommand(serviceCmd)
+}
+
+var serviceCmd = &cobra.Command{
+	Use:   "service",
+	Short: "run a tunnel or the api server as a service of the operating system",
+}
+
+var serviceInstallCmd = &cobra.Command{
+	Use:   "install -- tcp|udp|api [args...]",
+	Short: "install a service running a tunnel or the api server",
+	Long: "Installs a service that runs pomerium-cli with the given command and arguments, " +
+		"which are kept by the service manager. The service starts automatically and is restarted " +
+		"when it fails. Paths in the arguments should be absolute, as the service does not run " +
+		"in the current directory, and authentication should use a service account or cached login. " +
+		"With --systemd, the output of the service is kept in the journal, with --launchd it is appended to a file.",
+	Example: "  pomerium-cli service install --name db -- tcp db.example.com:5432 --listen 127.0.0.1:5432 " +
+		"--service-account-file C:\\ProgramData\\pomerium\\db.jwt",
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := serviceConfig(args)
+		if err != nil {
+			return err
+		}
+		m, err := getServiceManager()
+		if err != nil {
+			return err
+		}
+		if err := m.Install(cfg); err != nil {
+			return err
+		}
+		if serviceCmdOptions.launchd {
+			// launchd agents are started when they are loaded
+			fmt.Fprintf(cmd.OutOrStdout(), "installed and started service %s, logging to %s\n", cfg.Name, cfg.LogFile)
+			return nil
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "installed service %s, start it with: pomerium-cli service start --name %s\n",
+			cfg.Name, cfg.Name)
+		return nil
+	},
+}
+
+var serviceUninstallCmd = &cobra.Command{
+	Use:   "uninstall",
+	Short: "stop and remove the service",
+	Args:  cobra.NoArgs,
+	RunE: func(_ *cobra.Command, _ []string) error {
+		return withServiceManager(service.Manager.Uninstall)
+	},
+}
+
+var serviceStartCmd = &cobra.Command{
+	Use:   "start",
+	Short: "start the service",
+	Args:  cobra.NoArgs,
+	RunE: func(_ *cobra.Command, _ []string) error {
+		return withServiceManager(service.Manager.Start)
+	},
+}
+
+var serviceStopCmd = &cobra.Command{
+	Use:   "stop",
+	Short: "stop the service",
+	Args:  cobra.NoArgs,
+	RunE: func(_ *cobra.Command, _ []string) error {
+		return withServiceManager(service.Manager.Stop)
+	},
+}
+
+func withServiceManager(fn func(m service.Manager, name string) error) error {
+	if err := service.ValidateName(serviceCmdOptions.name); err != nil {
+		return newConfigError(err)
+	}
+	m, err := getServiceManager()
+	if err != nil {
+		return err
+	}
+	return fn(m, serviceCmdOptions.name)
+}
+
+func getServiceManager() (service.Manager, error) {
+	if serviceCmdOptions.systemUnit && !serviceCmdOptions.systemd {
+		return nil, newConfigError(fmt.Errorf("--system requires --systemd"))
+	}
+	switch {
+	case serviceCmdOptions.systemd:
+		return service.Systemd(!serviceCmdOptions.systemUnit), nil
+	case serviceCmdOptions.launchd:
+		return service.Launchd(), nil
+	}
+	m, err := service.System()
+	if errors.Is(err, service.ErrNotSupported) {
+		return nil, newConfigError(fmt.Errorf("%w, use --systemd or --launchd", err))
+	}
+	return m, err
+}
+
+// serviceConfig returns the service running this executable with the args
+func serviceConfig(args []string) (service.Config, error) {
+	cfg := service.Config{
+		Name:        serviceCmdOptions.name,
+		DisplayName: serviceCmdOptions.displayName,
+		Args:        args,
+	}
+	if err := service.ValidateName(cfg.Name); err != nil {
+		return cfg, newConfigError(err)
+	}
+	switch args[0] {
+	case "tcp", "udp":
+		cfg.Description = "Pomerium " + strings.ToUpper(args[0]) + " tunnel"
+		if len(args) > 1 {
+			cfg.Description += " to " + args[1]
+		}
+	case "api":
+		cfg.Description = "Pomerium api server"
+	default:
+		return cfg, newConfigError(fmt.Errorf("unsupported command %q, expected tcp, udp or api", args[0]))
+	}
+	if cfg.DisplayName == "" {
+		cfg.DisplayName = cfg.Name
+	}
+
+	if serviceCmdOptions.socketListen != "" {
+		if !serviceCmdOptions.systemd {
+			return cfg, newConfigError(fmt.Errorf("--socket-listen requires --systemd"))
+		}
+		if args[0] == "api" {
+			return cfg, newConfigError(fmt.Errorf("--socket-listen is only supported for tunnels"))
+		}
+		cfg.SocketListen = serviceCmdOptions.socketListen
+		cfg.SocketDatagram = args[0] == "udp"
+		// the last --listen takes precedence
+		cfg.Args = append(slices.Clone(args), "--listen", systemd.ListenPrefix)
+	}
+
+	if serviceCmdOptions.logFile != "" && !serviceCmdOptions.launchd {
+		return cfg, newConfigError(fmt.Errorf("--log-file requires --launchd"))
+	}
+	if serviceCmdOptions.launchd {
+		logFile := serviceCmdOptions.logFile
+		if logFile == "" {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				return cfg, err
+			}
+			logFile = filepath.Join(home, "Library", "Logs", "pomerium-cli", cfg.Name+".log")
+		}
+		var err error
+		if cfg.LogFile, err = filepath.Abs(logFile); err != nil {
+			return cfg, err
+		}
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		return cfg, err
+	}
+	if cfg.Executable, err = filepath.EvalSymlinks(exe); err != nil {
+		return cfg, err
+	}
+	return cfg, nil
+}
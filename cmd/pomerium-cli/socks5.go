@@ -0,0 +1,202 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/pomerium/cli/internal/socks5"
+	"github.com/pomerium/cli/tunnel"
+)
+
+var socks5CmdOptions struct {
+	listen      string
+	pomeriumURL string
+}
+
+func init() {
+	addBrowserFlags(socks5Cmd)
+	addServiceAccountFlags(socks5Cmd)
+	addExpectedUserFlags(socks5Cmd)
+	addTLSFlags(socks5Cmd)
+	addOutboundProxyFlags(socks5Cmd)
+	addProxyChainFlags(socks5Cmd)
+	addKeepAliveFlags(socks5Cmd)
+	addIPPreferenceFlags(socks5Cmd)
+	addReconnectFlags(socks5Cmd)
+	addMetricsFlags(socks5Cmd)
+	addProtocolFlags(socks5Cmd)
+	addProxyProtocolFlags(socks5Cmd)
+	addJWTCacheFlags(socks5Cmd)
+	addContextFlags(socks5Cmd)
+	flags := socks5Cmd.Flags()
+	flags.StringVar(&socks5CmdOptions.listen, "listen", "127.0.0.1:1080",
+		"local address to start a SOCKS5 listener on")
+	flags.StringVar(&socks5CmdOptions.pomeriumURL, "pomerium-url", "",
+		"the URL of the pomerium server to connect to, by default derived from each destination")
+	rootCmd.AddCommand(socks5Cmd)
+}
+
+var socks5Cmd = &cobra.Command{
+	Use:   "socks5",
+	Short: "creates a SOCKS5 proxy that opens a TCP tunnel through Pomerium for each requested destination",
+	Args:  cobra.ExactArgs(0),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		opts, err := getSOCKS5TunnelOptions()
+		if err != nil {
+			return err
+		}
+
+		li, err := net.Listen("tcp", socks5CmdOptions.listen)
+		if err != nil {
+			return fmt.Errorf("failed to start listener: %w", err)
+		}
+		defer li.Close()
+
+		c := make(chan os.Signal, 1)
+		signal.Notify(c, os.Interrupt, syscall.SIGTERM, syscall.SIGHUP)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go func() {
+			select {
+			case <-c:
+			case <-ctx.Done():
+			}
+			cancel()
+			_ = li.Close()
+		}()
+
+		err = startMetricsServer(ctx)
+		if err != nil {
+			return err
+		}
+
+		log.Info().Msgf("SOCKS5 proxy running at %s", li.Addr())
+		for {
+			conn, err := li.Accept()
+			if err != nil {
+				if ctx.Err() != nil {
+					return nil
+				}
+				return fmt.Errorf("failed to accept connection: %w", err)
+			}
+			go serveSOCKS5(ctx, conn, opts)
+		}
+	},
+}
+
+// getSOCKS5TunnelOptions returns the tunnel options shared by every destination.
+func getSOCKS5TunnelOptions() ([]tunnel.Option, error) {
+	outboundProxies, err := getOutboundProxyURLs()
+	if err != nil {
+		return nil, err
+	}
+	proxyChain, err := getProxyChain()
+	if err != nil {
+		return nil, err
+	}
+	callbackPorts, err := getCallbackPortRange()
+	if err != nil {
+		return nil, err
+	}
+	protocol, err := getProtocol()
+	if err != nil {
+		return nil, err
+	}
+	proxyProtocol, err := getProxyProtocolVersion()
+	if err != nil {
+		return nil, err
+	}
+	return []tunnel.Option{
+		tunnel.WithBrowserCommand(browserOptions.command),
+		tunnel.WithCallbackPath(browserOptions.callbackPath),
+		tunnel.WithCallbackPortRange(callbackPorts),
+		tunnel.WithRedirectAddress(browserOptions.callbackAddr),
+		tunnel.WithQRCode(browserOptions.qrCode),
+		tunnel.WithExpectedUser(expectedUserOptions.user),
+		tunnel.WithKeepAlive(keepAliveOptions.interval),
+		tunnel.WithIPPreference(getIPPreference()),
+		tunnel.WithOutboundProxy(outboundProxies...),
+		tunnel.WithProtocol(protocol),
+		tunnel.WithProxyChain(proxyChain...),
+		tunnel.WithProxyProtocol(proxyProtocol),
+		tunnel.WithReconnect(getReconnectSettings()),
+		tunnel.WithServiceAccount(serviceAccountOptions.serviceAccount),
+		tunnel.WithServiceAccountFile(serviceAccountOptions.serviceAccountFile),
+		tunnel.WithJWTCache(getJWTCache()),
+	}, nil
+}
+
+func serveSOCKS5(ctx context.Context, conn net.Conn, opts []tunnel.Option) {
+	defer conn.Close()
+
+	dst, err := socks5.Accept(conn)
+	if err != nil {
+		log.Error().Err(err).Msg("SOCKS5 handshake failed")
+		return
+	}
+	ctx = log.With().Str("destination", dst).Logger().WithContext(ctx)
+
+	destinationAddr, proxyURL, err := tunnel.ParseURLs(dst, socks5CmdOptions.pomeriumURL)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("invalid destination")
+		_ = socks5.WriteReply(conn, socks5.ReplyGeneralFailure)
+		return
+	}
+
+	var tlsConfig *tls.Config
+	if proxyURL.Scheme == "https" {
+		tlsConfig, err = getTLSConfig()
+		if err != nil {
+			log.Ctx(ctx).Error().Err(err).Msg("invalid TLS configuration")
+			_ = socks5.WriteReply(conn, socks5.ReplyGeneralFailure)
+			return
+		}
+	}
+
+	tun := tunnel.New(append(opts,
+		tunnel.WithDestinationHost(destinationAddr),
+		tunnel.WithProxyHost(proxyURL.Host),
+		tunnel.WithReconnect(getReconnectSettings()),
+		tunnel.WithTLSConfig(tlsConfig),
+	)...)
+
+	events := &socks5Events{EventSink: tunnel.LogEvents(), conn: conn}
+	err = tun.Run(ctx, conn, events)
+	if !events.replied {
+		reply := socks5.ReplyGeneralFailure
+		if errors.Is(err, tunnel.ErrUnauthenticated) {
+			reply = socks5.ReplyNotAllowed
+		}
+		_ = socks5.WriteReply(conn, reply)
+	}
+	if err != nil && ctx.Err() == nil {
+		log.Ctx(ctx).Error().Err(err).Msg("failed to run TCP tunnel")
+	}
+}
+
+// socks5Events replies to the SOCKS5 client once the tunnel is established,
+// so the client only sees success when the destination is reachable.
+type socks5Events struct {
+	tunnel.EventSink
+	conn    net.Conn
+	replied bool
+}
+
+func (evt *socks5Events) OnConnected(ctx context.Context, info tunnel.ConnectionInfo) {
+	evt.EventSink.OnConnected(ctx, info)
+	if !evt.replied {
+		evt.replied = true
+		if err := socks5.WriteReply(evt.conn, socks5.ReplySucceeded); err != nil {
+			log.Ctx(ctx).Error().Err(err).Msg("failed to send SOCKS5 reply")
+		}
+	}
+}
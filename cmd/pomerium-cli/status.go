@@ -0,0 +1,152 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	pb "github.com/pomerium/cli/proto"
+)
+
+var statusCmdOptions struct {
+	follow  bool
+	tags    []string
+	notTags []string
+}
+
+func init() {
+	addAPIClientFlags(statusCmd)
+	flags := statusCmd.Flags()
+	flags.BoolVarP(&statusCmdOptions.follow, "follow", "f", false,
+		"stream connection status updates")
+	flags.StringSliceVar(&statusCmdOptions.tags, "tags", nil,
+		"only show connections with the given tags")
+	flags.StringSliceVar(&statusCmdOptions.notTags, "not-tags", nil,
+		"do not show connections with the given tags")
+	_ = statusCmd.RegisterFlagCompletionFunc("tags", completeTags)
+	_ = statusCmd.RegisterFlagCompletionFunc("not-tags", completeTags)
+	rootCmd.AddCommand(statusCmd)
+}
+
+var statusCmd = &cobra.Command{
+	Use:   "status [connection-id...]",
+	Short: "show the status of connections managed by a running api server",
+
+	ValidArgsFunction: completeConnectionIDs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := newAPIClient()
+		if err != nil {
+			return err
+		}
+		defer func() { _ = client.Close() }()
+
+		ctx := cmd.Context()
+		sel := selectorFromArgs(args, statusCmdOptions.tags, statusCmdOptions.notTags)
+		recs, err := client.List(ctx, sel)
+		if err != nil {
+			return fmt.Errorf("list connections: %w", err)
+		}
+		status, err := client.GetStatus(ctx, sel)
+		if err != nil {
+			return fmt.Errorf("get status: %w", err)
+		}
+
+		printStatus(os.Stdout, recs.GetRecords(), status.GetListeners())
+		if !statusCmdOptions.follow {
+			return nil
+		}
+		return followStatus(ctx, client, recs.GetRecords())
+	},
+}
+
+func printStatus(w io.Writer, recs []*pb.Record, listeners map[string]*pb.ListenerStatus) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tNAME\tREMOTE\tSTATUS\tLISTEN\tERROR")
+	for _, r := range recs {
+		l := listeners[r.GetId()]
+		state := "stopped"
+		if l.GetListening() {
+			state = "listening"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			r.GetId(), r.GetConn().GetName(), r.GetConn().GetRemoteAddr(),
+			state, l.GetListenAddr(), l.GetLastError())
+	}
+	_ = tw.Flush()
+}
+
+func followStatus(ctx context.Context, client *apiClient, recs []*pb.Record) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	names := make(map[string]string, len(recs))
+	updates := make(chan *pb.ConnectionStatusUpdate)
+	errs := make(chan error, len(recs))
+	for _, r := range recs {
+		names[r.GetId()] = r.GetConn().GetName()
+		stream, err := client.StatusUpdates(ctx, &pb.StatusUpdatesRequest{ConnectionId: r.GetId()})
+		if err != nil {
+			return fmt.Errorf("status updates %s: %w", r.GetId(), err)
+		}
+		go func() {
+			for {
+				upd, err := stream.Recv()
+				if err != nil {
+					errs <- err
+					return
+				}
+				select {
+				case updates <- upd:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-errs:
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("status updates: %w", err)
+		case upd := <-updates:
+			printStatusUpdate(os.Stdout, names[upd.GetId()], upd)
+		}
+	}
+}
+
+func printStatusUpdate(w io.Writer, name string, upd *pb.ConnectionStatusUpdate) {
+	line := fmt.Sprintf("%s %s %s %s",
+		upd.GetTs().AsTime().Local().Format("2006-01-02 15:04:05"),
+		upd.GetId(), name, upd.GetStatus())
+	if peer := upd.GetPeerAddr(); peer != "" {
+		line += " peer=" + peer
+	}
+	if authURL := upd.GetAuthUrl(); authURL != "" {
+		line += " auth_url=" + authURL
+	}
+	if lastErr := upd.GetLastError(); lastErr != "" {
+		line += " error=" + lastErr
+	}
+	if d := upd.GetDetails(); d != nil {
+		line += " protocol=" + d.GetProtocol()
+		if ip := d.GetProxyIp(); ip != "" {
+			line += " proxy_ip=" + ip
+		}
+		if v := d.GetTlsVersion(); v != "" {
+			line += " tls=" + strings.ReplaceAll(v, " ", "") + "/" + d.GetTlsCipherSuite()
+		}
+		line += " time_to_connect=" + d.GetTimeToConnect().AsDuration().Round(time.Millisecond).String()
+	}
+	fmt.Fprintln(w, line)
+}
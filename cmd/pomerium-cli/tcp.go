@@ -21,11 +21,24 @@ var tcpCmdOptions struct {
 
 func init() {
 	addBrowserFlags(tcpCmd)
+	addNoBrowserFlags(tcpCmd)
 	addServiceAccountFlags(tcpCmd)
+	addExpectedUserFlags(tcpCmd)
 	addTLSFlags(tcpCmd)
+	addOutboundProxyFlags(tcpCmd)
+	addProxyChainFlags(tcpCmd)
+	addKeepAliveFlags(tcpCmd)
+	addIPPreferenceFlags(tcpCmd)
+	addReconnectFlags(tcpCmd)
+	addMetricsFlags(tcpCmd)
+	addProtocolFlags(tcpCmd)
+	addProxyProtocolFlags(tcpCmd)
+	addJWTCacheFlags(tcpCmd)
+	addContextFlags(tcpCmd)
 	flags := tcpCmd.Flags()
 	flags.StringVar(&tcpCmdOptions.listen, "listen", "127.0.0.1:0",
-		"local address to start a listener on")
+		"local address to start a listener on, unix:/path/to.sock for a Unix domain socket "+
+			"or systemd: for the socket passed by systemd socket activation")
 	flags.StringVar(&tcpCmdOptions.pomeriumURL, "pomerium-url", "",
 		"the URL of the pomerium server to connect to")
 	rootCmd.AddCommand(tcpCmd)
@@ -35,12 +48,15 @@ var tcpCmd = &cobra.Command{
 	Use:   "tcp destination",
 	Short: "creates a TCP tunnel through Pomerium",
 	Args:  cobra.ExactArgs(1),
+
+	ValidArgsFunction: completeRouteDestinations("tcp"),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		destinationAddr, proxyURL, err := tunnel.ParseURLs(args[0], tcpCmdOptions.pomeriumURL)
 		if err != nil {
-			return err
+			return newConfigError(err)
 		}
 		cacheLastURL(proxyURL.String())
+		rememberContextRoute(args[0])
 
 		var tlsConfig *tls.Config
 		if proxyURL.Scheme == "https" {
@@ -50,6 +66,27 @@ var tcpCmd = &cobra.Command{
 			}
 		}
 
+		outboundProxies, err := getOutboundProxyURLs()
+		if err != nil {
+			return err
+		}
+		proxyChain, err := getProxyChain()
+		if err != nil {
+			return err
+		}
+		callbackPorts, err := getCallbackPortRange()
+		if err != nil {
+			return err
+		}
+		protocol, err := getProtocol()
+		if err != nil {
+			return err
+		}
+		proxyProtocol, err := getProxyProtocolVersion()
+		if err != nil {
+			return err
+		}
+
 		c := make(chan os.Signal, 1)
 		signal.Notify(c, os.Interrupt, syscall.SIGTERM, syscall.SIGHUP)
 		ctx, cancel := context.WithCancel(context.Background())
@@ -58,13 +95,32 @@ var tcpCmd = &cobra.Command{
 			cancel()
 		}()
 
+		err = startMetricsServer(ctx)
+		if err != nil {
+			return err
+		}
+
 		tun := tunnel.New(
 			tunnel.WithBrowserCommand(browserOptions.command),
+			tunnel.WithCallbackPath(browserOptions.callbackPath),
+			tunnel.WithCallbackPortRange(callbackPorts),
+			tunnel.WithRedirectAddress(browserOptions.callbackAddr),
+			tunnel.WithQRCode(browserOptions.qrCode),
+			tunnel.WithNoBrowser(noBrowserOptions.enabled),
 			tunnel.WithDestinationHost(destinationAddr),
+			tunnel.WithExpectedUser(expectedUserOptions.user),
+			tunnel.WithKeepAlive(keepAliveOptions.interval),
+			tunnel.WithIPPreference(getIPPreference()),
+			tunnel.WithOutboundProxy(outboundProxies...),
+			tunnel.WithProtocol(protocol),
+			tunnel.WithProxyChain(proxyChain...),
 			tunnel.WithProxyHost(proxyURL.Host),
+			tunnel.WithProxyProtocol(proxyProtocol),
+			tunnel.WithReconnect(getReconnectSettings()),
 			tunnel.WithServiceAccount(serviceAccountOptions.serviceAccount),
 			tunnel.WithServiceAccountFile(serviceAccountOptions.serviceAccountFile),
 			tunnel.WithTLSConfig(tlsConfig),
+			tunnel.WithJWTCache(getJWTCache()),
 		)
 
 		if tcpCmdOptions.listen == "-" {
@@ -74,7 +130,7 @@ var tcpCmd = &cobra.Command{
 		}
 		if err != nil {
 			_, _ = fmt.Fprintf(os.Stderr, "%s\n", err.Error())
-			os.Exit(1)
+			exitWithError(err)
 		}
 
 		return nil
@@ -16,18 +16,22 @@ import (
 var udpCmdOptions struct {
 	listen      string
 	pomeriumURL string
+	settings    tunnel.UDPSettings
 }
 
 var udpCmd = &cobra.Command{
 	Use:   "udp destination",
 	Short: "creates a UDP tunnel through Pomerium",
 	Args:  cobra.ExactArgs(1),
+
+	ValidArgsFunction: completeRouteDestinations("udp"),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		destinationAddr, proxyURL, err := tunnel.ParseURLs(args[0], tcpCmdOptions.pomeriumURL)
+		destinationAddr, proxyURL, err := tunnel.ParseURLs(args[0], udpCmdOptions.pomeriumURL)
 		if err != nil {
-			return err
+			return newConfigError(err)
 		}
 		cacheLastURL(proxyURL.String())
+		rememberContextRoute(args[0])
 
 		var tlsConfig *tls.Config
 		if proxyURL.Scheme == "https" {
@@ -37,6 +41,23 @@ var udpCmd = &cobra.Command{
 			}
 		}
 
+		outboundProxies, err := getOutboundProxyURLs()
+		if err != nil {
+			return err
+		}
+		proxyChain, err := getProxyChain()
+		if err != nil {
+			return err
+		}
+		callbackPorts, err := getCallbackPortRange()
+		if err != nil {
+			return err
+		}
+		protocol, err := getProtocol()
+		if err != nil {
+			return err
+		}
+
 		c := make(chan os.Signal, 1)
 		signal.Notify(c, os.Interrupt, syscall.SIGTERM, syscall.SIGHUP)
 		ctx, cancel := context.WithCancel(context.Background())
@@ -45,13 +66,32 @@ var udpCmd = &cobra.Command{
 			cancel()
 		}()
 
+		err = startMetricsServer(ctx)
+		if err != nil {
+			return err
+		}
+
 		tun := tunnel.New(
 			tunnel.WithBrowserCommand(browserOptions.command),
+			tunnel.WithCallbackPath(browserOptions.callbackPath),
+			tunnel.WithCallbackPortRange(callbackPorts),
+			tunnel.WithRedirectAddress(browserOptions.callbackAddr),
+			tunnel.WithQRCode(browserOptions.qrCode),
+			tunnel.WithNoBrowser(noBrowserOptions.enabled),
 			tunnel.WithDestinationHost(destinationAddr),
+			tunnel.WithExpectedUser(expectedUserOptions.user),
+			tunnel.WithKeepAlive(keepAliveOptions.interval),
+			tunnel.WithIPPreference(getIPPreference()),
+			tunnel.WithOutboundProxy(outboundProxies...),
+			tunnel.WithProtocol(protocol),
+			tunnel.WithProxyChain(proxyChain...),
 			tunnel.WithProxyHost(proxyURL.Host),
+			tunnel.WithReconnect(getReconnectSettings()),
 			tunnel.WithServiceAccount(serviceAccountOptions.serviceAccount),
 			tunnel.WithServiceAccountFile(serviceAccountOptions.serviceAccountFile),
 			tunnel.WithTLSConfig(tlsConfig),
+			tunnel.WithJWTCache(getJWTCache()),
+			tunnel.WithUDPSettings(udpCmdOptions.settings),
 		)
 
 		if udpCmdOptions.listen == "-" {
@@ -61,7 +101,7 @@ var udpCmd = &cobra.Command{
 		}
 		if err != nil {
 			_, _ = fmt.Fprintf(os.Stderr, "%s\n", err.Error())
-			os.Exit(1)
+			exitWithError(err)
 		}
 
 		return nil
@@ -70,12 +110,33 @@ var udpCmd = &cobra.Command{
 
 func init() {
 	addBrowserFlags(udpCmd)
+	addNoBrowserFlags(udpCmd)
 	addServiceAccountFlags(udpCmd)
+	addExpectedUserFlags(udpCmd)
 	addTLSFlags(udpCmd)
+	addOutboundProxyFlags(udpCmd)
+	addProxyChainFlags(udpCmd)
+	addKeepAliveFlags(udpCmd)
+	addIPPreferenceFlags(udpCmd)
+	addReconnectFlags(udpCmd)
+	addMetricsFlags(udpCmd)
+	addProtocolFlags(udpCmd)
+	addJWTCacheFlags(udpCmd)
+	addContextFlags(udpCmd)
 	flags := udpCmd.Flags()
 	flags.StringVar(&udpCmdOptions.listen, "listen", "127.0.0.1:0",
-		"local address to start a listener on")
+		"local address to start a listener on, or systemd: for the socket passed by systemd socket activation")
 	flags.StringVar(&udpCmdOptions.pomeriumURL, "pomerium-url", "",
 		"the URL of the pomerium server to connect to")
+	flags.DurationVar(&udpCmdOptions.settings.SessionTimeout, "session-timeout", 0,
+		"maximum duration of a single UDP session (default 10m)")
+	flags.IntVar(&udpCmdOptions.settings.MaxSessions, "max-sessions", 0,
+		"maximum number of concurrent UDP sessions, unlimited if 0")
+	flags.IntVar(&udpCmdOptions.settings.MaxPacketSize, "max-packet-size", 0,
+		"maximum size of a datagram read from the listener (default 65535)")
+	flags.IntVar(&udpCmdOptions.settings.ReadBufferSize, "read-buffer-size", 0,
+		"listener socket read buffer size in bytes, system default if 0")
+	flags.IntVar(&udpCmdOptions.settings.WriteBufferSize, "write-buffer-size", 0,
+		"listener socket write buffer size in bytes, system default if 0")
 	rootCmd.AddCommand(udpCmd)
 }
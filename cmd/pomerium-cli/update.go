@@ -0,0 +1,114 @@
+package main
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pomerium/cli/internal/httputil"
+	"github.com/pomerium/cli/internal/selfupdate"
+	"github.com/pomerium/cli/version"
+)
+
+var updateCmdOptions struct {
+	channel string
+	dryRun  bool
+}
+
+func init() {
+	flags := updateCmd.Flags()
+	flags.StringVar(&updateCmdOptions.channel, "channel", string(selfupdate.ChannelStable),
+		"release channel to update from, stable or beta, which includes pre-releases")
+	flags.BoolVar(&updateCmdOptions.dryRun, "dry-run", false,
+		"only report whether a newer release is available")
+	addOutboundProxyFlags(updateCmd)
+	rootCmd.AddCommand(updateCmd)
+}
+
+var updateCmd = &cobra.Command{
+	Use:   "update",
+	Short: "update pomerium-cli to the latest release",
+	Long: "Checks GitHub for a newer release of pomerium-cli, and replaces this executable with it " +
+		"after verifying the signed checksum of the release. Installations managed by a package " +
+		"manager should be updated with the package manager instead.",
+	Args: cobra.NoArgs,
+
+	// the command itself checks for the latest release
+	Annotations: map[string]string{noVersionCheckAnnotation: "true"},
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		channel, err := selfupdate.ParseChannel(updateCmdOptions.channel)
+		if err != nil {
+			return newConfigError(err)
+		}
+		// without the signing key a release cannot be verified, so don't
+		// download anything
+		if version.ReleaseSigningKey == "" && !updateCmdOptions.dryRun {
+			return selfupdate.ErrNoPublicKey
+		}
+		u, err := newUpdater()
+		if err != nil {
+			return err
+		}
+
+		r, err := u.Latest(cmd.Context(), channel)
+		if err != nil {
+			return err
+		}
+		out := cmd.OutOrStdout()
+		if !r.Newer(version.Version) {
+			fmt.Fprintf(out, "pomerium-cli %s is up to date\n", version.Version)
+			return nil
+		}
+		fmt.Fprintf(out, "pomerium-cli %s is available: %s\n", r.Version, r.URL)
+		if updateCmdOptions.dryRun {
+			return nil
+		}
+
+		exe, err := os.Executable()
+		if err != nil {
+			return err
+		}
+		if exe, err = filepath.EvalSymlinks(exe); err != nil {
+			return err
+		}
+		if selfupdate.Managed(exe) {
+			return fmt.Errorf("%s was installed by a package manager, update it with the package manager instead", exe)
+		}
+
+		data, err := u.Download(cmd.Context(), r)
+		if err != nil {
+			return err
+		}
+		if err := selfupdate.Replace(exe, data); err != nil {
+			return fmt.Errorf("error replacing %s: %w", exe, err)
+		}
+		fmt.Fprintf(out, "updated pomerium-cli from %s to %s\n", version.Version, r.Version)
+		return nil
+	},
+}
+
+func newUpdater() (*selfupdate.Updater, error) {
+	proxyURL, err := getOutboundProxyURL()
+	if err != nil {
+		return nil, err
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = httputil.ProxyFunc(proxyURL)
+
+	opts := []selfupdate.Option{
+		selfupdate.WithHTTPClient(&http.Client{Transport: transport}),
+	}
+	if version.ReleaseSigningKey != "" {
+		key, err := base64.StdEncoding.DecodeString(version.ReleaseSigningKey)
+		if err != nil || len(key) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("invalid release signing key")
+		}
+		opts = append(opts, selfupdate.WithPublicKey(ed25519.PublicKey(key)))
+	}
+	return selfupdate.New(opts...), nil
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/pomerium/cli/internal/cache"
+	"github.com/pomerium/cli/internal/selfupdate"
+	"github.com/pomerium/cli/internal/versioncheck"
+	"github.com/pomerium/cli/version"
+)
+
+// versionCheckTimeout bounds the requests of the version checks
+const versionCheckTimeout = 10 * time.Second
+
+var getVersionChecker = sync.OnceValue(func() *versioncheck.Checker {
+	opts := []versioncheck.Option{
+		versioncheck.WithLatestRelease(func(ctx context.Context) (string, error) {
+			channel := selfupdate.ChannelStable
+			if version.IsPrerelease(version.Version) {
+				channel = selfupdate.ChannelBeta
+			}
+			r, err := selfupdate.New().Latest(ctx, channel)
+			if err != nil {
+				return "", err
+			}
+			return r.Version, nil
+		}),
+	}
+	if root, err := cache.RootPath(); err == nil {
+		opts = append(opts, versioncheck.WithStatePath(filepath.Join(root, "version-check.json")))
+	}
+	return versioncheck.New(version.Version, opts...)
+})
+
+// noVersionCheckAnnotation marks commands run by other programs, such as
+// kubectl, which must stay fast and quiet and so never check the version.
+const noVersionCheckAnnotation = "pomerium-cli/no-version-check"
+
+var versionCheckOptions struct {
+	disabled bool
+}
+
+func init() {
+	rootCmd.PersistentFlags().BoolVar(&versionCheckOptions.disabled, "no-version-check", false,
+		"do not check for a newer release of pomerium-cli, also disabled by setting "+versioncheck.DisableEnv)
+}
+
+// checkLatestVersion warns in the background when a newer release of
+// pomerium-cli is available. It is called once the flags are parsed.
+func checkLatestVersion(ctx context.Context) {
+	if versionCheckOptions.disabled || !versioncheck.Enabled() {
+		return
+	}
+	cmd, _, err := rootCmd.Find(os.Args[1:])
+	if err != nil || skipVersionCheck(cmd) {
+		return
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(ctx, versionCheckTimeout)
+		defer cancel()
+		logVersionWarnings(getVersionChecker().CheckLatest(ctx))
+	}()
+}
+
+// skipVersionCheck reports whether the command, or one of its parents, is
+// not interactive
+func skipVersionCheck(cmd *cobra.Command) bool {
+	for ; cmd != nil; cmd = cmd.Parent() {
+		switch cmd.Name() {
+		// shell completions run a hidden command on every key press
+		case cobra.ShellCompRequestCmd, cobra.ShellCompNoDescRequestCmd, "completion":
+			return true
+		}
+		if _, ok := cmd.Annotations[noVersionCheckAnnotation]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func logVersionWarnings(warnings []string, err error) {
+	if err != nil {
+		log.Debug().Err(err).Msg("version check")
+	}
+	for _, w := range warnings {
+		log.Warn().Msg(w)
+	}
+}
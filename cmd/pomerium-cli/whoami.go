@@ -0,0 +1,76 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pomerium/cli/jwt"
+)
+
+func init() {
+	addJWTCacheFlags(whoamiCmd)
+	addContextFlags(whoamiCmd)
+	rootCmd.AddCommand(whoamiCmd)
+}
+
+var whoamiCmd = &cobra.Command{
+	Use:   "whoami [server-url]",
+	Short: "shows the identity of the cached JWT for a Pomerium server",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		rawServerURL, err := routesServerURL(args)
+		if err != nil {
+			return err
+		}
+		keys, err := jwtCacheKeys(rawServerURL)
+		if err != nil {
+			return err
+		}
+
+		cache := getJWTCache()
+		for _, key := range keys {
+			rawJWT, err := cache.LoadJWT(key)
+			if rawJWT == "" {
+				if err != nil && !errors.Is(err, jwt.ErrNotFound) {
+					return fmt.Errorf("error loading JWT: %w", err)
+				}
+				continue
+			}
+			printWhoami(os.Stdout, key, rawJWT, err)
+			return nil
+		}
+		return fmt.Errorf("not logged in to %s", rawServerURL)
+	},
+}
+
+func printWhoami(w io.Writer, key, rawJWT string, loadErr error) {
+	claims, err := jwt.ParseClaims(rawJWT)
+	if err != nil {
+		loadErr = err
+	}
+
+	formatTime := func(t time.Time) string {
+		if t.IsZero() {
+			return "-"
+		}
+		return t.Local().Format(time.RFC3339)
+	}
+	status := "valid"
+	if loadErr != nil {
+		status = loadErr.Error()
+	}
+
+	fmt.Fprintln(w, "key:", key)
+	fmt.Fprintln(w, "subject:", claims.Subject)
+	fmt.Fprintln(w, "email:", claims.Email)
+	fmt.Fprintln(w, "groups:", strings.Join(claims.Groups, ", "))
+	fmt.Fprintln(w, "issued:", formatTime(claims.IssuedAt))
+	fmt.Fprintln(w, "expires:", formatTime(claims.Expiry))
+	fmt.Fprintln(w, "status:", status)
+}
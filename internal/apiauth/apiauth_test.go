@@ -0,0 +1,82 @@
+package apiauth
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestToken(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "sub", "api-token")
+	token, err := GenerateToken(path)
+	require.NoError(t, err)
+	assert.NotEmpty(t, token)
+	if runtime.GOOS != "windows" {
+		fi, err := os.Stat(path)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0o600), fi.Mode().Perm())
+	}
+
+	got, err := ReadToken(path)
+	require.NoError(t, err)
+	assert.Equal(t, token, got)
+
+	// a new token replaces the previous one
+	next, err := GenerateToken(path)
+	require.NoError(t, err)
+	assert.NotEqual(t, token, next)
+	got, err = ReadToken(path)
+	require.NoError(t, err)
+	assert.Equal(t, next, got)
+	entries, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "temporary files should be removed")
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	t.Parallel()
+
+	interceptor := UnaryServerInterceptor("secret")
+	handler := func(context.Context, any) (any, error) { return "ok", nil }
+
+	md, err := TokenCredentials("secret").GetRequestMetadata(context.Background())
+	require.NoError(t, err)
+	for _, tc := range []struct {
+		name string
+		md   metadata.MD
+		code codes.Code
+	}{
+		{"valid", metadata.New(md), codes.OK},
+		{"missing", metadata.MD{}, codes.Unauthenticated},
+		{"invalid", metadata.Pairs("authorization", "Bearer other"), codes.Unauthenticated},
+		{"no bearer prefix", metadata.Pairs("authorization", "secret"), codes.Unauthenticated},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := metadata.NewIncomingContext(context.Background(), tc.md)
+			resp, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{}, handler)
+			assert.Equal(t, tc.code, status.Code(err))
+			if tc.code == codes.OK {
+				assert.Equal(t, "ok", resp)
+			}
+		})
+	}
+
+	t.Run("health", func(t *testing.T) {
+		resp, err := interceptor(context.Background(), nil,
+			&grpc.UnaryServerInfo{FullMethod: healthpb.Health_Check_FullMethodName}, handler)
+		assert.NoError(t, err, "health checks do not need the token")
+		assert.Equal(t, "ok", resp)
+	})
+}
@@ -4,24 +4,42 @@ package cache
 import (
 	"os"
 	"path/filepath"
+
+	"github.com/pomerium/cli/internal/profile"
 )
 
-// Clear clears the cache.
+// Clear clears the cache of the profile.
 func Clear() error {
 	root, err := RootPath()
 	if err != nil {
 		return err
 	}
-	return os.RemoveAll(root)
+
+	entries, err := os.ReadDir(root)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		// the caches of the other profiles are kept
+		if profile.Name() == "" && e.Name() == profile.DirName {
+			continue
+		}
+		if err := os.RemoveAll(filepath.Join(root, e.Name())); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
-// RootPath returns the root cache path.
+// RootPath returns the root cache path of the profile.
 func RootPath() (string, error) {
 	root, err := os.UserCacheDir()
 	if err != nil {
 		return "", err
 	}
-	return filepath.Join(root, "pomerium-cli"), nil
+	return profile.Dir(filepath.Join(root, "pomerium-cli")), nil
 }
 
 // ExecCredentialsPath returns the path to the exec credentials.
@@ -42,6 +60,24 @@ func JWTsPath() (string, error) {
 	return filepath.Join(root, "jwts"), nil
 }
 
+// KeyringJWTsPath returns the path to the keys of the jwts stored in the keyring.
+func KeyringJWTsPath() (string, error) {
+	root, err := RootPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(root, "keyring-jwts"), nil
+}
+
+// RoutesPath returns the path to the cached routes.
+func RoutesPath() (string, error) {
+	root, err := RootPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(root, "routes"), nil
+}
+
 // LastURLPath returns the last URL.
 func LastURLPath() (string, error) {
 	root, err := RootPath()
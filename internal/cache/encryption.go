@@ -0,0 +1,211 @@
+package cache
+
+import (
+	"bytes"
+	"crypto/cipher"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"golang.org/x/crypto/argon2"
+
+	"github.com/pomerium/cli/internal/keyring"
+	"github.com/pomerium/cli/internal/profile"
+	"github.com/pomerium/pomerium/pkg/cryptutil"
+)
+
+// Encryption modes of the cache.
+const (
+	// EncryptionNone stores cached credentials in plaintext.
+	EncryptionNone = "none"
+	// EncryptionKeyring encrypts cached credentials with a random key kept in
+	// the keyring of the operating system.
+	EncryptionKeyring = "keyring"
+	// EncryptionPassphrase encrypts cached credentials with a key derived from
+	// the passphrase in the PassphraseEnv environment variable.
+	EncryptionPassphrase = "passphrase"
+)
+
+// PassphraseEnv is the environment variable holding the passphrase for
+// EncryptionPassphrase.
+const PassphraseEnv = "POMERIUM_CLI_CACHE_PASSPHRASE"
+
+// ErrUndecryptable indicates that a cache entry cannot be decrypted, because
+// cache encryption is disabled or it was encrypted with another key.
+var ErrUndecryptable = errors.New("the cache entry cannot be decrypted")
+
+const (
+	// encryptedPrefix marks encrypted cache entries, entries without it were
+	// written without encryption
+	encryptedPrefix = "pomerium-cli-encrypted:v1:"
+
+	keyringService    = "pomerium-cli"
+	keyringKeyAccount = "cache-encryption-key"
+
+	saltSize = 16
+	// argon2 parameters, see RFC 9106 section 4
+	argon2Time    = 3
+	argon2Memory  = 64 * 1024
+	argon2Threads = 4
+	keySize       = 32
+)
+
+var encryption = struct {
+	mode string
+
+	once sync.Once
+	aead cipher.AEAD
+	err  error
+}{mode: EncryptionNone}
+
+// SetEncryption selects the encryption mode of the cache, it has no effect
+// once the cache has been used.
+func SetEncryption(mode string) error {
+	switch mode {
+	case EncryptionNone, EncryptionKeyring, EncryptionPassphrase:
+	default:
+		return fmt.Errorf("unknown cache encryption %q, expected %s, %s or %s",
+			mode, EncryptionNone, EncryptionKeyring, EncryptionPassphrase)
+	}
+	encryption.mode = mode
+	return nil
+}
+
+// Seal encrypts data to be written to the cache file with the given name, if
+// cache encryption is enabled.
+func Seal(name string, data []byte) ([]byte, error) {
+	aead, err := getAEAD()
+	if err != nil || aead == nil {
+		return data, err
+	}
+
+	sealed := cryptutil.Encrypt(aead, data, []byte(name))
+	return append([]byte(encryptedPrefix), base64.StdEncoding.EncodeToString(sealed)...), nil
+}
+
+// Open decrypts data read from the cache file with the given name. Entries
+// written without encryption are returned as they are, migrate then reports
+// whether they should be written again to encrypt them.
+func Open(name string, data []byte) (plaintext []byte, migrate bool, err error) {
+	aead, err := getAEAD()
+	if err != nil {
+		return nil, false, err
+	}
+
+	sealed, ok := bytes.CutPrefix(data, []byte(encryptedPrefix))
+	if !ok {
+		return data, aead != nil, nil
+	}
+	if aead == nil {
+		return nil, false, fmt.Errorf("%w: cache encryption is disabled", ErrUndecryptable)
+	}
+
+	raw, err := base64.StdEncoding.DecodeString(string(sealed))
+	if err != nil {
+		return nil, false, fmt.Errorf("%w: %w", ErrUndecryptable, err)
+	}
+	plaintext, err = cryptutil.Decrypt(aead, raw, []byte(name))
+	if err != nil {
+		return nil, false, fmt.Errorf("%w: %w", ErrUndecryptable, err)
+	}
+	return plaintext, false, nil
+}
+
+// getAEAD returns the cipher for the cache, or nil if encryption is disabled.
+func getAEAD() (cipher.AEAD, error) {
+	encryption.once.Do(func() {
+		var key []byte
+		switch encryption.mode {
+		case EncryptionKeyring:
+			key, encryption.err = keyringKey(keyring.System())
+		case EncryptionPassphrase:
+			key, encryption.err = passphraseKey(os.Getenv(PassphraseEnv))
+		default:
+			return
+		}
+		if encryption.err != nil {
+			encryption.err = fmt.Errorf("cache encryption: %w", encryption.err)
+			return
+		}
+		encryption.aead, encryption.err = cryptutil.NewAEADCipher(key)
+	})
+	return encryption.aead, encryption.err
+}
+
+// keyringKey returns the key in the keyring, which is created if missing.
+func keyringKey(kr keyring.Keyring) ([]byte, error) {
+	service := profile.KeyringService(keyringService)
+	encoded, err := kr.Get(service, keyringKeyAccount)
+	if errors.Is(err, keyring.ErrNotFound) {
+		encoded = base64.StdEncoding.EncodeToString(cryptutil.NewKey())
+		err = kr.Set(service, keyringKeyAccount, encoded)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || len(key) != keySize {
+		return nil, fmt.Errorf("invalid key in keyring")
+	}
+	return key, nil
+}
+
+// passphraseKey derives the key from the passphrase with Argon2id, using a
+// random salt kept in the cache directory.
+func passphraseKey(passphrase string) ([]byte, error) {
+	if passphrase == "" {
+		return nil, fmt.Errorf("%s is not set", PassphraseEnv)
+	}
+
+	root, err := RootPath()
+	if err != nil {
+		return nil, err
+	}
+	salt, err := loadSalt(filepath.Join(root, "encryption-salt"))
+	if err != nil {
+		return nil, err
+	}
+	return argon2.IDKey([]byte(passphrase), salt, argon2Time, argon2Memory, argon2Threads, keySize), nil
+}
+
+// loadSalt reads the salt at path, or creates it if missing.
+func loadSalt(path string) ([]byte, error) {
+	salt, err := os.ReadFile(path)
+	if err == nil {
+		if len(salt) != saltSize {
+			return nil, fmt.Errorf("invalid salt in %s", path)
+		}
+		return salt, nil
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0o700)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = os.Remove(f.Name()) }()
+	_, err = f.Write(cryptutil.NewKey()[:saltSize])
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// linking fails if another process created the salt concurrently, in
+	// which case that one is used
+	err = os.Link(f.Name(), path)
+	if err != nil && !os.IsExist(err) {
+		return nil, err
+	}
+	return loadSalt(path)
+}
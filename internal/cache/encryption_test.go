@@ -0,0 +1,89 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pomerium/cli/internal/keyring"
+	"github.com/pomerium/pomerium/pkg/cryptutil"
+)
+
+type memoryKeyring map[string]string
+
+func (k memoryKeyring) Get(service, account string) (string, error) {
+	secret, ok := k[service+"/"+account]
+	if !ok {
+		return "", keyring.ErrNotFound
+	}
+	return secret, nil
+}
+
+func (k memoryKeyring) Set(service, account, secret string) error {
+	k[service+"/"+account] = secret
+	return nil
+}
+
+func (k memoryKeyring) Delete(service, account string) error {
+	delete(k, service+"/"+account)
+	return nil
+}
+
+func TestEncryption(t *testing.T) {
+	kr := make(memoryKeyring)
+	key, err := keyringKey(kr)
+	require.NoError(t, err)
+	key2, err := keyringKey(kr)
+	require.NoError(t, err)
+	assert.Equal(t, key, key2, "should reuse the key in the keyring")
+
+	aead, err := cryptutil.NewAEADCipher(key)
+	require.NoError(t, err)
+	encryption.once.Do(func() {})
+	encryption.aead = aead
+	t.Cleanup(func() { encryption.aead = nil })
+
+	sealed, err := Seal("a.jwt", []byte("secret"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(sealed), "secret")
+
+	plaintext, migrate, err := Open("a.jwt", sealed)
+	assert.NoError(t, err)
+	assert.False(t, migrate)
+	assert.Equal(t, "secret", string(plaintext))
+
+	_, _, err = Open("b.jwt", sealed)
+	assert.ErrorIs(t, err, ErrUndecryptable, "should bind the entry to its file")
+
+	plaintext, migrate, err = Open("a.jwt", []byte("plaintext"))
+	assert.NoError(t, err)
+	assert.True(t, migrate, "should migrate plaintext entries")
+	assert.Equal(t, "plaintext", string(plaintext))
+
+	encryption.aead = nil
+	_, _, err = Open("a.jwt", sealed)
+	assert.ErrorIs(t, err, ErrUndecryptable)
+	plaintext, migrate, err = Open("a.jwt", []byte("plaintext"))
+	assert.NoError(t, err)
+	assert.False(t, migrate)
+	assert.Equal(t, "plaintext", string(plaintext))
+}
+
+func TestLoadSalt(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "encryption-salt")
+
+	salt, err := loadSalt(path)
+	require.NoError(t, err)
+	assert.Len(t, salt, saltSize)
+
+	salt2, err := loadSalt(path)
+	require.NoError(t, err)
+	assert.Equal(t, salt, salt2, "should reuse the salt")
+
+	require.NoError(t, os.WriteFile(path, []byte("short"), 0o600))
+	_, err = loadSalt(path)
+	assert.Error(t, err)
+}
@@ -0,0 +1,180 @@
+// Package contexts stores named sets of connection settings for pomerium
+// clusters, similar to kubectl contexts.
+package contexts
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+
+	"github.com/pomerium/cli/internal/profile"
+)
+
+// maxLastRoutes is the number of recently used routes kept per context
+const maxLastRoutes = 10
+
+// Auth methods
+const (
+	AuthMethodBrowser        = "browser"
+	AuthMethodServiceAccount = "service-account"
+)
+
+// AuthMethods are the supported auth methods.
+var AuthMethods = []string{AuthMethodBrowser, AuthMethodServiceAccount}
+
+// A Context is a named set of settings for a pomerium cluster.
+type Context struct {
+	ServerURL string `json:"server_url"`
+	TLS       TLS    `json:"tls,omitempty"`
+
+	AuthMethod         string `json:"auth_method,omitempty"`
+	ServiceAccountFile string `json:"service_account_file,omitempty"`
+
+	// LastRoutes are the most recently used routes, most recent first.
+	LastRoutes []string `json:"last_routes,omitempty"`
+}
+
+// TLS are the TLS options of a context.
+type TLS struct {
+	DisableTLSVerification  bool     `json:"disable_tls_verification,omitempty"`
+	CAFile                  string   `json:"ca_file,omitempty"`
+	CACert                  string   `json:"ca_cert,omitempty"`
+	CADir                   string   `json:"ca_dir,omitempty"`
+	PinSHA256               []string `json:"pin_sha256,omitempty"`
+	CheckRevocation         bool     `json:"check_revocation,omitempty"`
+	ServerName              string   `json:"server_name,omitempty"`
+	MinVersion              string   `json:"min_version,omitempty"`
+	ClientCertFile          string   `json:"client_cert_file,omitempty"`
+	ClientKeyFile           string   `json:"client_key_file,omitempty"`
+	ClientCertChainFile     string   `json:"client_cert_chain_file,omitempty"`
+	ClientKeyPassphraseFile string   `json:"client_key_passphrase_file,omitempty"`
+	ClientCertFromStore     bool     `json:"client_cert_from_store,omitempty"`
+	ClientCertIssuer        string   `json:"client_cert_issuer,omitempty"`
+	ClientCertSubject       string   `json:"client_cert_subject,omitempty"`
+	RequireUserPresence     bool     `json:"require_user_presence,omitempty"`
+	ClientCertSelect        string   `json:"client_cert_select,omitempty"`
+	ClientCertPKCS11        string   `json:"client_cert_pkcs11,omitempty"`
+	ClientCertPIV           string   `json:"client_cert_piv,omitempty"`
+	ClientCertExpiryWarning string   `json:"client_cert_expiry_warning,omitempty"`
+	ClientCertRenewHook     string   `json:"client_cert_renew_hook,omitempty"`
+	ClientCertTPM           bool     `json:"client_cert_tpm,omitempty"`
+}
+
+// AddLastRoute records the route as the most recently used one.
+func (c *Context) AddLastRoute(route string) {
+	routes := []string{route}
+	for _, r := range c.LastRoutes {
+		if r != route && len(routes) < maxLastRoutes {
+			routes = append(routes, r)
+		}
+	}
+	c.LastRoutes = routes
+}
+
+// Config is the set of contexts.
+type Config struct {
+	CurrentContext string              `json:"current_context,omitempty"`
+	Contexts       map[string]*Context `json:"contexts,omitempty"`
+}
+
+// Path returns the path to the contexts config file of the profile.
+func Path() (string, error) {
+	root, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(profile.Dir(filepath.Join(root, "pomerium-cli")), "contexts.json"), nil
+}
+
+// Load loads the config. A missing file results in an empty config.
+func Load(path string) (*Config, error) {
+	cfg := &Config{Contexts: make(map[string]*Context)}
+
+	bs, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return cfg, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(bs, cfg); err != nil {
+		return nil, fmt.Errorf("invalid contexts config %s: %w", path, err)
+	}
+	if cfg.Contexts == nil {
+		cfg.Contexts = make(map[string]*Context)
+	}
+	return cfg, nil
+}
+
+// Save saves the config.
+func (cfg *Config) Save(path string) error {
+	bs, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to create contexts config directory: %w", err)
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(path), "*.tmp")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(f.Name()) }()
+
+	if _, err := f.Write(bs); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
+
+// Get returns the named context, or the current context if name is empty.
+// It returns nil if name is empty and there is no current context.
+func (cfg *Config) Get(name string) (*Context, error) {
+	if name == "" {
+		name = cfg.CurrentContext
+		if name == "" {
+			return nil, nil
+		}
+	}
+
+	c, ok := cfg.Contexts[name]
+	if !ok {
+		return nil, fmt.Errorf("context %q not found", name)
+	}
+	return c, nil
+}
+
+// Names returns the context names in sorted order.
+func (cfg *Config) Names() []string {
+	names := make([]string, 0, len(cfg.Contexts))
+	for name := range cfg.Contexts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Validate checks the context settings.
+func (c *Context) Validate() error {
+	if c.ServerURL == "" {
+		return fmt.Errorf("server url is required")
+	}
+	if c.AuthMethod != "" && !slices.Contains(AuthMethods, c.AuthMethod) {
+		return fmt.Errorf("unknown auth method %q", c.AuthMethod)
+	}
+	if c.AuthMethod == AuthMethodServiceAccount && c.ServiceAccountFile == "" {
+		return fmt.Errorf("a service account file is required for the %s auth method", c.AuthMethod)
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package contexts
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "pomerium-cli", "contexts.json")
+	cfg, err := Load(path)
+	require.NoError(t, err)
+	assert.Empty(t, cfg.Names())
+
+	c, err := cfg.Get("")
+	assert.NoError(t, err)
+	assert.Nil(t, c, "should return no context without a current context")
+
+	cfg.Contexts["prod"] = &Context{
+		ServerURL: "https://pomerium.example.com",
+		TLS:       TLS{CADir: "/etc/ssl/certs", PinSHA256: []string{"abc"}},
+	}
+	cfg.Contexts["dev"] = &Context{ServerURL: "https://pomerium.localhost.pomerium.io"}
+	cfg.CurrentContext = "prod"
+	require.NoError(t, cfg.Save(path))
+
+	cfg, err = Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"dev", "prod"}, cfg.Names())
+
+	c, err = cfg.Get("")
+	require.NoError(t, err)
+	assert.Equal(t, "https://pomerium.example.com", c.ServerURL)
+	assert.Equal(t, []string{"abc"}, c.TLS.PinSHA256)
+
+	_, err = cfg.Get("staging")
+	assert.ErrorContains(t, err, `context "staging" not found`)
+}
+
+func TestAddLastRoute(t *testing.T) {
+	t.Parallel()
+
+	var c Context
+	for i := 0; i < maxLastRoutes+2; i++ {
+		c.AddLastRoute(string(rune('a' + i)))
+	}
+	assert.Len(t, c.LastRoutes, maxLastRoutes)
+	assert.Equal(t, "l", c.LastRoutes[0])
+
+	c.AddLastRoute("e")
+	assert.Equal(t, []string{"e", "l", "k", "j"}, c.LastRoutes[:4])
+	assert.Len(t, c.LastRoutes, maxLastRoutes)
+}
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	assert.Error(t, (&Context{}).Validate())
+	assert.NoError(t, (&Context{ServerURL: "https://example.com"}).Validate())
+	assert.Error(t, (&Context{ServerURL: "https://example.com", AuthMethod: "kerberos"}).Validate())
+	assert.Error(t, (&Context{ServerURL: "https://example.com", AuthMethod: AuthMethodServiceAccount}).Validate())
+	assert.NoError(t, (&Context{
+		ServerURL:          "https://example.com",
+		AuthMethod:         AuthMethodServiceAccount,
+		ServiceAccountFile: "sa.jwt",
+	}).Validate())
+}
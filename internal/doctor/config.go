@@ -0,0 +1,58 @@
+package doctor
+
+import (
+	"context"
+	"crypto/tls"
+	"net/url"
+	"time"
+)
+
+type config struct {
+	cacheDir         string
+	dialQUIC         func(ctx context.Context, addr string, tlsConfig *tls.Config) error
+	now              func() time.Time
+	outboundProxyURL *url.URL
+	timeout          time.Duration
+	tlsConfig        *tls.Config
+}
+
+type Option func(cfg *config)
+
+// WithCacheDir sets the cache directory to check.
+func WithCacheDir(dir string) Option {
+	return func(cfg *config) {
+		cfg.cacheDir = dir
+	}
+}
+
+// WithOutboundProxy sets the HTTP proxy to connect to the proxy through.
+func WithOutboundProxy(proxyURL *url.URL) Option {
+	return func(cfg *config) {
+		cfg.outboundProxyURL = proxyURL
+	}
+}
+
+// WithTimeout sets the timeout of each check.
+func WithTimeout(timeout time.Duration) Option {
+	return func(cfg *config) {
+		cfg.timeout = timeout
+	}
+}
+
+// WithTLSConfig sets the TLS config used to connect to the proxy.
+func WithTLSConfig(tlsConfig *tls.Config) Option {
+	return func(cfg *config) {
+		cfg.tlsConfig = tlsConfig
+	}
+}
+
+func getConfig(options ...Option) *config {
+	cfg := new(config)
+	cfg.dialQUIC = dialQUIC
+	cfg.now = time.Now
+	WithTimeout(10 * time.Second)(cfg)
+	for _, option := range options {
+		option(cfg)
+	}
+	return cfg
+}
@@ -0,0 +1,370 @@
+// Package doctor diagnoses the connectivity to a Pomerium proxy, answering
+// the questions most connection problems start with.
+package doctor
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// A Status is the outcome of a check.
+type Status int
+
+const (
+	// StatusOK indicates the check passed.
+	StatusOK Status = iota
+	// StatusWarning indicates a problem that may affect some connections.
+	StatusWarning
+	// StatusFailed indicates a problem that prevents connecting.
+	StatusFailed
+	// StatusSkipped indicates the check could not be made.
+	StatusSkipped
+)
+
+func (s Status) String() string {
+	switch s {
+	case StatusOK:
+		return "ok"
+	case StatusWarning:
+		return "warn"
+	case StatusFailed:
+		return "FAIL"
+	case StatusSkipped:
+		return "skip"
+	}
+	return fmt.Sprintf("Status(%d)", int(s))
+}
+
+// A Result is the outcome of a check, with details for the report.
+type Result struct {
+	Check  string
+	Status Status
+	Detail string
+}
+
+const (
+	// certExpiryWarning is how long before the expiry of the certificate of
+	// the proxy a warning is reported
+	certExpiryWarning = 14 * 24 * time.Hour
+	// maxClockSkew is the difference to the clock of the proxy above which a
+	// warning is reported, as JWTs may be rejected
+	maxClockSkew = time.Minute
+)
+
+// A Doctor runs the checks.
+type Doctor struct {
+	cfg *config
+}
+
+// New creates a new Doctor.
+func New(options ...Option) *Doctor {
+	return &Doctor{cfg: getConfig(options...)}
+}
+
+// run carries the state of the checks made so far
+type run struct {
+	*Doctor
+	proxyURL *url.URL
+	results  []Result
+
+	res *http.Response
+}
+
+func (r *run) report(check string, status Status, format string, args ...any) {
+	r.results = append(r.results, Result{Check: check, Status: status, Detail: fmt.Sprintf(format, args...)})
+}
+
+// Run runs the checks against the proxy URL, in order. Checks that depend on
+// a failed check are skipped.
+func (d *Doctor) Run(ctx context.Context, proxyURL *url.URL) []Result {
+	if proxyURL.Port() == "" {
+		proxyURL = &url.URL{Scheme: proxyURL.Scheme, Host: net.JoinHostPort(proxyURL.Hostname(), "443")}
+		if proxyURL.Scheme == "http" {
+			proxyURL.Host = net.JoinHostPort(proxyURL.Hostname(), "80")
+		}
+	}
+	r := &run{Doctor: d, proxyURL: proxyURL}
+	switch {
+	case !r.checkDNS(ctx):
+		r.skip("the proxy host does not resolve", "tcp", "tls", "http2", "http3", "clock", "login")
+	case !r.checkTCP(ctx):
+		r.skip("the proxy is not reachable", "tls", "http2", "http3", "clock", "login")
+	case !r.checkHTTPS(ctx):
+		r.skip("no connection to the proxy", "http2", "http3", "clock", "login")
+	default:
+		r.checkHTTP2()
+		r.checkHTTP3(ctx)
+		r.checkClock()
+		r.checkLogin(ctx)
+	}
+	r.checkCacheDir()
+	return r.results
+}
+
+func (r *run) skip(reason string, checks ...string) {
+	for _, check := range checks {
+		r.report(check, StatusSkipped, "%s", reason)
+	}
+}
+
+func (r *run) checkDNS(ctx context.Context) bool {
+	if r.cfg.outboundProxyURL != nil {
+		r.report("dns", StatusSkipped, "%s is resolved by the outbound proxy %s",
+			r.proxyURL.Hostname(), r.cfg.outboundProxyURL.Host)
+		return true
+	}
+	ctx, cancel := context.WithTimeout(ctx, r.cfg.timeout)
+	defer cancel()
+	addrs, err := net.DefaultResolver.LookupHost(ctx, r.proxyURL.Hostname())
+	if err != nil {
+		r.report("dns", StatusFailed, "%v", err)
+		return false
+	}
+	r.report("dns", StatusOK, "%s resolves to %s", r.proxyURL.Hostname(), strings.Join(addrs, ", "))
+	return true
+}
+
+func (r *run) checkTCP(ctx context.Context) bool {
+	addr := r.proxyURL.Host
+	if r.cfg.outboundProxyURL != nil {
+		addr = r.cfg.outboundProxyURL.Host
+	}
+	ctx, cancel := context.WithTimeout(ctx, r.cfg.timeout)
+	defer cancel()
+	start := time.Now()
+	conn, err := new(net.Dialer).DialContext(ctx, "tcp", addr)
+	if err != nil {
+		r.report("tcp", StatusFailed, "%v", err)
+		return false
+	}
+	_ = conn.Close()
+	r.report("tcp", StatusOK, "connected to %s (%s) in %s", addr, conn.RemoteAddr(),
+		time.Since(start).Round(time.Millisecond))
+	return true
+}
+
+func (r *run) transport(tlsConfig *tls.Config) *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	transport.Proxy = http.ProxyURL(r.cfg.outboundProxyURL)
+	return transport
+}
+
+// get requests the path of the proxy without following redirects
+func (r *run) get(ctx context.Context, transport http.RoundTripper, path string) (*http.Response, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.cfg.timeout)
+	defer cancel()
+	u := r.proxyURL.ResolveReference(&url.URL{Path: path})
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	_, _ = io.Copy(io.Discard, io.LimitReader(res.Body, 1<<20))
+	_ = res.Body.Close()
+	return res, nil
+}
+
+func (r *run) checkHTTPS(ctx context.Context) bool {
+	transport := r.transport(r.cfg.tlsConfig)
+	defer transport.CloseIdleConnections()
+	res, err := r.get(ctx, transport, "/")
+	if err != nil {
+		var certErr *tls.CertificateVerificationError
+		var unknownAuthority x509.UnknownAuthorityError
+		var hostnameErr x509.HostnameError
+		if errors.As(err, &certErr) || errors.As(err, &unknownAuthority) || errors.As(err, &hostnameErr) {
+			r.report("tls", StatusFailed, "invalid certificate: %v%s", err, r.describeChain(ctx))
+		} else {
+			r.report("tls", StatusFailed, "%v", err)
+		}
+		return false
+	}
+	r.res = res
+	if res.TLS == nil {
+		r.report("tls", StatusWarning, "the proxy is not using TLS")
+		return true
+	}
+
+	leaf := res.TLS.PeerCertificates[0]
+	detail := fmt.Sprintf("%s, certificate %s issued by %s, expires %s", tls.VersionName(res.TLS.Version),
+		leaf.Subject, leaf.Issuer, leaf.NotAfter.Format(time.DateOnly))
+	if time.Until(leaf.NotAfter) < certExpiryWarning {
+		r.report("tls", StatusWarning, "%s, which is soon", detail)
+	} else {
+		r.report("tls", StatusOK, "%s", detail)
+	}
+	return true
+}
+
+// describeChain returns the certificate chain presented by the proxy, for a
+// certificate that failed to verify
+func (r *run) describeChain(ctx context.Context) string {
+	tlsConfig := &tls.Config{InsecureSkipVerify: true} //nolint:gosec
+	if r.cfg.tlsConfig != nil {
+		tlsConfig = r.cfg.tlsConfig.Clone()
+		tlsConfig.InsecureSkipVerify = true
+		tlsConfig.VerifyPeerCertificate = nil
+		tlsConfig.VerifyConnection = nil
+	}
+	transport := r.transport(tlsConfig)
+	defer transport.CloseIdleConnections()
+	res, err := r.get(ctx, transport, "/")
+	if err != nil || res.TLS == nil {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString(", the proxy presented:")
+	for _, cert := range res.TLS.PeerCertificates {
+		fmt.Fprintf(&b, "\n  %s issued by %s", cert.Subject, cert.Issuer)
+	}
+	return b.String()
+}
+
+func (r *run) checkHTTP2() {
+	if r.res.ProtoMajor == 2 {
+		r.report("http2", StatusOK, "the proxy supports HTTP/2")
+		return
+	}
+	r.report("http2", StatusWarning, "the proxy responded with %s, so tunnels fall back to HTTP/1.1", r.res.Proto)
+}
+
+func (r *run) checkHTTP3(ctx context.Context) {
+	if !strings.Contains(r.res.Header.Get("Alt-Svc"), "h3") {
+		r.report("http3", StatusSkipped, "the proxy does not advertise HTTP/3")
+		return
+	}
+	if r.cfg.outboundProxyURL != nil {
+		r.report("http3", StatusSkipped, "HTTP/3 is not used through an outbound proxy")
+		return
+	}
+	tlsConfig := &tls.Config{}
+	if r.cfg.tlsConfig != nil {
+		tlsConfig = r.cfg.tlsConfig.Clone()
+	}
+	ctx, cancel := context.WithTimeout(ctx, r.cfg.timeout)
+	defer cancel()
+	if err := r.cfg.dialQUIC(ctx, r.proxyURL.Host, tlsConfig); err != nil {
+		r.report("http3", StatusWarning, "UDP to %s is blocked or filtered, tunnels fall back to HTTP/2: %v",
+			r.proxyURL.Host, err)
+		return
+	}
+	r.report("http3", StatusOK, "connected to %s over UDP", r.proxyURL.Host)
+}
+
+func (r *run) checkClock() {
+	date, err := http.ParseTime(r.res.Header.Get("Date"))
+	if err != nil {
+		r.report("clock", StatusSkipped, "the proxy did not send its time")
+		return
+	}
+	skew := r.cfg.now().Sub(date)
+	// the date has a resolution of a second
+	if skew.Abs() > maxClockSkew {
+		r.report("clock", StatusWarning, "the local clock is %s off the clock of the proxy, "+
+			"so logins may be rejected", skew.Abs().Round(time.Second))
+		return
+	}
+	r.report("clock", StatusOK, "the local clock is in sync with the proxy")
+}
+
+func (r *run) checkLogin(ctx context.Context) {
+	transport := r.transport(r.cfg.tlsConfig)
+	defer transport.CloseIdleConnections()
+
+	ctx, cancel := context.WithTimeout(ctx, r.cfg.timeout)
+	defer cancel()
+	u := r.proxyURL.ResolveReference(&url.URL{
+		Path:     "/.pomerium/api/v1/login",
+		RawQuery: url.Values{"pomerium_redirect_uri": {"http://127.0.0.1/"}}.Encode(),
+	})
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		r.report("login", StatusFailed, "%v", err)
+		return
+	}
+	res, err := transport.RoundTrip(req)
+	if err != nil {
+		r.report("login", StatusFailed, "%v", err)
+		return
+	}
+	body, _ := io.ReadAll(io.LimitReader(res.Body, 4096))
+	_ = res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		r.report("login", StatusFailed, "the login endpoint responded with %s", res.Status)
+		return
+	}
+	loginURL, err := url.Parse(strings.TrimSpace(string(body)))
+	if err != nil || loginURL.Host == "" {
+		r.report("login", StatusFailed, "the login endpoint did not return a login URL")
+		return
+	}
+
+	// the authenticate service may be hosted elsewhere
+	req, err = http.NewRequestWithContext(ctx, http.MethodGet, loginURL.String(), nil)
+	if err != nil {
+		r.report("login", StatusFailed, "%v", err)
+		return
+	}
+	res, err = transport.RoundTrip(req)
+	if err != nil {
+		r.report("login", StatusFailed, "the authenticate service at %s is not reachable: %v", loginURL.Host, err)
+		return
+	}
+	_ = res.Body.Close()
+	if res.StatusCode >= http.StatusInternalServerError {
+		r.report("login", StatusFailed, "the authenticate service at %s responded with %s", loginURL.Host, res.Status)
+		return
+	}
+	r.report("login", StatusOK, "logins are handled by %s", loginURL.Host)
+}
+
+func (r *run) checkCacheDir() {
+	dir := r.cfg.cacheDir
+	if dir == "" {
+		r.report("cache", StatusSkipped, "no cache directory")
+		return
+	}
+	fi, err := os.Stat(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		r.report("cache", StatusOK, "%s will be created", dir)
+		return
+	} else if err != nil {
+		r.report("cache", StatusFailed, "%v", err)
+		return
+	}
+	if !fi.IsDir() {
+		r.report("cache", StatusFailed, "%s is not a directory", dir)
+		return
+	}
+
+	f, err := os.CreateTemp(dir, ".doctor-*")
+	if err != nil {
+		r.report("cache", StatusFailed, "%s is not writable, logins cannot be cached: %v", dir, err)
+		return
+	}
+	_ = f.Close()
+	_ = os.Remove(f.Name())
+
+	// the cache keeps the JWTs of the logins
+	if runtime.GOOS != "windows" && fi.Mode().Perm()&0o077 != 0 {
+		r.report("cache", StatusWarning, "%s is accessible by other users (%s), run chmod 700 %[1]s",
+			dir, fi.Mode().Perm())
+		return
+	}
+	r.report("cache", StatusOK, "%s is writable", dir)
+}
@@ -0,0 +1,142 @@
+package doctor
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestProxy(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	var srv *httptest.Server
+	srv = httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/":
+			w.Header().Set("Alt-Svc", `h3=":443"; ma=86400`)
+			http.Redirect(w, r, srv.URL+"/authenticate", http.StatusFound)
+		case "/.pomerium/api/v1/login":
+			_, _ = w.Write([]byte(srv.URL + "/authenticate?pomerium_redirect_uri=" +
+				url.QueryEscape(r.URL.Query().Get("pomerium_redirect_uri"))))
+		case "/authenticate":
+			http.Redirect(w, r, "https://idp.example.com", http.StatusFound)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	srv.EnableHTTP2 = true
+	srv.StartTLS()
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func statuses(results []Result) map[string]Status {
+	m := make(map[string]Status, len(results))
+	for _, r := range results {
+		m[r.Check] = r.Status
+	}
+	return m
+}
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	srv := newTestProxy(t)
+	proxyURL, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+	tlsConfig := srv.Client().Transport.(*http.Transport).TLSClientConfig
+
+	t.Run("ok", func(t *testing.T) {
+		t.Parallel()
+
+		cacheDir := t.TempDir()
+		require.NoError(t, os.Chmod(cacheDir, 0o700))
+		d := New(WithTLSConfig(tlsConfig), WithCacheDir(cacheDir))
+		d.cfg.dialQUIC = func(context.Context, string, *tls.Config) error { return nil }
+		results := d.Run(context.Background(), proxyURL)
+		assert.Equal(t, map[string]Status{
+			"dns":   StatusOK,
+			"tcp":   StatusOK,
+			"tls":   StatusOK,
+			"http2": StatusOK,
+			"http3": StatusOK,
+			"clock": StatusOK,
+			"login": StatusOK,
+			"cache": StatusOK,
+		}, statuses(results), "%v", results)
+	})
+	t.Run("untrusted certificate", func(t *testing.T) {
+		t.Parallel()
+
+		d := New()
+		results := d.Run(context.Background(), proxyURL)
+		s := statuses(results)
+		assert.Equal(t, StatusFailed, s["tls"], "%v", results)
+		assert.Equal(t, StatusSkipped, s["login"])
+		for _, r := range results {
+			if r.Check == "tls" {
+				assert.Contains(t, r.Detail, "the proxy presented:")
+			}
+		}
+	})
+	t.Run("udp blocked and clock skew", func(t *testing.T) {
+		t.Parallel()
+
+		d := New(WithTLSConfig(tlsConfig))
+		d.cfg.dialQUIC = func(context.Context, string, *tls.Config) error { return errors.New("timeout") }
+		d.cfg.now = func() time.Time { return time.Now().Add(5 * time.Minute) }
+		s := statuses(d.Run(context.Background(), proxyURL))
+		assert.Equal(t, StatusWarning, s["http3"])
+		assert.Equal(t, StatusWarning, s["clock"])
+		assert.Equal(t, StatusSkipped, s["cache"])
+	})
+	t.Run("unreachable", func(t *testing.T) {
+		t.Parallel()
+
+		li, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		addr := li.Addr().String()
+		require.NoError(t, li.Close())
+
+		s := statuses(New(WithTimeout(time.Second)).Run(context.Background(), &url.URL{Scheme: "https", Host: addr}))
+		assert.Equal(t, StatusFailed, s["tcp"])
+		assert.Equal(t, StatusSkipped, s["tls"])
+	})
+}
+
+func TestCheckCacheDir(t *testing.T) {
+	t.Parallel()
+
+	check := func(dir string) Result {
+		r := &run{Doctor: New(WithCacheDir(dir))}
+		r.checkCacheDir()
+		return r.results[0]
+	}
+
+	dir := t.TempDir()
+	assert.Equal(t, StatusOK, check(filepath.Join(dir, "missing")).Status)
+
+	require.NoError(t, os.Chmod(dir, 0o700))
+	assert.Equal(t, StatusOK, check(dir).Status)
+
+	if runtime.GOOS != "windows" {
+		require.NoError(t, os.Chmod(dir, 0o755))
+		assert.Equal(t, StatusWarning, check(dir).Status)
+	}
+
+	file := filepath.Join(dir, "file")
+	require.NoError(t, os.WriteFile(file, nil, 0o600))
+	assert.Equal(t, StatusFailed, check(file).Status)
+}
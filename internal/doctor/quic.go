@@ -0,0 +1,20 @@
+package doctor
+
+import (
+	"context"
+	"crypto/tls"
+
+	"github.com/quic-go/quic-go"
+	"github.com/quic-go/quic-go/http3"
+)
+
+// dialQUIC completes a QUIC handshake with the HTTP/3 server at addr
+func dialQUIC(ctx context.Context, addr string, tlsConfig *tls.Config) error {
+	tlsConfig = tlsConfig.Clone()
+	tlsConfig.NextProtos = []string{http3.NextProtoH3}
+	conn, err := quic.DialAddr(ctx, addr, tlsConfig, &quic.Config{})
+	if err != nil {
+		return err
+	}
+	return conn.CloseWithError(0, "")
+}
@@ -0,0 +1,58 @@
+// Package filelock provides exclusive advisory file locks shared between processes.
+package filelock
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const pollInterval = 100 * time.Millisecond
+
+// A Lock is an exclusive lock on a file, held until Unlock is called.
+type Lock struct {
+	f *os.File
+}
+
+// Acquire blocks until the lock on the file at path is acquired or ctx is done.
+// The file is created if it does not exist.
+func Acquire(ctx context.Context, path string) (*Lock, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("filelock: failed to create directory: %w", err)
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("filelock: failed to open lock file: %w", err)
+	}
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		ok, err := tryLock(f)
+		if err != nil {
+			_ = f.Close()
+			return nil, fmt.Errorf("filelock: failed to lock %s: %w", path, err)
+		} else if ok {
+			return &Lock{f: f}, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			_ = f.Close()
+			return nil, fmt.Errorf("filelock: failed to lock %s: %w", path, context.Cause(ctx))
+		case <-ticker.C:
+		}
+	}
+}
+
+// Unlock releases the lock.
+func (l *Lock) Unlock() error {
+	err := unlock(l.f)
+	if cerr := l.f.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
@@ -0,0 +1,47 @@
+package filelock
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAcquire(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "dir", "test.lock")
+
+	l1, err := Acquire(context.Background(), path)
+	require.NoError(t, err)
+
+	// a second lock on the same file blocks until the first is released
+	ctx, cancel := context.WithTimeout(context.Background(), 3*pollInterval)
+	defer cancel()
+	_, err = Acquire(ctx, path)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	acquired := make(chan *Lock)
+	go func() {
+		l2, err := Acquire(context.Background(), path)
+		assert.NoError(t, err)
+		acquired <- l2
+	}()
+
+	select {
+	case <-acquired:
+		t.Fatal("lock acquired while held")
+	case <-time.After(3 * pollInterval):
+	}
+
+	require.NoError(t, l1.Unlock())
+	select {
+	case l2 := <-acquired:
+		assert.NoError(t, l2.Unlock())
+	case <-time.After(10 * time.Second):
+		t.Fatal("lock not acquired after release")
+	}
+}
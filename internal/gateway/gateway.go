@@ -0,0 +1,441 @@
+// Package gateway serves gRPC services as REST endpoints with JSON bodies, in
+// the manner of grpc-gateway, and describes them in an OpenAPI document.
+//
+// Request messages are read from the JSON body of POST and PUT requests, and
+// from the query parameters and path otherwise. Responses are the response
+// messages in the protobuf JSON encoding, or a stream of server-sent events
+// with a message each for streaming methods. Errors are returned as an object
+// with the gRPC status code and message.
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	pb "github.com/pomerium/cli/proto"
+)
+
+// OpenAPIPath is the path of the OpenAPI document.
+const OpenAPIPath = "/v1/openapi.json"
+
+const maxRequestSize = 16 << 20
+
+// A route maps an HTTP method and path, in the form of http.ServeMux
+// patterns, to a gRPC method.
+type route struct {
+	pattern     string
+	fullMethod  string
+	body        bool   // the request message is the body rather than the query
+	idField     string // the request field set by the {id} path wildcard
+	operationID string
+	summary     string
+}
+
+var routes = []route{
+	{"GET /v1/records", pb.Config_List_FullMethodName, false, "",
+		"listRecords", "List the records matching the selector"},
+	{"GET /v1/records/{id}", pb.Config_List_FullMethodName, false, "ids",
+		"getRecord", "Get a record"},
+	{"POST /v1/records", pb.Config_Upsert_FullMethodName, true, "",
+		"upsertRecord", "Create a record, or update it if the id is given"},
+	{"PUT /v1/records/{id}", pb.Config_Upsert_FullMethodName, true, "id",
+		"putRecord", "Create or update a record"},
+	{"DELETE /v1/records", pb.Config_Delete_FullMethodName, false, "",
+		"deleteRecords", "Delete the records matching the selector"},
+	{"DELETE /v1/records/{id}", pb.Config_Delete_FullMethodName, false, "ids",
+		"deleteRecord", "Delete a record"},
+	{"GET /v1/tags", pb.Config_GetTags_FullMethodName, false, "",
+		"getTags", "List all tags"},
+	{"POST /v1/tags/rename", pb.Config_RenameTag_FullMethodName, true, "",
+		"renameTag", "Rename a tag in every record that has it"},
+	{"POST /v1/tags/merge", pb.Config_MergeTags_FullMethodName, true, "",
+		"mergeTags", "Replace several tags with a single tag"},
+	{"POST /v1/export", pb.Config_Export_FullMethodName, true, "",
+		"export", "Export records"},
+	{"POST /v1/import", pb.Config_Import_FullMethodName, true, "",
+		"import", "Import previously exported records"},
+	{"POST /v1/routes/fetch", pb.Config_FetchRoutes_FullMethodName, true, "",
+		"fetchRoutes", "Fetch the routes from the routes portal of a server"},
+	{"POST /v1/routes/import", pb.Config_ImportFromPortal_FullMethodName, true, "",
+		"importFromPortal", "Create or update records for the TCP and UDP routes of the routes portal"},
+	{"GET /v1/audit-events", pb.Config_ListAuditEvents_FullMethodName, false, "",
+		"listAuditEvents", "List the audit log of the changes to the records and listeners"},
+	{"GET /v1/listeners", pb.Listener_GetStatus_FullMethodName, false, "",
+		"getListenerStatus", "Get the status of the listeners matching the selector"},
+	{"POST /v1/listeners", pb.Listener_Update_FullMethodName, true, "",
+		"updateListeners", "Start or stop listening for connections"},
+	{"GET /v1/listeners/updates", pb.Listener_StatusUpdates_FullMethodName, false, "",
+		"streamStatusUpdates", "Stream connection status updates as server-sent events"},
+	{"GET /v1/events", pb.Listener_GetEvents_FullMethodName, false, "",
+		"getEvents", "Get the history of connection status updates"},
+	{"GET /v1/stats", pb.Listener_GetStats_FullMethodName, false, "",
+		"getStats", "Get the live traffic statistics of the records matching the selector"},
+	{"GET /v1/stats/updates", pb.Listener_StreamStats_FullMethodName, false, "",
+		"streamStats", "Stream the live traffic statistics as server-sent events"},
+}
+
+// A boundRoute is a route of a registered service.
+type boundRoute struct {
+	*route
+	method protoreflect.MethodDescriptor
+}
+
+// A Server serves the routes of the services registered with it.
+type Server struct {
+	mux    *http.ServeMux
+	unary  grpc.UnaryServerInterceptor
+	stream grpc.StreamServerInterceptor
+
+	mu      sync.Mutex
+	bound   []boundRoute
+	openAPI []byte
+}
+
+var _ grpc.ServiceRegistrar = (*Server)(nil)
+
+// NewServer creates a new server, which calls the interceptors for each
+// unary and streaming method, in order.
+func NewServer(unary []grpc.UnaryServerInterceptor, stream []grpc.StreamServerInterceptor) *Server {
+	srv := &Server{
+		mux:    http.NewServeMux(),
+		unary:  ChainUnaryInterceptors(unary...),
+		stream: ChainStreamInterceptors(stream...),
+	}
+	srv.mux.HandleFunc("GET "+OpenAPIPath, srv.serveOpenAPI)
+	return srv
+}
+
+// RegisterService registers the routes of the methods of a service. Methods
+// without a route are not served.
+func (srv *Server) RegisterService(desc *grpc.ServiceDesc, impl any) {
+	sd, err := serviceDescriptor(desc.ServiceName)
+	if err != nil {
+		panic(fmt.Sprintf("gateway: %v", err))
+	}
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	for i := range routes {
+		rt := &routes[i]
+		service, name, ok := strings.Cut(strings.TrimPrefix(rt.fullMethod, "/"), "/")
+		if !ok || service != desc.ServiceName {
+			continue
+		}
+		md := sd.Methods().ByName(protoreflect.Name(name))
+		if md == nil {
+			panic(fmt.Sprintf("gateway: unknown method %s", rt.fullMethod))
+		}
+		for j := range desc.Methods {
+			if desc.Methods[j].MethodName == name {
+				srv.mux.Handle(rt.pattern, srv.unaryHandler(rt, md, &desc.Methods[j], impl))
+			}
+		}
+		for j := range desc.Streams {
+			if desc.Streams[j].StreamName == name {
+				srv.mux.Handle(rt.pattern, srv.streamHandler(rt, md, &desc.Streams[j], impl))
+			}
+		}
+		srv.bound = append(srv.bound, boundRoute{route: rt, method: md})
+	}
+	srv.openAPI = nil
+}
+
+func (srv *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if code, err := CheckLocalRequest(r); err != nil {
+		http.Error(w, err.Error(), code)
+		return
+	}
+	srv.mux.ServeHTTP(w, r)
+}
+
+func (srv *Server) unaryHandler(rt *route, md protoreflect.MethodDescriptor, desc *grpc.MethodDesc, impl any) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var decodeErr error
+		dec := func(v any) error {
+			decodeErr = decodeRequest(r, rt, md, v)
+			return decodeErr
+		}
+		res, err := desc.Handler(impl, IncomingContext(r), dec, srv.unary)
+		if decodeErr != nil {
+			writeError(w, status.Error(codes.InvalidArgument, decodeErr.Error()))
+			return
+		}
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		msg, ok := res.(proto.Message)
+		if !ok {
+			writeError(w, status.Errorf(codes.Internal, "unexpected response type %T", res))
+			return
+		}
+		b, err := protojson.Marshal(msg)
+		if err != nil {
+			writeError(w, status.Error(codes.Internal, err.Error()))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(b)
+	})
+}
+
+func (srv *Server) streamHandler(rt *route, md protoreflect.MethodDescriptor, desc *grpc.StreamDesc, impl any) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ss := &eventStream{
+			ctx: IncomingContext(r),
+			w:   w,
+			decode: func(v any) error {
+				if err := decodeRequest(r, rt, md, v); err != nil {
+					return status.Error(codes.InvalidArgument, err.Error())
+				}
+				return nil
+			},
+		}
+		var err error
+		if srv.stream != nil {
+			err = srv.stream(impl, ss, &grpc.StreamServerInfo{
+				FullMethod:     rt.fullMethod,
+				IsClientStream: desc.ClientStreams,
+				IsServerStream: desc.ServerStreams,
+			}, desc.Handler)
+		} else {
+			err = desc.Handler(impl, ss)
+		}
+		if err == nil {
+			return
+		}
+		if !ss.started {
+			writeError(w, err)
+			return
+		}
+		_, _ = fmt.Fprintf(w, "event: error\ndata: %s\n\n", statusJSON(err))
+		_ = http.NewResponseController(w).Flush()
+	})
+}
+
+// decodeRequest reads the request message from the body if the route has
+// one, and from the query parameters and the {id} path wildcard.
+func decodeRequest(r *http.Request, rt *route, md protoreflect.MethodDescriptor, v any) error {
+	msg, ok := v.(proto.Message)
+	if !ok {
+		return fmt.Errorf("unexpected request type %T", v)
+	}
+
+	if rt.body {
+		b, err := io.ReadAll(http.MaxBytesReader(nil, r.Body, maxRequestSize))
+		if err != nil {
+			return err
+		}
+		if len(strings.TrimSpace(string(b))) > 0 {
+			if err := protojson.Unmarshal(b, msg); err != nil {
+				return err
+			}
+		}
+	}
+
+	params := r.URL.Query()
+	if rt.idField != "" {
+		params.Add(rt.idField, r.PathValue("id"))
+	}
+	if len(params) == 0 {
+		return nil
+	}
+	b, err := paramsJSON(md.Input().Fields(), params)
+	if err != nil {
+		return err
+	}
+	fromParams := msg.ProtoReflect().New().Interface()
+	if err := protojson.Unmarshal(b, fromParams); err != nil {
+		return err
+	}
+	proto.Merge(msg, fromParams)
+	return nil
+}
+
+// paramsJSON converts query parameters to the JSON encoding of a message, so
+// that they are parsed like the JSON body. The fields of nested messages are
+// given with dotted names, i.e. selector.all.
+func paramsJSON(fields protoreflect.FieldDescriptors, params url.Values) ([]byte, error) {
+	obj := make(map[string]any, len(params))
+	for key, values := range params {
+		fd, parent, err := paramField(fields, obj, key)
+		if err != nil {
+			return nil, err
+		}
+
+		encoded := make([]any, len(values))
+		for i, v := range values {
+			if fd.Kind() != protoreflect.BoolKind {
+				// protojson also accepts numbers as strings
+				encoded[i] = v
+				continue
+			}
+			switch v {
+			case "", "true", "1": // bare flags, i.e. ?all, are true
+				encoded[i] = true
+			case "false", "0":
+				encoded[i] = false
+			default:
+				return nil, fmt.Errorf("invalid value for query parameter %q: %s", key, v)
+			}
+		}
+		if fd.IsList() {
+			parent[fd.JSONName()] = encoded
+		} else {
+			parent[fd.JSONName()] = encoded[len(encoded)-1]
+		}
+	}
+	return json.Marshal(obj)
+}
+
+// paramField returns the field of a query parameter and the JSON object of
+// the message it belongs to, creating the objects of nested messages in obj.
+func paramField(fields protoreflect.FieldDescriptors, obj map[string]any, key string) (
+	protoreflect.FieldDescriptor, map[string]any, error,
+) {
+	names := strings.Split(key, ".")
+	for i, name := range names {
+		fd := fields.ByJSONName(name)
+		if fd == nil {
+			fd = fields.ByName(protoreflect.Name(name))
+		}
+		if fd == nil || fd.IsMap() {
+			break
+		}
+		if i == len(names)-1 {
+			if !isParamKind(fd) {
+				break
+			}
+			return fd, obj, nil
+		}
+		if fd.IsList() || isParamKind(fd) {
+			break
+		}
+		nested, ok := obj[fd.JSONName()].(map[string]any)
+		if !ok {
+			nested = make(map[string]any)
+			obj[fd.JSONName()] = nested
+		}
+		obj, fields = nested, fd.Message().Fields()
+	}
+	return nil, nil, fmt.Errorf("unknown query parameter %q", key)
+}
+
+// isParamKind reports whether the field can be given as a query parameter:
+// scalars and the well-known types encoded as JSON strings.
+func isParamKind(fd protoreflect.FieldDescriptor) bool {
+	switch fd.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		switch fd.Message().FullName() {
+		case "google.protobuf.Timestamp", "google.protobuf.Duration":
+			return true
+		}
+		return false
+	}
+	return true
+}
+
+// An eventStream sends the messages of a server streaming method as
+// server-sent events.
+type eventStream struct {
+	ctx     context.Context
+	w       http.ResponseWriter
+	decode  func(any) error
+	started bool
+	decoded bool
+}
+
+func (s *eventStream) Context() context.Context     { return s.ctx }
+func (s *eventStream) SetHeader(metadata.MD) error  { return nil }
+func (s *eventStream) SendHeader(metadata.MD) error { return nil }
+func (s *eventStream) SetTrailer(metadata.MD)       {}
+
+// RecvMsg decodes the request message once, as for a server streaming method.
+func (s *eventStream) RecvMsg(m any) error {
+	if s.decoded {
+		return io.EOF
+	}
+	s.decoded = true
+	return s.decode(m)
+}
+
+func (s *eventStream) SendMsg(m any) error {
+	msg, ok := m.(proto.Message)
+	if !ok {
+		return status.Errorf(codes.Internal, "unexpected message type %T", m)
+	}
+	b, err := protojson.Marshal(msg)
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	if !s.started {
+		s.started = true
+		s.w.Header().Set("Content-Type", "text/event-stream")
+		s.w.Header().Set("Cache-Control", "no-cache")
+		s.w.WriteHeader(http.StatusOK)
+	}
+	if _, err := fmt.Fprintf(s.w, "data: %s\n\n", b); err != nil {
+		return err
+	}
+	return http.NewResponseController(s.w).Flush()
+}
+
+type statusBody struct {
+	Code    codes.Code `json:"code"`
+	Message string     `json:"message"`
+}
+
+func statusJSON(err error) []byte {
+	st := status.Convert(err)
+	b, _ := json.Marshal(statusBody{Code: st.Code(), Message: st.Message()})
+	return b
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(httpStatus(status.Code(err)))
+	_, _ = w.Write(statusJSON(err))
+}
+
+// httpStatus maps gRPC status codes to HTTP status codes like grpc-gateway.
+func httpStatus(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.Canceled:
+		return 499
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusInternalServerError
+}
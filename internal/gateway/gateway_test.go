@@ -0,0 +1,217 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/pomerium/cli/proto"
+)
+
+type configServer struct {
+	pb.UnimplementedConfigServer
+	selectors []*pb.Selector
+}
+
+func (s *configServer) List(_ context.Context, sel *pb.Selector) (*pb.Records, error) {
+	s.selectors = append(s.selectors, sel)
+	return &pb.Records{Records: []*pb.Record{{Id: proto.String("r1"), Tags: []string{"a"}}}}, nil
+}
+
+func (s *configServer) Upsert(_ context.Context, r *pb.Record) (*pb.Record, error) {
+	if r.GetConn().GetRemoteAddr() == "" {
+		return nil, status.Error(codes.InvalidArgument, "remote address is required")
+	}
+	return r, nil
+}
+
+type listenerServer struct {
+	pb.UnimplementedListenerServer
+}
+
+func (listenerServer) StatusUpdates(req *pb.StatusUpdatesRequest, stream grpc.ServerStreamingServer[pb.ConnectionStatusUpdate]) error {
+	for _, st := range []pb.ConnectionStatusUpdate_ConnectionStatus{
+		pb.ConnectionStatusUpdate_CONNECTION_STATUS_LISTENING,
+		pb.ConnectionStatusUpdate_CONNECTION_STATUS_CONNECTED,
+	} {
+		if err := stream.Send(&pb.ConnectionStatusUpdate{Id: req.GetConnectionId(), Status: st}); err != nil {
+			return err
+		}
+	}
+	return status.Error(codes.Unavailable, "shutting down")
+}
+
+func newTestServer(t *testing.T) (*Server, *configServer) {
+	t.Helper()
+
+	auth := func(ctx context.Context) error {
+		md, _ := metadata.FromIncomingContext(ctx)
+		if v := md.Get("authorization"); len(v) != 1 || v[0] != "Bearer token" {
+			return status.Error(codes.Unauthenticated, "invalid token")
+		}
+		return nil
+	}
+	srv := NewServer(
+		[]grpc.UnaryServerInterceptor{func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			if err := auth(ctx); err != nil {
+				return nil, err
+			}
+			return handler(ctx, req)
+		}},
+		[]grpc.StreamServerInterceptor{func(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			if err := auth(ss.Context()); err != nil {
+				return err
+			}
+			return handler(srv, ss)
+		}},
+	)
+	cfg := new(configServer)
+	pb.RegisterConfigServer(srv, cfg)
+	pb.RegisterListenerServer(srv, listenerServer{})
+	return srv, cfg
+}
+
+func serve(srv http.Handler, method, target, body string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(method, "http://127.0.0.1:8900"+target, strings.NewReader(body))
+	r.Header.Set("Authorization", "Bearer token")
+	if body != "" {
+		r.Header.Set("Content-Type", "application/json")
+	}
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, r)
+	return w
+}
+
+func TestUnary(t *testing.T) {
+	t.Parallel()
+
+	srv, cfg := newTestServer(t)
+
+	w := serve(srv, http.MethodGet, "/v1/records?tags=a&tags=b&notIds=x&all", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"records":[{"id":"r1","tags":["a"]}]}`, w.Body.String())
+	w = serve(srv, http.MethodGet, "/v1/records/r1", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	if assert.Len(t, cfg.selectors, 2) {
+		assert.True(t, proto.Equal(&pb.Selector{All: true, Tags: []string{"a", "b"}, NotIds: []string{"x"}}, cfg.selectors[0]),
+			"query parameters should set the fields: %v", cfg.selectors[0])
+		assert.True(t, proto.Equal(&pb.Selector{Ids: []string{"r1"}}, cfg.selectors[1]),
+			"path wildcards should set the fields: %v", cfg.selectors[1])
+	}
+
+	w = serve(srv, http.MethodPut, "/v1/records/r2", `{"tags":["t"],"conn":{"remoteAddr":"db:5432"}}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"id":"r2","tags":["t"],"conn":{"remoteAddr":"db:5432"}}`, w.Body.String())
+
+	for _, tc := range []struct {
+		name, method, target, body string
+		code                       int
+	}{
+		{"method error", http.MethodPost, "/v1/records", `{"tags":["t"]}`, http.StatusBadRequest},
+		{"invalid body", http.MethodPost, "/v1/records", `{"unknown":1}`, http.StatusBadRequest},
+		{"unknown query parameter", http.MethodGet, "/v1/records?unknown=1", "", http.StatusBadRequest},
+		{"invalid bool", http.MethodGet, "/v1/records?all=maybe", "", http.StatusBadRequest},
+		{"unimplemented", http.MethodGet, "/v1/tags", "", http.StatusNotImplemented},
+		{"no route", http.MethodGet, "/v1/unknown", "", http.StatusNotFound},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			w := serve(srv, tc.method, tc.target, tc.body)
+			assert.Equal(t, tc.code, w.Code)
+		})
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "http://127.0.0.1:8900/v1/records", nil)
+	w = httptest.NewRecorder()
+	srv.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	var st map[string]any
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &st))
+	assert.Equal(t, map[string]any{"code": float64(codes.Unauthenticated), "message": "invalid token"}, st)
+
+	r = httptest.NewRequest(http.MethodPost, "http://127.0.0.1:8900/v1/records", strings.NewReader(`{}`))
+	r.Header.Set("Content-Type", "text/plain")
+	w = httptest.NewRecorder()
+	srv.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusUnsupportedMediaType, w.Code)
+}
+
+func TestStream(t *testing.T) {
+	t.Parallel()
+
+	srv, _ := newTestServer(t)
+	w := serve(srv, http.MethodGet, "/v1/listeners/updates?connectionId=r1", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/event-stream", w.Header().Get("Content-Type"))
+	events := strings.Split(strings.TrimSuffix(w.Body.String(), "\n\n"), "\n\n")
+	require.Len(t, events, 3)
+	for i, expected := range []string{
+		`{"id":"r1","status":"CONNECTION_STATUS_LISTENING"}`,
+		`{"id":"r1","status":"CONNECTION_STATUS_CONNECTED"}`,
+	} {
+		data, ok := strings.CutPrefix(events[i], "data: ")
+		require.True(t, ok, events[i])
+		assert.JSONEq(t, expected, data)
+	}
+	assert.Equal(t, "event: error\n"+`data: {"code":14,"message":"shutting down"}`, events[2])
+
+	r := httptest.NewRequest(http.MethodGet, "http://127.0.0.1:8900/v1/listeners/updates", nil)
+	w = httptest.NewRecorder()
+	srv.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusUnauthorized, w.Code, "errors before the first event should set the status")
+}
+
+func TestOpenAPI(t *testing.T) {
+	t.Parallel()
+
+	srv, _ := newTestServer(t)
+	r := httptest.NewRequest(http.MethodGet, "http://localhost:8900"+OpenAPIPath, nil)
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, r)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var doc struct {
+		Paths      map[string]map[string]any `json:"paths"`
+		Components struct {
+			Schemas map[string]any `json:"schemas"`
+		} `json:"components"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &doc))
+	assert.Len(t, doc.Paths, 15)
+	assert.Contains(t, doc.Paths["/v1/records/{id}"], "put")
+	assert.Contains(t, doc.Paths["/v1/listeners/updates"], "get")
+	for _, name := range []string{
+		"pomerium.cli.Record", "pomerium.cli.Connection", "pomerium.cli.ConnectionStatusUpdate", "Status",
+	} {
+		assert.Contains(t, doc.Components.Schemas, name)
+	}
+}
+
+func TestParamsJSON(t *testing.T) {
+	t.Parallel()
+
+	fields := new(pb.StreamStatsRequest).ProtoReflect().Descriptor().Fields()
+	b, err := paramsJSON(fields, url.Values{
+		"selector.all":  {""},
+		"selector.tags": {"a", "b"},
+		"interval":      {"2s"},
+	})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"selector":{"all":true,"tags":["a","b"]},"interval":"2s"}`, string(b))
+
+	for _, key := range []string{"selector", "interval.seconds", "selector.unknown", "unknown.all"} {
+		_, err := paramsJSON(fields, url.Values{key: {"1"}})
+		assert.ErrorContains(t, err, "unknown query parameter", key)
+	}
+}
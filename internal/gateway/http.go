@@ -0,0 +1,100 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"net"
+	"net/http"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// CheckLocalRequest returns an HTTP status code and error unless the request
+// may have been sent by a local client other than a web page: requests with a
+// body must have the application/json content type, which browsers send a
+// CORS preflight for, and the host must be an IP address or localhost, which
+// defeats DNS rebinding.
+func CheckLocalRequest(r *http.Request) (int, error) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead && r.Method != http.MethodDelete {
+		if mt, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mt != "application/json" {
+			return http.StatusUnsupportedMediaType, fmt.Errorf("content type must be application/json")
+		}
+	}
+	if !isLocalHost(r.Host) {
+		return http.StatusForbidden, fmt.Errorf("invalid host")
+	}
+	return http.StatusOK, nil
+}
+
+// isLocalHost reports whether the host header names an IP address or
+// localhost, rather than a domain that may resolve to the loopback interface.
+func isLocalHost(hostport string) bool {
+	host, _, err := net.SplitHostPort(hostport)
+	if err != nil {
+		host = hostport
+	}
+	host = strings.TrimSuffix(strings.TrimPrefix(host, "["), "]")
+	return strings.EqualFold(host, "localhost") || net.ParseIP(host) != nil
+}
+
+// IncomingContext passes the HTTP headers of the request, i.e. the
+// authorization, to the interceptors as gRPC metadata, and the client
+// address and TLS state as the gRPC peer.
+func IncomingContext(r *http.Request) context.Context {
+	md := make(metadata.MD, len(r.Header))
+	for k, v := range r.Header {
+		md.Append(k, v...)
+	}
+	p := &peer.Peer{Addr: remoteAddr(r.RemoteAddr)}
+	if r.TLS != nil {
+		p.AuthInfo = credentials.TLSInfo{State: *r.TLS}
+	}
+	return peer.NewContext(metadata.NewIncomingContext(r.Context(), md), p)
+}
+
+// remoteAddr is the address of an HTTP client
+type remoteAddr string
+
+func (a remoteAddr) Network() string { return "tcp" }
+func (a remoteAddr) String() string  { return string(a) }
+
+// ChainUnaryInterceptors returns an interceptor calling the interceptors in
+// order, or nil if there are none.
+func ChainUnaryInterceptors(interceptors ...grpc.UnaryServerInterceptor) grpc.UnaryServerInterceptor {
+	if len(interceptors) == 0 {
+		return nil
+	}
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		next := handler
+		for i := len(interceptors) - 1; i > 0; i-- {
+			interceptor, h := interceptors[i], next
+			next = func(ctx context.Context, req any) (any, error) {
+				return interceptor(ctx, req, info, h)
+			}
+		}
+		return interceptors[0](ctx, req, info, next)
+	}
+}
+
+// ChainStreamInterceptors returns an interceptor calling the interceptors in
+// order, or nil if there are none.
+func ChainStreamInterceptors(interceptors ...grpc.StreamServerInterceptor) grpc.StreamServerInterceptor {
+	if len(interceptors) == 0 {
+		return nil
+	}
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		next := handler
+		for i := len(interceptors) - 1; i > 0; i-- {
+			interceptor, h := interceptors[i], next
+			next = func(srv any, ss grpc.ServerStream) error {
+				return interceptor(srv, ss, info, h)
+			}
+		}
+		return interceptors[0](srv, ss, info, next)
+	}
+}
@@ -0,0 +1,213 @@
+package gateway
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+
+	"github.com/pomerium/cli/version"
+)
+
+func serviceDescriptor(name string) (protoreflect.ServiceDescriptor, error) {
+	d, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(name))
+	if err != nil {
+		return nil, fmt.Errorf("service %s: %w", name, err)
+	}
+	sd, ok := d.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a service", name)
+	}
+	return sd, nil
+}
+
+func (srv *Server) serveOpenAPI(w http.ResponseWriter, _ *http.Request) {
+	srv.mu.Lock()
+	if srv.openAPI == nil {
+		srv.openAPI, _ = json.MarshalIndent(openAPIDocument(srv.bound), "", "  ")
+	}
+	b := srv.openAPI
+	srv.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(b)
+}
+
+// openAPIDocument describes the routes in an OpenAPI 3 document, with the
+// schemas of the messages derived from their descriptors.
+func openAPIDocument(bound []boundRoute) map[string]any {
+	schemas := map[string]any{
+		"Status": map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"code":    map[string]any{"type": "integer", "description": "gRPC status code"},
+				"message": map[string]any{"type": "string"},
+			},
+		},
+	}
+	paths := make(map[string]map[string]any)
+	for _, rt := range bound {
+		method, path, _ := strings.Cut(rt.pattern, " ")
+		op := map[string]any{
+			"operationId": rt.operationID,
+			"summary":     rt.summary,
+			"tags":        []string{string(rt.method.Parent().Name())},
+		}
+
+		var params []any
+		if rt.idField != "" {
+			params = append(params, map[string]any{
+				"name": "id", "in": "path", "required": true,
+				"schema": map[string]any{"type": "string"},
+			})
+		}
+		input := rt.method.Input()
+		if rt.body {
+			op["requestBody"] = map[string]any{
+				"required": true,
+				"content": map[string]any{
+					"application/json": map[string]any{"schema": messageRef(input, schemas)},
+				},
+			}
+		} else {
+			params = append(params, queryParams(input, "", rt.idField, schemas)...)
+		}
+		if params != nil {
+			op["parameters"] = params
+		}
+
+		output := rt.method.Output()
+		ok := map[string]any{
+			"description": string(output.Name()),
+			"content": map[string]any{
+				"application/json": map[string]any{"schema": messageRef(output, schemas)},
+			},
+		}
+		if rt.method.IsStreamingServer() {
+			ok = map[string]any{
+				"description": "server-sent events, each with a " + string(output.Name()) +
+					" as data, or a Status with the error event",
+				"content": map[string]any{
+					"text/event-stream": map[string]any{"schema": map[string]any{"type": "string"}},
+				},
+			}
+			messageRef(output, schemas)
+		}
+		op["responses"] = map[string]any{
+			"200": ok,
+			"default": map[string]any{
+				"description": "error",
+				"content": map[string]any{
+					"application/json": map[string]any{"schema": map[string]any{"$ref": "#/components/schemas/Status"}},
+				},
+			},
+		}
+
+		if paths[path] == nil {
+			paths[path] = make(map[string]any)
+		}
+		paths[path][strings.ToLower(method)] = op
+	}
+
+	return map[string]any{
+		"openapi": "3.0.3",
+		"info": map[string]any{
+			"title":   "Pomerium CLI API",
+			"version": version.FullVersion(),
+		},
+		"paths":      paths,
+		"components": map[string]any{"schemas": schemas},
+	}
+}
+
+// queryParams describes the query parameters of the fields of a message,
+// including those of nested messages one level deep, except the field set by
+// the path.
+func queryParams(md protoreflect.MessageDescriptor, prefix, idField string, schemas map[string]any) []any {
+	var params []any
+	fields := md.Fields()
+	for i := range fields.Len() {
+		fd := fields.Get(i)
+		switch {
+		case fd.IsMap() || (prefix == "" && string(fd.Name()) == idField):
+		case isParamKind(fd):
+			params = append(params, map[string]any{
+				"name": prefix + fd.JSONName(), "in": "query",
+				"schema": fieldSchema(fd, schemas),
+			})
+		case !fd.IsList() && prefix == "":
+			params = append(params, queryParams(fd.Message(), fd.JSONName()+".", "", schemas)...)
+		}
+	}
+	return params
+}
+
+// messageRef returns a reference to the schema of the message, adding it and
+// the schemas of its fields to schemas.
+func messageRef(md protoreflect.MessageDescriptor, schemas map[string]any) map[string]any {
+	name := string(md.FullName())
+	ref := map[string]any{"$ref": "#/components/schemas/" + name}
+	if _, ok := schemas[name]; ok {
+		return ref
+	}
+	properties := make(map[string]any)
+	schemas[name] = map[string]any{"type": "object", "properties": properties}
+	fields := md.Fields()
+	for i := range fields.Len() {
+		fd := fields.Get(i)
+		properties[fd.JSONName()] = fieldSchema(fd, schemas)
+	}
+	return ref
+}
+
+func fieldSchema(fd protoreflect.FieldDescriptor, schemas map[string]any) map[string]any {
+	switch {
+	case fd.IsMap():
+		return map[string]any{"type": "object", "additionalProperties": kindSchema(fd.MapValue(), schemas)}
+	case fd.IsList():
+		return map[string]any{"type": "array", "items": kindSchema(fd, schemas)}
+	}
+	return kindSchema(fd, schemas)
+}
+
+// kindSchema returns the schema of a single value of the field in the
+// protobuf JSON encoding.
+func kindSchema(fd protoreflect.FieldDescriptor, schemas map[string]any) map[string]any {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return map[string]any{"type": "boolean"}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return map[string]any{"type": "integer", "format": "int32"}
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return map[string]any{"type": "integer", "format": "int64", "minimum": 0}
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return map[string]any{"type": "string", "format": "int64"}
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return map[string]any{"type": "string", "format": "uint64"}
+	case protoreflect.FloatKind:
+		return map[string]any{"type": "number", "format": "float"}
+	case protoreflect.DoubleKind:
+		return map[string]any{"type": "number", "format": "double"}
+	case protoreflect.BytesKind:
+		return map[string]any{"type": "string", "format": "byte"}
+	case protoreflect.EnumKind:
+		values := fd.Enum().Values()
+		names := make([]string, values.Len())
+		for i := range values.Len() {
+			names[i] = string(values.Get(i).Name())
+		}
+		return map[string]any{"type": "string", "enum": names}
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		switch fd.Message().FullName() {
+		case "google.protobuf.Timestamp":
+			return map[string]any{"type": "string", "format": "date-time"}
+		case "google.protobuf.Duration":
+			return map[string]any{"type": "string", "example": "1.5s"}
+		}
+		return messageRef(fd.Message(), schemas)
+	}
+	return map[string]any{"type": "string"}
+}
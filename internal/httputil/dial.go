@@ -0,0 +1,182 @@
+package httputil
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+)
+
+// An IPPreference selects the address family that is tried first when a host
+// has both IPv4 and IPv6 addresses.
+type IPPreference int
+
+const (
+	// PreferAny tries the address family resolved first.
+	PreferAny IPPreference = iota
+	// PreferIPv4 tries IPv4 addresses first.
+	PreferIPv4
+	// PreferIPv6 tries IPv6 addresses first.
+	PreferIPv6
+)
+
+// Network returns the network restricted to the preferred address family,
+// i.e. udp6 for udp when IPv6 is preferred.
+func (pref IPPreference) Network(network string) string {
+	switch pref {
+	case PreferIPv4:
+		return network + "4"
+	case PreferIPv6:
+		return network + "6"
+	}
+	return network
+}
+
+// connectionAttemptDelay is the time to wait for a connection attempt before
+// starting the next one in parallel, see RFC 8305 section 5
+const connectionAttemptDelay = 250 * time.Millisecond
+
+// A Dialer dials TCP connections using Happy Eyeballs (RFC 8305): all
+// addresses of the host are tried, alternating between the address families,
+// with a new attempt started whenever the previous one fails or takes longer
+// than 250ms. The first connection established is used.
+type Dialer struct {
+	// Prefer selects the address family tried first.
+	Prefer IPPreference
+
+	lookupIPAddr func(ctx context.Context, host string) ([]net.IPAddr, error)
+}
+
+// DialContext connects to the address on the named network.
+func (d *Dialer) DialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	var nd net.Dialer
+	switch network {
+	case "tcp", "tcp4", "tcp6":
+	default:
+		return nd.DialContext(ctx, network, addr)
+	}
+
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, err
+	}
+	if net.ParseIP(host) != nil {
+		return nd.DialContext(ctx, network, addr)
+	}
+
+	lookupIPAddr := net.DefaultResolver.LookupIPAddr
+	if d.lookupIPAddr != nil {
+		lookupIPAddr = d.lookupIPAddr
+	}
+	ips, err := lookupIPAddr(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	ips = sortAddrs(filterAddrs(ips, network), d.Prefer)
+	if len(ips) == 0 {
+		return nil, &net.DNSError{Err: "no suitable address found", Name: host, IsNotFound: true}
+	}
+
+	return dialParallel(ctx, network, ips, port)
+}
+
+type dialResult struct {
+	conn net.Conn
+	err  error
+}
+
+// dialParallel races connection attempts to the addresses in order, starting
+// each one when the previous one fails or the attempt delay elapses.
+func dialParallel(ctx context.Context, network string, ips []net.IPAddr, port string) (net.Conn, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make(chan dialResult)
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	var firstErr error
+	started, pending := 0, 0
+	for {
+		if started == len(ips) && pending == 0 {
+			return nil, firstErr
+		}
+
+		select {
+		case <-timer.C:
+			if started == len(ips) {
+				continue
+			}
+			ip := ips[started]
+			started++
+			pending++
+			go func() {
+				var nd net.Dialer
+				conn, err := nd.DialContext(ctx, network, net.JoinHostPort(ip.String(), port))
+				select {
+				case results <- dialResult{conn, err}:
+				case <-ctx.Done():
+					if conn != nil {
+						_ = conn.Close()
+					}
+				}
+			}()
+			timer.Reset(connectionAttemptDelay)
+		case res := <-results:
+			pending--
+			if res.err == nil {
+				return res.conn, nil
+			}
+			if firstErr == nil {
+				firstErr = res.err
+			}
+			// start the next attempt right away
+			timer.Reset(0)
+		case <-ctx.Done():
+			return nil, fmt.Errorf("dial %s: %w", network, context.Cause(ctx))
+		}
+	}
+}
+
+// filterAddrs removes the addresses that cannot be dialed on the network.
+func filterAddrs(ips []net.IPAddr, network string) []net.IPAddr {
+	filtered := make([]net.IPAddr, 0, len(ips))
+	for _, ip := range ips {
+		switch {
+		case network == "tcp4" && ip.IP.To4() == nil:
+		case network == "tcp6" && ip.IP.To4() != nil:
+		default:
+			filtered = append(filtered, ip)
+		}
+	}
+	return filtered
+}
+
+// sortAddrs orders the addresses for connection attempts, alternating between
+// the preferred address family, or the one resolved first, and the other one.
+func sortAddrs(ips []net.IPAddr, prefer IPPreference) []net.IPAddr {
+	var v4, v6 []net.IPAddr
+	for _, ip := range ips {
+		if ip.IP.To4() != nil {
+			v4 = append(v4, ip)
+		} else {
+			v6 = append(v6, ip)
+		}
+	}
+
+	first, second := v4, v6
+	if prefer == PreferIPv6 || (prefer == PreferAny && len(ips) > 0 && ips[0].IP.To4() == nil) {
+		first, second = v6, v4
+	}
+
+	sorted := make([]net.IPAddr, 0, len(ips))
+	for i := 0; i < len(first) || i < len(second); i++ {
+		if i < len(first) {
+			sorted = append(sorted, first[i])
+		}
+		if i < len(second) {
+			sorted = append(sorted, second[i])
+		}
+	}
+	return sorted
+}
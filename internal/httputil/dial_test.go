@@ -0,0 +1,69 @@
+package httputil
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSortAddrs(t *testing.T) {
+	t.Parallel()
+
+	v4a := net.IPAddr{IP: net.ParseIP("192.0.2.1")}
+	v4b := net.IPAddr{IP: net.ParseIP("192.0.2.2")}
+	v6a := net.IPAddr{IP: net.ParseIP("2001:db8::1")}
+	v6b := net.IPAddr{IP: net.ParseIP("2001:db8::2")}
+
+	for _, tc := range []struct {
+		name   string
+		ips    []net.IPAddr
+		prefer IPPreference
+		expect []net.IPAddr
+	}{
+		{"resolved order v6", []net.IPAddr{v6a, v6b, v4a, v4b}, PreferAny, []net.IPAddr{v6a, v4a, v6b, v4b}},
+		{"resolved order v4", []net.IPAddr{v4a, v6a, v6b}, PreferAny, []net.IPAddr{v4a, v6a, v6b}},
+		{"prefer v4", []net.IPAddr{v6a, v6b, v4a, v4b}, PreferIPv4, []net.IPAddr{v4a, v6a, v4b, v6b}},
+		{"prefer v6", []net.IPAddr{v4a, v4b, v6a}, PreferIPv6, []net.IPAddr{v6a, v4a, v4b}},
+		{"prefer missing family", []net.IPAddr{v4a, v4b}, PreferIPv6, []net.IPAddr{v4a, v4b}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expect, sortAddrs(tc.ips, tc.prefer))
+		})
+	}
+}
+
+func TestDialer(t *testing.T) {
+	t.Parallel()
+
+	ctx, clearTimeout := context.WithTimeout(context.Background(), 10*time.Second)
+	t.Cleanup(clearTimeout)
+
+	li, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = li.Close() })
+	_, port, err := net.SplitHostPort(li.Addr().String())
+	require.NoError(t, err)
+
+	d := &Dialer{
+		lookupIPAddr: func(_ context.Context, host string) ([]net.IPAddr, error) {
+			assert.Equal(t, "example.com", host)
+			// the first address is not reachable
+			return []net.IPAddr{{IP: net.ParseIP("192.0.2.1")}, {IP: net.ParseIP("127.0.0.1")}}, nil
+		},
+	}
+	start := time.Now()
+	conn, err := d.DialContext(ctx, "tcp", net.JoinHostPort("example.com", port))
+	require.NoError(t, err)
+	_ = conn.Close()
+	assert.Less(t, time.Since(start), 2*time.Second)
+	assert.Equal(t, li.Addr().String(), conn.RemoteAddr().String())
+
+	_, err = d.DialContext(ctx, "tcp6", net.JoinHostPort("example.com", port))
+	assert.ErrorContains(t, err, "no suitable address")
+}
@@ -6,21 +6,43 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
+	"net/url"
 	"time"
 )
 
 // ErrUnauthenticated indicates the user needs to authenticate.
 var ErrUnauthenticated = errors.New("unauthenticated")
 
-// Fetch fetches the http request.
-func Fetch(ctx context.Context, tlsConfig *tls.Config, req *http.Request) ([]byte, error) {
+// A StatusError is returned for unexpected HTTP response status codes.
+type StatusError struct {
+	StatusCode int
+	Status     string
+}
+
+func (err *StatusError) Error() string {
+	return "unexpected status code: " + err.Status
+}
+
+// A DialContextFunc connects to the address on the named network.
+type DialContextFunc func(ctx context.Context, network, addr string) (net.Conn, error)
+
+// Fetch fetches the http request, via the outbound proxy if one is given.
+// If dial is not nil, it is used to connect instead, and the outbound proxy
+// is ignored.
+func Fetch(ctx context.Context, tlsConfig *tls.Config, proxyURL *url.URL, dial DialContextFunc, req *http.Request) ([]byte, error) {
 	ctx, clearTimeout := context.WithTimeout(ctx, 10*time.Second)
 	defer clearTimeout()
 	req = req.WithContext(ctx)
 
 	transport := http.DefaultTransport.(*http.Transport).Clone()
 	transport.TLSClientConfig = tlsConfig
+	transport.Proxy = ProxyFunc(proxyURL)
+	if dial != nil {
+		transport.Proxy = nil
+		transport.DialContext = dial
+	}
 	hc := &http.Client{
 		Transport: transport,
 	}
@@ -41,7 +63,7 @@ func Fetch(ctx context.Context, tlsConfig *tls.Config, req *http.Request) ([]byt
 	}
 
 	if res.StatusCode/100 != 2 {
-		return nil, fmt.Errorf("unexpected status code: %s", res.Status)
+		return nil, &StatusError{StatusCode: res.StatusCode, Status: res.Status}
 	}
 
 	return io.ReadAll(res.Body)
@@ -0,0 +1,9 @@
+//go:build !windows
+
+package httputil
+
+const negotiateSupported = false
+
+func newNegotiator(_ string) (negotiator, error) {
+	return nil, errNegotiateUnsupported
+}
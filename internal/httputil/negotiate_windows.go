@@ -0,0 +1,135 @@
+//go:build windows
+
+package httputil
+
+import (
+	"bytes"
+	"fmt"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+const negotiateSupported = true
+
+// sspi.h constants
+const (
+	secpkgCredOutbound   = 0x2        // SECPKG_CRED_OUTBOUND
+	securityNativeDRep   = 0x10       // SECURITY_NATIVE_DREP
+	secbufferVersion     = 0          // SECBUFFER_VERSION
+	secbufferToken       = 2          // SECBUFFER_TOKEN
+	iscReqAllocateMemory = 0x100      // ISC_REQ_ALLOCATE_MEMORY
+	iscReqConnection     = 0x800      // ISC_REQ_CONNECTION
+	secEOK               = 0          // SEC_E_OK
+	secIContinueNeeded   = 0x00090312 // SEC_I_CONTINUE_NEEDED
+)
+
+var (
+	secur32                        = windows.NewLazySystemDLL("secur32.dll")
+	procAcquireCredentialsHandleW  = secur32.NewProc("AcquireCredentialsHandleW")
+	procInitializeSecurityContextW = secur32.NewProc("InitializeSecurityContextW")
+	procDeleteSecurityContext      = secur32.NewProc("DeleteSecurityContext")
+	procFreeCredentialsHandle      = secur32.NewProc("FreeCredentialsHandle")
+	procFreeContextBuffer          = secur32.NewProc("FreeContextBuffer")
+)
+
+// sspi.h structs
+type secHandle struct {
+	lower, upper uintptr
+}
+type secBuffer struct {
+	size       uint32
+	bufferType uint32
+	buffer     *byte
+}
+type secBufferDesc struct {
+	version uint32
+	count   uint32
+	buffers *secBuffer
+}
+
+// sspiNegotiator authenticates as the logged on user via SSPI.
+type sspiNegotiator struct {
+	target     *uint16
+	credential secHandle
+	context    secHandle
+	hasContext bool
+}
+
+func newNegotiator(host string) (negotiator, error) {
+	target, err := windows.UTF16PtrFromString("HTTP/" + host)
+	if err != nil {
+		return nil, err
+	}
+	pkg, err := windows.UTF16PtrFromString("Negotiate")
+	if err != nil {
+		return nil, err
+	}
+
+	n := &sspiNegotiator{target: target}
+	var expiry windows.Filetime
+	ret, _, _ := procAcquireCredentialsHandleW.Call(
+		0,
+		uintptr(unsafe.Pointer(pkg)),
+		secpkgCredOutbound,
+		0, 0, 0, 0,
+		uintptr(unsafe.Pointer(&n.credential)),
+		uintptr(unsafe.Pointer(&expiry)),
+	)
+	if ret != secEOK {
+		return nil, fmt.Errorf("failed to acquire credentials: %w", windows.Errno(ret))
+	}
+	return n, nil
+}
+
+func (n *sspiNegotiator) Step(challenge []byte) ([]byte, error) {
+	var input *secBufferDesc
+	if len(challenge) > 0 {
+		input = &secBufferDesc{
+			version: secbufferVersion,
+			count:   1,
+			buffers: &secBuffer{size: uint32(len(challenge)), bufferType: secbufferToken, buffer: &challenge[0]},
+		}
+	}
+	outputBuffer := secBuffer{bufferType: secbufferToken}
+	output := secBufferDesc{version: secbufferVersion, count: 1, buffers: &outputBuffer}
+
+	var context *secHandle
+	if n.hasContext {
+		context = &n.context
+	}
+	var attributes uint32
+	var expiry windows.Filetime
+	ret, _, _ := procInitializeSecurityContextW.Call(
+		uintptr(unsafe.Pointer(&n.credential)),
+		uintptr(unsafe.Pointer(context)),
+		uintptr(unsafe.Pointer(n.target)),
+		iscReqAllocateMemory|iscReqConnection,
+		0,
+		securityNativeDRep,
+		uintptr(unsafe.Pointer(input)),
+		0,
+		uintptr(unsafe.Pointer(&n.context)),
+		uintptr(unsafe.Pointer(&output)),
+		uintptr(unsafe.Pointer(&attributes)),
+		uintptr(unsafe.Pointer(&expiry)),
+	)
+	if ret != secEOK && ret != secIContinueNeeded {
+		return nil, fmt.Errorf("failed to initialize security context: %w", windows.Errno(ret))
+	}
+	n.hasContext = true
+
+	if outputBuffer.buffer == nil {
+		return nil, nil
+	}
+	defer func() { _, _, _ = procFreeContextBuffer.Call(uintptr(unsafe.Pointer(outputBuffer.buffer))) }()
+	return bytes.Clone(unsafe.Slice(outputBuffer.buffer, outputBuffer.size)), nil
+}
+
+func (n *sspiNegotiator) Close() error {
+	if n.hasContext {
+		_, _, _ = procDeleteSecurityContext.Call(uintptr(unsafe.Pointer(&n.context)))
+	}
+	_, _, _ = procFreeCredentialsHandle.Call(uintptr(unsafe.Pointer(&n.credential)))
+	return nil
+}
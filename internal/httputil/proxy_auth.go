@@ -0,0 +1,135 @@
+package httputil
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// maxProxyAuthRounds limits the number of challenges answered when
+// authenticating to an outbound proxy
+const maxProxyAuthRounds = 8
+
+// errNegotiateUnsupported indicates Negotiate authentication is not available
+// on this platform
+var errNegotiateUnsupported = errors.New("negotiate authentication is not supported on this platform")
+
+// A negotiator produces the tokens of a SPNEGO (Negotiate) authentication.
+type negotiator interface {
+	// Step returns the next token to send, given the challenge of the proxy.
+	// The challenge is nil for the first token.
+	Step(challenge []byte) ([]byte, error)
+	Close() error
+}
+
+// proxyAuthenticator keeps track of the Proxy-Authorization header sent to an
+// outbound proxy. It starts with Basic authentication if the proxy URL has
+// credentials and switches to Negotiate when the proxy asks for it.
+type proxyAuthenticator struct {
+	proxyURL      *url.URL
+	authorization string
+	rounds        int
+
+	newNegotiator func(host string) (negotiator, error)
+	negotiator    negotiator
+}
+
+func newProxyAuthenticator(proxyURL *url.URL) *proxyAuthenticator {
+	return &proxyAuthenticator{
+		proxyURL:      proxyURL,
+		authorization: proxyAuthorization(proxyURL),
+		newNegotiator: newNegotiator,
+	}
+}
+
+// next prepares the authorization to retry with after the proxy responded
+// with the given 407 headers.
+func (a *proxyAuthenticator) next(hdr http.Header) error {
+	a.rounds++
+	challenge, ok := negotiateChallenge(hdr)
+	switch {
+	case !ok:
+		return a.authRequiredError(hdr)
+	case a.rounds > maxProxyAuthRounds:
+		return fmt.Errorf("%w: negotiate: too many challenges", ErrProxyAuthRequired)
+	case a.negotiator == nil:
+		n, err := a.newNegotiator(a.proxyURL.Hostname())
+		if errors.Is(err, errNegotiateUnsupported) {
+			return a.authRequiredError(hdr)
+		} else if err != nil {
+			return fmt.Errorf("%w: negotiate: %w", ErrProxyAuthRequired, err)
+		}
+		a.negotiator = n
+	case challenge == nil:
+		// the proxy did not accept the negotiated credentials
+		return fmt.Errorf("%w: negotiate: credentials rejected", ErrProxyAuthRequired)
+	}
+
+	token, err := a.negotiator.Step(challenge)
+	if err != nil {
+		return fmt.Errorf("%w: negotiate: %w", ErrProxyAuthRequired, err)
+	}
+	a.authorization = "Negotiate " + base64.StdEncoding.EncodeToString(token)
+	return nil
+}
+
+func (a *proxyAuthenticator) authRequiredError(hdr http.Header) error {
+	supported := "Basic"
+	if negotiateSupported {
+		supported += ", Negotiate"
+	}
+	return fmt.Errorf("%w (supported: %s, offered: %s)",
+		ErrProxyAuthRequired, supported, strings.Join(proxyAuthSchemes(hdr), ", "))
+}
+
+func (a *proxyAuthenticator) close() {
+	if a.negotiator != nil {
+		_ = a.negotiator.Close()
+	}
+}
+
+// negotiateChallenge returns the token of the Negotiate challenge, if the
+// proxy offers Negotiate authentication. The token is nil for the initial
+// challenge.
+func negotiateChallenge(hdr http.Header) ([]byte, bool) {
+	for _, v := range hdr.Values("Proxy-Authenticate") {
+		scheme, param, _ := strings.Cut(v, " ")
+		if !strings.EqualFold(scheme, "Negotiate") {
+			continue
+		}
+		param = strings.TrimSpace(param)
+		if param == "" {
+			return nil, true
+		}
+		token, err := base64.StdEncoding.DecodeString(param)
+		if err != nil {
+			return nil, false
+		}
+		return token, true
+	}
+	return nil, false
+}
+
+func proxyAuthorization(u *url.URL) string {
+	if u.User == nil {
+		return ""
+	}
+	password, _ := u.User.Password()
+	return "Basic " + base64.StdEncoding.EncodeToString([]byte(u.User.Username()+":"+password))
+}
+
+func proxyAuthSchemes(hdr http.Header) []string {
+	var schemes []string
+	for _, v := range hdr.Values("Proxy-Authenticate") {
+		if scheme, _, _ := strings.Cut(v, " "); scheme != "" {
+			schemes = append(schemes, scheme)
+		}
+	}
+	if len(schemes) == 0 {
+		return []string{"none"}
+	}
+	return schemes
+}
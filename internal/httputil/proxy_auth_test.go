@@ -0,0 +1,107 @@
+package httputil
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testNegotiator struct{}
+
+func (testNegotiator) Step(challenge []byte) ([]byte, error) {
+	switch string(challenge) {
+	case "":
+		return []byte("first"), nil
+	case "challenge":
+		return []byte("second"), nil
+	}
+	return nil, errors.New("unexpected challenge")
+}
+
+func (testNegotiator) Close() error { return nil }
+
+func TestProxyNegotiate(t *testing.T) {
+	t.Parallel()
+
+	ctx, clearTimeout := context.WithTimeout(context.Background(), 10*time.Second)
+	t.Cleanup(clearTimeout)
+
+	proxy, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = proxy.Close() })
+	go func() {
+		for {
+			conn, err := proxy.Accept()
+			if err != nil {
+				return
+			}
+			go serveTestNegotiateProxy(conn)
+		}
+	}()
+
+	d := &ProxyDialer{ProxyURL: &url.URL{Scheme: "http", Host: proxy.Addr().String()}}
+	dial := func(newNegotiator func(string) (negotiator, error)) (net.Conn, error) {
+		conn, err := net.Dial("tcp", proxy.Addr().String())
+		require.NoError(t, err)
+		auth := newProxyAuthenticator(d.ProxyURL)
+		auth.newNegotiator = newNegotiator
+		return d.connect(ctx, conn, "example.com:443", auth)
+	}
+
+	t.Run("negotiated", func(t *testing.T) {
+		t.Parallel()
+
+		conn, err := dial(func(host string) (negotiator, error) {
+			assert.Equal(t, "127.0.0.1", host)
+			return testNegotiator{}, nil
+		})
+		require.NoError(t, err)
+		_ = conn.Close()
+	})
+	t.Run("unsupported", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := dial(func(string) (negotiator, error) {
+			return nil, errNegotiateUnsupported
+		})
+		assert.ErrorIs(t, err, ErrProxyAuthRequired)
+		assert.ErrorContains(t, err, "offered: Negotiate")
+	})
+}
+
+// serveTestNegotiateProxy accepts the CONNECT request after a two-legged
+// Negotiate authentication on the same connection.
+func serveTestNegotiateProxy(conn net.Conn) {
+	defer conn.Close()
+
+	br := bufio.NewReader(conn)
+	for {
+		req, err := http.ReadRequest(br)
+		if err != nil {
+			return
+		}
+		switch req.Header.Get("Proxy-Authorization") {
+		case "Negotiate " + base64.StdEncoding.EncodeToString([]byte("first")):
+			_, _ = io.WriteString(conn, "HTTP/1.1 407 Proxy Authentication Required\r\n"+
+				"Proxy-Authenticate: Negotiate "+base64.StdEncoding.EncodeToString([]byte("challenge"))+"\r\n"+
+				"Content-Length: 0\r\n\r\n")
+		case "Negotiate " + base64.StdEncoding.EncodeToString([]byte("second")):
+			_, _ = io.WriteString(conn, "HTTP/1.1 200 Connection Established\r\n\r\n")
+			return
+		default:
+			_, _ = io.WriteString(conn, "HTTP/1.1 407 Proxy Authentication Required\r\n"+
+				"Proxy-Authenticate: Negotiate\r\n"+
+				"Content-Length: 0\r\n\r\n")
+		}
+	}
+}
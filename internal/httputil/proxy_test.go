@@ -0,0 +1,181 @@
+package httputil
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseProxyURL(t *testing.T) {
+	t.Parallel()
+
+	u, err := ParseProxyURL("")
+	assert.NoError(t, err)
+	assert.Nil(t, u)
+
+	u, err = ParseProxyURL("user:pass@proxy.example.com:3128")
+	require.NoError(t, err)
+	assert.Equal(t, "http", u.Scheme)
+	assert.Equal(t, "proxy.example.com:3128", u.Host)
+	assert.Equal(t, "user", u.User.Username())
+
+	_, err = ParseProxyURL("socks5://proxy.example.com")
+	assert.Error(t, err)
+}
+
+func TestProxyDialer(t *testing.T) {
+	t.Parallel()
+
+	ctx, clearTimeout := context.WithTimeout(context.Background(), 10*time.Second)
+	t.Cleanup(clearTimeout)
+
+	// the destination echoes everything back
+	dst, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = dst.Close() })
+	go func() {
+		for {
+			conn, err := dst.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				_, _ = io.Copy(conn, conn)
+			}()
+		}
+	}()
+
+	proxy, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = proxy.Close() })
+	go func() {
+		for {
+			conn, err := proxy.Accept()
+			if err != nil {
+				return
+			}
+			go serveTestProxy(conn)
+		}
+	}()
+
+	t.Run("authenticated", func(t *testing.T) {
+		t.Parallel()
+
+		d := &ProxyDialer{ProxyURL: &url.URL{
+			Scheme: "http",
+			Host:   proxy.Addr().String(),
+			User:   url.UserPassword("user", "secret"),
+		}}
+		conn, err := d.DialContext(ctx, "tcp", dst.Addr().String())
+		require.NoError(t, err)
+		defer conn.Close()
+
+		_, err = conn.Write([]byte("hello"))
+		require.NoError(t, err)
+		buf := make([]byte, 5)
+		_, err = io.ReadFull(conn, buf)
+		require.NoError(t, err)
+		assert.Equal(t, "hello", string(buf))
+	})
+	t.Run("unauthenticated", func(t *testing.T) {
+		t.Parallel()
+
+		d := &ProxyDialer{ProxyURL: &url.URL{
+			Scheme: "http",
+			Host:   proxy.Addr().String(),
+		}}
+		_, err := d.DialContext(ctx, "tcp", dst.Addr().String())
+		assert.ErrorIs(t, err, ErrProxyAuthRequired)
+		assert.ErrorContains(t, err, "Negotiate")
+	})
+	t.Run("direct", func(t *testing.T) {
+		t.Parallel()
+
+		conn, err := (&ProxyDialer{}).DialContext(ctx, "tcp", dst.Addr().String())
+		require.NoError(t, err)
+		_ = conn.Close()
+	})
+	t.Run("chained", func(t *testing.T) {
+		t.Parallel()
+
+		proxyURL := &url.URL{
+			Scheme: "http",
+			Host:   proxy.Addr().String(),
+			User:   url.UserPassword("user", "secret"),
+		}
+		// the second proxy is reached through the first one
+		d := &ProxyDialer{ProxyURL: proxyURL, Via: &ProxyDialer{ProxyURL: proxyURL}}
+		conn, err := d.DialContext(ctx, "tcp", dst.Addr().String())
+		require.NoError(t, err)
+		defer conn.Close()
+
+		_, err = conn.Write([]byte("hello"))
+		require.NoError(t, err)
+		buf := make([]byte, 5)
+		_, err = io.ReadFull(conn, buf)
+		require.NoError(t, err)
+		assert.Equal(t, "hello", string(buf))
+	})
+}
+
+func TestProxyFromEnvironment(t *testing.T) {
+	t.Setenv("HTTPS_PROXY", "http://proxy.example.com:3128")
+	t.Setenv("HTTP_PROXY", "")
+	t.Setenv("NO_PROXY", "internal.example.com")
+
+	u, err := ProxyFromEnvironment("pomerium.example.com:443", true)
+	require.NoError(t, err)
+	assert.Equal(t, "http://proxy.example.com:3128", u.String())
+
+	u, err = ProxyFromEnvironment("pomerium.example.com:80", false)
+	require.NoError(t, err)
+	assert.Nil(t, u)
+
+	u, err = ProxyFromEnvironment("pomerium.internal.example.com:443", true)
+	require.NoError(t, err)
+	assert.Nil(t, u)
+
+	t.Setenv("HTTPS_PROXY", "socks5://proxy.example.com:1080")
+	_, err = ProxyFromEnvironment("pomerium.example.com:443", true)
+	assert.Error(t, err)
+}
+
+func serveTestProxy(conn net.Conn) {
+	defer conn.Close()
+
+	br := bufio.NewReader(conn)
+	req, err := http.ReadRequest(br)
+	if err != nil {
+		return
+	}
+	user, password, ok := (&http.Request{Header: http.Header{
+		"Authorization": req.Header.Values("Proxy-Authorization"),
+	}}).BasicAuth()
+	if !ok || user != "user" || password != "secret" {
+		_, _ = io.WriteString(conn, "HTTP/1.1 407 Proxy Authentication Required\r\n"+
+			"Proxy-Authenticate: Negotiate\r\n"+
+			"Proxy-Authenticate: Basic realm=\"test\"\r\n"+
+			"Content-Length: 0\r\n\r\n")
+		return
+	}
+
+	remote, err := net.Dial("tcp", req.Host)
+	if err != nil {
+		_, _ = io.WriteString(conn, "HTTP/1.1 502 Bad Gateway\r\nContent-Length: 0\r\n\r\n")
+		return
+	}
+	defer remote.Close()
+	_, _ = io.WriteString(conn, "HTTP/1.1 200 Connection Established\r\n\r\n")
+
+	go func() { _, _ = io.Copy(remote, br) }()
+	_, _ = io.Copy(conn, remote)
+}
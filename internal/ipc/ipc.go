@@ -0,0 +1,95 @@
+// Package ipc listens and dials on the addresses of the api server: TCP host
+// and port pairs, Unix domain sockets and, on Windows, named pipes.
+package ipc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Address prefixes of Unix domain sockets and Windows named pipes. Other
+// addresses are TCP host and port pairs.
+const (
+	UnixPrefix      = "unix:"
+	NamedPipePrefix = "npipe:"
+)
+
+var errNamedPipeNotSupported = errors.New("named pipes are only supported on Windows")
+
+// IsLocal reports whether the address is a Unix domain socket or named pipe,
+// which only local processes can connect to.
+func IsLocal(addr string) bool {
+	return strings.HasPrefix(addr, UnixPrefix) || strings.HasPrefix(addr, NamedPipePrefix)
+}
+
+// Listen listens on the address. A Unix domain socket is only accessible to
+// the current user, and a stale socket file left behind by a previous process
+// is replaced. A named pipe is only accessible to the current user and
+// LocalSystem.
+func Listen(ctx context.Context, addr string) (net.Listener, error) {
+	switch {
+	case strings.HasPrefix(addr, UnixPrefix):
+		return listenUnix(ctx, strings.TrimPrefix(addr, UnixPrefix))
+	case strings.HasPrefix(addr, NamedPipePrefix):
+		return listenPipe(pipePath(strings.TrimPrefix(addr, NamedPipePrefix)))
+	}
+	var lc net.ListenConfig
+	return lc.Listen(ctx, "tcp", addr)
+}
+
+// Dial connects to the address.
+func Dial(ctx context.Context, addr string) (net.Conn, error) {
+	switch {
+	case strings.HasPrefix(addr, UnixPrefix):
+		var d net.Dialer
+		return d.DialContext(ctx, "unix", strings.TrimPrefix(addr, UnixPrefix))
+	case strings.HasPrefix(addr, NamedPipePrefix):
+		return dialPipe(ctx, pipePath(strings.TrimPrefix(addr, NamedPipePrefix)))
+	}
+	var d net.Dialer
+	return d.DialContext(ctx, "tcp", addr)
+}
+
+func listenUnix(ctx context.Context, path string) (net.Listener, error) {
+	if path == "" {
+		return nil, fmt.Errorf("unix socket path is required")
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, fmt.Errorf("unix socket %s: %w", path, err)
+	}
+	if _, err := os.Lstat(path); err == nil {
+		var d net.Dialer
+		if c, err := d.DialContext(ctx, "unix", path); err == nil {
+			_ = c.Close()
+			return nil, fmt.Errorf("unix socket %s: already in use", path)
+		}
+		if err := os.Remove(path); err != nil {
+			return nil, fmt.Errorf("unix socket %s: removing stale socket: %w", path, err)
+		}
+	}
+
+	var lc net.ListenConfig
+	li, err := lc.Listen(ctx, "unix", path)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Chmod(path, 0o600); err != nil {
+		_ = li.Close()
+		return nil, fmt.Errorf("unix socket %s: %w", path, err)
+	}
+	return li, nil
+}
+
+// pipePath returns the full path of a named pipe, which may be given by name
+// only.
+func pipePath(name string) string {
+	if strings.HasPrefix(name, `\\`) {
+		return name
+	}
+	return `\\.\pipe\` + name
+}
@@ -0,0 +1,84 @@
+package ipc
+
+import (
+	"context"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnixSocket(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "sub", "api.sock")
+	addr := UnixPrefix + path
+	assert.True(t, IsLocal(addr))
+
+	li, err := Listen(ctx, addr)
+	require.NoError(t, err)
+	if runtime.GOOS != "windows" {
+		fi, err := os.Stat(path)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0o600), fi.Mode().Perm())
+	}
+
+	_, err = Listen(ctx, addr)
+	assert.ErrorContains(t, err, "already in use")
+
+	// the probe of the second Listen is accepted too
+	go func() {
+		for {
+			c, err := li.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				_, _ = io.Copy(c, c)
+				_ = c.Close()
+			}()
+		}
+	}()
+	c, err := Dial(ctx, addr)
+	require.NoError(t, err)
+	_, err = c.Write([]byte("hello"))
+	require.NoError(t, err)
+	b := make([]byte, 5)
+	_, err = io.ReadFull(c, b)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(b))
+	require.NoError(t, c.Close())
+	require.NoError(t, li.Close())
+
+	// a stale socket is replaced
+	stale, err := net.Listen("unix", path)
+	require.NoError(t, err)
+	stale.(*net.UnixListener).SetUnlinkOnClose(false)
+	require.NoError(t, stale.Close())
+	li, err = Listen(ctx, addr)
+	require.NoError(t, err)
+	require.NoError(t, li.Close())
+}
+
+func TestTCP(t *testing.T) {
+	t.Parallel()
+
+	assert.False(t, IsLocal("127.0.0.1:0"))
+	li, err := Listen(context.Background(), "127.0.0.1:0")
+	require.NoError(t, err)
+	defer li.Close()
+	assert.Equal(t, "tcp", li.Addr().Network())
+}
+
+func TestNamedPipePath(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, `\\.\pipe\pomerium`, pipePath("pomerium"))
+	assert.Equal(t, `\\.\pipe\pomerium`, pipePath(`\\.\pipe\pomerium`))
+}
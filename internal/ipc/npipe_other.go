@@ -0,0 +1,16 @@
+//go:build !windows
+
+package ipc
+
+import (
+	"context"
+	"net"
+)
+
+func listenPipe(string) (net.Listener, error) {
+	return nil, errNamedPipeNotSupported
+}
+
+func dialPipe(context.Context, string) (net.Conn, error) {
+	return nil, errNamedPipeNotSupported
+}
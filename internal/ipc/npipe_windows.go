@@ -0,0 +1,362 @@
+package ipc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+const (
+	pipeBufferSize = 64 * 1024
+
+	// clients only allow the server to identify them, not to impersonate them
+	securitySQOSPresent    = 0x00100000
+	securityIdentification = 0x00010000
+)
+
+type pipeAddr string
+
+func (a pipeAddr) Network() string { return "pipe" }
+func (a pipeAddr) String() string  { return string(a) }
+
+// A pipeListener accepts connections on a named pipe, with a new instance of
+// the pipe for each client.
+type pipeListener struct {
+	path       string
+	sa         *windows.SecurityAttributes
+	closeEvent windows.Handle // signaled when the listener is closed
+	accepting  sync.WaitGroup
+
+	mu     sync.Mutex
+	closed bool
+	next   windows.Handle // the pipe instance for the next client, if created
+}
+
+func listenPipe(path string) (net.Listener, error) {
+	sa, err := currentUserSecurityAttributes()
+	if err != nil {
+		return nil, &net.OpError{Op: "listen", Net: "pipe", Addr: pipeAddr(path), Err: err}
+	}
+	closeEvent, err := windows.CreateEvent(nil, 1, 0, nil)
+	if err != nil {
+		return nil, err
+	}
+	l := &pipeListener{path: path, sa: sa, closeEvent: closeEvent}
+	// fail if another process already listens on the pipe
+	if l.next, err = l.createInstance(true); err != nil {
+		_ = windows.CloseHandle(closeEvent)
+		return nil, err
+	}
+	return l, nil
+}
+
+// currentUserSecurityAttributes only allows the current user and LocalSystem
+// to access the pipe.
+func currentUserSecurityAttributes() (*windows.SecurityAttributes, error) {
+	user, err := windows.GetCurrentProcessToken().GetTokenUser()
+	if err != nil {
+		return nil, err
+	}
+	sd, err := windows.SecurityDescriptorFromString("D:P(A;;GA;;;" + user.User.Sid.String() + ")(A;;GA;;;SY)")
+	if err != nil {
+		return nil, err
+	}
+	sa := &windows.SecurityAttributes{SecurityDescriptor: sd}
+	sa.Length = uint32(unsafe.Sizeof(*sa))
+	return sa, nil
+}
+
+func (l *pipeListener) createInstance(first bool) (windows.Handle, error) {
+	name, err := windows.UTF16PtrFromString(l.path)
+	if err != nil {
+		return 0, err
+	}
+	flags := uint32(windows.PIPE_ACCESS_DUPLEX | windows.FILE_FLAG_OVERLAPPED)
+	if first {
+		flags |= windows.FILE_FLAG_FIRST_PIPE_INSTANCE
+	}
+	h, err := windows.CreateNamedPipe(name, flags,
+		windows.PIPE_TYPE_BYTE|windows.PIPE_READMODE_BYTE|windows.PIPE_WAIT|windows.PIPE_REJECT_REMOTE_CLIENTS,
+		windows.PIPE_UNLIMITED_INSTANCES, pipeBufferSize, pipeBufferSize, 0, l.sa)
+	if err != nil {
+		return 0, &net.OpError{Op: "listen", Net: "pipe", Addr: pipeAddr(l.path), Err: err}
+	}
+	return h, nil
+}
+
+func (l *pipeListener) Accept() (net.Conn, error) {
+	l.mu.Lock()
+	if l.closed {
+		l.mu.Unlock()
+		return nil, net.ErrClosed
+	}
+	l.accepting.Add(1)
+	l.mu.Unlock()
+	defer l.accepting.Done()
+
+	for {
+		h, err := l.takeInstance()
+		if err != nil {
+			return nil, err
+		}
+		_, err = overlappedIO(h, l.closeEvent, time.Time{}, func(ov *windows.Overlapped) error {
+			return windows.ConnectNamedPipe(h, ov)
+		})
+		if err == nil || errors.Is(err, windows.ERROR_PIPE_CONNECTED) {
+			l.prepareInstance()
+			return newPipeConn(h, l.path, true)
+		}
+		_ = windows.CloseHandle(h)
+		if errors.Is(err, net.ErrClosed) {
+			return nil, err
+		}
+		if !errors.Is(err, windows.ERROR_NO_DATA) {
+			return nil, &net.OpError{Op: "accept", Net: "pipe", Addr: pipeAddr(l.path), Err: err}
+		}
+		// the client disconnected before it was accepted
+	}
+}
+
+// takeInstance returns the pipe instance for the next client, creating it if
+// needed. The caller owns the instance.
+func (l *pipeListener) takeInstance() (windows.Handle, error) {
+	l.mu.Lock()
+	h := l.next
+	l.next = 0
+	l.mu.Unlock()
+	if h != 0 {
+		return h, nil
+	}
+	return l.createInstance(false)
+}
+
+// prepareInstance creates the instance for the next client right away, so
+// that clients connecting before the next Accept find the pipe. Otherwise it
+// is created by the next Accept.
+func (l *pipeListener) prepareInstance() {
+	h, err := l.createInstance(false)
+	if err != nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.closed || l.next != 0 {
+		_ = windows.CloseHandle(h)
+		return
+	}
+	l.next = h
+}
+
+func (l *pipeListener) Close() error {
+	l.mu.Lock()
+	if l.closed {
+		l.mu.Unlock()
+		return nil
+	}
+	l.closed = true
+	_ = windows.SetEvent(l.closeEvent)
+	if l.next != 0 {
+		_ = windows.CloseHandle(l.next)
+		l.next = 0
+	}
+	l.mu.Unlock()
+
+	// pending calls to Accept return before the event is closed
+	l.accepting.Wait()
+	return windows.CloseHandle(l.closeEvent)
+}
+
+func (l *pipeListener) Addr() net.Addr {
+	return pipeAddr(l.path)
+}
+
+func dialPipe(ctx context.Context, path string) (net.Conn, error) {
+	name, err := windows.UTF16PtrFromString(path)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		h, err := windows.CreateFile(name, windows.GENERIC_READ|windows.GENERIC_WRITE, 0, nil, windows.OPEN_EXISTING,
+			windows.FILE_FLAG_OVERLAPPED|securitySQOSPresent|securityIdentification, 0)
+		if err == nil {
+			return newPipeConn(h, path, false)
+		}
+		if !errors.Is(err, windows.ERROR_PIPE_BUSY) {
+			return nil, &net.OpError{Op: "dial", Net: "pipe", Addr: pipeAddr(path), Err: err}
+		}
+		// all instances are connected, wait for the server to create another
+		select {
+		case <-ctx.Done():
+			return nil, &net.OpError{Op: "dial", Net: "pipe", Addr: pipeAddr(path), Err: ctx.Err()}
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
+
+// A pipeConn is one end of a connected pipe instance.
+type pipeConn struct {
+	h          windows.Handle
+	path       string
+	server     bool
+	closeEvent windows.Handle // signaled when the connection is closed
+
+	// mu is held for reading during I/O and for writing to close the handle
+	mu            sync.RWMutex
+	closed        atomic.Bool
+	readDeadline  atomic.Int64
+	writeDeadline atomic.Int64
+}
+
+func newPipeConn(h windows.Handle, path string, server bool) (*pipeConn, error) {
+	closeEvent, err := windows.CreateEvent(nil, 1, 0, nil)
+	if err != nil {
+		_ = windows.CloseHandle(h)
+		return nil, err
+	}
+	return &pipeConn{h: h, path: path, server: server, closeEvent: closeEvent}, nil
+}
+
+func (c *pipeConn) Read(b []byte) (int, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.closed.Load() {
+		return 0, net.ErrClosed
+	}
+	if len(b) == 0 {
+		return 0, nil
+	}
+
+	n, err := overlappedIO(c.h, c.closeEvent, loadDeadline(&c.readDeadline), func(ov *windows.Overlapped) error {
+		return windows.ReadFile(c.h, b, nil, ov)
+	})
+	if errors.Is(err, windows.ERROR_BROKEN_PIPE) || errors.Is(err, windows.ERROR_PIPE_NOT_CONNECTED) {
+		return 0, io.EOF
+	}
+	return int(n), c.opError("read", err)
+}
+
+func (c *pipeConn) Write(b []byte) (int, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.closed.Load() {
+		return 0, net.ErrClosed
+	}
+
+	written := 0
+	for written < len(b) {
+		n, err := overlappedIO(c.h, c.closeEvent, loadDeadline(&c.writeDeadline), func(ov *windows.Overlapped) error {
+			return windows.WriteFile(c.h, b[written:], nil, ov)
+		})
+		written += int(n)
+		if err != nil {
+			return written, c.opError("write", err)
+		}
+	}
+	return written, nil
+}
+
+func (c *pipeConn) opError(op string, err error) error {
+	if err == nil || errors.Is(err, net.ErrClosed) || errors.Is(err, os.ErrDeadlineExceeded) {
+		return err
+	}
+	return &net.OpError{Op: op, Net: "pipe", Addr: pipeAddr(c.path), Err: err}
+}
+
+func (c *pipeConn) Close() error {
+	if c.closed.Swap(true) {
+		return nil
+	}
+	// abort pending I/O, then wait for it to return before closing the handle
+	_ = windows.SetEvent(c.closeEvent)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.server {
+		_ = windows.DisconnectNamedPipe(c.h)
+	}
+	err := windows.CloseHandle(c.h)
+	_ = windows.CloseHandle(c.closeEvent)
+	return err
+}
+
+func (c *pipeConn) LocalAddr() net.Addr  { return pipeAddr(c.path) }
+func (c *pipeConn) RemoteAddr() net.Addr { return pipeAddr(c.path) }
+
+// SetDeadline and friends apply to the next reads and writes, but not those
+// already in progress.
+func (c *pipeConn) SetDeadline(t time.Time) error {
+	_ = c.SetReadDeadline(t)
+	return c.SetWriteDeadline(t)
+}
+
+func (c *pipeConn) SetReadDeadline(t time.Time) error {
+	storeDeadline(&c.readDeadline, t)
+	return nil
+}
+
+func (c *pipeConn) SetWriteDeadline(t time.Time) error {
+	storeDeadline(&c.writeDeadline, t)
+	return nil
+}
+
+func storeDeadline(d *atomic.Int64, t time.Time) {
+	if t.IsZero() {
+		d.Store(0)
+	} else {
+		d.Store(t.UnixNano())
+	}
+}
+
+func loadDeadline(d *atomic.Int64) time.Time {
+	if ns := d.Load(); ns != 0 {
+		return time.Unix(0, ns)
+	}
+	return time.Time{}
+}
+
+// overlappedIO starts an overlapped operation on h and waits for it to
+// complete. The operation is canceled with net.ErrClosed when cancel is
+// signaled, or with os.ErrDeadlineExceeded at the deadline.
+func overlappedIO(h, cancel windows.Handle, deadline time.Time, op func(*windows.Overlapped) error) (uint32, error) {
+	event, err := windows.CreateEvent(nil, 1, 0, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = windows.CloseHandle(event) }()
+
+	ov := &windows.Overlapped{HEvent: event}
+	var n uint32
+	if err := op(ov); !errors.Is(err, windows.ERROR_IO_PENDING) {
+		if err != nil {
+			return 0, err
+		}
+		err = windows.GetOverlappedResult(h, ov, &n, false)
+		return n, err
+	}
+
+	timeout := uint32(windows.INFINITE)
+	if !deadline.IsZero() {
+		timeout = uint32(max(0, min(time.Until(deadline).Milliseconds()+1, windows.INFINITE-1)))
+	}
+	wait, err := windows.WaitForMultipleObjects([]windows.Handle{event, cancel}, false, timeout)
+	if err != nil || wait != windows.WAIT_OBJECT_0 {
+		_ = windows.CancelIoEx(h, ov)
+	}
+	err = windows.GetOverlappedResult(h, ov, &n, true)
+	if errors.Is(err, windows.ERROR_OPERATION_ABORTED) {
+		switch wait {
+		case windows.WAIT_OBJECT_0 + 1:
+			return n, net.ErrClosed
+		case uint32(windows.WAIT_TIMEOUT):
+			return n, os.ErrDeadlineExceeded
+		}
+	}
+	return n, err
+}
@@ -0,0 +1,220 @@
+// Package jsonrpc serves the unary methods of gRPC services as JSON-RPC 2.0
+// over HTTP, for clients that cannot speak gRPC.
+//
+// Methods are named after the service and method, i.e. "Config.List", their
+// params and results are the request and response messages in the protobuf
+// JSON encoding. Requests are sent with POST and the application/json content
+// type, HTTP headers are passed to the interceptors as gRPC metadata, see
+// [gateway.IncomingContext].
+package jsonrpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/pomerium/cli/internal/gateway"
+)
+
+// Error codes defined by the JSON-RPC 2.0 specification.
+const (
+	CodeParseError     = -32700
+	CodeInvalidRequest = -32600
+	CodeMethodNotFound = -32601
+	CodeInvalidParams  = -32602
+	CodeInternalError  = -32603
+	// CodeServerError is returned for errors of the methods, with the gRPC
+	// status code name as the error data.
+	CodeServerError = -32000
+)
+
+const maxRequestSize = 16 << 20
+
+// A Request is a JSON-RPC request. A request without an id is a notification,
+// which is not answered.
+type Request struct {
+	Version string          `json:"jsonrpc"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+	ID      json.RawMessage `json:"id,omitempty"`
+}
+
+// A Response is a JSON-RPC response.
+type Response struct {
+	Version string          `json:"jsonrpc"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *Error          `json:"error,omitempty"`
+	ID      json.RawMessage `json:"id"`
+}
+
+// An Error is the error of a JSON-RPC response.
+type Error struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Data    any    `json:"data,omitempty"`
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("json-rpc error %d: %s", e.Code, e.Message)
+}
+
+type method struct {
+	impl any
+	desc *grpc.MethodDesc
+	name string
+}
+
+// A Server serves the unary methods of the services registered with it.
+type Server struct {
+	methods     map[string]*method
+	interceptor grpc.UnaryServerInterceptor
+}
+
+var _ grpc.ServiceRegistrar = (*Server)(nil)
+
+// NewServer creates a new server, which calls the interceptors for each
+// method, in order.
+func NewServer(interceptors ...grpc.UnaryServerInterceptor) *Server {
+	return &Server{
+		methods:     make(map[string]*method),
+		interceptor: gateway.ChainUnaryInterceptors(interceptors...),
+	}
+}
+
+// RegisterService registers the unary methods of a service. Streaming methods
+// are not supported.
+func (srv *Server) RegisterService(desc *grpc.ServiceDesc, impl any) {
+	service := desc.ServiceName[strings.LastIndex(desc.ServiceName, ".")+1:]
+	for i := range desc.Methods {
+		name := service + "." + desc.Methods[i].MethodName
+		srv.methods[name] = &method{impl: impl, desc: &desc.Methods[i], name: name}
+	}
+}
+
+// ServeHTTP handles a single request or a batch of requests.
+func (srv *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	if code, err := gateway.CheckLocalRequest(r); err != nil {
+		http.Error(w, err.Error(), code)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxRequestSize))
+	if err != nil {
+		writeJSON(w, &Response{Version: "2.0", Error: &Error{Code: CodeParseError, Message: err.Error()}})
+		return
+	}
+	ctx := gateway.IncomingContext(r)
+
+	body = bytes.TrimSpace(body)
+	if len(body) > 0 && body[0] == '[' {
+		var batch []json.RawMessage
+		if err := json.Unmarshal(body, &batch); err != nil {
+			writeJSON(w, &Response{Version: "2.0", Error: &Error{Code: CodeParseError, Message: err.Error()}})
+			return
+		}
+		if len(batch) == 0 {
+			writeJSON(w, &Response{Version: "2.0", Error: &Error{Code: CodeInvalidRequest, Message: "empty batch"}})
+			return
+		}
+		var res []*Response
+		for _, req := range batch {
+			if r := srv.handle(ctx, req); r != nil {
+				res = append(res, r)
+			}
+		}
+		if len(res) == 0 {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		writeJSON(w, res)
+		return
+	}
+
+	res := srv.handle(ctx, body)
+	if res == nil {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	writeJSON(w, res)
+}
+
+// handle calls the method of a single request, and returns nil for
+// notifications.
+func (srv *Server) handle(ctx context.Context, raw json.RawMessage) *Response {
+	var req Request
+	if err := json.Unmarshal(raw, &req); err != nil {
+		var syntaxErr *json.SyntaxError
+		if errors.As(err, &syntaxErr) {
+			return &Response{Version: "2.0", Error: &Error{Code: CodeParseError, Message: err.Error()}}
+		}
+		return &Response{Version: "2.0", Error: &Error{Code: CodeInvalidRequest, Message: err.Error()}}
+	}
+	if req.Version != "2.0" || req.Method == "" {
+		return &Response{Version: "2.0", Error: &Error{Code: CodeInvalidRequest, Message: "invalid request"}, ID: req.ID}
+	}
+
+	result, rpcErr := srv.call(ctx, &req)
+	if req.ID == nil {
+		return nil
+	}
+	return &Response{Version: "2.0", Result: result, Error: rpcErr, ID: req.ID}
+}
+
+func (srv *Server) call(ctx context.Context, req *Request) (json.RawMessage, *Error) {
+	m, ok := srv.methods[req.Method]
+	if !ok {
+		return nil, &Error{Code: CodeMethodNotFound, Message: "method not found: " + req.Method}
+	}
+
+	var paramsErr error
+	dec := func(v any) error {
+		msg, ok := v.(proto.Message)
+		if !ok {
+			return fmt.Errorf("unexpected request type %T", v)
+		}
+		params := bytes.TrimSpace(req.Params)
+		if len(params) == 0 || bytes.Equal(params, []byte("null")) {
+			return nil
+		}
+		paramsErr = protojson.Unmarshal(params, msg)
+		return paramsErr
+	}
+	res, err := m.desc.Handler(m.impl, ctx, dec, srv.interceptor)
+	if paramsErr != nil {
+		return nil, &Error{Code: CodeInvalidParams, Message: paramsErr.Error()}
+	}
+	if err != nil {
+		st := status.Convert(err)
+		return nil, &Error{Code: CodeServerError, Message: st.Message(), Data: st.Code().String()}
+	}
+
+	msg, ok := res.(proto.Message)
+	if !ok {
+		return nil, &Error{Code: CodeInternalError, Message: fmt.Sprintf("unexpected response type %T", res)}
+	}
+	b, err := protojson.Marshal(msg)
+	if err != nil {
+		return nil, &Error{Code: CodeInternalError, Message: err.Error()}
+	}
+	return b, nil
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,176 @@
+package jsonrpc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/pomerium/cli/proto"
+)
+
+type configServer struct {
+	pb.UnimplementedConfigServer
+}
+
+func (configServer) GetTags(context.Context, *pb.GetTagsRequest) (*pb.GetTagsResponse, error) {
+	return &pb.GetTagsResponse{Tags: []string{"a", "b"}}, nil
+}
+
+func (configServer) RenameTag(_ context.Context, req *pb.RenameTagRequest) (*pb.Records, error) {
+	if req.GetNewTag() == "" {
+		return nil, status.Error(codes.InvalidArgument, "new tag is required")
+	}
+	return &pb.Records{Records: []*pb.Record{{Tags: []string{req.GetNewTag()}}}}, nil
+}
+
+func TestServer(t *testing.T) {
+	t.Parallel()
+
+	var fullMethod string
+	var authorization []string
+	srv := NewServer(func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		fullMethod, authorization = info.FullMethod, md.Get("authorization")
+		return handler(ctx, req)
+	})
+	pb.RegisterConfigServer(srv, configServer{})
+
+	post := func(t *testing.T, body string) *httptest.ResponseRecorder {
+		t.Helper()
+		r := httptest.NewRequest(http.MethodPost, "http://127.0.0.1:8900/", strings.NewReader(body))
+		r.Header.Set("Content-Type", "application/json")
+		r.Header.Set("Authorization", "Bearer token")
+		w := httptest.NewRecorder()
+		srv.ServeHTTP(w, r)
+		return w
+	}
+
+	w := post(t, `{"jsonrpc":"2.0","method":"Config.GetTags","id":1}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"jsonrpc":"2.0","result":{"tags":["a","b"]},"id":1}`, w.Body.String())
+	assert.Equal(t, pb.Config_GetTags_FullMethodName, fullMethod)
+	assert.Equal(t, []string{"Bearer token"}, authorization)
+
+	for _, tc := range []struct {
+		name, req, res string
+	}{
+		{
+			"params",
+			`{"jsonrpc":"2.0","method":"Config.RenameTag","params":{"oldTag":"a","newTag":"c"},"id":"x"}`,
+			`{"jsonrpc":"2.0","result":{"records":[{"tags":["c"]}]},"id":"x"}`,
+		},
+		{
+			"method error",
+			`{"jsonrpc":"2.0","method":"Config.RenameTag","params":{},"id":2}`,
+			`{"jsonrpc":"2.0","error":{"code":-32000,"message":"new tag is required","data":"InvalidArgument"},"id":2}`,
+		},
+		{
+			"invalid params",
+			`{"jsonrpc":"2.0","method":"Config.RenameTag","params":{"unknown":1},"id":3}`,
+			`{"jsonrpc":"2.0","error":{"code":-32602},"id":3}`,
+		},
+		{
+			"method not found",
+			`{"jsonrpc":"2.0","method":"Config.Unknown","id":4}`,
+			`{"jsonrpc":"2.0","error":{"code":-32601},"id":4}`,
+		},
+		{
+			"invalid request",
+			`{"method":"Config.GetTags","id":5}`,
+			`{"jsonrpc":"2.0","error":{"code":-32600},"id":5}`,
+		},
+		{
+			"parse error",
+			`{"jsonrpc":`,
+			`{"jsonrpc":"2.0","error":{"code":-32700},"id":null}`,
+		},
+		{
+			"batch",
+			`[{"jsonrpc":"2.0","method":"Config.GetTags","id":1},{"jsonrpc":"2.0","method":"Config.GetTags"}]`,
+			`[{"jsonrpc":"2.0","result":{"tags":["a","b"]},"id":1}]`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			w := post(t, tc.req)
+			assert.Equal(t, http.StatusOK, w.Code)
+			assertResponse(t, tc.res, w.Body.String())
+		})
+	}
+
+	w = post(t, `{"jsonrpc":"2.0","method":"Config.GetTags"}`)
+	assert.Equal(t, http.StatusNoContent, w.Code, "notifications should not be answered")
+	assert.Empty(t, w.Body.String())
+}
+
+// assertResponse compares responses, ignoring error messages not given in
+// expected.
+func assertResponse(t *testing.T, expected, actual string) {
+	t.Helper()
+
+	var e, a any
+	require.NoError(t, json.Unmarshal([]byte(expected), &e))
+	require.NoError(t, json.Unmarshal([]byte(actual), &a))
+	stripMessages(e, a)
+	assert.Equal(t, e, a)
+}
+
+func stripMessages(expected, actual any) {
+	switch e := expected.(type) {
+	case []any:
+		if a, ok := actual.([]any); ok {
+			for i := range min(len(e), len(a)) {
+				stripMessages(e[i], a[i])
+			}
+		}
+	case map[string]any:
+		a, ok := actual.(map[string]any)
+		if !ok {
+			return
+		}
+		if _, ok := e["message"]; !ok {
+			if _, ok := e["code"]; ok {
+				delete(a, "message")
+			}
+		}
+		for k := range e {
+			stripMessages(e[k], a[k])
+		}
+	}
+}
+
+func TestServerRejectsBrowserRequests(t *testing.T) {
+	t.Parallel()
+
+	srv := NewServer()
+	pb.RegisterConfigServer(srv, configServer{})
+	body := `{"jsonrpc":"2.0","method":"Config.GetTags","id":1}`
+
+	for _, tc := range []struct {
+		name, method, url, contentType string
+		code                           int
+	}{
+		{"get", http.MethodGet, "http://127.0.0.1:8900/", "application/json", http.StatusMethodNotAllowed},
+		{"text/plain", http.MethodPost, "http://127.0.0.1:8900/", "text/plain", http.StatusUnsupportedMediaType},
+		{"dns rebinding", http.MethodPost, "http://attacker.example.com:8900/", "application/json", http.StatusForbidden},
+		{"localhost", http.MethodPost, "http://localhost:8900/", "application/json; charset=utf-8", http.StatusOK},
+		{"ipv6", http.MethodPost, "http://[::1]:8900/", "application/json", http.StatusOK},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r := httptest.NewRequest(tc.method, tc.url, strings.NewReader(body))
+			r.Header.Set("Content-Type", tc.contentType)
+			w := httptest.NewRecorder()
+			srv.ServeHTTP(w, r)
+			assert.Equal(t, tc.code, w.Code)
+		})
+	}
+}
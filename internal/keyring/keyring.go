@@ -0,0 +1,38 @@
+// Package keyring stores secrets in the keyring of the operating system: the
+// Keychain on macOS, the Credential Manager on Windows and the Secret Service,
+// via secret-tool, elsewhere.
+package keyring
+
+import "errors"
+
+// ErrNotFound indicates there is no secret for the service and account.
+var ErrNotFound = errors.New("secret not found in keyring")
+
+// A Keyring stores secrets by service and account.
+type Keyring interface {
+	// Get returns the secret, or ErrNotFound.
+	Get(service, account string) (string, error)
+	// Set creates or replaces the secret.
+	Set(service, account, secret string) error
+	// Delete deletes the secret, it is not an error if there is none.
+	Delete(service, account string) error
+}
+
+// System returns the keyring of the operating system.
+func System() Keyring {
+	return systemKeyring{}
+}
+
+type systemKeyring struct{}
+
+func (systemKeyring) Get(service, account string) (string, error) {
+	return get(service, account)
+}
+
+func (systemKeyring) Set(service, account, secret string) error {
+	return set(service, account, secret)
+}
+
+func (systemKeyring) Delete(service, account string) error {
+	return del(service, account)
+}
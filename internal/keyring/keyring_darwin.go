@@ -0,0 +1,110 @@
+//go:build darwin && cgo
+
+package keyring
+
+/*
+#cgo LDFLAGS: -framework CoreFoundation -framework Security
+
+#include <stdlib.h>
+#include <CoreFoundation/CoreFoundation.h>
+#include <Security/Security.h>
+
+static CFMutableDictionaryRef newQuery(const char *service, const char *account) {
+	CFMutableDictionaryRef query = CFDictionaryCreateMutable(NULL, 0,
+		&kCFTypeDictionaryKeyCallBacks, &kCFTypeDictionaryValueCallBacks);
+	CFStringRef s = CFStringCreateWithCString(NULL, service, kCFStringEncodingUTF8);
+	CFStringRef a = CFStringCreateWithCString(NULL, account, kCFStringEncodingUTF8);
+	CFDictionarySetValue(query, kSecClass, kSecClassGenericPassword);
+	CFDictionarySetValue(query, kSecAttrService, s);
+	CFDictionarySetValue(query, kSecAttrAccount, a);
+	CFRelease(s);
+	CFRelease(a);
+	return query;
+}
+
+static OSStatus keychainGet(const char *service, const char *account, CFDataRef *data) {
+	CFMutableDictionaryRef query = newQuery(service, account);
+	CFDictionarySetValue(query, kSecReturnData, kCFBooleanTrue);
+	CFDictionarySetValue(query, kSecMatchLimit, kSecMatchLimitOne);
+	OSStatus status = SecItemCopyMatching(query, (CFTypeRef *)data);
+	CFRelease(query);
+	return status;
+}
+
+static OSStatus keychainSet(const char *service, const char *account, const UInt8 *secret, CFIndex length) {
+	CFMutableDictionaryRef query = newQuery(service, account);
+	CFDataRef data = CFDataCreate(NULL, secret, length);
+	CFMutableDictionaryRef attrs = CFDictionaryCreateMutable(NULL, 0,
+		&kCFTypeDictionaryKeyCallBacks, &kCFTypeDictionaryValueCallBacks);
+	CFDictionarySetValue(attrs, kSecValueData, data);
+	OSStatus status = SecItemUpdate(query, attrs);
+	if (status == errSecItemNotFound) {
+		CFDictionarySetValue(query, kSecValueData, data);
+		status = SecItemAdd(query, NULL);
+	}
+	CFRelease(attrs);
+	CFRelease(data);
+	CFRelease(query);
+	return status;
+}
+
+static OSStatus keychainDelete(const char *service, const char *account) {
+	CFMutableDictionaryRef query = newQuery(service, account);
+	OSStatus status = SecItemDelete(query);
+	CFRelease(query);
+	return status;
+}
+*/
+import "C"
+
+import (
+	"fmt"
+	"unsafe"
+)
+
+func get(service, account string) (string, error) {
+	cService, cAccount := C.CString(service), C.CString(account)
+	defer C.free(unsafe.Pointer(cService))
+	defer C.free(unsafe.Pointer(cAccount))
+
+	var data C.CFDataRef
+	status := C.keychainGet(cService, cAccount, &data)
+	if status == C.errSecItemNotFound {
+		return "", ErrNotFound
+	} else if status != C.errSecSuccess {
+		return "", keychainError(status)
+	}
+	defer C.CFRelease(C.CFTypeRef(data))
+
+	return C.GoStringN((*C.char)(unsafe.Pointer(C.CFDataGetBytePtr(data))), C.int(C.CFDataGetLength(data))), nil
+}
+
+func set(service, account, secret string) error {
+	cService, cAccount := C.CString(service), C.CString(account)
+	defer C.free(unsafe.Pointer(cService))
+	defer C.free(unsafe.Pointer(cAccount))
+	cSecret := C.CBytes([]byte(secret))
+	defer C.free(cSecret)
+
+	status := C.keychainSet(cService, cAccount, (*C.UInt8)(cSecret), C.CFIndex(len(secret)))
+	if status != C.errSecSuccess {
+		return keychainError(status)
+	}
+	return nil
+}
+
+func del(service, account string) error {
+	cService, cAccount := C.CString(service), C.CString(account)
+	defer C.free(unsafe.Pointer(cService))
+	defer C.free(unsafe.Pointer(cAccount))
+
+	status := C.keychainDelete(cService, cAccount)
+	if status != C.errSecSuccess && status != C.errSecItemNotFound {
+		return keychainError(status)
+	}
+	return nil
+}
+
+func keychainError(status C.OSStatus) error {
+	return fmt.Errorf("keychain: error %d", int(status))
+}
@@ -0,0 +1,74 @@
+//go:build !windows && !(darwin && cgo)
+
+package keyring
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// secret-tool is the command line client of libsecret, it talks to the
+// Secret Service (i.e. GNOME Keyring or KWallet) over D-Bus.
+const secretTool = "secret-tool"
+
+func get(service, account string) (string, error) {
+	var stdout bytes.Buffer
+	err := runSecretTool(nil, &stdout, "lookup", "service", service, "account", account)
+	if isNotFound(err) && stdout.Len() == 0 {
+		return "", ErrNotFound
+	} else if err != nil {
+		return "", err
+	}
+	return stdout.String(), nil
+}
+
+func set(service, account, secret string) error {
+	return runSecretTool(strings.NewReader(secret), nil, "store",
+		"--label="+service+" "+account, "service", service, "account", account)
+}
+
+func del(service, account string) error {
+	err := runSecretTool(nil, nil, "clear", "service", service, "account", account)
+	if isNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// isNotFound reports whether secret-tool failed without a message, which it
+// does when there is no matching secret.
+func isNotFound(err error) bool {
+	var exitErr *exec.ExitError
+	return errors.As(err, &exitErr) && len(bytes.TrimSpace(exitErr.Stderr)) == 0
+}
+
+func runSecretTool(stdin *strings.Reader, stdout *bytes.Buffer, args ...string) error {
+	cmd := exec.Command(secretTool, args...)
+	if stdin != nil {
+		cmd.Stdin = stdin
+	}
+	if stdout != nil {
+		cmd.Stdout = stdout
+	}
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	err := cmd.Run()
+	if errors.Is(err, exec.ErrNotFound) {
+		return fmt.Errorf("%s is required to use the Secret Service keyring: %w", secretTool, err)
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		exitErr.Stderr = stderr.Bytes()
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("%s %s: %s: %w", secretTool, args[0], msg, err)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("%s %s: %w", secretTool, args[0], err)
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+//go:build windows
+
+package keyring
+
+import (
+	"errors"
+	"fmt"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// wincred.h constants
+const (
+	credTypeGeneric           = 1    // CRED_TYPE_GENERIC
+	credPersistLocalMachine   = 2    // CRED_PERSIST_LOCAL_MACHINE
+	credMaxCredentialBlobSize = 2560 // CRED_MAX_CREDENTIAL_BLOB_SIZE
+)
+
+var (
+	advapi32        = windows.NewLazySystemDLL("advapi32.dll")
+	procCredReadW   = advapi32.NewProc("CredReadW")
+	procCredWriteW  = advapi32.NewProc("CredWriteW")
+	procCredDeleteW = advapi32.NewProc("CredDeleteW")
+	procCredFree    = advapi32.NewProc("CredFree")
+)
+
+// credential is CREDENTIALW
+type credential struct {
+	flags              uint32
+	credType           uint32
+	targetName         *uint16
+	comment            *uint16
+	lastWritten        windows.Filetime
+	credentialBlobSize uint32
+	credentialBlob     *byte
+	persist            uint32
+	attributeCount     uint32
+	attributes         uintptr
+	targetAlias        *uint16
+	userName           *uint16
+}
+
+func get(service, account string) (string, error) {
+	target, err := windows.UTF16PtrFromString(targetName(service, account))
+	if err != nil {
+		return "", err
+	}
+
+	var cred *credential
+	ret, _, err := procCredReadW.Call(uintptr(unsafe.Pointer(target)), credTypeGeneric, 0,
+		uintptr(unsafe.Pointer(&cred)))
+	if ret == 0 {
+		if errors.Is(err, windows.ERROR_NOT_FOUND) {
+			return "", ErrNotFound
+		}
+		return "", fmt.Errorf("credential manager: %w", err)
+	}
+	defer func() { _, _, _ = procCredFree.Call(uintptr(unsafe.Pointer(cred))) }()
+
+	return string(unsafe.Slice(cred.credentialBlob, cred.credentialBlobSize)), nil
+}
+
+func set(service, account, secret string) error {
+	if len(secret) > credMaxCredentialBlobSize {
+		return fmt.Errorf("credential manager: the secret exceeds %d bytes", credMaxCredentialBlobSize)
+	}
+	target, err := windows.UTF16PtrFromString(targetName(service, account))
+	if err != nil {
+		return err
+	}
+	userName, err := windows.UTF16PtrFromString(account)
+	if err != nil {
+		return err
+	}
+
+	cred := credential{
+		credType:           credTypeGeneric,
+		targetName:         target,
+		credentialBlobSize: uint32(len(secret)),
+		persist:            credPersistLocalMachine,
+		userName:           userName,
+	}
+	if len(secret) > 0 {
+		blob := []byte(secret)
+		cred.credentialBlob = &blob[0]
+	}
+	ret, _, err := procCredWriteW.Call(uintptr(unsafe.Pointer(&cred)), 0)
+	if ret == 0 {
+		return fmt.Errorf("credential manager: %w", err)
+	}
+	return nil
+}
+
+func del(service, account string) error {
+	target, err := windows.UTF16PtrFromString(targetName(service, account))
+	if err != nil {
+		return err
+	}
+
+	ret, _, err := procCredDeleteW.Call(uintptr(unsafe.Pointer(target)), credTypeGeneric, 0)
+	if ret == 0 && !errors.Is(err, windows.ERROR_NOT_FOUND) {
+		return fmt.Errorf("credential manager: %w", err)
+	}
+	return nil
+}
+
+func targetName(service, account string) string {
+	return service + ":" + account
+}
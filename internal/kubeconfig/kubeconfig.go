@@ -0,0 +1,189 @@
+// Package kubeconfig adds the pomerium-cli credential plugin to kubeconfig
+// files, keeping the other entries and settings of the file.
+package kubeconfig
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"sigs.k8s.io/yaml"
+)
+
+// ExecAPIVersion is the ExecCredential API version requested from the plugin.
+const ExecAPIVersion = "client.authentication.k8s.io/v1"
+
+// An Entry is a cluster, and the user and context using it, all of the same
+// name.
+type Entry struct {
+	Name string
+	// Server is the URL of the Kubernetes API server route.
+	Server string
+	// CertificateAuthority is the path of the CA of the server, if not trusted
+	// by the system.
+	CertificateAuthority string
+	Exec                 Exec
+}
+
+// Exec is the credential plugin of the user.
+type Exec struct {
+	Command string
+	Args    []string
+	Env     []EnvVar
+}
+
+// EnvVar is an environment variable of the credential plugin.
+type EnvVar struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// Path returns the path of the kubeconfig file kubectl uses by default: the
+// first of the KUBECONFIG environment variable, or ~/.kube/config.
+func Path() (string, error) {
+	for _, path := range filepath.SplitList(os.Getenv("KUBECONFIG")) {
+		if path != "" {
+			return path, nil
+		}
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".kube", "config"), nil
+}
+
+// Config is a kubeconfig file. Fields it does not know of are kept as they
+// are.
+type Config struct {
+	m map[string]any
+}
+
+// Load loads the config. A missing or empty file results in an empty config.
+func Load(path string) (*Config, error) {
+	cfg := &Config{m: map[string]any{
+		"apiVersion": "v1",
+		"kind":       "Config",
+	}}
+
+	bs, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return cfg, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var m map[string]any
+	if err := yaml.Unmarshal(bs, &m); err != nil {
+		return nil, fmt.Errorf("invalid kubeconfig %s: %w", path, err)
+	}
+	if m != nil {
+		cfg.m = m
+	}
+	return cfg, nil
+}
+
+// Save saves the config, which only the current user can read.
+func (cfg *Config) Save(path string) error {
+	bs, err := yaml.Marshal(cfg.m)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to create kubeconfig directory: %w", err)
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(path), "*.tmp")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(f.Name()) }()
+
+	if _, err := f.Write(bs); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
+
+// Set adds the cluster, user and context of the entry, or updates them if
+// they exist. The server and CA of an existing cluster, and the credentials of
+// an existing user, are replaced, and the namespace of an existing context is
+// kept.
+func (cfg *Config) Set(e Entry) error {
+	cluster, err := cfg.named("clusters", e.Name, "cluster")
+	if err != nil {
+		return err
+	}
+	cluster["server"] = e.Server
+	if e.CertificateAuthority != "" {
+		delete(cluster, "certificate-authority-data")
+		cluster["certificate-authority"] = e.CertificateAuthority
+	}
+
+	user, err := cfg.named("users", e.Name, "user")
+	if err != nil {
+		return err
+	}
+	// other credentials would take precedence over the plugin
+	clear(user)
+	exec := map[string]any{
+		"apiVersion":      ExecAPIVersion,
+		"command":         e.Exec.Command,
+		"args":            e.Exec.Args,
+		"interactiveMode": "IfAvailable",
+	}
+	if len(e.Exec.Env) > 0 {
+		exec["env"] = e.Exec.Env
+	}
+	user["exec"] = exec
+
+	kubeContext, err := cfg.named("contexts", e.Name, "context")
+	if err != nil {
+		return err
+	}
+	kubeContext["cluster"] = e.Name
+	kubeContext["user"] = e.Name
+	return nil
+}
+
+// SetCurrentContext sets the context kubectl uses by default.
+func (cfg *Config) SetCurrentContext(name string) {
+	cfg.m["current-context"] = name
+}
+
+// named returns the map under the key of the named item of the list, adding
+// the item if there is none
+func (cfg *Config) named(list, name, key string) (map[string]any, error) {
+	var items []any
+	if v, ok := cfg.m[list]; ok && v != nil {
+		if items, ok = v.([]any); !ok {
+			return nil, fmt.Errorf("invalid kubeconfig: %s is not a list", list)
+		}
+	}
+
+	for _, v := range items {
+		item, ok := v.(map[string]any)
+		if !ok || item["name"] != name {
+			continue
+		}
+		if item[key] == nil {
+			item[key] = make(map[string]any)
+		}
+		m, ok := item[key].(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("invalid kubeconfig: %s %s is not a map", list, name)
+		}
+		return m, nil
+	}
+
+	m := make(map[string]any)
+	cfg.m[list] = append(items, map[string]any{"name": name, key: m})
+	return m, nil
+}
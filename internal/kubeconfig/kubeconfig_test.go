@@ -0,0 +1,153 @@
+package kubeconfig
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSet(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), ".kube", "config")
+	entry := Entry{
+		Name:   "prod",
+		Server: "https://k8s.example.com",
+		Exec: Exec{
+			Command: "pomerium-cli",
+			Args:    []string{"k8s", "exec-credential", "https://k8s.example.com"},
+		},
+	}
+
+	cfg, err := Load(path)
+	require.NoError(t, err)
+	require.NoError(t, cfg.Set(entry))
+	cfg.SetCurrentContext("prod")
+	require.NoError(t, cfg.Save(path))
+
+	bs, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.YAMLEq(t, `
+apiVersion: v1
+kind: Config
+clusters:
+- name: prod
+  cluster:
+    server: https://k8s.example.com
+users:
+- name: prod
+  user:
+    exec:
+      apiVersion: client.authentication.k8s.io/v1
+      command: pomerium-cli
+      args: [k8s, exec-credential, "https://k8s.example.com"]
+      interactiveMode: IfAvailable
+contexts:
+- name: prod
+  context:
+    cluster: prod
+    user: prod
+current-context: prod
+`, string(bs))
+}
+
+func TestSetExisting(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "config")
+	require.NoError(t, os.WriteFile(path, []byte(`
+apiVersion: v1
+kind: Config
+preferences: {}
+clusters:
+- name: dev
+  cluster:
+    server: https://dev.example.com
+- name: prod
+  cluster:
+    server: https://old.example.com
+    certificate-authority-data: Zm9v
+    tls-server-name: k8s.example.com
+users:
+- name: prod
+  user:
+    token: secret
+contexts:
+- name: prod
+  context:
+    cluster: old
+    user: prod
+    namespace: apps
+current-context: dev
+`), 0o600))
+
+	cfg, err := Load(path)
+	require.NoError(t, err)
+	require.NoError(t, cfg.Set(Entry{
+		Name:                 "prod",
+		Server:               "https://k8s.example.com",
+		CertificateAuthority: "/etc/ssl/ca.pem",
+		Exec: Exec{
+			Command: "/usr/local/bin/pomerium-cli",
+			Args:    []string{"k8s", "exec-credential", "https://k8s.example.com"},
+			Env:     []EnvVar{{Name: "POMERIUM_CLI_PROFILE", Value: "work"}},
+		},
+	}))
+	require.NoError(t, cfg.Save(path))
+
+	bs, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.YAMLEq(t, `
+apiVersion: v1
+kind: Config
+preferences: {}
+clusters:
+- name: dev
+  cluster:
+    server: https://dev.example.com
+- name: prod
+  cluster:
+    server: https://k8s.example.com
+    certificate-authority: /etc/ssl/ca.pem
+    tls-server-name: k8s.example.com
+users:
+- name: prod
+  user:
+    exec:
+      apiVersion: client.authentication.k8s.io/v1
+      command: /usr/local/bin/pomerium-cli
+      args: [k8s, exec-credential, "https://k8s.example.com"]
+      env:
+      - name: POMERIUM_CLI_PROFILE
+        value: work
+      interactiveMode: IfAvailable
+contexts:
+- name: prod
+  context:
+    cluster: prod
+    user: prod
+    namespace: apps
+current-context: dev
+`, string(bs))
+}
+
+func TestLoadInvalid(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "config")
+	require.NoError(t, os.WriteFile(path, []byte("clusters: foo\n"), 0o600))
+	cfg, err := Load(path)
+	require.NoError(t, err)
+	assert.Error(t, cfg.Set(Entry{Name: "prod"}))
+}
+
+func TestPath(t *testing.T) {
+	sep := string(filepath.ListSeparator)
+	t.Setenv("KUBECONFIG", sep+"/tmp/a"+sep+"/tmp/b")
+	path, err := Path()
+	require.NoError(t, err)
+	assert.Equal(t, "/tmp/a", path)
+}
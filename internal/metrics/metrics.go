@@ -0,0 +1,137 @@
+// Package metrics contains the Prometheus metrics of the tunnels and the API server.
+package metrics
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+const namespace = "pomerium_cli"
+
+var (
+	registry = prometheus.NewRegistry()
+
+	tunnelActiveConnections = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "tunnel",
+		Name:      "active_connections",
+		Help:      "The number of established tunnel connections.",
+	}, []string{"destination"})
+	tunnelConnections = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "tunnel",
+		Name:      "connections_total",
+		Help:      "The number of established tunnel connections by the protocol used to connect to the proxy.",
+	}, []string{"destination", "protocol"})
+	tunnelSentBytes = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "tunnel",
+		Name:      "sent_bytes_total",
+		Help:      "The number of bytes sent from local clients to the destination.",
+	}, []string{"destination"})
+	tunnelReceivedBytes = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "tunnel",
+		Name:      "received_bytes_total",
+		Help:      "The number of bytes received from the destination for local clients.",
+	}, []string{"destination"})
+	udpActiveSessions = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "udp",
+		Name:      "active_sessions",
+		Help:      "The number of active UDP sessions.",
+	}, []string{"destination"})
+	authRefreshes = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "auth",
+		Name:      "refreshes_total",
+		Help:      "The number of times a new JWT was requested from the proxy.",
+	}, []string{"result"})
+	grpcRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "grpc",
+		Name:      "requests_total",
+		Help:      "The number of API requests by method and status code.",
+	}, []string{"method", "code"})
+)
+
+func init() {
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		tunnelActiveConnections,
+		tunnelConnections,
+		tunnelSentBytes,
+		tunnelReceivedBytes,
+		udpActiveSessions,
+		authRefreshes,
+		grpcRequests,
+	)
+}
+
+// Handler returns an HTTP handler serving the metrics in the Prometheus format.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+}
+
+// TunnelConnected records a connection to the destination established via the protocol.
+func TunnelConnected(destination, protocol string) {
+	tunnelActiveConnections.WithLabelValues(destination).Inc()
+	tunnelConnections.WithLabelValues(destination, protocol).Inc()
+}
+
+// TunnelDisconnected records the end of a connection to the destination.
+func TunnelDisconnected(destination string) {
+	tunnelActiveConnections.WithLabelValues(destination).Dec()
+}
+
+// TunnelSent records bytes sent to the destination.
+func TunnelSent(destination string, n int) {
+	if n > 0 {
+		tunnelSentBytes.WithLabelValues(destination).Add(float64(n))
+	}
+}
+
+// TunnelReceived records bytes received from the destination.
+func TunnelReceived(destination string, n int) {
+	if n > 0 {
+		tunnelReceivedBytes.WithLabelValues(destination).Add(float64(n))
+	}
+}
+
+// UDPSessionStarted records the start of a UDP session to the destination.
+func UDPSessionStarted(destination string) {
+	udpActiveSessions.WithLabelValues(destination).Inc()
+}
+
+// UDPSessionStopped records the end of a UDP session to the destination.
+func UDPSessionStopped(destination string) {
+	udpActiveSessions.WithLabelValues(destination).Dec()
+}
+
+// AuthRefreshed records a request for a new JWT and whether it succeeded.
+func AuthRefreshed(err error) {
+	result := "success"
+	if err != nil {
+		result = "failure"
+	}
+	authRefreshes.WithLabelValues(result).Inc()
+}
+
+// UnaryServerInterceptor counts gRPC requests by method and status code.
+func UnaryServerInterceptor(
+	ctx context.Context,
+	req any,
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	res, err := handler(ctx, req)
+	grpcRequests.WithLabelValues(info.FullMethod, status.Code(err).String()).Inc()
+	return res, err
+}
@@ -0,0 +1,40 @@
+package metrics
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler(t *testing.T) {
+	TunnelConnected("example.com:5432", "h2")
+	TunnelSent("example.com:5432", 10)
+	TunnelReceived("example.com:5432", 20)
+	UDPSessionStarted("example.com:53")
+	AuthRefreshed(errors.New("error"))
+
+	srv := httptest.NewServer(Handler())
+	defer srv.Close()
+
+	res, err := http.Get(srv.URL)
+	require.NoError(t, err)
+	defer res.Body.Close()
+	body, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+
+	for _, expect := range []string{
+		`pomerium_cli_tunnel_active_connections{destination="example.com:5432"} 1`,
+		`pomerium_cli_tunnel_connections_total{destination="example.com:5432",protocol="h2"} 1`,
+		`pomerium_cli_tunnel_sent_bytes_total{destination="example.com:5432"} 10`,
+		`pomerium_cli_tunnel_received_bytes_total{destination="example.com:5432"} 20`,
+		`pomerium_cli_udp_active_sessions{destination="example.com:53"} 1`,
+		`pomerium_cli_auth_refreshes_total{result="failure"} 1`,
+	} {
+		assert.Contains(t, string(body), expect)
+	}
+}
@@ -0,0 +1,84 @@
+package portal
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// maxStaleRoutesAge is how long cached routes are served while the portal is unreachable
+const maxStaleRoutesAge = 24 * time.Hour
+
+// routesCache stores ListRoutes results on disk, keyed by server URL
+type routesCache struct {
+	dir string
+}
+
+type cachedRoutes struct {
+	FetchedAt time.Time `json:"fetched_at"`
+	Routes    []Route   `json:"routes"`
+}
+
+// CachedRoutes returns the routes of the server cached in dir, however old,
+// without contacting the server.
+func CachedRoutes(dir, rawServerURL string) ([]Route, error) {
+	serverURL, err := url.Parse(rawServerURL)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing raw server url: %w", err)
+	}
+	cache := &routesCache{dir: dir}
+	cached, err := cache.load(serverURL.String())
+	if err != nil {
+		return nil, err
+	}
+	return cached.Routes, nil
+}
+
+func (c *routesCache) load(serverURL string) (*cachedRoutes, error) {
+	bs, err := os.ReadFile(c.fileName(serverURL))
+	if err != nil {
+		return nil, err
+	}
+
+	var cached cachedRoutes
+	if err := json.Unmarshal(bs, &cached); err != nil {
+		return nil, fmt.Errorf("invalid cached routes: %w", err)
+	}
+	return &cached, nil
+}
+
+func (c *routesCache) store(serverURL string, routes []Route) error {
+	bs, err := json.Marshal(cachedRoutes{FetchedAt: time.Now(), Routes: routes})
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(c.dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create routes cache directory: %w", err)
+	}
+
+	f, err := os.CreateTemp(c.dir, "*.tmp")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(f.Name()) }()
+
+	if _, err := f.Write(bs); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), c.fileName(serverURL))
+}
+
+func (c *routesCache) fileName(serverURL string) string {
+	h := sha256.Sum256([]byte(serverURL))
+	return filepath.Join(c.dir, hex.EncodeToString(h[:])+".json")
+}
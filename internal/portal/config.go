@@ -2,13 +2,24 @@ package portal
 
 import (
 	"crypto/tls"
+	"net/url"
+	"time"
 
+	"github.com/pomerium/cli/authclient"
 	"github.com/pomerium/cli/jwt"
 )
 
 type config struct {
 	browserCommand     string
+	callbackPath       string
+	callbackPorts      authclient.PortRange
+	redirectAddr       string
 	jwtCache           jwt.Cache
+	proxyURL           *url.URL
+	qrCode             bool
+	refresh            bool
+	routesCacheDir     string
+	routesCacheTTL     time.Duration
 	serviceAccount     string
 	serviceAccountFile string
 	tlsConfig          *tls.Config
@@ -22,12 +33,59 @@ func WithBrowserCommand(browserCommand string) Option {
 	}
 }
 
+func WithCallbackPath(callbackPath string) Option {
+	return func(cfg *config) {
+		cfg.callbackPath = callbackPath
+	}
+}
+
+func WithCallbackPortRange(ports authclient.PortRange) Option {
+	return func(cfg *config) {
+		cfg.callbackPorts = ports
+	}
+}
+
+func WithRedirectAddress(addr string) Option {
+	return func(cfg *config) {
+		cfg.redirectAddr = addr
+	}
+}
+
 func WithJWTCache(jwtCache jwt.Cache) Option {
 	return func(cfg *config) {
 		cfg.jwtCache = jwtCache
 	}
 }
 
+func WithOutboundProxy(proxyURL *url.URL) Option {
+	return func(cfg *config) {
+		cfg.proxyURL = proxyURL
+	}
+}
+
+// WithQRCode also renders the login URL as a QR code.
+func WithQRCode(enabled bool) Option {
+	return func(cfg *config) {
+		cfg.qrCode = enabled
+	}
+}
+
+// WithRefresh bypasses cached routes.
+func WithRefresh(refresh bool) Option {
+	return func(cfg *config) {
+		cfg.refresh = refresh
+	}
+}
+
+// WithRoutesCache caches routes in dir for ttl. Cached routes are also served
+// for a while when the portal is unreachable. An empty dir disables caching.
+func WithRoutesCache(dir string, ttl time.Duration) Option {
+	return func(cfg *config) {
+		cfg.routesCacheDir = dir
+		cfg.routesCacheTTL = ttl
+	}
+}
+
 func WithServiceAccount(serviceAccount string) Option {
 	return func(cfg *config) {
 		cfg.serviceAccount = serviceAccount
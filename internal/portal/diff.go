@@ -0,0 +1,62 @@
+package portal
+
+import (
+	"slices"
+	"strings"
+)
+
+// A RouteChangeType describes how a route changed.
+type RouteChangeType string
+
+// route change types
+const (
+	RouteAdded   RouteChangeType = "added"
+	RouteRemoved RouteChangeType = "removed"
+	RouteChanged RouteChangeType = "changed"
+)
+
+// A RouteChange is a difference between two lists of routes.
+type RouteChange struct {
+	Type  RouteChangeType `json:"type"`
+	Route Route           `json:"route"`
+	// Previous is the route before the change, and only set for changed routes.
+	Previous *Route `json:"previous,omitempty"`
+}
+
+// DiffRoutes returns the changes from the previous to the current routes,
+// sorted by route name. Routes are matched by ID, or by From without an ID.
+func DiffRoutes(previous, current []Route) []RouteChange {
+	key := func(r Route) string {
+		if r.ID != "" {
+			return r.ID
+		}
+		return r.From
+	}
+
+	byKey := make(map[string]Route, len(previous))
+	for _, r := range previous {
+		byKey[key(r)] = r
+	}
+
+	var changes []RouteChange
+	for _, r := range current {
+		prev, ok := byKey[key(r)]
+		delete(byKey, key(r))
+		switch {
+		case !ok:
+			changes = append(changes, RouteChange{Type: RouteAdded, Route: r})
+		case prev != r:
+			changes = append(changes, RouteChange{Type: RouteChanged, Route: r, Previous: &prev})
+		}
+	}
+	for _, r := range previous {
+		if _, ok := byKey[key(r)]; ok {
+			changes = append(changes, RouteChange{Type: RouteRemoved, Route: r})
+		}
+	}
+
+	slices.SortStableFunc(changes, func(a, b RouteChange) int {
+		return strings.Compare(a.Route.Name, b.Route.Name)
+	})
+	return changes
+}
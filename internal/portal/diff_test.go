@@ -0,0 +1,31 @@
+package portal_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pomerium/cli/internal/portal"
+)
+
+func TestDiffRoutes(t *testing.T) {
+	t.Parallel()
+
+	previous := []portal.Route{
+		{ID: "r1", Name: "a", From: "https://a.example.com"},
+		{ID: "r2", Name: "b", From: "https://b.example.com"},
+		{ID: "r3", Name: "c", From: "https://c.example.com"},
+	}
+	current := []portal.Route{
+		{ID: "r4", Name: "d", From: "https://d.example.com"},
+		{ID: "r2", Name: "b", From: "https://b2.example.com"},
+		{ID: "r3", Name: "c", From: "https://c.example.com"},
+	}
+
+	assert.Equal(t, []portal.RouteChange{
+		{Type: portal.RouteRemoved, Route: previous[0]},
+		{Type: portal.RouteChanged, Route: current[1], Previous: &previous[1]},
+		{Type: portal.RouteAdded, Route: current[0]},
+	}, portal.DiffRoutes(previous, current))
+	assert.Empty(t, portal.DiffRoutes(current, current))
+}
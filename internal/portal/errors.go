@@ -0,0 +1,45 @@
+package portal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/pomerium/cli/internal/httputil"
+)
+
+var (
+	// ErrUnauthenticated indicates the user needs to log in.
+	ErrUnauthenticated = httputil.ErrUnauthenticated
+	// ErrForbidden indicates the user is not allowed to list routes.
+	ErrForbidden = errors.New("forbidden")
+	// ErrServer indicates the portal API failed. Retrying later may succeed.
+	ErrServer = errors.New("server error")
+	// ErrUnavailable indicates the portal API could not be reached. Retrying later may succeed.
+	ErrUnavailable = errors.New("unavailable")
+)
+
+// classifyError wraps a fetch error with one of the predefined errors
+func classifyError(err error) error {
+	var statusErr *httputil.StatusError
+	switch {
+	case errors.Is(err, ErrUnauthenticated),
+		errors.Is(err, context.Canceled),
+		errors.Is(err, context.DeadlineExceeded):
+		return err
+	case errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusForbidden:
+		return fmt.Errorf("%w: %w", ErrForbidden, err)
+	case errors.As(err, &statusErr) && statusErr.StatusCode >= 500:
+		return fmt.Errorf("%w: %w", ErrServer, err)
+	case statusErr != nil:
+		return err
+	default:
+		return fmt.Errorf("%w: %w", ErrUnavailable, err)
+	}
+}
+
+// isRetryable reports whether retrying the request may succeed
+func isRetryable(err error) bool {
+	return errors.Is(err, ErrServer) || errors.Is(err, ErrUnavailable)
+}
@@ -0,0 +1,46 @@
+package portal
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+)
+
+// A Filter selects routes. The zero value selects all routes.
+type Filter struct {
+	// Types are the route types to select, i.e. tcp, udp or http.
+	Types []string
+	// Name is a glob pattern the route name must match, see path.Match.
+	Name string
+}
+
+// FilterRoutes returns the routes selected by the filter, sorted by name and ID.
+func FilterRoutes(routes []Route, filter Filter) ([]Route, error) {
+	if _, err := path.Match(filter.Name, ""); err != nil {
+		return nil, fmt.Errorf("invalid name pattern %q: %w", filter.Name, err)
+	}
+
+	var selected []Route
+	for _, r := range routes {
+		if len(filter.Types) > 0 && !slices.ContainsFunc(filter.Types, func(t string) bool {
+			return strings.EqualFold(t, r.Type)
+		}) {
+			continue
+		}
+		if filter.Name != "" {
+			if ok, _ := path.Match(filter.Name, r.Name); !ok {
+				continue
+			}
+		}
+		selected = append(selected, r)
+	}
+
+	slices.SortStableFunc(selected, func(a, b Route) int {
+		if c := strings.Compare(a.Name, b.Name); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+	return selected, nil
+}
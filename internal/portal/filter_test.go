@@ -0,0 +1,42 @@
+package portal_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pomerium/cli/internal/portal"
+)
+
+func TestFilterRoutes(t *testing.T) {
+	t.Parallel()
+
+	routes := []portal.Route{
+		{ID: "r3", Name: "db-prod", Type: "tcp"},
+		{ID: "r1", Name: "web", Type: "http"},
+		{ID: "r2", Name: "db-dev", Type: "tcp"},
+		{ID: "r4", Name: "dns", Type: "udp"},
+	}
+	ids := func(routes []portal.Route) []string {
+		var ids []string
+		for _, r := range routes {
+			ids = append(ids, r.ID)
+		}
+		return ids
+	}
+
+	selected, err := portal.FilterRoutes(routes, portal.Filter{})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"r2", "r3", "r4", "r1"}, ids(selected))
+
+	selected, err = portal.FilterRoutes(routes, portal.Filter{Types: []string{"TCP", "udp"}})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"r2", "r3", "r4"}, ids(selected))
+
+	selected, err = portal.FilterRoutes(routes, portal.Filter{Types: []string{"tcp"}, Name: "*-prod"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"r3"}, ids(selected))
+
+	_, err = portal.FilterRoutes(routes, portal.Filter{Name: "["})
+	assert.Error(t, err)
+}
@@ -8,6 +8,10 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"time"
+
+	backoff "github.com/cenkalti/backoff/v4"
+	"github.com/rs/zerolog/log"
 
 	"github.com/pomerium/cli/authclient"
 	"github.com/pomerium/cli/internal/httputil"
@@ -17,6 +21,11 @@ import (
 
 type Route = portal.Route
 
+const (
+	maxRoutesRetries    = 2
+	routesRetryInterval = 200 * time.Millisecond
+)
+
 type Portal struct {
 	cfg        *config
 	authClient *authclient.AuthClient
@@ -29,6 +38,11 @@ func New(options ...Option) *Portal {
 	}
 	p.authClient = authclient.New(
 		authclient.WithBrowserCommand(p.cfg.browserCommand),
+		authclient.WithCallbackPath(p.cfg.callbackPath),
+		authclient.WithCallbackPortRange(p.cfg.callbackPorts),
+		authclient.WithRedirectAddress(p.cfg.redirectAddr),
+		authclient.WithOutboundProxy(p.cfg.proxyURL),
+		authclient.WithQRCode(p.cfg.qrCode),
 		authclient.WithServiceAccount(p.cfg.serviceAccount),
 		authclient.WithServiceAccountFile(p.cfg.serviceAccountFile),
 		authclient.WithTLSConfig(p.cfg.tlsConfig),
@@ -42,7 +56,31 @@ func (p *Portal) ListRoutes(ctx context.Context, rawServerURL string) ([]Route,
 		return nil, fmt.Errorf("error parsing raw server url: %w", err)
 	}
 
-	return p.listRoutesWithCachedJWT(ctx, serverURL)
+	if p.cfg.routesCacheDir == "" {
+		return p.listRoutesWithCachedJWT(ctx, serverURL)
+	}
+
+	cache := &routesCache{dir: p.cfg.routesCacheDir}
+	cached, cacheErr := cache.load(serverURL.String())
+	if cacheErr == nil && !p.cfg.refresh && time.Since(cached.FetchedAt) < p.cfg.routesCacheTTL {
+		return cached.Routes, nil
+	}
+
+	routes, err := p.listRoutesWithCachedJWT(ctx, serverURL)
+	if err != nil {
+		// serve recently cached routes while the portal is unreachable
+		if cacheErr == nil && !p.cfg.refresh && time.Since(cached.FetchedAt) < maxStaleRoutesAge {
+			log.Ctx(ctx).Warn().Err(err).Time("fetched-at", cached.FetchedAt).
+				Msg("failed to list routes, using cached routes")
+			return cached.Routes, nil
+		}
+		return nil, err
+	}
+
+	if err := cache.store(serverURL.String(), routes); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("failed to cache routes")
+	}
+	return routes, nil
 }
 
 func (p *Portal) listRoutesWithCachedJWT(ctx context.Context, serverURL *url.URL) ([]Route, error) {
@@ -64,7 +102,10 @@ func (p *Portal) listRoutesWithCachedJWT(ctx context.Context, serverURL *url.URL
 		// if we aren't authenticated, try to login first before returning an error
 		return p.listRoutesWithNewJWT(ctx, serverURL)
 	} else if err != nil {
-		_ = p.cfg.jwtCache.DeleteJWT(cacheKey)
+		// keep the jwt if the portal is only temporarily unavailable
+		if !isRetryable(err) {
+			_ = p.cfg.jwtCache.DeleteJWT(cacheKey)
+		}
 		return nil, fmt.Errorf("error listing routes: %w", err)
 	}
 
@@ -85,36 +126,54 @@ func (p *Portal) listRoutesWithNewJWT(ctx context.Context, serverURL *url.URL) (
 
 	routes, err := p.listRoutes(ctx, serverURL, rawJWT)
 	if err != nil {
-		_ = p.cfg.jwtCache.DeleteJWT(cacheKey)
+		if !isRetryable(err) {
+			_ = p.cfg.jwtCache.DeleteJWT(cacheKey)
+		}
 		return nil, fmt.Errorf("error listing routes: %w", err)
 	}
 	return routes, err
 }
 
+// listRoutes lists the routes of the routes portal, retrying server and network errors
 func (p *Portal) listRoutes(ctx context.Context, serverURL *url.URL, rawJWT string) ([]Route, error) {
-	serverURL = serverURL.ResolveReference(&url.URL{
+	u := serverURL.ResolveReference(&url.URL{
 		Path: "/.pomerium/api/v1/routes",
 	})
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, serverURL.String(), nil)
-	if err != nil {
-		return nil, fmt.Errorf("error creating routes portal request: %w", err)
+	var routes []Route
+	fetch := func() error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+		if err != nil {
+			return backoff.Permanent(fmt.Errorf("error creating routes portal request: %w", err))
+		}
+		req.Header.Set("Accept", "application/json")
+		req.Header.Set("Authorization", "Bearer Pomerium-"+rawJWT)
+
+		bs, err := httputil.Fetch(ctx, p.cfg.tlsConfig, p.cfg.proxyURL, nil, req)
+		if err != nil {
+			err = fmt.Errorf("error fetching routes portal: %w", classifyError(err))
+			if isRetryable(err) {
+				log.Ctx(ctx).Debug().Err(err).Msg("retrying routes portal request")
+				return err
+			}
+			return backoff.Permanent(err)
+		}
+
+		var res struct {
+			Routes []portal.Route `json:"routes"`
+		}
+		if err := json.Unmarshal(bs, &res); err != nil {
+			return backoff.Permanent(fmt.Errorf("%w: error unmarshaling routes portal response: %w", ErrServer, err))
+		}
+		routes = res.Routes
+		return nil
 	}
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("Authorization", "Bearer Pomerium-"+rawJWT)
 
-	bs, err := httputil.Fetch(ctx, p.cfg.tlsConfig, req)
+	bo := backoff.NewExponentialBackOff()
+	bo.InitialInterval = routesRetryInterval
+	err := backoff.Retry(fetch, backoff.WithContext(backoff.WithMaxRetries(bo, maxRoutesRetries), ctx))
 	if err != nil {
-		return nil, fmt.Errorf("error fetching routes portal: %w", err)
-	}
-
-	var res struct {
-		Routes []portal.Route `json:"routes"`
+		return nil, err
 	}
-	err = json.Unmarshal(bs, &res)
-	if err != nil {
-		return nil, fmt.Errorf("error unmarshaling routes portal response: %w", err)
-	}
-
-	return res.Routes, nil
+	return routes, nil
 }
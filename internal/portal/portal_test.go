@@ -6,11 +6,14 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
 	"github.com/pomerium/cli/internal/portal"
+	"github.com/pomerium/cli/jwt"
 )
 
 func TestPortal(t *testing.T) {
@@ -46,3 +49,123 @@ func TestPortal(t *testing.T) {
 		{ID: "r3", Name: "route-3", Type: "http", From: "https://r3.example.com", Description: "Route #3"},
 	}, routes)
 }
+
+func TestPortalRoutesCache(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int32
+	var fail atomic.Bool
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests.Add(1)
+		if fail.Load() {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"routes": []portal.Route{{ID: "r1", Name: "route-1"}},
+		})
+	}))
+	t.Cleanup(srv.Close)
+
+	ctx := context.Background()
+	dir := t.TempDir()
+	newPortal := func(ttl time.Duration, refresh bool) *portal.Portal {
+		return portal.New(
+			portal.WithServiceAccount("SERVICE-ACCOUNT"),
+			portal.WithTLSConfig(&tls.Config{
+				InsecureSkipVerify: true,
+			}),
+			portal.WithJWTCache(jwt.NewMemoryCache()),
+			portal.WithRoutesCache(dir, ttl),
+			portal.WithRefresh(refresh),
+		)
+	}
+	expect := []portal.Route{{ID: "r1", Name: "route-1"}}
+
+	routes, err := newPortal(time.Hour, false).ListRoutes(ctx, srv.URL)
+	assert.NoError(t, err)
+	assert.Equal(t, expect, routes)
+	assert.EqualValues(t, 1, requests.Load())
+
+	// fresh cached routes are served without a request
+	routes, err = newPortal(time.Hour, false).ListRoutes(ctx, srv.URL)
+	assert.NoError(t, err)
+	assert.Equal(t, expect, routes)
+	assert.EqualValues(t, 1, requests.Load())
+
+	// refresh bypasses the cache
+	_, err = newPortal(time.Hour, true).ListRoutes(ctx, srv.URL)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 2, requests.Load())
+
+	// stale cached routes are served if the portal is unavailable, unless refreshing
+	fail.Store(true)
+	routes, err = newPortal(time.Nanosecond, false).ListRoutes(ctx, srv.URL)
+	assert.NoError(t, err)
+	assert.Equal(t, expect, routes)
+	_, err = newPortal(time.Nanosecond, true).ListRoutes(ctx, srv.URL)
+	assert.Error(t, err)
+
+	// the cached routes are also read without a portal, however old
+	routes, err = portal.CachedRoutes(dir, srv.URL)
+	assert.NoError(t, err)
+	assert.Equal(t, expect, routes)
+	_, err = portal.CachedRoutes(dir, "https://unknown.example.com")
+	assert.Error(t, err)
+}
+
+func TestPortalRetry(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int32
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		// the first request fails, and is retried
+		if requests.Add(1) == 1 {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"routes": []portal.Route{{ID: "r1"}},
+		})
+	}))
+	t.Cleanup(srv.Close)
+
+	p := portal.New(
+		portal.WithServiceAccount("SERVICE-ACCOUNT"),
+		portal.WithTLSConfig(&tls.Config{
+			InsecureSkipVerify: true,
+		}),
+		portal.WithJWTCache(jwt.NewMemoryCache()),
+	)
+	routes, err := p.ListRoutes(context.Background(), srv.URL)
+	assert.NoError(t, err)
+	assert.Equal(t, []portal.Route{{ID: "r1"}}, routes)
+	assert.EqualValues(t, 2, requests.Load())
+}
+
+func TestPortalErrors(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		statusCode int
+		expect     error
+	}{
+		{http.StatusForbidden, portal.ErrForbidden},
+		{http.StatusInternalServerError, portal.ErrServer},
+	} {
+		srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			http.Error(w, http.StatusText(tc.statusCode), tc.statusCode)
+		}))
+		t.Cleanup(srv.Close)
+
+		p := portal.New(
+			portal.WithServiceAccount("SERVICE-ACCOUNT"),
+			portal.WithTLSConfig(&tls.Config{
+				InsecureSkipVerify: true,
+			}),
+			portal.WithJWTCache(jwt.NewMemoryCache()),
+		)
+		_, err := p.ListRoutes(context.Background(), srv.URL)
+		assert.ErrorIs(t, err, tc.expect)
+	}
+}
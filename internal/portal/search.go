@@ -0,0 +1,58 @@
+package portal
+
+import (
+	"slices"
+	"strings"
+)
+
+// SearchRoutes returns the routes fuzzy matching the query, best matches first.
+// A route matches if the characters of the query appear in order in its name
+// or from URL, ignoring case. An empty query matches all routes.
+func SearchRoutes(routes []Route, query string) []Route {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return routes
+	}
+
+	type match struct {
+		route Route
+		score int
+	}
+	var matches []match
+	for _, r := range routes {
+		name, from := strings.ToLower(r.Name), strings.ToLower(r.From)
+		score := 0
+		switch {
+		case strings.HasPrefix(name, query):
+		case strings.Contains(name, query):
+			score = 1
+		case isSubsequence(query, name):
+			score = 2
+		case strings.Contains(from, query):
+			score = 3
+		case isSubsequence(query, from):
+			score = 4
+		default:
+			continue
+		}
+		matches = append(matches, match{r, score})
+	}
+
+	slices.SortStableFunc(matches, func(a, b match) int { return a.score - b.score })
+	selected := make([]Route, len(matches))
+	for i, m := range matches {
+		selected[i] = m.route
+	}
+	return selected
+}
+
+func isSubsequence(query, s string) bool {
+	for _, c := range query {
+		i := strings.IndexRune(s, c)
+		if i < 0 {
+			return false
+		}
+		s = s[i+len(string(c)):]
+	}
+	return true
+}
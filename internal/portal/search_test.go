@@ -0,0 +1,34 @@
+package portal_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pomerium/cli/internal/portal"
+)
+
+func TestSearchRoutes(t *testing.T) {
+	t.Parallel()
+
+	routes := []portal.Route{
+		{ID: "r1", Name: "postgres-dev", From: "tcp+https://pg.dev.example.com:5432"},
+		{ID: "r2", Name: "dev-postgres", From: "tcp+https://pg2.dev.example.com:5432"},
+		{ID: "r3", Name: "dns", From: "udp+https://dns.example.com:53"},
+		{ID: "r4", Name: "redis", From: "tcp+https://cache.example.com:6379"},
+	}
+	ids := func(routes []portal.Route) []string {
+		var ids []string
+		for _, r := range routes {
+			ids = append(ids, r.ID)
+		}
+		return ids
+	}
+
+	assert.Equal(t, []string{"r1", "r2", "r3", "r4"}, ids(portal.SearchRoutes(routes, " ")))
+	assert.Equal(t, []string{"r2", "r1"}, ids(portal.SearchRoutes(routes, "DEV")))
+	assert.Equal(t, []string{"r1", "r2"}, ids(portal.SearchRoutes(routes, "pgdev")))
+	assert.Equal(t, []string{"r1", "r2", "r3", "r4"}, ids(portal.SearchRoutes(routes, "s")))
+	assert.Equal(t, []string{"r4"}, ids(portal.SearchRoutes(routes, "cache")))
+	assert.Empty(t, portal.SearchRoutes(routes, "mysql"))
+}
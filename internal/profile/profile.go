@@ -0,0 +1,51 @@
+// Package profile selects the named profile, which scopes the config and
+// cache directories and the keyring, so that separate logins can be kept side
+// by side.
+package profile
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+)
+
+// DirName is the directory the profiles are kept in, below the config and
+// cache directories of the default profile.
+const DirName = "profiles"
+
+var (
+	current   string
+	nameRegex = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]*$`)
+)
+
+// Set selects the profile, the default profile if name is empty.
+func Set(name string) error {
+	if name != "" && !nameRegex.MatchString(name) {
+		return fmt.Errorf("invalid profile name %q, expected letters, digits, '.', '_' or '-'", name)
+	}
+	current = name
+	return nil
+}
+
+// Name returns the name of the profile, it is empty for the default profile.
+func Name() string {
+	return current
+}
+
+// Dir returns the directory of the profile, given the directory of the
+// default profile.
+func Dir(root string) string {
+	if current == "" {
+		return root
+	}
+	return filepath.Join(root, DirName, current)
+}
+
+// KeyringService returns the keyring service of the profile, given the
+// service of the default profile.
+func KeyringService(service string) string {
+	if current == "" {
+		return service
+	}
+	return service + "/" + current
+}
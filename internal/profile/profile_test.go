@@ -0,0 +1,25 @@
+package profile
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProfile(t *testing.T) {
+	t.Cleanup(func() { current = "" })
+
+	assert.Equal(t, "root", Dir("root"))
+	assert.Equal(t, "pomerium-cli", KeyringService("pomerium-cli"))
+
+	assert.NoError(t, Set("prod.admin"))
+	assert.Equal(t, "prod.admin", Name())
+	assert.Equal(t, filepath.Join("root", "profiles", "prod.admin"), Dir("root"))
+	assert.Equal(t, "pomerium-cli/prod.admin", KeyringService("pomerium-cli"))
+
+	for _, name := range []string{"..", ".hidden", "a/b", `a\b`, "a b"} {
+		assert.Error(t, Set(name), name)
+	}
+	assert.Equal(t, "prod.admin", Name(), "should keep the profile on error")
+}
@@ -0,0 +1,79 @@
+// Package proxyproto encodes PROXY protocol headers, which pass the addresses
+// of a proxied connection on to its destination.
+//
+// See https://www.haproxy.org/download/2.9/doc/proxy-protocol.txt
+package proxyproto
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"net/netip"
+)
+
+var v2Signature = []byte("\r\n\r\n\x00\r\nQUIT\n")
+
+const (
+	v2CommandLocal = 0x20
+	v2CommandProxy = 0x21
+	v2FamilyTCP4   = 0x11
+	v2FamilyTCP6   = 0x21
+)
+
+// Header returns the PROXY protocol header of the given version, 1 or 2, for
+// a connection from src to dst. The addresses are omitted unless both are TCP
+// addresses.
+func Header(version int, src, dst net.Addr) ([]byte, error) {
+	srcAddr, srcOK := tcpAddrPort(src)
+	dstAddr, dstOK := tcpAddrPort(dst)
+	known := srcOK && dstOK
+	// mixed address families are sent as IPv6
+	if known && srcAddr.Addr().Is4() != dstAddr.Addr().Is4() {
+		srcAddr = to6(srcAddr)
+		dstAddr = to6(dstAddr)
+	}
+
+	switch version {
+	case 1:
+		if !known {
+			return []byte("PROXY UNKNOWN\r\n"), nil
+		}
+		family := "TCP4"
+		if srcAddr.Addr().Is6() {
+			family = "TCP6"
+		}
+		return fmt.Appendf(nil, "PROXY %s %s %s %d %d\r\n",
+			family, srcAddr.Addr(), dstAddr.Addr(), srcAddr.Port(), dstAddr.Port()), nil
+	case 2:
+		b := append([]byte(nil), v2Signature...)
+		if !known {
+			return append(b, v2CommandLocal, 0, 0, 0), nil
+		}
+		family := byte(v2FamilyTCP4)
+		if srcAddr.Addr().Is6() {
+			family = v2FamilyTCP6
+		}
+		var addrs []byte
+		addrs = append(addrs, srcAddr.Addr().AsSlice()...)
+		addrs = append(addrs, dstAddr.Addr().AsSlice()...)
+		addrs = binary.BigEndian.AppendUint16(addrs, srcAddr.Port())
+		addrs = binary.BigEndian.AppendUint16(addrs, dstAddr.Port())
+		b = append(b, v2CommandProxy, family)
+		b = binary.BigEndian.AppendUint16(b, uint16(len(addrs)))
+		return append(b, addrs...), nil
+	}
+	return nil, fmt.Errorf("unsupported PROXY protocol version: %d", version)
+}
+
+func tcpAddrPort(addr net.Addr) (netip.AddrPort, bool) {
+	tcpAddr, ok := addr.(*net.TCPAddr)
+	if !ok {
+		return netip.AddrPort{}, false
+	}
+	addrPort := tcpAddr.AddrPort()
+	return netip.AddrPortFrom(addrPort.Addr().Unmap(), addrPort.Port()), addrPort.IsValid()
+}
+
+func to6(addrPort netip.AddrPort) netip.AddrPort {
+	return netip.AddrPortFrom(netip.AddrFrom16(addrPort.Addr().As16()), addrPort.Port())
+}
@@ -0,0 +1,54 @@
+package proxyproto
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHeader(t *testing.T) {
+	t.Parallel()
+
+	tcp4Src := &net.TCPAddr{IP: net.IPv4(192, 168, 0, 1), Port: 56324}
+	tcp4Dst := &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 5432}
+	tcp6Dst := &net.TCPAddr{IP: net.IPv6loopback, Port: 5432}
+	unixAddr := &net.UnixAddr{Name: "/tmp/tunnel.sock", Net: "unix"}
+
+	for _, tc := range []struct {
+		name     string
+		version  int
+		src, dst net.Addr
+		expect   string
+	}{
+		{"v1 tcp4", 1, tcp4Src, tcp4Dst, "PROXY TCP4 192.168.0.1 127.0.0.1 56324 5432\r\n"},
+		{"v1 mixed", 1, tcp4Src, tcp6Dst, "PROXY TCP6 ::ffff:192.168.0.1 ::1 56324 5432\r\n"},
+		{"v1 unknown", 1, unixAddr, unixAddr, "PROXY UNKNOWN\r\n"},
+		{"v1 nil", 1, nil, nil, "PROXY UNKNOWN\r\n"},
+		{
+			"v2 tcp4", 2, tcp4Src, tcp4Dst,
+			"\r\n\r\n\x00\r\nQUIT\n\x21\x11\x00\x0c" +
+				"\xc0\xa8\x00\x01\x7f\x00\x00\x01\xdc\x04\x15\x38",
+		},
+		{
+			"v2 mixed", 2, tcp4Src, tcp6Dst,
+			"\r\n\r\n\x00\r\nQUIT\n\x21\x21\x00\x24" +
+				"\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\xff\xff\xc0\xa8\x00\x01" +
+				"\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x01" +
+				"\xdc\x04\x15\x38",
+		},
+		{"v2 unknown", 2, unixAddr, unixAddr, "\r\n\r\n\x00\r\nQUIT\n\x20\x00\x00\x00"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			header, err := Header(tc.version, tc.src, tc.dst)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expect, string(header))
+		})
+	}
+
+	_, err := Header(3, tcp4Src, tcp4Dst)
+	assert.Error(t, err)
+}
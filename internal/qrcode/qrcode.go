@@ -0,0 +1,64 @@
+// Package qrcode renders QR codes, i.e. to show URLs in a terminal.
+package qrcode
+
+import (
+	"io"
+	"strings"
+
+	"github.com/skip2/go-qrcode"
+)
+
+// A Code is a QR code, including the light border around it.
+type Code struct {
+	modules [][]bool
+}
+
+// Encode encodes the data with the low error correction level, which is
+// enough since a terminal is a clean medium.
+func Encode(data []byte) (*Code, error) {
+	qr, err := qrcode.New(string(data), qrcode.Low)
+	if err != nil {
+		return nil, err
+	}
+	return &Code{modules: qr.Bitmap()}, nil
+}
+
+// Size returns the number of modules per side, including the border.
+func (c *Code) Size() int {
+	return len(c.modules)
+}
+
+// Dark reports whether the module at x, y is dark. Modules outside of the
+// code are light.
+func (c *Code) Dark(x, y int) bool {
+	return y >= 0 && y < len(c.modules) && x >= 0 && x < len(c.modules[y]) && c.modules[y][x]
+}
+
+// WriteANSI writes the code for display in a terminal, using ANSI colors and
+// half blocks, so that each character shows two modules on top of each other.
+func (c *Code) WriteANSI(w io.Writer) error {
+	const (
+		darkTop     = "\x1b[30m"
+		lightTop    = "\x1b[97m"
+		darkBottom  = "\x1b[40m"
+		lightBottom = "\x1b[107m"
+		reset       = "\x1b[0m"
+	)
+
+	var sb strings.Builder
+	for y := 0; y < c.Size(); y += 2 {
+		for x := 0; x < c.Size(); x++ {
+			top, bottom := lightTop, lightBottom
+			if c.Dark(x, y) {
+				top = darkTop
+			}
+			if c.Dark(x, y+1) {
+				bottom = darkBottom
+			}
+			sb.WriteString(top + bottom + "▀")
+		}
+		sb.WriteString(reset + "\n")
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
@@ -0,0 +1,43 @@
+package qrcode
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncode(t *testing.T) {
+	t.Parallel()
+
+	c, err := Encode([]byte("https://authenticate.example.com/.pomerium/sign_in?pomerium_redirect_uri=http%3A%2F%2F127.0.0.1%3A8800"))
+	require.NoError(t, err)
+	// the finder pattern in the top left corner, after the border
+	assert.False(t, c.Dark(3, 3))
+	for i := 0; i < 7; i++ {
+		assert.True(t, c.Dark(4+i, 4), i)
+		assert.True(t, c.Dark(4, 4+i), i)
+	}
+	assert.False(t, c.Dark(-1, 0))
+	assert.False(t, c.Dark(0, c.Size()))
+
+	_, err = Encode(make([]byte, 3000))
+	assert.Error(t, err)
+}
+
+func TestWriteANSI(t *testing.T) {
+	t.Parallel()
+
+	c, err := Encode([]byte("HELLO WORLD"))
+	require.NoError(t, err)
+	// version 1 with a border of 4 modules
+	assert.Equal(t, 21+8, c.Size())
+
+	var buf bytes.Buffer
+	require.NoError(t, c.WriteANSI(&buf))
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	assert.Len(t, lines, (c.Size()+1)/2)
+	assert.Equal(t, c.Size(), strings.Count(lines[0], "▀"))
+}
@@ -0,0 +1,77 @@
+package selfupdate
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+)
+
+// extractExecutable returns the pomerium-cli executable in the named archive
+func extractExecutable(archive []byte, name string) ([]byte, error) {
+	if strings.HasSuffix(name, ".zip") {
+		return extractZip(archive)
+	}
+	return extractTarGz(archive)
+}
+
+func isExecutable(name string) bool {
+	base := path.Base(strings.ReplaceAll(name, `\`, "/"))
+	return base == "pomerium-cli" || base == "pomerium-cli.exe"
+}
+
+func extractTarGz(archive []byte) ([]byte, error) {
+	zr, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return nil, fmt.Errorf("invalid archive: %w", err)
+	}
+	tr := tar.NewReader(zr)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("invalid archive: %w", err)
+		}
+		if hdr.Typeflag != tar.TypeReg || !isExecutable(hdr.Name) {
+			continue
+		}
+		return readAll(tr)
+	}
+	return nil, fmt.Errorf("archive does not contain pomerium-cli")
+}
+
+func extractZip(archive []byte) ([]byte, error) {
+	zr, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid archive: %w", err)
+	}
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() || !isExecutable(f.Name) {
+			continue
+		}
+		r, err := f.Open()
+		if err != nil {
+			return nil, fmt.Errorf("invalid archive: %w", err)
+		}
+		defer func() { _ = r.Close() }()
+		return readAll(r)
+	}
+	return nil, fmt.Errorf("archive does not contain pomerium-cli")
+}
+
+func readAll(r io.Reader) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(r, maxAssetSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("invalid archive: %w", err)
+	}
+	if len(data) > maxAssetSize {
+		return nil, fmt.Errorf("executable exceeds %d bytes", maxAssetSize)
+	}
+	return data, nil
+}
@@ -0,0 +1,72 @@
+package selfupdate
+
+import (
+	"crypto/ed25519"
+	"net/http"
+	"runtime"
+	"runtime/debug"
+)
+
+type config struct {
+	client      *http.Client
+	releasesURL string
+	publicKey   ed25519.PublicKey
+	goos        string
+	goarch      string
+	goarm       string
+}
+
+type Option func(cfg *config)
+
+func WithHTTPClient(client *http.Client) Option {
+	return func(cfg *config) {
+		cfg.client = client
+	}
+}
+
+// WithPlatform sets the platform of the release artifact to download.
+func WithPlatform(goos, goarch, goarm string) Option {
+	return func(cfg *config) {
+		cfg.goos = goos
+		cfg.goarch = goarch
+		cfg.goarm = goarm
+	}
+}
+
+// WithPublicKey sets the key the checksums of a release must be signed with.
+// Without a key, releases cannot be verified and are not downloaded.
+func WithPublicKey(key ed25519.PublicKey) Option {
+	return func(cfg *config) {
+		cfg.publicKey = key
+	}
+}
+
+// WithReleasesURL sets the GitHub API endpoint listing the releases.
+func WithReleasesURL(releasesURL string) Option {
+	return func(cfg *config) {
+		cfg.releasesURL = releasesURL
+	}
+}
+
+func getConfig(options ...Option) *config {
+	cfg := new(config)
+	WithHTTPClient(http.DefaultClient)(cfg)
+	WithPlatform(runtime.GOOS, runtime.GOARCH, buildGOARM())(cfg)
+	WithReleasesURL(DefaultReleasesURL)(cfg)
+	for _, option := range options {
+		option(cfg)
+	}
+	return cfg
+}
+
+// buildGOARM returns the ARM version the executable was built for
+func buildGOARM() string {
+	if info, ok := debug.ReadBuildInfo(); ok {
+		for _, s := range info.Settings {
+			if s.Key == "GOARM" {
+				return s.Value
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,78 @@
+package selfupdate
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// managedPrefixes contain executables installed by a package manager
+var managedPrefixes = []string{"/usr/bin/", "/usr/sbin/", "/nix/store/"}
+
+// Managed reports whether the executable was installed by a package manager,
+// which should also update it.
+func Managed(exe string) bool {
+	exe = filepath.ToSlash(exe)
+	for _, prefix := range managedPrefixes {
+		if strings.HasPrefix(exe, prefix) {
+			return true
+		}
+	}
+	// homebrew keeps formulae in the Cellar, under its prefix
+	return strings.Contains(exe, "/Cellar/")
+}
+
+// Replace atomically replaces the executable at the path with the data,
+// keeping its permissions. As a running executable cannot be replaced on
+// Windows, it is renamed to a .old file first, which is removed by the next
+// update.
+func Replace(exe string, data []byte) error {
+	fi, err := os.Stat(exe)
+	if err != nil {
+		return err
+	}
+
+	// the new executable is written to the same directory, so that it can be
+	// renamed over the current one
+	f, err := os.CreateTemp(filepath.Dir(exe), "."+filepath.Base(exe)+".*.new")
+	if err != nil {
+		return fmt.Errorf("error writing executable: %w", err)
+	}
+	tmp := f.Name()
+	defer func() { _ = os.Remove(tmp) }()
+
+	_, err = f.Write(data)
+	if err == nil {
+		err = f.Sync()
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Chmod(tmp, fi.Mode().Perm())
+	}
+	if err != nil {
+		return fmt.Errorf("error writing executable: %w", err)
+	}
+
+	if runtime.GOOS != "windows" {
+		return os.Rename(tmp, exe)
+	}
+
+	old := exe + ".old"
+	if err := os.Remove(old); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("error removing previous executable: %w", err)
+	}
+	if err := os.Rename(exe, old); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, exe); err != nil {
+		_ = os.Rename(old, exe)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,251 @@
+// Package selfupdate replaces the running executable with the latest release
+// of pomerium-cli published on GitHub.
+package selfupdate
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/pomerium/cli/internal/httputil"
+	"github.com/pomerium/cli/version"
+)
+
+// DefaultReleasesURL lists the releases of pomerium-cli.
+const DefaultReleasesURL = "https://api.github.com/repos/pomerium/cli/releases?per_page=100"
+
+const (
+	// checksumsAsset lists the SHA-256 checksums of the release artifacts
+	checksumsAsset = "pomerium-cli_checksums.txt"
+	// signatureAsset is the ed25519 signature of the checksums
+	signatureAsset = checksumsAsset + ".sig"
+
+	maxAssetSize = 256 << 20
+)
+
+// ErrNoPublicKey indicates the executable was built without the key the
+// releases are signed with, so a downloaded release cannot be verified.
+var ErrNoPublicKey = errors.New("self-update not available in this build, " +
+	"download the release from https://github.com/pomerium/cli/releases instead")
+
+// ErrNoRelease indicates no release was found on the channel.
+var ErrNoRelease = errors.New("no release found")
+
+// A Channel selects the releases to update to.
+type Channel string
+
+const (
+	// ChannelStable only updates to releases.
+	ChannelStable Channel = "stable"
+	// ChannelBeta also updates to pre-releases.
+	ChannelBeta Channel = "beta"
+)
+
+// ParseChannel parses the name of a channel.
+func ParseChannel(s string) (Channel, error) {
+	switch c := Channel(s); c {
+	case ChannelStable, ChannelBeta:
+		return c, nil
+	}
+	return "", fmt.Errorf("unknown channel %q, expected %s or %s", s, ChannelStable, ChannelBeta)
+}
+
+// A Release is a published release of pomerium-cli.
+type Release struct {
+	Version    string
+	Prerelease bool
+	// URL is the web page of the release.
+	URL string
+
+	// assets maps the names of the artifacts to their download URLs
+	assets map[string]string
+}
+
+// Newer reports whether the release is newer than the current version.
+func (r *Release) Newer(current string) bool {
+	return version.Compare(r.Version, current) > 0
+}
+
+// An Updater finds and downloads releases.
+type Updater struct {
+	cfg *config
+}
+
+// New creates a new Updater.
+func New(options ...Option) *Updater {
+	return &Updater{cfg: getConfig(options...)}
+}
+
+type githubRelease struct {
+	TagName    string `json:"tag_name"`
+	HTMLURL    string `json:"html_url"`
+	Draft      bool   `json:"draft"`
+	Prerelease bool   `json:"prerelease"`
+	Assets     []struct {
+		Name               string `json:"name"`
+		BrowserDownloadURL string `json:"browser_download_url"`
+	} `json:"assets"`
+}
+
+// Latest returns the newest release on the channel.
+func (u *Updater) Latest(ctx context.Context, channel Channel) (*Release, error) {
+	data, err := u.get(ctx, u.cfg.releasesURL, "application/vnd.github+json")
+	if err != nil {
+		return nil, fmt.Errorf("error listing releases: %w", err)
+	}
+	var releases []githubRelease
+	if err := json.Unmarshal(data, &releases); err != nil {
+		return nil, fmt.Errorf("error listing releases: %w", err)
+	}
+
+	var latest *Release
+	for _, gr := range releases {
+		if gr.Draft || !version.IsValid(gr.TagName) {
+			continue
+		}
+		prerelease := gr.Prerelease || version.IsPrerelease(gr.TagName)
+		if prerelease && channel != ChannelBeta {
+			continue
+		}
+		if latest != nil && version.Compare(gr.TagName, latest.Version) <= 0 {
+			continue
+		}
+		latest = &Release{
+			Version:    gr.TagName,
+			Prerelease: prerelease,
+			URL:        gr.HTMLURL,
+			assets:     make(map[string]string, len(gr.Assets)),
+		}
+		for _, a := range gr.Assets {
+			latest.assets[a.Name] = a.BrowserDownloadURL
+		}
+	}
+	if latest == nil {
+		return nil, fmt.Errorf("%w on the %s channel", ErrNoRelease, channel)
+	}
+	return latest, nil
+}
+
+// ArchiveName returns the name of the release artifact for the platform.
+func (u *Updater) ArchiveName() (string, error) {
+	name := "pomerium-cli-" + u.cfg.goos + "-" + u.cfg.goarch
+	if u.cfg.goarch == "arm" {
+		// the setting may include the floating point mode, as in 7,hardfloat
+		goarm, _, _ := strings.Cut(u.cfg.goarm, ",")
+		if goarm == "" {
+			return "", fmt.Errorf("unknown ARM version")
+		}
+		name += "v" + goarm
+	}
+	if u.cfg.goos == "windows" {
+		return name + ".zip", nil
+	}
+	return name + ".tar.gz", nil
+}
+
+// Download downloads the executable of the release for the platform, after
+// verifying the signature of the release checksums and the checksum of the
+// artifact.
+func (u *Updater) Download(ctx context.Context, r *Release) ([]byte, error) {
+	if len(u.cfg.publicKey) == 0 {
+		return nil, ErrNoPublicKey
+	}
+	name, err := u.ArchiveName()
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := r.assets[name]; !ok {
+		return nil, fmt.Errorf("release %s has no %s artifact", r.Version, name)
+	}
+
+	checksums, err := u.getAsset(ctx, r, checksumsAsset)
+	if err != nil {
+		return nil, err
+	}
+	signature, err := u.getAsset(ctx, r, signatureAsset)
+	if err != nil {
+		return nil, err
+	}
+	if !ed25519.Verify(u.cfg.publicKey, checksums, signature) {
+		return nil, fmt.Errorf("invalid signature of the checksums of release %s", r.Version)
+	}
+	want, err := findChecksum(checksums, name)
+	if err != nil {
+		return nil, err
+	}
+
+	archive, err := u.getAsset(ctx, r, name)
+	if err != nil {
+		return nil, err
+	}
+	if got := sha256.Sum256(archive); !bytes.Equal(got[:], want) {
+		return nil, fmt.Errorf("checksum mismatch for %s", name)
+	}
+	return extractExecutable(archive, name)
+}
+
+func (u *Updater) getAsset(ctx context.Context, r *Release, name string) ([]byte, error) {
+	rawURL, ok := r.assets[name]
+	if !ok {
+		return nil, fmt.Errorf("release %s has no %s", r.Version, name)
+	}
+	data, err := u.get(ctx, rawURL, "application/octet-stream")
+	if err != nil {
+		return nil, fmt.Errorf("error downloading %s: %w", name, err)
+	}
+	return data, nil
+}
+
+func (u *Updater) get(ctx context.Context, rawURL, accept string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", accept)
+	req.Header.Set("User-Agent", version.UserAgent())
+
+	res, err := u.cfg.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = res.Body.Close() }()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, &httputil.StatusError{StatusCode: res.StatusCode, Status: res.Status}
+	}
+	data, err := io.ReadAll(io.LimitReader(res.Body, maxAssetSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxAssetSize {
+		return nil, fmt.Errorf("response exceeds %d bytes", maxAssetSize)
+	}
+	return data, nil
+}
+
+// findChecksum returns the checksum of the named file, from lines in the
+// format of sha256sum
+func findChecksum(checksums []byte, name string) ([]byte, error) {
+	s := bufio.NewScanner(bytes.NewReader(checksums))
+	for s.Scan() {
+		fields := strings.Fields(s.Text())
+		if len(fields) != 2 || strings.TrimPrefix(fields[1], "*") != name {
+			continue
+		}
+		sum, err := hex.DecodeString(fields[0])
+		if err != nil || len(sum) != sha256.Size {
+			return nil, fmt.Errorf("invalid checksum for %s", name)
+		}
+		return sum, nil
+	}
+	return nil, fmt.Errorf("no checksum for %s", name)
+}
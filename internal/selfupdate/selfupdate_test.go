@@ -0,0 +1,198 @@
+package selfupdate
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testRelease struct {
+	pub       ed25519.PublicKey
+	exe       []byte
+	checksums []byte
+	signature []byte
+}
+
+// newTestRelease returns a server publishing v0.30.0, with the pre-release
+// v0.31.0-rc1 and the draft v0.32.0
+func newTestRelease(t *testing.T) (*httptest.Server, *testRelease) {
+	t.Helper()
+
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	tr := &testRelease{pub: pub, exe: []byte("#!/bin/sh\necho v0.30.0\n")}
+
+	var archive bytes.Buffer
+	zw := gzip.NewWriter(&archive)
+	tw := tar.NewWriter(zw)
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: "pomerium-cli", Mode: 0o755, Size: int64(len(tr.exe))}))
+	_, err = tw.Write(tr.exe)
+	require.NoError(t, err)
+	require.NoError(t, tw.Close())
+	require.NoError(t, zw.Close())
+
+	sum := sha256.Sum256(archive.Bytes())
+	tr.checksums = []byte("0000000000000000000000000000000000000000000000000000000000000000  pomerium-cli-darwin-arm64.tar.gz\n" +
+		hex.EncodeToString(sum[:]) + "  pomerium-cli-linux-amd64.tar.gz\n")
+	tr.signature = ed25519.Sign(priv, tr.checksums)
+
+	mux := http.NewServeMux()
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	assets := func(tag string) []map[string]string {
+		var as []map[string]string
+		for _, name := range []string{checksumsAsset, signatureAsset, "pomerium-cli-linux-amd64.tar.gz"} {
+			as = append(as, map[string]string{
+				"name":                 name,
+				"browser_download_url": srv.URL + "/download/" + tag + "/" + name,
+			})
+		}
+		return as
+	}
+	mux.HandleFunc("/releases", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode([]map[string]any{
+			{"tag_name": "v0.32.0", "draft": true, "assets": assets("v0.32.0")},
+			{"tag_name": "v0.31.0-rc1", "prerelease": true, "assets": assets("v0.31.0-rc1")},
+			{"tag_name": "v0.30.0", "html_url": "https://example.com/v0.30.0", "assets": assets("v0.30.0")},
+			{"tag_name": "v0.29.1", "assets": assets("v0.29.1")},
+		})
+	})
+	mux.HandleFunc("/download/v0.30.0/"+checksumsAsset, func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(tr.checksums)
+	})
+	mux.HandleFunc("/download/v0.30.0/"+signatureAsset, func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(tr.signature)
+	})
+	mux.HandleFunc("/download/v0.30.0/pomerium-cli-linux-amd64.tar.gz", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(archive.Bytes())
+	})
+	return srv, tr
+}
+
+func TestLatest(t *testing.T) {
+	t.Parallel()
+
+	srv, _ := newTestRelease(t)
+	u := New(WithReleasesURL(srv.URL + "/releases"))
+
+	r, err := u.Latest(context.Background(), ChannelStable)
+	require.NoError(t, err)
+	assert.Equal(t, "v0.30.0", r.Version)
+	assert.Equal(t, "https://example.com/v0.30.0", r.URL)
+	assert.False(t, r.Prerelease)
+	assert.True(t, r.Newer("v0.29.1"))
+	assert.False(t, r.Newer("v0.30.0"))
+
+	r, err = u.Latest(context.Background(), ChannelBeta)
+	require.NoError(t, err)
+	assert.Equal(t, "v0.31.0-rc1", r.Version)
+	assert.True(t, r.Prerelease)
+}
+
+func TestDownload(t *testing.T) {
+	t.Parallel()
+
+	srv, tr := newTestRelease(t)
+	latest := func(t *testing.T, options ...Option) (*Updater, *Release) {
+		u := New(append([]Option{WithReleasesURL(srv.URL + "/releases")}, options...)...)
+		r, err := u.Latest(context.Background(), ChannelStable)
+		require.NoError(t, err)
+		return u, r
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		u, r := latest(t, WithPublicKey(tr.pub), WithPlatform("linux", "amd64", ""))
+		exe, err := u.Download(context.Background(), r)
+		require.NoError(t, err)
+		assert.Equal(t, tr.exe, exe)
+	})
+	t.Run("no public key", func(t *testing.T) {
+		u, r := latest(t, WithPlatform("linux", "amd64", ""))
+		_, err := u.Download(context.Background(), r)
+		assert.ErrorIs(t, err, ErrNoPublicKey)
+	})
+	t.Run("wrong public key", func(t *testing.T) {
+		pub, _, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+		u, r := latest(t, WithPublicKey(pub), WithPlatform("linux", "amd64", ""))
+		_, err = u.Download(context.Background(), r)
+		assert.ErrorContains(t, err, "invalid signature")
+	})
+	t.Run("checksum mismatch", func(t *testing.T) {
+		u, r := latest(t, WithPublicKey(tr.pub), WithPlatform("darwin", "arm64", ""))
+		r.assets["pomerium-cli-darwin-arm64.tar.gz"] = r.assets["pomerium-cli-linux-amd64.tar.gz"]
+		_, err := u.Download(context.Background(), r)
+		assert.ErrorContains(t, err, "checksum mismatch")
+	})
+	t.Run("missing artifact", func(t *testing.T) {
+		u, r := latest(t, WithPublicKey(tr.pub), WithPlatform("freebsd", "amd64", ""))
+		_, err := u.Download(context.Background(), r)
+		assert.ErrorContains(t, err, "has no pomerium-cli-freebsd-amd64.tar.gz")
+	})
+}
+
+func TestArchiveName(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		goos, goarch, goarm string
+		expect              string
+	}{
+		{"linux", "amd64", "", "pomerium-cli-linux-amd64.tar.gz"},
+		{"linux", "arm", "7,hardfloat", "pomerium-cli-linux-armv7.tar.gz"},
+		{"darwin", "arm64", "", "pomerium-cli-darwin-arm64.tar.gz"},
+		{"windows", "amd64", "", "pomerium-cli-windows-amd64.zip"},
+	} {
+		name, err := New(WithPlatform(tc.goos, tc.goarch, tc.goarm)).ArchiveName()
+		require.NoError(t, err)
+		assert.Equal(t, tc.expect, name)
+	}
+}
+
+func TestReplace(t *testing.T) {
+	t.Parallel()
+
+	exe := filepath.Join(t.TempDir(), "pomerium-cli")
+	require.NoError(t, os.WriteFile(exe, []byte("old"), 0o750))
+
+	require.NoError(t, Replace(exe, []byte("new")))
+	data, err := os.ReadFile(exe)
+	require.NoError(t, err)
+	assert.Equal(t, "new", string(data))
+
+	if runtime.GOOS != "windows" {
+		fi, err := os.Stat(exe)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0o750), fi.Mode().Perm())
+	}
+	entries, err := os.ReadDir(filepath.Dir(exe))
+	require.NoError(t, err)
+	for _, e := range entries {
+		assert.NotContains(t, e.Name(), ".new", "temporary files should be removed")
+	}
+}
+
+func TestManaged(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, Managed("/usr/bin/pomerium-cli"))
+	assert.True(t, Managed("/opt/homebrew/Cellar/pomerium-cli/0.29.0/bin/pomerium-cli"))
+	assert.False(t, Managed("/usr/local/bin/pomerium-cli"))
+	assert.False(t, Managed("/home/user/bin/pomerium-cli"))
+}
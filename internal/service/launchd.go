@@ -0,0 +1,153 @@
+package service
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// launchdLabelPrefix is prepended to the service name for the launchd label
+const launchdLabelPrefix = "com.pomerium.cli."
+
+// Launchd returns the manager of the launchd agents of the current user, which
+// run while the user is logged in.
+func Launchd() Manager {
+	return launchd{}
+}
+
+type launchd struct{}
+
+func (launchd) plistPath(name string) (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, "Library", "LaunchAgents", launchdLabelPrefix+name+".plist"), nil
+}
+
+// domain is the launchd domain of the agents of the current user
+func (launchd) domain() string {
+	return "gui/" + strconv.Itoa(os.Getuid())
+}
+
+func (m launchd) Install(cfg Config) error {
+	path, err := m.plistPath(cfg.Name)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(path); err == nil {
+		return fmt.Errorf("%s already exists", path)
+	}
+
+	if cfg.LogFile != "" {
+		if err := os.MkdirAll(filepath.Dir(cfg.LogFile), 0o700); err != nil {
+			return err
+		}
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, launchdPlist(cfg), 0o644); err != nil {
+		return err
+	}
+	// the agent starts when it is loaded, and at every login
+	return launchctl("bootstrap", m.domain(), path)
+}
+
+func (m launchd) Uninstall(name string) error {
+	if err := m.Stop(name); err != nil {
+		return err
+	}
+	path, err := m.plistPath(name)
+	if err != nil {
+		return err
+	}
+	return os.Remove(path)
+}
+
+func (m launchd) Start(name string) error {
+	path, err := m.installed(name)
+	if err != nil {
+		return err
+	}
+	target := m.domain() + "/" + launchdLabelPrefix + name
+	if launchctl("print", target) == nil {
+		return launchctl("kickstart", target)
+	}
+	return launchctl("bootstrap", m.domain(), path)
+}
+
+func (m launchd) Stop(name string) error {
+	if _, err := m.installed(name); err != nil {
+		return err
+	}
+	// the agent is kept alive, so it is unloaded rather than killed
+	target := m.domain() + "/" + launchdLabelPrefix + name
+	if launchctl("print", target) != nil {
+		return nil
+	}
+	return launchctl("bootout", target)
+}
+
+// installed returns the path of the property list of the agent
+func (m launchd) installed(name string) (string, error) {
+	path, err := m.plistPath(name)
+	if err != nil {
+		return "", err
+	}
+	if _, err := os.Stat(path); errors.Is(err, fs.ErrNotExist) {
+		return "", fmt.Errorf("%s: %w", name, ErrNotInstalled)
+	} else if err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+func launchctl(args ...string) error {
+	var out bytes.Buffer
+	cmd := exec.Command("launchctl", args...)
+	cmd.Stderr = &out
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("launchctl %s: %w: %s", strings.Join(args, " "), err, bytes.TrimSpace(out.Bytes()))
+	}
+	return nil
+}
+
+// launchdPlist returns the property list of an agent that runs at login and is
+// restarted when it fails
+func launchdPlist(cfg Config) []byte {
+	var b bytes.Buffer
+	b.WriteString(xml.Header)
+	b.WriteString(`<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">` + "\n")
+	b.WriteString("<plist version=\"1.0\">\n<dict>\n")
+	plistString(&b, "Label", launchdLabelPrefix+cfg.Name)
+	b.WriteString("\t<key>ProgramArguments</key>\n\t<array>\n")
+	for _, arg := range append([]string{cfg.Executable}, cfg.Args...) {
+		b.WriteString("\t\t<string>")
+		_ = xml.EscapeText(&b, []byte(arg))
+		b.WriteString("</string>\n")
+	}
+	b.WriteString("\t</array>\n")
+	b.WriteString("\t<key>RunAtLoad</key>\n\t<true/>\n")
+	b.WriteString("\t<key>KeepAlive</key>\n\t<dict>\n\t\t<key>SuccessfulExit</key>\n\t\t<false/>\n\t</dict>\n")
+	b.WriteString("\t<key>ThrottleInterval</key>\n\t<integer>5</integer>\n")
+	if cfg.LogFile != "" {
+		plistString(&b, "StandardOutPath", cfg.LogFile)
+		plistString(&b, "StandardErrorPath", cfg.LogFile)
+	}
+	b.WriteString("</dict>\n</plist>\n")
+	return b.Bytes()
+}
+
+func plistString(b *bytes.Buffer, key, value string) {
+	fmt.Fprintf(b, "\t<key>%s</key>\n\t<string>", key)
+	_ = xml.EscapeText(b, []byte(value))
+	b.WriteString("</string>\n")
+}
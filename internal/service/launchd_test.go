@@ -0,0 +1,48 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLaunchdPlist(t *testing.T) {
+	t.Parallel()
+
+	cfg := Config{
+		Name:       "db",
+		Executable: "/usr/local/bin/pomerium-cli",
+		Args:       []string{"tcp", "db.example.com:5432", "--browser-cmd", `open -a "Safari" <&>`},
+		LogFile:    "/Users/me/Library/Logs/pomerium-cli/db.log",
+	}
+	assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+	<key>Label</key>
+	<string>com.pomerium.cli.db</string>
+	<key>ProgramArguments</key>
+	<array>
+		<string>/usr/local/bin/pomerium-cli</string>
+		<string>tcp</string>
+		<string>db.example.com:5432</string>
+		<string>--browser-cmd</string>
+		<string>open -a &#34;Safari&#34; &lt;&amp;&gt;</string>
+	</array>
+	<key>RunAtLoad</key>
+	<true/>
+	<key>KeepAlive</key>
+	<dict>
+		<key>SuccessfulExit</key>
+		<false/>
+	</dict>
+	<key>ThrottleInterval</key>
+	<integer>5</integer>
+	<key>StandardOutPath</key>
+	<string>/Users/me/Library/Logs/pomerium-cli/db.log</string>
+	<key>StandardErrorPath</key>
+	<string>/Users/me/Library/Logs/pomerium-cli/db.log</string>
+</dict>
+</plist>
+`, string(launchdPlist(cfg)))
+}
@@ -0,0 +1,64 @@
+// Package service registers pomerium-cli with the service manager of the
+// operating system, so that a tunnel or the api server keeps running in the
+// background.
+package service
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+)
+
+// ErrNotSupported indicates the operating system has no supported service manager.
+var ErrNotSupported = errors.New("services are not supported on this platform")
+
+// ErrNotInstalled indicates there is no service with the name.
+var ErrNotInstalled = errors.New("service is not installed")
+
+// Config describes a service running an executable with arguments.
+type Config struct {
+	Name        string
+	DisplayName string
+	Description string
+	// Executable is the absolute path of the executable to run.
+	Executable string
+	Args       []string
+
+	// SocketListen, if set, is the address the service manager listens on,
+	// starting the service when the first client connects. Only systemd
+	// supports socket activation.
+	SocketListen string
+	// SocketDatagram listens for UDP datagrams rather than TCP connections.
+	SocketDatagram bool
+
+	// LogFile, if set, is the file the output of the service is appended to.
+	// Only launchd routes the output to a file, systemd keeps it in the
+	// journal and the output of Windows services is discarded.
+	LogFile string
+}
+
+// A Manager installs and controls services.
+type Manager interface {
+	// Install registers the service to start automatically, and restart
+	// when it fails.
+	Install(cfg Config) error
+	// Uninstall stops and removes the service.
+	Uninstall(name string) error
+	// Start starts the service.
+	Start(name string) error
+	// Stop stops the service.
+	Stop(name string) error
+}
+
+var namePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]{0,79}$`)
+
+// ValidateName returns an error unless the name is valid for every service
+// manager: up to 80 letters, digits, '_', '.' or '-', starting with a letter
+// or digit.
+func ValidateName(name string) error {
+	if !namePattern.MatchString(name) {
+		return fmt.Errorf("invalid service name %q: must be up to 80 letters, digits, '_', '.' or '-', "+
+			"starting with a letter or digit", name)
+	}
+	return nil
+}
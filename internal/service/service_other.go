@@ -0,0 +1,15 @@
+//go:build !windows
+
+package service
+
+import "context"
+
+// System returns the service manager of the operating system.
+func System() (Manager, error) {
+	return nil, ErrNotSupported
+}
+
+// Run calls run.
+func Run(ctx context.Context, run func(context.Context) error) error {
+	return run(ctx)
+}
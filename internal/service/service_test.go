@@ -0,0 +1,19 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateName(t *testing.T) {
+	t.Parallel()
+
+	for _, name := range []string{"pomerium-cli", "db_tunnel.1", "A", strings.Repeat("a", 80)} {
+		assert.NoError(t, ValidateName(name), name)
+	}
+	for _, name := range []string{"", "-db", "db tunnel", "db/tunnel", `db\tunnel`, strings.Repeat("a", 81)} {
+		assert.Error(t, ValidateName(name), name)
+	}
+}
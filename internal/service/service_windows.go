@@ -0,0 +1,184 @@
+//go:build windows
+
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"golang.org/x/sys/windows"
+	"golang.org/x/sys/windows/svc"
+	"golang.org/x/sys/windows/svc/mgr"
+)
+
+const (
+	// restartDelay is how long the service control manager waits before
+	// restarting a failed service
+	restartDelay = 5 * time.Second
+	// resetPeriod is how long a service must run without failing for the
+	// restart count to be reset
+	resetPeriod = 24 * time.Hour
+	// stopTimeout is how long to wait for a service to stop
+	stopTimeout = 30 * time.Second
+)
+
+// System returns the service control manager.
+func System() (Manager, error) {
+	return scm{}, nil
+}
+
+type scm struct{}
+
+func (scm) Install(cfg Config) error {
+	return withManager(func(m *mgr.Mgr) error {
+		if s, err := m.OpenService(cfg.Name); err == nil {
+			_ = s.Close()
+			return fmt.Errorf("service %s already exists", cfg.Name)
+		}
+
+		s, err := m.CreateService(cfg.Name, cfg.Executable, mgr.Config{
+			DisplayName: cfg.DisplayName,
+			Description: cfg.Description,
+			StartType:   mgr.StartAutomatic,
+		}, cfg.Args...)
+		if err != nil {
+			return fmt.Errorf("create service: %w", err)
+		}
+		defer func() { _ = s.Close() }()
+
+		restart := mgr.RecoveryAction{Type: mgr.ServiceRestart, Delay: restartDelay}
+		err = s.SetRecoveryActions([]mgr.RecoveryAction{restart, restart, restart}, uint32(resetPeriod.Seconds()))
+		if err == nil {
+			// also restart when the process exits with an error, rather than crashing
+			err = s.SetRecoveryActionsOnNonCrashFailures(true)
+		}
+		if err != nil {
+			_ = s.Delete()
+			return fmt.Errorf("set recovery actions: %w", err)
+		}
+		return nil
+	})
+}
+
+func (scm) Uninstall(name string) error {
+	return withService(name, func(s *mgr.Service) error {
+		if err := stop(s); err != nil {
+			return err
+		}
+		return s.Delete()
+	})
+}
+
+func (scm) Start(name string) error {
+	return withService(name, func(s *mgr.Service) error {
+		return s.Start()
+	})
+}
+
+func (scm) Stop(name string) error {
+	return withService(name, stop)
+}
+
+func stop(s *mgr.Service) error {
+	st, err := s.Query()
+	if err != nil {
+		return err
+	}
+	if st.State == svc.Stopped {
+		return nil
+	}
+	if st.State != svc.StopPending {
+		if st, err = s.Control(svc.Stop); err != nil {
+			return fmt.Errorf("stop service: %w", err)
+		}
+	}
+
+	deadline := time.Now().Add(stopTimeout)
+	for st.State != svc.Stopped {
+		if time.Now().After(deadline) {
+			return fmt.Errorf("service did not stop within %s", stopTimeout)
+		}
+		time.Sleep(300 * time.Millisecond)
+		if st, err = s.Query(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func withManager(fn func(m *mgr.Mgr) error) error {
+	m, err := mgr.Connect()
+	if err != nil {
+		return fmt.Errorf("connect to service control manager: %w", err)
+	}
+	defer func() { _ = m.Disconnect() }()
+	return fn(m)
+}
+
+func withService(name string, fn func(s *mgr.Service) error) error {
+	return withManager(func(m *mgr.Mgr) error {
+		s, err := m.OpenService(name)
+		if errors.Is(err, windows.ERROR_SERVICE_DOES_NOT_EXIST) {
+			return fmt.Errorf("%s: %w", name, ErrNotInstalled)
+		} else if err != nil {
+			return err
+		}
+		defer func() { _ = s.Close() }()
+		return fn(s)
+	})
+}
+
+// Run calls run, under the service control manager if the process was
+// started as a service, in which case the context is canceled when the
+// service is stopped.
+func Run(ctx context.Context, run func(context.Context) error) error {
+	isService, err := svc.IsWindowsService()
+	if err != nil || !isService {
+		return run(ctx)
+	}
+
+	h := &handler{ctx: ctx, run: run}
+	if err := svc.Run("", h); err != nil {
+		return err
+	}
+	return h.err
+}
+
+type handler struct {
+	ctx context.Context
+	run func(context.Context) error
+	err error
+}
+
+func (h *handler) Execute(_ []string, requests <-chan svc.ChangeRequest, changes chan<- svc.Status) (bool, uint32) {
+	changes <- svc.Status{State: svc.StartPending}
+
+	ctx, cancel := context.WithCancel(h.ctx)
+	defer cancel()
+	done := make(chan error, 1)
+	go func() { done <- h.run(ctx) }()
+
+	changes <- svc.Status{State: svc.Running, Accepts: svc.AcceptStop | svc.AcceptShutdown}
+	for {
+		select {
+		case h.err = <-done:
+			if h.err != nil {
+				// a non-zero exit code lets the recovery actions restart the service
+				return false, 1
+			}
+			return false, 0
+		case req := <-requests:
+			switch req.Cmd {
+			case svc.Interrogate:
+				changes <- req.CurrentStatus
+			case svc.Stop, svc.Shutdown:
+				changes <- svc.Status{State: svc.StopPending}
+				cancel()
+				<-done
+				return false, 0
+			}
+		}
+	}
+}
@@ -0,0 +1,199 @@
+package service
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// Systemd returns the manager of the systemd units of the current user, or
+// of the system units if user is false.
+func Systemd(user bool) Manager {
+	return systemd{user: user}
+}
+
+type systemd struct {
+	user bool
+}
+
+// unitDir returns the directory of the unit files
+func (m systemd) unitDir() (string, error) {
+	if !m.user {
+		return "/etc/systemd/system", nil
+	}
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, "systemd", "user"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".config", "systemd", "user"), nil
+}
+
+func (m systemd) Install(cfg Config) error {
+	dir, err := m.unitDir()
+	if err != nil {
+		return err
+	}
+	servicePath := filepath.Join(dir, cfg.Name+".service")
+	socketPath := filepath.Join(dir, cfg.Name+".socket")
+	for _, p := range []string{servicePath, socketPath} {
+		if _, err := os.Stat(p); err == nil {
+			return fmt.Errorf("%s already exists", p)
+		}
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(servicePath, systemdServiceUnit(cfg, m.user), 0o644); err != nil {
+		return err
+	}
+	enable := cfg.Name + ".service"
+	if cfg.SocketListen != "" {
+		if err := os.WriteFile(socketPath, systemdSocketUnit(cfg), 0o644); err != nil {
+			return err
+		}
+		enable = cfg.Name + ".socket"
+	}
+
+	if err := m.systemctl("daemon-reload"); err != nil {
+		return err
+	}
+	return m.systemctl("enable", enable)
+}
+
+func (m systemd) Uninstall(name string) error {
+	units, err := m.units(name)
+	if err != nil {
+		return err
+	}
+	if err := m.systemctl(append([]string{"disable", "--now"}, units...)...); err != nil {
+		return err
+	}
+
+	dir, err := m.unitDir()
+	if err != nil {
+		return err
+	}
+	for _, unit := range units {
+		if err := os.Remove(filepath.Join(dir, unit)); err != nil {
+			return err
+		}
+	}
+	return m.systemctl("daemon-reload")
+}
+
+func (m systemd) Start(name string) error {
+	units, err := m.units(name)
+	if err != nil {
+		return err
+	}
+	// a socket activated service is started by its socket
+	return m.systemctl("start", units[len(units)-1])
+}
+
+func (m systemd) Stop(name string) error {
+	units, err := m.units(name)
+	if err != nil {
+		return err
+	}
+	return m.systemctl(append([]string{"stop"}, units...)...)
+}
+
+// units returns the installed units of the service, the service unit first
+func (m systemd) units(name string) ([]string, error) {
+	dir, err := m.unitDir()
+	if err != nil {
+		return nil, err
+	}
+	var units []string
+	for _, unit := range []string{name + ".service", name + ".socket"} {
+		_, err := os.Stat(filepath.Join(dir, unit))
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		units = append(units, unit)
+	}
+	if len(units) == 0 {
+		return nil, fmt.Errorf("%s: %w", name, ErrNotInstalled)
+	}
+	return units, nil
+}
+
+func (m systemd) systemctl(args ...string) error {
+	if m.user {
+		args = append([]string{"--user"}, args...)
+	}
+	var stderr bytes.Buffer
+	cmd := exec.Command("systemctl", args...)
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("systemctl %s: %w: %s", strings.Join(args, " "), err, bytes.TrimSpace(stderr.Bytes()))
+	}
+	return nil
+}
+
+func systemdServiceUnit(cfg Config, user bool) []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "[Unit]\nDescription=%s\n", unitValue(cfg.Description))
+	if cfg.SocketListen != "" {
+		fmt.Fprintf(&b, "Requires=%s.socket\nAfter=%[1]s.socket\n", cfg.Name)
+	}
+	b.WriteString("Wants=network-online.target\nAfter=network-online.target\n")
+
+	b.WriteString("\n[Service]\nExecStart=")
+	for i, arg := range append([]string{cfg.Executable}, cfg.Args...) {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(systemdQuote(arg))
+	}
+	b.WriteString("\nRestart=on-failure\nRestartSec=5\n")
+
+	// a socket activated service is started by its socket rather than at boot
+	if cfg.SocketListen == "" {
+		target := "multi-user.target"
+		if user {
+			target = "default.target"
+		}
+		fmt.Fprintf(&b, "\n[Install]\nWantedBy=%s\n", target)
+	}
+	return b.Bytes()
+}
+
+func systemdSocketUnit(cfg Config) []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "[Unit]\nDescription=%s socket\n\n[Socket]\n", unitValue(cfg.Description))
+	listen := strings.TrimPrefix(cfg.SocketListen, "unix:")
+	if cfg.SocketDatagram {
+		fmt.Fprintf(&b, "ListenDatagram=%s\n", listen)
+	} else {
+		fmt.Fprintf(&b, "ListenStream=%s\n", listen)
+	}
+	b.WriteString("\n[Install]\nWantedBy=sockets.target\n")
+	return b.Bytes()
+}
+
+// unitValue returns s on a single line, with specifiers escaped
+func unitValue(s string) string {
+	return strings.ReplaceAll(strings.Join(strings.Fields(s), " "), "%", "%%")
+}
+
+// systemdQuote quotes an argument of ExecStart, escaping the specifiers and
+// variables systemd would otherwise expand
+func systemdQuote(arg string) string {
+	arg = strings.NewReplacer("%", "%%", "$", "$$").Replace(arg)
+	if arg != "" && !strings.ContainsAny(arg, " \t\n\"'\\;") {
+		return arg
+	}
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(arg) + `"`
+}
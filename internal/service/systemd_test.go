@@ -0,0 +1,78 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSystemdServiceUnit(t *testing.T) {
+	t.Parallel()
+
+	cfg := Config{
+		Name:        "db",
+		Description: "Pomerium TCP tunnel\nto db",
+		Executable:  "/usr/local/bin/pomerium-cli",
+		Args:        []string{"tcp", "db.example.com:5432", "--browser-cmd", `firefox "%u" $HOME`},
+	}
+	assert.Equal(t, `[Unit]
+Description=Pomerium TCP tunnel to db
+Wants=network-online.target
+After=network-online.target
+
+[Service]
+ExecStart=/usr/local/bin/pomerium-cli tcp db.example.com:5432 --browser-cmd "firefox \"%%u\" $$HOME"
+Restart=on-failure
+RestartSec=5
+
+[Install]
+WantedBy=default.target
+`, string(systemdServiceUnit(cfg, true)))
+
+	cfg.Args = []string{"udp", "dns.example.com:53", "--listen", "systemd:"}
+	cfg.SocketListen = "127.0.0.1:5353"
+	cfg.SocketDatagram = true
+	assert.Equal(t, `[Unit]
+Description=Pomerium TCP tunnel to db
+Requires=db.socket
+After=db.socket
+Wants=network-online.target
+After=network-online.target
+
+[Service]
+ExecStart=/usr/local/bin/pomerium-cli udp dns.example.com:53 --listen systemd:
+Restart=on-failure
+RestartSec=5
+`, string(systemdServiceUnit(cfg, false)), "socket activated services are not started at boot")
+	assert.Equal(t, `[Unit]
+Description=Pomerium TCP tunnel to db socket
+
+[Socket]
+ListenDatagram=127.0.0.1:5353
+
+[Install]
+WantedBy=sockets.target
+`, string(systemdSocketUnit(cfg)))
+
+	cfg.SocketListen = "unix:/run/db.sock"
+	cfg.SocketDatagram = false
+	assert.Contains(t, string(systemdSocketUnit(cfg)), "\nListenStream=/run/db.sock\n")
+}
+
+func TestSystemdQuote(t *testing.T) {
+	t.Parallel()
+
+	for arg, expect := range map[string]string{
+		"plain":       "plain",
+		"":            `""`,
+		"with space":  `"with space"`,
+		`C:\path`:     `"C:\\path"`,
+		`say "hi"`:    `"say \"hi\""`,
+		"100%":        "100%%",
+		"$VAR":        "$$VAR",
+		"a;b":         `"a;b"`,
+		"line\nbreak": `"line\nbreak"`,
+	} {
+		assert.Equal(t, expect, systemdQuote(arg), arg)
+	}
+}
@@ -0,0 +1,136 @@
+// Package socks5 implements the server side of the SOCKS5 protocol (RFC 1928)
+// needed to accept CONNECT requests without authentication.
+package socks5
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+)
+
+const version = 0x05
+
+const (
+	methodNoAuth       = 0x00
+	methodNoAcceptable = 0xff
+
+	cmdConnect = 0x01
+
+	atypIPv4   = 0x01
+	atypDomain = 0x03
+	atypIPv6   = 0x04
+)
+
+// A Reply is the status code sent back to the client for a request.
+type Reply byte
+
+// Reply codes defined by RFC 1928.
+const (
+	ReplySucceeded               Reply = 0x00
+	ReplyGeneralFailure          Reply = 0x01
+	ReplyNotAllowed              Reply = 0x02
+	ReplyNetworkUnreachable      Reply = 0x03
+	ReplyHostUnreachable         Reply = 0x04
+	ReplyConnectionRefused       Reply = 0x05
+	ReplyCommandNotSupported     Reply = 0x07
+	ReplyAddressTypeNotSupported Reply = 0x08
+)
+
+var (
+	// ErrNoAcceptableMethod indicates the client does not support unauthenticated access.
+	ErrNoAcceptableMethod = errors.New("socks5: no acceptable authentication method")
+	// ErrCommandNotSupported indicates the client requested something other than CONNECT.
+	ErrCommandNotSupported = errors.New("socks5: command not supported")
+	// ErrAddressTypeNotSupported indicates the client used an unknown address type.
+	ErrAddressTypeNotSupported = errors.New("socks5: address type not supported")
+)
+
+// Accept performs the method negotiation and reads the client's request,
+// returning the requested destination as host:port. Unsupported requests are
+// answered with the matching reply before an error is returned. On success the
+// caller must send a reply using WriteReply.
+func Accept(rw io.ReadWriter) (string, error) {
+	var hdr [2]byte
+	if _, err := io.ReadFull(rw, hdr[:]); err != nil {
+		return "", fmt.Errorf("socks5: error reading greeting: %w", err)
+	}
+	if hdr[0] != version {
+		return "", fmt.Errorf("socks5: unsupported version %d", hdr[0])
+	}
+	methods := make([]byte, hdr[1])
+	if _, err := io.ReadFull(rw, methods); err != nil {
+		return "", fmt.Errorf("socks5: error reading methods: %w", err)
+	}
+	method := byte(methodNoAcceptable)
+	for _, m := range methods {
+		if m == methodNoAuth {
+			method = methodNoAuth
+			break
+		}
+	}
+	if _, err := rw.Write([]byte{version, method}); err != nil {
+		return "", fmt.Errorf("socks5: error writing method: %w", err)
+	}
+	if method == methodNoAcceptable {
+		return "", ErrNoAcceptableMethod
+	}
+
+	var req [4]byte
+	if _, err := io.ReadFull(rw, req[:]); err != nil {
+		return "", fmt.Errorf("socks5: error reading request: %w", err)
+	}
+	if req[0] != version {
+		return "", fmt.Errorf("socks5: unsupported version %d", req[0])
+	}
+
+	var host string
+	switch req[3] {
+	case atypIPv4, atypIPv6:
+		ip := make(net.IP, net.IPv4len)
+		if req[3] == atypIPv6 {
+			ip = make(net.IP, net.IPv6len)
+		}
+		if _, err := io.ReadFull(rw, ip); err != nil {
+			return "", fmt.Errorf("socks5: error reading address: %w", err)
+		}
+		host = ip.String()
+	case atypDomain:
+		var n [1]byte
+		if _, err := io.ReadFull(rw, n[:]); err != nil {
+			return "", fmt.Errorf("socks5: error reading address: %w", err)
+		}
+		domain := make([]byte, n[0])
+		if _, err := io.ReadFull(rw, domain); err != nil {
+			return "", fmt.Errorf("socks5: error reading address: %w", err)
+		}
+		host = string(domain)
+	default:
+		_ = WriteReply(rw, ReplyAddressTypeNotSupported)
+		return "", ErrAddressTypeNotSupported
+	}
+
+	var port [2]byte
+	if _, err := io.ReadFull(rw, port[:]); err != nil {
+		return "", fmt.Errorf("socks5: error reading port: %w", err)
+	}
+
+	if req[1] != cmdConnect {
+		_ = WriteReply(rw, ReplyCommandNotSupported)
+		return "", ErrCommandNotSupported
+	}
+
+	return net.JoinHostPort(host, strconv.Itoa(int(binary.BigEndian.Uint16(port[:])))), nil
+}
+
+// WriteReply sends a reply to the client. The bound address is always reported
+// as 0.0.0.0:0 since the connection is made remotely by Pomerium.
+func WriteReply(w io.Writer, reply Reply) error {
+	_, err := w.Write([]byte{version, byte(reply), 0x00, atypIPv4, 0, 0, 0, 0, 0, 0})
+	if err != nil {
+		return fmt.Errorf("socks5: error writing reply: %w", err)
+	}
+	return nil
+}
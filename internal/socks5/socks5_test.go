@@ -0,0 +1,101 @@
+package socks5
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type readWriter struct {
+	io.Reader
+	io.Writer
+}
+
+func TestAccept(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name   string
+		input  []byte
+		expect string
+		err    error
+		output []byte
+	}{
+		{
+			name: "ipv4",
+			input: []byte{
+				0x05, 0x01, 0x00,
+				0x05, 0x01, 0x00, 0x01, 10, 0, 0, 1, 0x00, 0x16,
+			},
+			expect: "10.0.0.1:22",
+			output: []byte{0x05, 0x00},
+		},
+		{
+			name: "ipv6",
+			input: []byte{
+				0x05, 0x01, 0x00,
+				0x05, 0x01, 0x00, 0x04, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1, 0x01, 0xbb,
+			},
+			expect: "[::1]:443",
+			output: []byte{0x05, 0x00},
+		},
+		{
+			name: "domain",
+			input: append(append([]byte{
+				0x05, 0x02, 0x02, 0x00,
+				0x05, 0x01, 0x00, 0x03, 11,
+			}, "example.com"...), 0x1f, 0x90),
+			expect: "example.com:8080",
+			output: []byte{0x05, 0x00},
+		},
+		{
+			name:   "no acceptable method",
+			input:  []byte{0x05, 0x01, 0x02},
+			err:    ErrNoAcceptableMethod,
+			output: []byte{0x05, 0xff},
+		},
+		{
+			name: "bind",
+			input: []byte{
+				0x05, 0x01, 0x00,
+				0x05, 0x02, 0x00, 0x01, 10, 0, 0, 1, 0x00, 0x16,
+			},
+			err:    ErrCommandNotSupported,
+			output: []byte{0x05, 0x00, 0x05, 0x07, 0x00, 0x01, 0, 0, 0, 0, 0, 0},
+		},
+		{
+			name: "unknown address type",
+			input: []byte{
+				0x05, 0x01, 0x00,
+				0x05, 0x01, 0x00, 0x09,
+			},
+			err:    ErrAddressTypeNotSupported,
+			output: []byte{0x05, 0x00, 0x05, 0x08, 0x00, 0x01, 0, 0, 0, 0, 0, 0},
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var out bytes.Buffer
+			dst, err := Accept(readWriter{Reader: bytes.NewReader(tc.input), Writer: &out})
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.expect, dst)
+			}
+			assert.Equal(t, tc.output, out.Bytes())
+		})
+	}
+}
+
+func TestAcceptTruncated(t *testing.T) {
+	t.Parallel()
+
+	_, err := Accept(readWriter{Reader: bytes.NewReader([]byte{0x05, 0x02, 0x00}), Writer: io.Discard})
+	assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+}
@@ -0,0 +1,103 @@
+// Package systemd implements the socket activation protocol of systemd, which
+// passes listening sockets to the service it starts when the first client
+// connects.
+package systemd
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// ListenPrefix marks a listen address as a socket passed by systemd,
+// optionally followed by the FileDescriptorName of the socket.
+const ListenPrefix = "systemd:"
+
+// listenFDsStart is the first file descriptor passed by systemd
+const listenFDsStart = 3
+
+// ErrNoSockets indicates that systemd did not pass any sockets to the process.
+var ErrNoSockets = errors.New("no sockets were passed by systemd, " +
+	"the service must be started by a socket unit")
+
+// activationFD is a file descriptor passed by systemd
+type activationFD struct {
+	fd   int
+	name string
+}
+
+// parseEnv returns the file descriptors passed to the process with the pid,
+// as described by the LISTEN_PID, LISTEN_FDS and LISTEN_FDNAMES variables.
+func parseEnv(getenv func(string) string, pid int) ([]activationFD, error) {
+	if getenv("LISTEN_PID") != strconv.Itoa(pid) {
+		return nil, ErrNoSockets
+	}
+	n, err := strconv.Atoi(getenv("LISTEN_FDS"))
+	if err != nil || n < 0 {
+		return nil, fmt.Errorf("invalid LISTEN_FDS %q", getenv("LISTEN_FDS"))
+	} else if n == 0 {
+		return nil, ErrNoSockets
+	}
+
+	var names []string
+	if v := getenv("LISTEN_FDNAMES"); v != "" {
+		names = strings.Split(v, ":")
+	}
+	fds := make([]activationFD, n)
+	for i := range fds {
+		fds[i].fd = listenFDsStart + i
+		if i < len(names) {
+			fds[i].name = names[i]
+		}
+	}
+	return fds, nil
+}
+
+// file returns the passed socket with the name, or the first if name is empty
+func file(name string) (*os.File, error) {
+	fds, err := parseEnv(os.Getenv, os.Getpid())
+	if err != nil {
+		return nil, err
+	}
+	for _, fd := range fds {
+		if name == "" || fd.name == name {
+			return newFile(fd)
+		}
+	}
+	return nil, fmt.Errorf("no socket named %q was passed by systemd", name)
+}
+
+// Listener returns the stream socket passed by systemd with the name, or the
+// first if name is empty.
+func Listener(name string) (net.Listener, error) {
+	f, err := file(name)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+
+	li, err := net.FileListener(f)
+	if err != nil {
+		return nil, fmt.Errorf("systemd socket %s: %w", f.Name(), err)
+	}
+	return li, nil
+}
+
+// PacketConn returns the datagram socket passed by systemd with the name, or
+// the first if name is empty.
+func PacketConn(name string) (net.PacketConn, error) {
+	f, err := file(name)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+
+	conn, err := net.FilePacketConn(f)
+	if err != nil {
+		return nil, fmt.Errorf("systemd socket %s: %w", f.Name(), err)
+	}
+	return conn, nil
+}
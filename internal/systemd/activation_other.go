@@ -0,0 +1,12 @@
+//go:build !unix
+
+package systemd
+
+import (
+	"errors"
+	"os"
+)
+
+func newFile(activationFD) (*os.File, error) {
+	return nil, errors.New("systemd socket activation is not supported on this platform")
+}
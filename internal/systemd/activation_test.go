@@ -0,0 +1,37 @@
+package systemd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseEnv(t *testing.T) {
+	t.Parallel()
+
+	env := func(vars map[string]string) func(string) string {
+		return func(k string) string { return vars[k] }
+	}
+
+	fds, err := parseEnv(env(map[string]string{
+		"LISTEN_PID":     "42",
+		"LISTEN_FDS":     "2",
+		"LISTEN_FDNAMES": "grpc:http",
+	}), 42)
+	require.NoError(t, err)
+	assert.Equal(t, []activationFD{{3, "grpc"}, {4, "http"}}, fds)
+
+	fds, err = parseEnv(env(map[string]string{"LISTEN_PID": "42", "LISTEN_FDS": "1"}), 42)
+	require.NoError(t, err)
+	assert.Equal(t, []activationFD{{3, ""}}, fds)
+
+	_, err = parseEnv(env(map[string]string{"LISTEN_PID": "42", "LISTEN_FDS": "1"}), 43)
+	assert.ErrorIs(t, err, ErrNoSockets, "sockets passed to another process")
+	_, err = parseEnv(env(nil), 42)
+	assert.ErrorIs(t, err, ErrNoSockets)
+	_, err = parseEnv(env(map[string]string{"LISTEN_PID": "42", "LISTEN_FDS": "0"}), 42)
+	assert.ErrorIs(t, err, ErrNoSockets)
+	_, err = parseEnv(env(map[string]string{"LISTEN_PID": "42", "LISTEN_FDS": "x"}), 42)
+	assert.Error(t, err)
+}
@@ -0,0 +1,19 @@
+//go:build unix
+
+package systemd
+
+import (
+	"os"
+	"strconv"
+	"syscall"
+)
+
+func newFile(fd activationFD) (*os.File, error) {
+	// the sockets are not passed on to child processes
+	syscall.CloseOnExec(fd.fd)
+	name := fd.name
+	if name == "" {
+		name = "fd" + strconv.Itoa(fd.fd)
+	}
+	return os.NewFile(uintptr(fd.fd), name), nil
+}
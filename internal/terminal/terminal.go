@@ -0,0 +1,53 @@
+// Package terminal reads secrets from the terminal without echoing them.
+package terminal
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ErrNotTerminal is returned when standard input is not a terminal.
+var ErrNotTerminal = errors.New("standard input is not a terminal")
+
+// ReadPassword writes the prompt to standard error and reads a line from
+// standard input with echo disabled.
+func ReadPassword(prompt string) ([]byte, error) {
+	restore, err := disableEcho(os.Stdin)
+	if err != nil {
+		return nil, err
+	}
+	fmt.Fprint(os.Stderr, prompt)
+	line, err := readLine(os.Stdin)
+	restore()
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(line), nil
+}
+
+// readLine reads up to the end of the line one byte at a time, so that no
+// input past it is consumed.
+func readLine(f *os.File) (string, error) {
+	var sb strings.Builder
+	var b [1]byte
+	for {
+		n, err := f.Read(b[:])
+		if n == 1 {
+			if b[0] == '\n' {
+				break
+			}
+			sb.WriteByte(b[0])
+			continue
+		}
+		if err != nil {
+			if sb.Len() > 0 {
+				break
+			}
+			return "", err
+		}
+	}
+	return strings.TrimSuffix(sb.String(), "\r"), nil
+}
@@ -0,0 +1,10 @@
+//go:build !linux && !darwin && !windows
+
+package terminal
+
+import "os"
+
+// echo cannot be disabled, so passwords are never read
+func disableEcho(*os.File) (restore func(), err error) {
+	return nil, ErrNotTerminal
+}
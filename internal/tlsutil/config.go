@@ -0,0 +1,279 @@
+package tlsutil
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pomerium/cli/certstore"
+)
+
+// Options are the TLS options shared by the CLI commands and the api server.
+type Options struct {
+	// InsecureSkipVerify disables verification of the server certificate.
+	InsecureSkipVerify bool
+	// CA contains PEM-encoded CA certificates to trust.
+	CA []byte
+	// CAFile is the path to a file of PEM-encoded CA certificates to trust.
+	CAFile string
+	// CADir is the path to a directory of PEM-encoded CA certificates to trust.
+	CADir string
+
+	// ClientCert and ClientKey contain a PEM-encoded client certificate and key.
+	ClientCert, ClientKey []byte
+	// ClientCertFile and ClientKeyFile are paths to a PEM-encoded client
+	// certificate and key. ClientCertFile may contain the full chain. The
+	// files are re-read when they change.
+	ClientCertFile, ClientKeyFile string
+	// ClientCertChainFile is the path to PEM-encoded intermediate certificates
+	// to present along with the client certificate from ClientCertFile.
+	ClientCertChainFile string
+	// ClientKeyPassphrase returns the passphrase of an encrypted ClientKey or
+	// ClientKeyFile. It is called only when the key is encrypted, and again
+	// whenever the key file changes.
+	ClientKeyPassphrase PassphraseFunc
+	// ClientCertFromStore indicates to search the system trust store for a
+	// client certificate.
+	ClientCertFromStore bool
+	// ClientCertIssuerFilter and ClientCertSubjectFilter restrict the system
+	// trust store search. See [certstore.GetClientCertificateFunc].
+	ClientCertIssuerFilter, ClientCertSubjectFilter string
+	// ClientCertRequireUserPresence requires the user to approve each use
+	// of the client certificate from the system trust store. If not nil,
+	// OnUserPresencePrompt is called whenever the user is prompted.
+	ClientCertRequireUserPresence bool
+	OnUserPresencePrompt          func()
+	// ClientCertSelect chooses among several certificates matching in the
+	// system trust store, see [certstore.ParseSelectPolicy]. For "prompt",
+	// OnClientCertSelectPrompt is called to ask the user.
+	ClientCertSelect         string
+	OnClientCertSelectPrompt func([]*x509.Certificate) (int, error)
+	// ClientCertTPM indicates that the client key is protected by the TPM:
+	// ClientKeyFile is a TSS2 key file on Linux, and the system trust store
+	// search is restricted to keys of the TPM on Windows.
+	ClientCertTPM bool
+	// ClientCertPKCS11 selects a client certificate on a PKCS#11 token, see
+	// [certstore.ParsePKCS11Config].
+	ClientCertPKCS11 string
+	// ClientCertPIV selects a client certificate in a slot of the PIV applet
+	// of a smart card, see [certstore.ParsePIVConfig].
+	ClientCertPIV string
+	// OnClientCertExpiring is called once for each client certificate
+	// presented that expires within ClientCertExpiryWarning.
+	OnClientCertExpiring    func(leaf *x509.Certificate)
+	ClientCertExpiryWarning time.Duration
+
+	// ServerName overrides the server name used for SNI and verification.
+	ServerName string
+	// MinVersion is the minimum TLS version to accept, e.g. tls.VersionTLS13.
+	MinVersion uint16
+	// PinnedSPKIHashes are base64-encoded SHA-256 hashes of the server's leaf
+	// or intermediate certificate public key. See [PinnedSPKIVerifier].
+	PinnedSPKIHashes []string
+	// CheckRevocation rejects the server certificate if it has been revoked,
+	// or if its status cannot be determined. See [RevocationVerifier].
+	CheckRevocation bool
+}
+
+// NewConfig creates a new client TLS config from the given options.
+func NewConfig(opts *Options) (*tls.Config, error) {
+	cfg := &tls.Config{
+		//nolint: gosec
+		InsecureSkipVerify: opts.InsecureSkipVerify,
+		ServerName:         opts.ServerName,
+		MinVersion:         opts.MinVersion,
+	}
+
+	if len(opts.CA) > 0 || opts.CAFile != "" || opts.CADir != "" {
+		rootCAs, err := x509.SystemCertPool()
+		if err != nil {
+			return nil, fmt.Errorf("get system cert pool: %w", err)
+		}
+		if len(opts.CA) > 0 {
+			if ok := rootCAs.AppendCertsFromPEM(opts.CA); !ok {
+				return nil, fmt.Errorf("failed to append provided certificate")
+			}
+		}
+		if opts.CAFile != "" {
+			data, err := os.ReadFile(opts.CAFile)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read CA file: %w", err)
+			}
+			if ok := rootCAs.AppendCertsFromPEM(data); !ok {
+				return nil, fmt.Errorf("failed to append certificates from %s", opts.CAFile)
+			}
+		}
+		if opts.CADir != "" {
+			if err := AppendCertsFromDir(rootCAs, opts.CADir); err != nil {
+				return nil, err
+			}
+		}
+		cfg.RootCAs = rootCAs
+	}
+
+	if len(opts.PinnedSPKIHashes) > 0 {
+		f, err := PinnedSPKIVerifier(opts.PinnedSPKIHashes)
+		if err != nil {
+			return nil, err
+		}
+		cfg.VerifyPeerCertificate = f
+	}
+	if opts.CheckRevocation {
+		cfg.VerifyConnection = RevocationVerifier()
+	}
+
+	if sources := clientCertSources(opts); len(sources) > 1 {
+		return nil, fmt.Errorf("only one client certificate source may be used, but %s are set",
+			strings.Join(sources, ", "))
+	}
+	if len(opts.ClientCert) > 0 || len(opts.ClientKey) > 0 {
+		if len(opts.ClientCert) == 0 {
+			return nil, fmt.Errorf("client cert: certificate is missing")
+		}
+		if len(opts.ClientKey) == 0 {
+			return nil, fmt.Errorf("client cert: key is missing")
+		}
+		cert, err := X509KeyPair(opts.ClientCert, opts.ClientKey, opts.ClientKeyPassphrase)
+		if err != nil {
+			return nil, fmt.Errorf("client cert: %w", err)
+		}
+		cfg.Certificates = append(cfg.Certificates, cert)
+	}
+	if opts.ClientCertFile != "" || opts.ClientKeyFile != "" {
+		l, err := newKeyPairLoader(opts.ClientCertFile, opts.ClientKeyFile, opts.ClientCertChainFile, opts.ClientCertTPM, opts.ClientKeyPassphrase)
+		if err != nil {
+			return nil, fmt.Errorf("loading client cert: %w", err)
+		}
+		cfg.GetClientCertificate = l.GetClientCertificate
+	} else if opts.ClientCertChainFile != "" {
+		return nil, fmt.Errorf("client cert chain requires a client cert file")
+	} else if opts.ClientCertTPM && !opts.ClientCertFromStore {
+		return nil, fmt.Errorf("client cert tpm requires a client cert file or the system trust store")
+	}
+	if opts.ClientCertFromStore {
+		var storeOpts []certstore.Option
+		if opts.ClientCertRequireUserPresence {
+			storeOpts = append(storeOpts, certstore.WithRequireUserPresence(opts.OnUserPresencePrompt))
+		}
+		if opts.ClientCertTPM {
+			storeOpts = append(storeOpts, certstore.WithTPM())
+		}
+		policy, err := certstore.ParseSelectPolicy(opts.ClientCertSelect)
+		if err != nil {
+			return nil, fmt.Errorf("client cert from store: %w", err)
+		}
+		storeOpts = append(storeOpts, certstore.WithSelect(policy, opts.OnClientCertSelectPrompt))
+		f, err := certstore.GetClientCertificateFunc(opts.ClientCertIssuerFilter, opts.ClientCertSubjectFilter, storeOpts...)
+		if err != nil {
+			return nil, fmt.Errorf("client cert from store: %w", err)
+		}
+		cfg.GetClientCertificate = f
+	}
+	if opts.ClientCertPKCS11 != "" {
+		p11, err := certstore.ParsePKCS11Config(opts.ClientCertPKCS11)
+		if err != nil {
+			return nil, fmt.Errorf("client cert from pkcs11: %w", err)
+		}
+		f, err := certstore.GetPKCS11ClientCertificateFunc(p11)
+		if err != nil {
+			return nil, fmt.Errorf("client cert from pkcs11: %w", err)
+		}
+		cfg.GetClientCertificate = f
+	}
+	if opts.ClientCertPIV != "" {
+		piv, err := certstore.ParsePIVConfig(opts.ClientCertPIV)
+		if err != nil {
+			return nil, fmt.Errorf("client cert from piv: %w", err)
+		}
+		f, err := certstore.GetPIVClientCertificateFunc(piv)
+		if err != nil {
+			return nil, fmt.Errorf("client cert from piv: %w", err)
+		}
+		cfg.GetClientCertificate = f
+	}
+
+	if opts.OnClientCertExpiring != nil {
+		w := newExpiryWarner(opts.ClientCertExpiryWarning, opts.OnClientCertExpiring)
+		for i := range cfg.Certificates {
+			w.check(&cfg.Certificates[i])
+		}
+		if cfg.GetClientCertificate != nil {
+			cfg.GetClientCertificate = w.wrap(cfg.GetClientCertificate)
+		}
+	}
+
+	return cfg, nil
+}
+
+// clientCertSources returns the sources of client certificates set in the
+// options. A source would replace the certificates of any other.
+func clientCertSources(opts *Options) []string {
+	var sources []string
+	if len(opts.ClientCert) > 0 || len(opts.ClientKey) > 0 {
+		sources = append(sources, "client cert")
+	}
+	if opts.ClientCertFile != "" || opts.ClientKeyFile != "" {
+		sources = append(sources, "client cert file")
+	}
+	if opts.ClientCertFromStore {
+		sources = append(sources, "client cert from store")
+	}
+	if opts.ClientCertPKCS11 != "" {
+		sources = append(sources, "client cert from pkcs11")
+	}
+	if opts.ClientCertPIV != "" {
+		sources = append(sources, "client cert from piv")
+	}
+	return sources
+}
+
+// appendChainFromFile appends the PEM-encoded certificates in the given file
+// to the certificate chain presented to the server.
+func appendChainFromFile(cert *tls.Certificate, name string) error {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return err
+	}
+	n := 0
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		if _, err := x509.ParseCertificate(block.Bytes); err != nil {
+			return err
+		}
+		cert.Certificate = append(cert.Certificate, block.Bytes)
+		n++
+	}
+	if n == 0 {
+		return fmt.Errorf("no PEM-encoded certificates found in %s", name)
+	}
+	return nil
+}
+
+// ParseVersion parses a TLS version of the form "1.2" or "1.3". An empty
+// string results in 0, leaving the crypto/tls default in place.
+func ParseVersion(version string) (uint16, error) {
+	switch version {
+	case "":
+		return 0, nil
+	case "1.0":
+		return tls.VersionTLS10, nil
+	case "1.1":
+		return tls.VersionTLS11, nil
+	case "1.2":
+		return tls.VersionTLS12, nil
+	case "1.3":
+		return tls.VersionTLS13, nil
+	}
+	return 0, fmt.Errorf("unsupported TLS version %q", version)
+}
@@ -0,0 +1,150 @@
+package tlsutil
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewConfig(t *testing.T) {
+	t.Parallel()
+
+	t.Run("defaults", func(t *testing.T) {
+		cfg, err := NewConfig(&Options{})
+		require.NoError(t, err)
+		assert.False(t, cfg.InsecureSkipVerify)
+		assert.Nil(t, cfg.RootCAs)
+		assert.Nil(t, cfg.VerifyPeerCertificate)
+		assert.Empty(t, cfg.Certificates)
+	})
+	t.Run("options", func(t *testing.T) {
+		cfg, err := NewConfig(&Options{
+			InsecureSkipVerify: true,
+			CA:                 newCAPEM(t, "CA"),
+			ServerName:         "proxy.example.com",
+			MinVersion:         tls.VersionTLS13,
+			PinnedSPKIHashes:   []string{"47DEQpj8HBSa+/TImW+5JCeuQeRkm5NMpJWZG3hSuFU="},
+		})
+		require.NoError(t, err)
+		assert.True(t, cfg.InsecureSkipVerify)
+		assert.NotNil(t, cfg.RootCAs)
+		assert.Equal(t, "proxy.example.com", cfg.ServerName)
+		assert.Equal(t, uint16(tls.VersionTLS13), cfg.MinVersion)
+		assert.NotNil(t, cfg.VerifyPeerCertificate)
+	})
+	t.Run("invalid ca", func(t *testing.T) {
+		_, err := NewConfig(&Options{CA: []byte("not a cert")})
+		assert.Error(t, err)
+	})
+	t.Run("client cert chain", func(t *testing.T) {
+		dir := t.TempDir()
+		certFile, keyFile := writeClientCert(t, dir)
+		chainFile := filepath.Join(dir, "chain.pem")
+		chain := append(newCAPEM(t, "Intermediate A"), newCAPEM(t, "Intermediate B")...)
+		require.NoError(t, os.WriteFile(chainFile, chain, 0o600))
+
+		cfg, err := NewConfig(&Options{
+			ClientCertFile:      certFile,
+			ClientKeyFile:       keyFile,
+			ClientCertChainFile: chainFile,
+		})
+		require.NoError(t, err)
+		require.NotNil(t, cfg.GetClientCertificate)
+		cert, err := cfg.GetClientCertificate(&tls.CertificateRequestInfo{})
+		require.NoError(t, err)
+		assert.Len(t, cert.Certificate, 3)
+	})
+	t.Run("client cert chain without cert", func(t *testing.T) {
+		_, err := NewConfig(&Options{ClientCertChainFile: "chain.pem"})
+		assert.Error(t, err)
+	})
+	t.Run("empty client cert chain", func(t *testing.T) {
+		dir := t.TempDir()
+		certFile, keyFile := writeClientCert(t, dir)
+		chainFile := filepath.Join(dir, "chain.pem")
+		require.NoError(t, os.WriteFile(chainFile, []byte("not a cert"), 0o600))
+
+		_, err := NewConfig(&Options{
+			ClientCertFile:      certFile,
+			ClientKeyFile:       keyFile,
+			ClientCertChainFile: chainFile,
+		})
+		assert.Error(t, err)
+	})
+	t.Run("several client cert sources", func(t *testing.T) {
+		certFile, keyFile := writeClientCert(t, t.TempDir())
+		_, err := NewConfig(&Options{
+			ClientCertFile:      certFile,
+			ClientKeyFile:       keyFile,
+			ClientCertFromStore: true,
+		})
+		assert.ErrorContains(t, err, "client cert file, client cert from store")
+
+		_, err = NewConfig(&Options{
+			ClientCert:       newCAPEM(t, "client"),
+			ClientCertPKCS11: "module=/usr/lib/opensc-pkcs11.so",
+		})
+		assert.ErrorContains(t, err, "only one client certificate source")
+	})
+	t.Run("missing client key", func(t *testing.T) {
+		_, err := NewConfig(&Options{ClientCert: newCAPEM(t, "client")})
+		assert.Error(t, err)
+	})
+}
+
+func TestParseVersion(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		in     string
+		expect uint16
+	}{
+		{"", 0},
+		{"1.2", tls.VersionTLS12},
+		{"1.3", tls.VersionTLS13},
+	} {
+		v, err := ParseVersion(tc.in)
+		assert.NoError(t, err, tc.in)
+		assert.Equal(t, tc.expect, v, tc.in)
+	}
+
+	_, err := ParseVersion("2.0")
+	assert.Error(t, err)
+}
+
+func writeClientCert(t *testing.T, dir string) (certFile, keyFile string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "client"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, key.Public(), key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certFile, keyFile = filepath.Join(dir, "client.pem"), filepath.Join(dir, "client-key.pem")
+	require.NoError(t, os.WriteFile(certFile,
+		pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(keyFile,
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+	return certFile, keyFile
+}
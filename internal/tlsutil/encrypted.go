@@ -0,0 +1,253 @@
+package tlsutil
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/des"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"hash"
+	"os"
+
+	"golang.org/x/crypto/pbkdf2"
+)
+
+// ErrIncorrectPassphrase is returned when an encrypted key cannot be
+// decrypted with the passphrase.
+var ErrIncorrectPassphrase = errors.New("incorrect passphrase")
+
+// A PassphraseFunc returns the passphrase of an encrypted private key. It is
+// only called when the key is encrypted.
+type PassphraseFunc func() ([]byte, error)
+
+// PassphraseFromFile returns a PassphraseFunc that reads the passphrase from
+// the first line of the file.
+func PassphraseFromFile(name string) PassphraseFunc {
+	return func() ([]byte, error) {
+		b, err := os.ReadFile(name)
+		if err != nil {
+			return nil, err
+		}
+		line, _, _ := bytes.Cut(b, []byte("\n"))
+		return bytes.TrimSuffix(line, []byte("\r")), nil
+	}
+}
+
+// X509KeyPair parses a public/private key pair from PEM encoded data, like
+// [tls.X509KeyPair], except that the private key may be encrypted, either as
+// a PKCS #8 ENCRYPTED PRIVATE KEY with PBES2 or with the legacy OpenSSL
+// Proc-Type and DEK-Info headers.
+func X509KeyPair(certPEMBlock, keyPEMBlock []byte, passphrase PassphraseFunc) (tls.Certificate, error) {
+	keyPEMBlock, err := decryptPEMKeys(keyPEMBlock, passphrase)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	return tls.X509KeyPair(certPEMBlock, keyPEMBlock)
+}
+
+// LoadX509KeyPair reads a public/private key pair from a pair of files, like
+// [tls.LoadX509KeyPair], except that the private key may be encrypted.
+func LoadX509KeyPair(certFile, keyFile string, passphrase PassphraseFunc) (tls.Certificate, error) {
+	certPEMBlock, err := os.ReadFile(certFile)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	keyPEMBlock, err := os.ReadFile(keyFile)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	return X509KeyPair(certPEMBlock, keyPEMBlock, passphrase)
+}
+
+// decryptPEMKeys returns the PEM data with any encrypted private keys
+// replaced by their decrypted form. The data is returned unchanged if no key
+// is encrypted.
+func decryptPEMKeys(data []byte, passphrase PassphraseFunc) ([]byte, error) {
+	var out []byte
+	var password []byte
+	encrypted := false
+	for rest := data; ; {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		switch {
+		case block.Type == "ENCRYPTED PRIVATE KEY" || isLegacyEncrypted(block):
+			if !encrypted {
+				encrypted = true
+				if passphrase == nil {
+					return nil, fmt.Errorf("private key is encrypted and no passphrase was provided")
+				}
+				var err error
+				password, err = passphrase()
+				if err != nil {
+					return nil, fmt.Errorf("reading passphrase: %w", err)
+				}
+			}
+			decrypted, err := decryptPEMBlock(block, password)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, pem.EncodeToMemory(decrypted)...)
+		default:
+			out = append(out, pem.EncodeToMemory(block)...)
+		}
+	}
+	if !encrypted {
+		return data, nil
+	}
+	return out, nil
+}
+
+func isLegacyEncrypted(block *pem.Block) bool {
+	return x509.IsEncryptedPEMBlock(block) //nolint:staticcheck
+}
+
+func decryptPEMBlock(block *pem.Block, password []byte) (*pem.Block, error) {
+	if block.Type != "ENCRYPTED PRIVATE KEY" {
+		// the legacy encryption uses a single MD5 iteration to derive the key,
+		// but it is still what "openssl rsa -aes256" writes
+		der, err := x509.DecryptPEMBlock(block, password) //nolint:staticcheck
+		if errors.Is(err, x509.IncorrectPasswordError) {  //nolint:staticcheck
+			return nil, ErrIncorrectPassphrase
+		} else if err != nil {
+			return nil, fmt.Errorf("decrypting %s: %w", block.Type, err)
+		}
+		return &pem.Block{Type: block.Type, Bytes: der}, nil
+	}
+
+	der, err := decryptPKCS8(block.Bytes, password)
+	if err != nil {
+		return nil, err
+	}
+	return &pem.Block{Type: "PRIVATE KEY", Bytes: der}, nil
+}
+
+var (
+	oidPBES2      = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 13}
+	oidPBKDF2     = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 12}
+	oidHMACSHA1   = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 7}
+	oidHMACSHA256 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 9}
+	oidHMACSHA384 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 10}
+	oidHMACSHA512 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 11}
+	oidAES128CBC  = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 2}
+	oidAES192CBC  = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 22}
+	oidAES256CBC  = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 42}
+	oidDESEDE3CBC = asn1.ObjectIdentifier{1, 2, 840, 113549, 3, 7}
+)
+
+// encryptedPrivateKeyInfo is defined in RFC 5208, section 6.
+type encryptedPrivateKeyInfo struct {
+	Algorithm     pkix.AlgorithmIdentifier
+	EncryptedData []byte
+}
+
+// pbes2Params and pbkdf2Params are defined in RFC 8018, appendix A.
+type pbes2Params struct {
+	KeyDerivationFunc pkix.AlgorithmIdentifier
+	EncryptionScheme  pkix.AlgorithmIdentifier
+}
+
+type pbkdf2Params struct {
+	Salt           []byte
+	IterationCount int
+	KeyLength      int                      `asn1:"optional"`
+	PRF            pkix.AlgorithmIdentifier `asn1:"optional"`
+}
+
+// decryptPKCS8 decrypts a PKCS #8 private key encrypted with PBES2, which is
+// what OpenSSL and most other tools write by default.
+func decryptPKCS8(der, password []byte) ([]byte, error) {
+	var info encryptedPrivateKeyInfo
+	if _, err := asn1.Unmarshal(der, &info); err != nil {
+		return nil, fmt.Errorf("invalid encrypted private key: %w", err)
+	}
+	if !info.Algorithm.Algorithm.Equal(oidPBES2) {
+		return nil, fmt.Errorf("unsupported private key encryption %s, only PBES2 is supported", info.Algorithm.Algorithm)
+	}
+	var params pbes2Params
+	if _, err := asn1.Unmarshal(info.Algorithm.Parameters.FullBytes, &params); err != nil {
+		return nil, fmt.Errorf("invalid PBES2 parameters: %w", err)
+	}
+	if !params.KeyDerivationFunc.Algorithm.Equal(oidPBKDF2) {
+		return nil, fmt.Errorf("unsupported key derivation function %s, only PBKDF2 is supported",
+			params.KeyDerivationFunc.Algorithm)
+	}
+	var kdf pbkdf2Params
+	if _, err := asn1.Unmarshal(params.KeyDerivationFunc.Parameters.FullBytes, &kdf); err != nil {
+		return nil, fmt.Errorf("invalid PBKDF2 parameters: %w", err)
+	}
+
+	var prf func() hash.Hash
+	switch alg := kdf.PRF.Algorithm; {
+	case len(alg) == 0 || alg.Equal(oidHMACSHA1):
+		prf = sha1.New
+	case alg.Equal(oidHMACSHA256):
+		prf = sha256.New
+	case alg.Equal(oidHMACSHA384):
+		prf = sha512.New384
+	case alg.Equal(oidHMACSHA512):
+		prf = sha512.New
+	default:
+		return nil, fmt.Errorf("unsupported PBKDF2 pseudorandom function %s", alg)
+	}
+
+	var newCipher func([]byte) (cipher.Block, error)
+	var keyLen int
+	switch alg := params.EncryptionScheme.Algorithm; {
+	case alg.Equal(oidAES128CBC):
+		newCipher, keyLen = aes.NewCipher, 16
+	case alg.Equal(oidAES192CBC):
+		newCipher, keyLen = aes.NewCipher, 24
+	case alg.Equal(oidAES256CBC):
+		newCipher, keyLen = aes.NewCipher, 32
+	case alg.Equal(oidDESEDE3CBC):
+		newCipher, keyLen = des.NewTripleDESCipher, 24
+	default:
+		return nil, fmt.Errorf("unsupported private key cipher %s", alg)
+	}
+	if kdf.KeyLength != 0 && kdf.KeyLength != keyLen {
+		return nil, fmt.Errorf("invalid PBKDF2 key length %d", kdf.KeyLength)
+	}
+	var iv []byte
+	if _, err := asn1.Unmarshal(params.EncryptionScheme.Parameters.FullBytes, &iv); err != nil {
+		return nil, fmt.Errorf("invalid cipher parameters: %w", err)
+	}
+
+	block, err := newCipher(pbkdf2.Key(password, kdf.Salt, kdf.IterationCount, keyLen, prf))
+	if err != nil {
+		return nil, err
+	}
+	if len(iv) != block.BlockSize() {
+		return nil, fmt.Errorf("invalid cipher IV length %d", len(iv))
+	}
+	data := info.EncryptedData
+	if len(data) == 0 || len(data)%block.BlockSize() != 0 {
+		return nil, fmt.Errorf("invalid encrypted private key length %d", len(data))
+	}
+	plain := make([]byte, len(data))
+	cipher.NewCBCDecrypter(block, iv).CryptBlocks(plain, data)
+
+	// a wrong passphrase almost always results in invalid padding, and
+	// otherwise in an invalid key
+	n := int(plain[len(plain)-1])
+	if n == 0 || n > block.BlockSize() ||
+		!bytes.Equal(plain[len(plain)-n:], bytes.Repeat([]byte{byte(n)}, n)) {
+		return nil, ErrIncorrectPassphrase
+	}
+	plain = plain[:len(plain)-n]
+	if _, err := x509.ParsePKCS8PrivateKey(plain); err != nil {
+		return nil, ErrIncorrectPassphrase
+	}
+	return plain, nil
+}
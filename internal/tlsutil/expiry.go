@@ -0,0 +1,104 @@
+package tlsutil
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultClientCertExpiryWarning is how long before a client certificate
+// expires to start warning about it, unless configured otherwise.
+const DefaultClientCertExpiryWarning = 7 * 24 * time.Hour
+
+// Environment variables describing the client certificate to a renew hook.
+const (
+	RenewHookSubjectEnv  = "POMERIUM_CLI_CLIENT_CERT_SUBJECT"
+	RenewHookSerialEnv   = "POMERIUM_CLI_CLIENT_CERT_SERIAL"
+	RenewHookNotAfterEnv = "POMERIUM_CLI_CLIENT_CERT_NOT_AFTER"
+)
+
+// expiryWarner calls onExpiring once for each client certificate that
+// expires within the window, or has expired already.
+type expiryWarner struct {
+	window     time.Duration
+	onExpiring func(*x509.Certificate)
+	now        func() time.Time
+
+	mu     sync.Mutex
+	warned map[string]bool
+}
+
+func newExpiryWarner(window time.Duration, onExpiring func(*x509.Certificate)) *expiryWarner {
+	return &expiryWarner{
+		window:     window,
+		onExpiring: onExpiring,
+		now:        time.Now,
+		warned:     make(map[string]bool),
+	}
+}
+
+// wrap returns a GetClientCertificate func that checks each certificate
+// returned by f.
+func (w *expiryWarner) wrap(
+	f func(*tls.CertificateRequestInfo) (*tls.Certificate, error),
+) func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	return func(cri *tls.CertificateRequestInfo) (*tls.Certificate, error) {
+		cert, err := f(cri)
+		if err == nil {
+			w.check(cert)
+		}
+		return cert, err
+	}
+}
+
+func (w *expiryWarner) check(cert *tls.Certificate) {
+	if cert == nil || len(cert.Certificate) == 0 {
+		return
+	}
+	leaf := cert.Leaf
+	if leaf == nil {
+		var err error
+		if leaf, err = x509.ParseCertificate(cert.Certificate[0]); err != nil {
+			return
+		}
+	}
+	if w.now().Add(w.window).Before(leaf.NotAfter) {
+		return
+	}
+
+	w.mu.Lock()
+	warned := w.warned[string(leaf.Raw)]
+	w.warned[string(leaf.Raw)] = true
+	w.mu.Unlock()
+	if !warned {
+		w.onExpiring(leaf)
+	}
+}
+
+// RunRenewHook runs the command to renew the client certificate, passing the
+// subject, serial number and expiry of the certificate in the environment,
+// and waits for it to exit. The command is split into arguments at spaces.
+func RunRenewHook(command string, leaf *x509.Certificate) error {
+	args := strings.Fields(command)
+	if len(args) == 0 {
+		return fmt.Errorf("empty renew hook command")
+	}
+	cmd := exec.Command(args[0], args[1:]...) //nolint: gosec
+	cmd.Env = append(os.Environ(),
+		RenewHookSubjectEnv+"="+leaf.Subject.String(),
+		RenewHookSerialEnv+"="+leaf.SerialNumber.Text(16),
+		RenewHookNotAfterEnv+"="+leaf.NotAfter.UTC().Format(time.RFC3339),
+	)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		if out := strings.TrimSpace(string(out)); out != "" {
+			return fmt.Errorf("renew hook %s: %w: %s", args[0], err, out)
+		}
+		return fmt.Errorf("renew hook %s: %w", args[0], err)
+	}
+	return nil
+}
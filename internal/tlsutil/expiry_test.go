@@ -0,0 +1,91 @@
+package tlsutil
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientCertExpiry(t *testing.T) {
+	t.Parallel()
+
+	certFile, keyFile := writeClientCert(t, t.TempDir())
+
+	var expiring []*x509.Certificate
+	cfg, err := NewConfig(&Options{
+		ClientCertFile:          certFile,
+		ClientKeyFile:           keyFile,
+		ClientCertExpiryWarning: 2 * time.Hour,
+		OnClientCertExpiring:    func(leaf *x509.Certificate) { expiring = append(expiring, leaf) },
+	})
+	require.NoError(t, err)
+	for range 2 {
+		_, err := cfg.GetClientCertificate(nil)
+		require.NoError(t, err)
+	}
+	require.Len(t, expiring, 1, "should warn once for each certificate")
+	assert.Equal(t, "client", expiring[0].Subject.CommonName)
+
+	expiring = nil
+	cfg, err = NewConfig(&Options{
+		ClientCertFile:          certFile,
+		ClientKeyFile:           keyFile,
+		ClientCertExpiryWarning: 30 * time.Minute,
+		OnClientCertExpiring:    func(leaf *x509.Certificate) { expiring = append(expiring, leaf) },
+	})
+	require.NoError(t, err)
+	_, err = cfg.GetClientCertificate(nil)
+	require.NoError(t, err)
+	assert.Empty(t, expiring)
+
+	// inline certificates are checked once when creating the config
+	certPEM, err := os.ReadFile(certFile)
+	require.NoError(t, err)
+	keyPEM, err := os.ReadFile(keyFile)
+	require.NoError(t, err)
+	_, err = NewConfig(&Options{
+		ClientCert:              certPEM,
+		ClientKey:               keyPEM,
+		ClientCertExpiryWarning: 2 * time.Hour,
+		OnClientCertExpiring:    func(leaf *x509.Certificate) { expiring = append(expiring, leaf) },
+	})
+	require.NoError(t, err)
+	assert.Len(t, expiring, 1)
+}
+
+func TestRunRenewHook(t *testing.T) {
+	t.Parallel()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("the hook is a shell script")
+	}
+
+	dir := t.TempDir()
+	certFile, _ := writeClientCert(t, dir)
+	certPEM, err := os.ReadFile(certFile)
+	require.NoError(t, err)
+	block, _ := pem.Decode(certPEM)
+	leaf, err := x509.ParseCertificate(block.Bytes)
+	require.NoError(t, err)
+
+	out := filepath.Join(dir, "out")
+	hook := filepath.Join(dir, "hook.sh")
+	require.NoError(t, os.WriteFile(hook, []byte("#!/bin/sh\n"+
+		"echo \"$"+RenewHookSubjectEnv+" $"+RenewHookSerialEnv+" $"+RenewHookNotAfterEnv+"\" > "+out+"\n"), 0o700))
+
+	require.NoError(t, RunRenewHook(hook, leaf))
+	b, err := os.ReadFile(out)
+	require.NoError(t, err)
+	assert.Equal(t, "CN=client 1 "+leaf.NotAfter.UTC().Format(time.RFC3339), strings.TrimSpace(string(b)))
+
+	require.NoError(t, os.WriteFile(hook, []byte("#!/bin/sh\necho renewal failed\nexit 1\n"), 0o700))
+	assert.ErrorContains(t, RunRenewHook(hook, leaf), "renewal failed")
+}
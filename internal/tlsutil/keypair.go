@@ -0,0 +1,98 @@
+package tlsutil
+
+import (
+	"crypto/tls"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/pomerium/cli/certstore"
+)
+
+// keyPairLoader loads a client certificate from files and reloads it
+// whenever one of the files changes, so that certificates rotated by an
+// external process are picked up without restarting.
+type keyPairLoader struct {
+	certFile, keyFile, chainFile string
+	loadKeyPair                  func(certFile, keyFile string) (tls.Certificate, error)
+
+	mu       sync.Mutex
+	modTimes [3]time.Time
+	cert     *tls.Certificate
+}
+
+// newKeyPairLoader creates a loader for the files. If tpm is set, the key file
+// is a TSS2 key file of a key protected by the TPM. Otherwise the key may be
+// encrypted, and passphrase is called on each load to decrypt it.
+func newKeyPairLoader(certFile, keyFile, chainFile string, tpm bool, passphrase PassphraseFunc) (*keyPairLoader, error) {
+	l := &keyPairLoader{
+		certFile:  certFile,
+		keyFile:   keyFile,
+		chainFile: chainFile,
+		loadKeyPair: func(certFile, keyFile string) (tls.Certificate, error) {
+			return LoadX509KeyPair(certFile, keyFile, passphrase)
+		},
+	}
+	if tpm {
+		l.loadKeyPair = certstore.LoadTPMKeyPair
+	}
+	if _, err := l.get(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// GetClientCertificate implements tls.Config.GetClientCertificate.
+func (l *keyPairLoader) GetClientCertificate(_ *tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	return l.get()
+}
+
+func (l *keyPairLoader) get() (*tls.Certificate, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	modTimes, err := l.stat()
+	if err == nil && l.cert != nil && modTimes == l.modTimes {
+		return l.cert, nil
+	}
+
+	cert, err := l.load()
+	if err != nil {
+		// keep presenting the previous certificate while a rotation is in progress
+		if l.cert != nil {
+			return l.cert, nil
+		}
+		return nil, err
+	}
+	l.cert, l.modTimes = cert, modTimes
+	return l.cert, nil
+}
+
+func (l *keyPairLoader) load() (*tls.Certificate, error) {
+	cert, err := l.loadKeyPair(l.certFile, l.keyFile)
+	if err != nil {
+		return nil, err
+	}
+	if l.chainFile != "" {
+		if err := appendChainFromFile(&cert, l.chainFile); err != nil {
+			return nil, fmt.Errorf("chain: %w", err)
+		}
+	}
+	return &cert, nil
+}
+
+func (l *keyPairLoader) stat() ([3]time.Time, error) {
+	var modTimes [3]time.Time
+	for i, name := range []string{l.certFile, l.keyFile, l.chainFile} {
+		if name == "" {
+			continue
+		}
+		fi, err := os.Stat(name)
+		if err != nil {
+			return modTimes, err
+		}
+		modTimes[i] = fi.ModTime()
+	}
+	return modTimes, nil
+}
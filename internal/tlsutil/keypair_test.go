@@ -0,0 +1,56 @@
+package tlsutil
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyPairLoader(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	certFile, keyFile := writeClientCert(t, dir)
+
+	l, err := newKeyPairLoader(certFile, keyFile, "", false, nil)
+	require.NoError(t, err)
+	first, err := l.GetClientCertificate(nil)
+	require.NoError(t, err)
+
+	same, err := l.GetClientCertificate(nil)
+	require.NoError(t, err)
+	assert.Same(t, first, same, "should not reload unchanged files")
+
+	// rotate the certificate
+	rotated := t.TempDir()
+	newCert, newKey := writeClientCert(t, rotated)
+	copyFile(t, newCert, certFile)
+	copyFile(t, newKey, keyFile)
+	later := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(certFile, later, later))
+	require.NoError(t, os.Chtimes(keyFile, later, later))
+
+	second, err := l.GetClientCertificate(nil)
+	require.NoError(t, err)
+	assert.NotEqual(t, first.Certificate[0], second.Certificate[0])
+
+	// a half-written rotation keeps the previous certificate
+	require.NoError(t, os.WriteFile(keyFile, []byte("garbage"), 0o600))
+	later = later.Add(time.Minute)
+	require.NoError(t, os.Chtimes(keyFile, later, later))
+
+	third, err := l.GetClientCertificate(nil)
+	require.NoError(t, err)
+	assert.Same(t, second, third)
+}
+
+func copyFile(t *testing.T, src, dst string) {
+	t.Helper()
+
+	data, err := os.ReadFile(src)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(dst, data, 0o600))
+}
@@ -0,0 +1,194 @@
+package tlsutil
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/ocsp"
+)
+
+// ErrCertificateRevoked indicates the server certificate has been revoked.
+var ErrCertificateRevoked = errors.New("server certificate has been revoked")
+
+const (
+	// revocationCheckTimeout bounds each OCSP and CRL request
+	revocationCheckTimeout = 10 * time.Second
+	// maxOCSPResponseSize and maxCRLSize limit what is read from the responders
+	maxOCSPResponseSize = 1 << 20
+	maxCRLSize          = 32 << 20
+)
+
+// RevocationVerifier returns a function suitable for use as a
+// [tls.Config.VerifyConnection] callback. The callback checks that the server
+// certificate has not been revoked, preferring an OCSP response stapled to
+// the handshake, then asking the OCSP responders of the certificate and
+// finally fetching its CRLs. It fails closed: unless one of them vouches for
+// the certificate, the connection is rejected. Certificates that name neither
+// OCSP responders nor CRLs cannot be checked and are accepted.
+func RevocationVerifier() func(tls.ConnectionState) error {
+	c := &revocationChecker{
+		client: &http.Client{Timeout: revocationCheckTimeout},
+		crls:   make(map[string]*x509.RevocationList),
+	}
+	return c.verifyConnection
+}
+
+type revocationChecker struct {
+	client *http.Client
+
+	mu   sync.Mutex
+	crls map[string]*x509.RevocationList
+}
+
+func (c *revocationChecker) verifyConnection(cs tls.ConnectionState) error {
+	if len(cs.PeerCertificates) == 0 {
+		return nil
+	}
+	leaf := cs.PeerCertificates[0]
+	if len(cs.OCSPResponse) == 0 && len(leaf.OCSPServer) == 0 && len(leaf.CRLDistributionPoints) == 0 {
+		return nil
+	}
+
+	var issuer *x509.Certificate
+	if len(cs.VerifiedChains) > 0 && len(cs.VerifiedChains[0]) > 1 {
+		issuer = cs.VerifiedChains[0][1]
+	} else if len(cs.PeerCertificates) > 1 {
+		issuer = cs.PeerCertificates[1]
+	} else {
+		return fmt.Errorf("revocation check: the issuer of the server certificate is unknown")
+	}
+
+	var errs []error
+	if len(cs.OCSPResponse) > 0 {
+		err := checkOCSPResponse(cs.OCSPResponse, leaf, issuer)
+		if err == nil || errors.Is(err, ErrCertificateRevoked) {
+			return err
+		}
+		errs = append(errs, fmt.Errorf("stapled ocsp response: %w", err))
+	}
+	for _, server := range leaf.OCSPServer {
+		err := c.checkOCSP(server, leaf, issuer)
+		if err == nil || errors.Is(err, ErrCertificateRevoked) {
+			return err
+		}
+		errs = append(errs, fmt.Errorf("ocsp %s: %w", server, err))
+	}
+	for _, crlURL := range leaf.CRLDistributionPoints {
+		err := c.checkCRL(crlURL, leaf, issuer)
+		if err == nil || errors.Is(err, ErrCertificateRevoked) {
+			return err
+		}
+		errs = append(errs, fmt.Errorf("crl %s: %w", crlURL, err))
+	}
+	return fmt.Errorf("revocation check: failed to determine the status of the server certificate: %w",
+		errors.Join(errs...))
+}
+
+func (c *revocationChecker) checkOCSP(server string, leaf, issuer *x509.Certificate) error {
+	if !isHTTPURL(server) {
+		return fmt.Errorf("unsupported url")
+	}
+
+	req, err := ocsp.CreateRequest(leaf, issuer, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	res, err := c.client.Post(server, "application/ocsp-request", bytes.NewReader(req))
+	if err != nil {
+		return err
+	}
+	defer func() { _ = res.Body.Close() }()
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code: %s", res.Status)
+	}
+	der, err := io.ReadAll(io.LimitReader(res.Body, maxOCSPResponseSize))
+	if err != nil {
+		return err
+	}
+	return checkOCSPResponse(der, leaf, issuer)
+}
+
+func checkOCSPResponse(der []byte, leaf, issuer *x509.Certificate) error {
+	res, err := ocsp.ParseResponseForCert(der, leaf, issuer)
+	if err != nil {
+		return fmt.Errorf("invalid response: %w", err)
+	}
+	if !res.NextUpdate.IsZero() && time.Now().After(res.NextUpdate) {
+		return fmt.Errorf("response expired at %s", res.NextUpdate.Format(time.RFC3339))
+	}
+
+	switch res.Status {
+	case ocsp.Good:
+		return nil
+	case ocsp.Revoked:
+		return fmt.Errorf("%w at %s", ErrCertificateRevoked, res.RevokedAt.Format(time.RFC3339))
+	}
+	return fmt.Errorf("unknown certificate status")
+}
+
+func (c *revocationChecker) checkCRL(crlURL string, leaf, issuer *x509.Certificate) error {
+	crl, err := c.getCRL(crlURL, issuer)
+	if err != nil {
+		return err
+	}
+	for _, entry := range crl.RevokedCertificateEntries {
+		if entry.SerialNumber.Cmp(leaf.SerialNumber) == 0 {
+			return fmt.Errorf("%w at %s", ErrCertificateRevoked, entry.RevocationTime.Format(time.RFC3339))
+		}
+	}
+	return nil
+}
+
+// getCRL returns the CRL at the URL, which is cached until its next update.
+func (c *revocationChecker) getCRL(crlURL string, issuer *x509.Certificate) (*x509.RevocationList, error) {
+	c.mu.Lock()
+	crl, ok := c.crls[crlURL]
+	c.mu.Unlock()
+	if ok && time.Now().Before(crl.NextUpdate) {
+		return crl, nil
+	}
+
+	if !isHTTPURL(crlURL) {
+		return nil, fmt.Errorf("unsupported url")
+	}
+	res, err := c.client.Get(crlURL)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = res.Body.Close() }()
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %s", res.Status)
+	}
+	der, err := io.ReadAll(io.LimitReader(res.Body, maxCRLSize))
+	if err != nil {
+		return nil, err
+	}
+
+	crl, err = x509.ParseRevocationList(der)
+	if err != nil {
+		return nil, fmt.Errorf("invalid crl: %w", err)
+	}
+	if err := crl.CheckSignatureFrom(issuer); err != nil {
+		return nil, fmt.Errorf("invalid crl: %w", err)
+	}
+	if !crl.NextUpdate.IsZero() && time.Now().After(crl.NextUpdate) {
+		return nil, fmt.Errorf("crl expired at %s", crl.NextUpdate.Format(time.RFC3339))
+	}
+
+	c.mu.Lock()
+	c.crls[crlURL] = crl
+	c.mu.Unlock()
+	return crl, nil
+}
+
+func isHTTPURL(rawURL string) bool {
+	return strings.HasPrefix(rawURL, "http://") || strings.HasPrefix(rawURL, "https://")
+}
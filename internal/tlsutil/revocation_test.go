@@ -0,0 +1,96 @@
+package tlsutil
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRevocationVerifier(t *testing.T) {
+	t.Parallel()
+
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	require.NoError(t, err)
+	ca, err := x509.ParseCertificate(caDER)
+	require.NoError(t, err)
+
+	crlDER, err := x509.CreateRevocationList(rand.Reader, &x509.RevocationList{
+		Number:     big.NewInt(1),
+		ThisUpdate: time.Now().Add(-time.Hour),
+		NextUpdate: time.Now().Add(time.Hour),
+		RevokedCertificateEntries: []x509.RevocationListEntry{
+			{SerialNumber: big.NewInt(3), RevocationTime: time.Now().Add(-time.Minute)},
+		},
+	}, ca, caKey)
+	require.NoError(t, err)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/ca.crl" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write(crlDER)
+	}))
+	t.Cleanup(srv.Close)
+
+	newLeaf := func(serial int64, crlURLs ...string) *x509.Certificate {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		der, err := x509.CreateCertificate(rand.Reader, &x509.Certificate{
+			SerialNumber:          big.NewInt(serial),
+			Subject:               pkix.Name{CommonName: "proxy.example.com"},
+			NotBefore:             time.Now().Add(-time.Hour),
+			NotAfter:              time.Now().Add(time.Hour),
+			CRLDistributionPoints: crlURLs,
+		}, ca, &key.PublicKey, caKey)
+		require.NoError(t, err)
+		leaf, err := x509.ParseCertificate(der)
+		require.NoError(t, err)
+		return leaf
+	}
+	verify := RevocationVerifier()
+	state := func(leaf *x509.Certificate) tls.ConnectionState {
+		return tls.ConnectionState{
+			PeerCertificates: []*x509.Certificate{leaf},
+			VerifiedChains:   [][]*x509.Certificate{{leaf, ca}},
+		}
+	}
+
+	t.Run("good", func(t *testing.T) {
+		assert.NoError(t, verify(state(newLeaf(2, srv.URL+"/ca.crl"))))
+	})
+	t.Run("revoked", func(t *testing.T) {
+		assert.ErrorIs(t, verify(state(newLeaf(3, srv.URL+"/ca.crl"))), ErrCertificateRevoked)
+	})
+	t.Run("unavailable", func(t *testing.T) {
+		err := verify(state(newLeaf(2, srv.URL+"/missing.crl")))
+		assert.ErrorContains(t, err, "failed to determine the status")
+	})
+	t.Run("fallback", func(t *testing.T) {
+		assert.NoError(t, verify(state(newLeaf(2, srv.URL+"/missing.crl", srv.URL+"/ca.crl"))))
+	})
+	t.Run("nothing to check", func(t *testing.T) {
+		assert.NoError(t, verify(state(newLeaf(3))))
+	})
+}
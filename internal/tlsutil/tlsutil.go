@@ -0,0 +1,97 @@
+// Package tlsutil contains helpers for building TLS configurations.
+package tlsutil
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// ErrPinMismatch indicates no certificate of the peer matches the pinned SPKI hashes.
+var ErrPinMismatch = errors.New("no peer certificate matches the pinned SPKI hashes")
+
+// AppendCertsFromDir appends every PEM-encoded certificate found in the files
+// of the given directory to the pool. Subdirectories and files without any
+// certificates are skipped, but an error is returned if the directory does not
+// contain any certificates at all.
+func AppendCertsFromDir(pool *x509.CertPool, dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("failed to read CA directory: %w", err)
+	}
+
+	found := false
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return fmt.Errorf("failed to read CA file: %w", err)
+		}
+		if pool.AppendCertsFromPEM(data) {
+			found = true
+		}
+	}
+	if !found {
+		return fmt.Errorf("no PEM-encoded certificates found in %s", dir)
+	}
+	return nil
+}
+
+// PinnedSPKIVerifier returns a function suitable for use as a
+// [tls.Config.VerifyPeerCertificate] callback. The callback succeeds only if
+// the SHA-256 hash of the SubjectPublicKeyInfo of a certificate matches one of
+// the given base64-encoded pins.
+//
+// When the peer chain was verified, only certificates of the verified chains
+// (the leaf, intermediates and root) are considered, so a peer cannot satisfy
+// the pin by appending an unrelated pinned certificate to what it sends. When
+// verification was skipped, only the leaf certificate is considered.
+func PinnedSPKIVerifier(pins []string) (func([][]byte, [][]*x509.Certificate) error, error) {
+	hashes := make(map[[sha256.Size]byte]struct{}, len(pins))
+	for _, pin := range pins {
+		raw, err := base64.StdEncoding.DecodeString(pin)
+		if err != nil {
+			return nil, fmt.Errorf("invalid SPKI pin %q: %w", pin, err)
+		}
+		if len(raw) != sha256.Size {
+			return nil, fmt.Errorf("invalid SPKI pin %q: expected %d bytes, got %d", pin, sha256.Size, len(raw))
+		}
+		hashes[[sha256.Size]byte(raw)] = struct{}{}
+	}
+	matches := func(cert *x509.Certificate) bool {
+		_, ok := hashes[sha256.Sum256(cert.RawSubjectPublicKeyInfo)]
+		return ok
+	}
+
+	return func(rawCerts [][]byte, verifiedChains [][]*x509.Certificate) error {
+		if len(verifiedChains) > 0 {
+			for _, chain := range verifiedChains {
+				for _, cert := range chain {
+					if matches(cert) {
+						return nil
+					}
+				}
+			}
+			return ErrPinMismatch
+		}
+
+		if len(rawCerts) == 0 {
+			return ErrPinMismatch
+		}
+		leaf, err := x509.ParseCertificate(rawCerts[0])
+		if err != nil {
+			return fmt.Errorf("failed to parse peer certificate: %w", err)
+		}
+		if matches(leaf) {
+			return nil
+		}
+		return ErrPinMismatch
+	}, nil
+}
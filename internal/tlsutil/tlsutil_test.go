@@ -0,0 +1,125 @@
+package tlsutil
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppendCertsFromDir(t *testing.T) {
+	t.Parallel()
+
+	t.Run("ok", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "a.pem"), newCAPEM(t, "CA A"), 0o600))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "b.crt"), newCAPEM(t, "CA B"), 0o600))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "README"), []byte("not a cert"), 0o600))
+		require.NoError(t, os.Mkdir(filepath.Join(dir, "sub"), 0o700))
+
+		pool := x509.NewCertPool()
+		assert.NoError(t, AppendCertsFromDir(pool, dir))
+		//nolint:staticcheck
+		assert.Len(t, pool.Subjects(), 2)
+	})
+	t.Run("empty", func(t *testing.T) {
+		pool := x509.NewCertPool()
+		assert.Error(t, AppendCertsFromDir(pool, t.TempDir()))
+	})
+	t.Run("missing", func(t *testing.T) {
+		pool := x509.NewCertPool()
+		assert.Error(t, AppendCertsFromDir(pool, filepath.Join(t.TempDir(), "missing")))
+	})
+}
+
+func TestPinnedSPKIVerifier(t *testing.T) {
+	t.Parallel()
+
+	leaf := newCADER(t, "Leaf")
+	intermediate := newCADER(t, "Intermediate")
+	other := newCADER(t, "Other")
+	parse := func(der []byte) *x509.Certificate {
+		cert, err := x509.ParseCertificate(der)
+		require.NoError(t, err)
+		return cert
+	}
+	pin := func(der []byte) string {
+		h := sha256.Sum256(parse(der).RawSubjectPublicKeyInfo)
+		return base64.StdEncoding.EncodeToString(h[:])
+	}
+
+	t.Run("leaf", func(t *testing.T) {
+		f, err := PinnedSPKIVerifier([]string{pin(leaf)})
+		require.NoError(t, err)
+		assert.NoError(t, f([][]byte{leaf, intermediate}, nil))
+	})
+	t.Run("intermediate", func(t *testing.T) {
+		f, err := PinnedSPKIVerifier([]string{"AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=", pin(intermediate)})
+		require.NoError(t, err)
+		chains := [][]*x509.Certificate{{parse(leaf), parse(intermediate)}}
+		assert.NoError(t, f([][]byte{leaf, intermediate}, chains))
+	})
+	t.Run("mismatch", func(t *testing.T) {
+		f, err := PinnedSPKIVerifier([]string{pin(intermediate)})
+		require.NoError(t, err)
+		assert.Error(t, f([][]byte{leaf}, nil))
+	})
+	t.Run("appended unverified", func(t *testing.T) {
+		f, err := PinnedSPKIVerifier([]string{pin(intermediate)})
+		require.NoError(t, err)
+		// the pinned certificate is sent by the peer but is not part of the
+		// verified chain for the leaf
+		chains := [][]*x509.Certificate{{parse(other), parse(leaf)}}
+		assert.ErrorIs(t, f([][]byte{other, leaf, intermediate}, chains), ErrPinMismatch)
+	})
+	t.Run("appended skip verify", func(t *testing.T) {
+		f, err := PinnedSPKIVerifier([]string{pin(intermediate)})
+		require.NoError(t, err)
+		assert.ErrorIs(t, f([][]byte{other, intermediate}, nil), ErrPinMismatch)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		_, err := PinnedSPKIVerifier([]string{"not base64"})
+		assert.Error(t, err)
+		_, err = PinnedSPKIVerifier([]string{"AAAA"})
+		assert.Error(t, err)
+	})
+}
+
+func newCAPEM(t *testing.T, commonName string) []byte {
+	t.Helper()
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: newCADER(t, commonName)})
+}
+
+func newCADER(t *testing.T, commonName string) []byte {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, key.Public(), key)
+	require.NoError(t, err)
+
+	return der
+}
@@ -0,0 +1,155 @@
+// Package tun provides TUN network interfaces for tunneling IP packets.
+package tun
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+	"os"
+	"sync"
+
+	"github.com/pomerium/cli/tunnel"
+)
+
+// ErrUnsupported indicates TUN devices are not supported on this platform.
+var ErrUnsupported = errors.New("tun: not supported on this platform")
+
+// A Device is a TUN network interface. It implements tunnel.IPDevice.
+type Device struct {
+	file *os.File
+	name string
+	mtu  int
+
+	packets   chan []byte
+	closed    chan struct{}
+	closeOnce sync.Once
+	readErr   error
+}
+
+var _ tunnel.IPDevice = (*Device)(nil)
+
+// Open creates a TUN interface with the given name and MTU and brings it up.
+// The name may contain %d, which is replaced by the first free number.
+// Creating the interface usually requires elevated privileges.
+func Open(name string, mtu int) (*Device, error) {
+	file, name, err := open(name)
+	if err != nil {
+		return nil, err
+	}
+	if err := setUp(name, mtu); err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+
+	dev := &Device{
+		file:    file,
+		name:    name,
+		mtu:     mtu,
+		packets: make(chan []byte, 64),
+		closed:  make(chan struct{}),
+	}
+	go dev.readLoop()
+	return dev, nil
+}
+
+// Name returns the name of the interface.
+func (dev *Device) Name() string {
+	return dev.name
+}
+
+// Close removes the interface.
+func (dev *Device) Close() error {
+	var err error
+	dev.closeOnce.Do(func() {
+		err = dev.file.Close()
+	})
+	return err
+}
+
+// ReadPacket reads the next IP packet sent to the interface.
+func (dev *Device) ReadPacket(ctx context.Context) ([]byte, error) {
+	select {
+	case <-ctx.Done():
+		return nil, context.Cause(ctx)
+	case packet := <-dev.packets:
+		return packet, nil
+	case <-dev.closed:
+		return nil, dev.readErr
+	}
+}
+
+// WritePacket delivers an IP packet to the interface.
+func (dev *Device) WritePacket(_ context.Context, packet []byte) error {
+	_, err := dev.file.Write(packet)
+	if err != nil {
+		return fmt.Errorf("tun: error writing packet: %w", err)
+	}
+	return nil
+}
+
+// AssignAddresses adds the addresses to the interface.
+func (dev *Device) AssignAddresses(_ context.Context, prefixes []netip.Prefix) error {
+	for _, prefix := range prefixes {
+		if err := addAddress(dev.name, prefix); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AddRoutes routes the address ranges via the interface. Routes limited to a
+// single IP protocol are added for all protocols, the proxy filters the rest.
+func (dev *Device) AddRoutes(_ context.Context, routes []tunnel.IPRoute) error {
+	for _, route := range routes {
+		for _, prefix := range rangeToPrefixes(route.Start, route.End) {
+			if err := addRoute(dev.name, prefix); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (dev *Device) readLoop() {
+	defer close(dev.closed)
+	for {
+		buf := make([]byte, dev.mtu)
+		n, err := dev.file.Read(buf)
+		if err != nil {
+			dev.readErr = fmt.Errorf("tun: error reading packet: %w", err)
+			return
+		}
+		dev.packets <- buf[:n]
+	}
+}
+
+// rangeToPrefixes returns the smallest list of prefixes covering start to end.
+func rangeToPrefixes(start, end netip.Addr) []netip.Prefix {
+	if start.BitLen() != end.BitLen() {
+		return nil
+	}
+	var prefixes []netip.Prefix
+	for start.IsValid() && !end.Less(start) {
+		for bits := 0; bits <= start.BitLen(); bits++ {
+			prefix := netip.PrefixFrom(start, bits)
+			if prefix.Masked().Addr() != start || end.Less(lastAddr(prefix)) {
+				continue
+			}
+			prefixes = append(prefixes, prefix)
+			start = lastAddr(prefix).Next()
+			break
+		}
+	}
+	return prefixes
+}
+
+// lastAddr returns the last address in the prefix.
+func lastAddr(prefix netip.Prefix) netip.Addr {
+	b := prefix.Addr().AsSlice()
+	for i := prefix.Bits(); i < len(b)*8; i++ {
+		b[i/8] |= 0x80 >> (i % 8)
+	}
+	addr, _ := netip.AddrFromSlice(b)
+	return addr
+}
@@ -0,0 +1,68 @@
+package tun
+
+import (
+	"bytes"
+	"fmt"
+	"net/netip"
+	"os"
+	"os/exec"
+	"strconv"
+	"syscall"
+	"unsafe"
+)
+
+const (
+	tunSetIff = 0x400454ca
+	iffTun    = 0x0001
+	iffNoPI   = 0x1000
+)
+
+type ifreq struct {
+	name  [syscall.IFNAMSIZ]byte
+	flags uint16
+	_     [22]byte
+}
+
+func open(name string) (*os.File, string, error) {
+	fd, err := syscall.Open("/dev/net/tun", syscall.O_RDWR|syscall.O_CLOEXEC, 0)
+	if err != nil {
+		return nil, "", fmt.Errorf("tun: error opening /dev/net/tun: %w", err)
+	}
+
+	var req ifreq
+	copy(req.name[:syscall.IFNAMSIZ-1], name)
+	req.flags = iffTun | iffNoPI
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, uintptr(fd), tunSetIff, uintptr(unsafe.Pointer(&req)))
+	if errno != 0 {
+		_ = syscall.Close(fd)
+		return nil, "", fmt.Errorf("tun: error creating interface: %w", errno)
+	}
+
+	// use non-blocking I/O so that Close interrupts pending reads
+	if err := syscall.SetNonblock(fd, true); err != nil {
+		_ = syscall.Close(fd)
+		return nil, "", fmt.Errorf("tun: error setting non-blocking mode: %w", err)
+	}
+
+	return os.NewFile(uintptr(fd), "/dev/net/tun"), string(bytes.TrimRight(req.name[:], "\x00")), nil
+}
+
+func setUp(name string, mtu int) error {
+	return runIP("link", "set", "dev", name, "mtu", strconv.Itoa(mtu), "up")
+}
+
+func addAddress(name string, prefix netip.Prefix) error {
+	return runIP("address", "replace", prefix.String(), "dev", name)
+}
+
+func addRoute(name string, prefix netip.Prefix) error {
+	return runIP("route", "replace", prefix.String(), "dev", name)
+}
+
+func runIP(args ...string) error {
+	out, err := exec.Command("ip", args...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("tun: ip %v: %w: %s", args, err, bytes.TrimSpace(out))
+	}
+	return nil
+}
@@ -0,0 +1,16 @@
+//go:build !linux
+
+package tun
+
+import (
+	"net/netip"
+	"os"
+)
+
+func open(string) (*os.File, string, error) { return nil, "", ErrUnsupported }
+
+func setUp(string, int) error { return ErrUnsupported }
+
+func addAddress(string, netip.Prefix) error { return ErrUnsupported }
+
+func addRoute(string, netip.Prefix) error { return ErrUnsupported }
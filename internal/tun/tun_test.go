@@ -0,0 +1,32 @@
+package tun
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRangeToPrefixes(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		start, end string
+		expect     []string
+	}{
+		{"10.0.0.0", "10.0.255.255", []string{"10.0.0.0/16"}},
+		{"192.168.1.10", "192.168.1.10", []string{"192.168.1.10/32"}},
+		{"10.0.0.1", "10.0.0.6", []string{"10.0.0.1/32", "10.0.0.2/31", "10.0.0.4/31", "10.0.0.6/32"}},
+		{"0.0.0.0", "255.255.255.255", []string{"0.0.0.0/0"}},
+		{"255.255.255.254", "255.255.255.255", []string{"255.255.255.254/31"}},
+		{"fd00::", "fd00::ffff", []string{"fd00::/112"}},
+		{"10.0.0.2", "10.0.0.1", nil},
+	} {
+		var expect []netip.Prefix
+		for _, s := range tc.expect {
+			expect = append(expect, netip.MustParsePrefix(s))
+		}
+		assert.Equal(t, expect, rangeToPrefixes(netip.MustParseAddr(tc.start), netip.MustParseAddr(tc.end)),
+			"%s-%s", tc.start, tc.end)
+	}
+}
@@ -0,0 +1,46 @@
+package versioncheck
+
+import (
+	"context"
+	"time"
+)
+
+type config struct {
+	interval  time.Duration
+	latest    func(ctx context.Context) (string, error)
+	statePath string
+}
+
+type Option func(cfg *config)
+
+// WithInterval sets how often the checks are made.
+func WithInterval(interval time.Duration) Option {
+	return func(cfg *config) {
+		cfg.interval = interval
+	}
+}
+
+// WithLatestRelease sets the function returning the version of the latest
+// release. Without it, the latest release is not checked.
+func WithLatestRelease(latest func(ctx context.Context) (string, error)) Option {
+	return func(cfg *config) {
+		cfg.latest = latest
+	}
+}
+
+// WithStatePath sets the file recording when the checks were made. Without
+// it, every check is made.
+func WithStatePath(statePath string) Option {
+	return func(cfg *config) {
+		cfg.statePath = statePath
+	}
+}
+
+func getConfig(options ...Option) *config {
+	cfg := new(config)
+	WithInterval(DefaultInterval)(cfg)
+	for _, option := range options {
+		option(cfg)
+	}
+	return cfg
+}
@@ -0,0 +1,138 @@
+// Package versioncheck warns when a newer release of pomerium-cli is
+// available.
+package versioncheck
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/pomerium/cli/version"
+)
+
+// DisableEnv disables the version checks when set to a non-empty value.
+const DisableEnv = "POMERIUM_CLI_NO_VERSION_CHECK"
+
+// DefaultInterval is how often the checks are made.
+const DefaultInterval = 24 * time.Hour
+
+// Enabled reports whether the version checks are enabled. They are disabled
+// by the environment, and for development builds.
+func Enabled() bool {
+	return os.Getenv(DisableEnv) == "" && version.IsValid(version.Version) && version.Version != "v0.0.0"
+}
+
+// A Checker checks for a newer release of pomerium-cli, at most once per
+// interval.
+type Checker struct {
+	cfg     *config
+	current string
+
+	mu sync.Mutex
+}
+
+// New creates a new Checker of the current version.
+func New(current string, options ...Option) *Checker {
+	return &Checker{cfg: getConfig(options...), current: current}
+}
+
+// state records when the checks were last made, by kind of check
+type state struct {
+	CheckedAt map[string]time.Time `json:"checked_at"`
+}
+
+const latestCheckKey = "latest"
+
+// CheckLatest returns a warning if a newer release is available. Nothing is
+// returned if the check was made within the interval.
+func (c *Checker) CheckLatest(ctx context.Context) ([]string, error) {
+	if c.cfg.latest == nil {
+		return nil, nil
+	}
+	due, err := c.due(latestCheckKey)
+	if err != nil || !due {
+		return nil, err
+	}
+
+	latest, err := c.cfg.latest(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error getting the latest release: %w", err)
+	}
+	if version.Compare(latest, c.current) <= 0 {
+		return nil, nil
+	}
+	return []string{fmt.Sprintf("pomerium-cli %s is available, you are running %s, run pomerium-cli update",
+		latest, c.current)}, nil
+}
+
+// due reports whether the check with the key is due, and records it as made
+// if so
+func (c *Checker) due(key string) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	s, err := c.load()
+	if err != nil {
+		return false, err
+	}
+	now := time.Now()
+	if t, ok := s.CheckedAt[key]; ok && now.Sub(t) < c.cfg.interval && !t.After(now) {
+		return false, nil
+	}
+	s.CheckedAt[key] = now
+	// a failed check is not retried before the interval either, so that the
+	// checks do not slow down every command while offline
+	return true, c.save(s)
+}
+
+func (c *Checker) load() (*state, error) {
+	s := &state{CheckedAt: make(map[string]time.Time)}
+	if c.cfg.statePath == "" {
+		return s, nil
+	}
+	data, err := os.ReadFile(c.cfg.statePath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return s, nil
+	} else if err != nil {
+		return nil, err
+	}
+	// a corrupt state is replaced
+	if err := json.Unmarshal(data, s); err != nil || s.CheckedAt == nil {
+		s.CheckedAt = make(map[string]time.Time)
+	}
+	return s, nil
+}
+
+func (c *Checker) save(s *state) error {
+	if c.cfg.statePath == "" {
+		return nil
+	}
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(c.cfg.statePath), 0o700); err != nil {
+		return err
+	}
+	// other processes may check at the same time, so the state is replaced
+	// rather than written in place
+	f, err := os.CreateTemp(filepath.Dir(c.cfg.statePath), filepath.Base(c.cfg.statePath)+".*")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(f.Name()) }()
+	_, err = f.Write(data)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), c.cfg.statePath)
+}
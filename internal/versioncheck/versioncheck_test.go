@@ -0,0 +1,46 @@
+package versioncheck
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckLatest(t *testing.T) {
+	t.Parallel()
+
+	statePath := filepath.Join(t.TempDir(), "version-check.json")
+	calls := 0
+	latest := func(_ context.Context) (string, error) {
+		calls++
+		return "v0.30.0", nil
+	}
+
+	c := New("v0.29.0", WithStatePath(statePath), WithLatestRelease(latest))
+	warnings, err := c.CheckLatest(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"pomerium-cli v0.30.0 is available, you are running v0.29.0, run pomerium-cli update"},
+		warnings)
+
+	// the check is made once per interval, also by other processes
+	c = New("v0.29.0", WithStatePath(statePath), WithLatestRelease(latest))
+	warnings, err = c.CheckLatest(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, warnings)
+	assert.Equal(t, 1, calls)
+
+	c = New("v0.29.0", WithStatePath(statePath), WithLatestRelease(latest), WithInterval(time.Nanosecond))
+	time.Sleep(time.Millisecond)
+	_, err = c.CheckLatest(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls)
+
+	c = New("v0.30.0", WithLatestRelease(latest))
+	warnings, err = c.CheckLatest(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, warnings, "up to date")
+}
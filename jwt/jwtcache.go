@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
 	"time"
 
@@ -24,6 +25,8 @@ var (
 	ErrExpired  = errors.New("expired")
 	ErrInvalid  = errors.New("invalid")
 	ErrNotFound = errors.New("not found")
+	// ErrUnexpectedUser indicates that a JWT was issued for a different user than expected.
+	ErrUnexpectedUser = errors.New("unexpected user")
 )
 
 // A Cache loads and stores JWTs.
@@ -33,15 +36,43 @@ type Cache interface {
 	StoreJWT(key string, rawJWT string) error
 }
 
+// Stores of the Cache returned by GetCache.
+const (
+	// StoreFile stores JWTs in files in the user's cache directory.
+	StoreFile = "file"
+	// StoreKeyring stores JWTs in the keyring of the operating system.
+	StoreKeyring = "keyring"
+)
+
 var (
-	globalCacheOnce sync.Once
-	globalCache     Cache
+	globalCacheOnce  sync.Once
+	globalCache      Cache
+	globalCacheStore = StoreFile
 )
 
+// SetStore selects the store of the Cache returned by GetCache, it has no
+// effect once GetCache has been called.
+func SetStore(store string) error {
+	switch store {
+	case StoreFile, StoreKeyring:
+	default:
+		return fmt.Errorf("unknown JWT store %q, expected %s or %s", store, StoreFile, StoreKeyring)
+	}
+	globalCacheStore = store
+	return nil
+}
+
 // GetCache gets the Cache. Either a local one is used or if that's not possible an in-memory one is used.
 func GetCache() Cache {
 	globalCacheOnce.Do(func() {
-		if c, err := NewLocalCache(); err == nil {
+		var c Cache
+		var err error
+		if globalCacheStore == StoreKeyring {
+			c, err = NewKeyringCache()
+		} else {
+			c, err = NewLocalCache()
+		}
+		if err == nil {
 			globalCache = c
 		} else {
 			log.Error().Err(err).Msg("error creating local JWT cache, using in-memory JWT cache")
@@ -80,19 +111,30 @@ func (cache *LocalCache) DeleteJWT(key string) error {
 	if os.IsNotExist(err) {
 		err = nil
 	}
+	_ = os.Remove(filepath.Join(cache.dir, cache.keyFileName(key)))
 	return err
 }
 
 // LoadJWT loads a raw JWT from the local cache.
 func (cache *LocalCache) LoadJWT(key string) (rawJWT string, err error) {
 	path := filepath.Join(cache.dir, cache.fileName(key))
-	rawBS, err := os.ReadFile(path)
+	data, err := os.ReadFile(path)
 	if os.IsNotExist(err) {
 		return "", ErrNotFound
 	} else if err != nil {
 		return "", err
 	}
-	rawJWT = string(rawBS)
+	rawJWT, migrate, err := openJWT(cache.fileName(key), data)
+	if err != nil {
+		return "", err
+	}
+
+	if migrate {
+		// encrypt the JWT stored before cache encryption was enabled
+		if err := cache.StoreJWT(key, rawJWT); err != nil {
+			log.Error().Err(err).Msg("error encrypting cached JWT")
+		}
+	}
 
 	return rawJWT, checkExpiry(rawJWT)
 }
@@ -104,8 +146,20 @@ func (cache *LocalCache) StoreJWT(key string, rawJWT string) error {
 		return err
 	}
 
+	data, err := sealJWT(cache.fileName(key), rawJWT)
+	if err != nil {
+		return err
+	}
+
 	path := filepath.Join(cache.dir, cache.fileName(key))
-	err = os.WriteFile(path, []byte(rawJWT), 0o600)
+	err = os.WriteFile(path, data, 0o600)
+	if err != nil {
+		return err
+	}
+
+	// the file name is a hash, so keep the key alongside for listing
+	path = filepath.Join(cache.dir, cache.keyFileName(key))
+	err = os.WriteFile(path, []byte(key), 0o600)
 	if err != nil {
 		return err
 	}
@@ -113,13 +167,70 @@ func (cache *LocalCache) StoreJWT(key string, rawJWT string) error {
 	return nil
 }
 
-func (cache *LocalCache) hash(str string) string {
-	h := cryptutil.Hash("LocalJWTCache", []byte(str))
-	return base36.EncodeBytes(h)
+// ListJWTs lists the JWTs in the local cache. Entries stored by older
+// versions have no key recorded, for those the key is empty.
+func (cache *LocalCache) ListJWTs() ([]Entry, error) {
+	files, err := os.ReadDir(cache.dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var entries []Entry
+	for _, f := range files {
+		name, ok := strings.CutSuffix(f.Name(), ".jwt")
+		if !ok || f.IsDir() {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(cache.dir, f.Name()))
+		if err != nil {
+			return nil, err
+		}
+		keyBS, _ := os.ReadFile(filepath.Join(cache.dir, name+".key"))
+		rawJWT, _, err := openJWT(f.Name(), data)
+		if err != nil && !errors.Is(err, ErrInvalid) {
+			return nil, err
+		}
+		e := Entry{Key: string(keyBS), Err: err}
+		if err == nil {
+			e = newEntry(string(keyBS), rawJWT)
+		}
+		e.Path = filepath.Join(cache.dir, f.Name())
+		entries = append(entries, e)
+	}
+	return entries, nil
 }
 
 func (cache *LocalCache) fileName(key string) string {
-	return cache.hash(key) + ".jwt"
+	return hashKey(key) + ".jwt"
+}
+
+func (cache *LocalCache) keyFileName(key string) string {
+	return hashKey(key) + ".key"
+}
+
+// sealJWT encrypts a JWT for the file, if cache encryption is enabled.
+func sealJWT(fileName, rawJWT string) ([]byte, error) {
+	return cache.Seal(fileName, []byte(rawJWT))
+}
+
+// openJWT decrypts the JWT in the file, migrate reports whether it was stored
+// without encryption and should be stored again. JWTs that cannot be
+// decrypted are invalid.
+func openJWT(fileName string, data []byte) (rawJWT string, migrate bool, err error) {
+	rawBS, migrate, err := cache.Open(fileName, data)
+	if errors.Is(err, cache.ErrUndecryptable) {
+		return "", false, ErrInvalid
+	} else if err != nil {
+		return "", false, err
+	}
+	return string(rawBS), migrate, nil
+}
+
+func hashKey(key string) string {
+	h := cryptutil.Hash("LocalJWTCache", []byte(key))
+	return base36.EncodeBytes(h)
 }
 
 // A MemoryCache stores JWTs in an in-memory map.
@@ -165,28 +276,124 @@ func (cache *MemoryCache) StoreJWT(key string, rawJWT string) error {
 	return nil
 }
 
+// ListJWTs lists the JWTs in the in-memory map.
+func (cache *MemoryCache) ListJWTs() ([]Entry, error) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	entries := make([]Entry, 0, len(cache.entries))
+	for key, rawJWT := range cache.entries {
+		entries = append(entries, newEntry(key, rawJWT))
+	}
+	return entries, nil
+}
+
 func checkExpiry(rawJWT string) error {
+	claims, err := ParseClaims(rawJWT)
+	if err != nil {
+		return err
+	}
+
+	if !claims.Expiry.IsZero() && claims.Expiry.Before(time.Now()) {
+		return ErrExpired
+	}
+
+	return nil
+}
+
+// Claims are the JWT claims used to describe a cached session.
+type Claims struct {
+	Subject  string
+	Email    string
+	Groups   []string
+	IssuedAt time.Time
+	// Expiry is zero if the JWT does not expire.
+	Expiry time.Time
+}
+
+// ParseClaims parses the claims of a raw JWT without verifying its signature.
+func ParseClaims(rawJWT string) (Claims, error) {
 	tok, err := jose.ParseSigned(rawJWT)
 	if err != nil {
-		return ErrInvalid
+		return Claims{}, ErrInvalid
 	}
 
 	var claims struct {
-		Expiry null.Int64 `json:"exp"`
+		Subject  null.String `json:"sub"`
+		Email    null.String `json:"email"`
+		Groups   []string    `json:"groups"`
+		IssuedAt null.Int64  `json:"iat"`
+		Expiry   null.Int64  `json:"exp"`
 	}
 	err = json.Unmarshal(tok.UnsafePayloadWithoutVerification(), &claims)
 	if err != nil {
-		return ErrInvalid
+		return Claims{}, ErrInvalid
 	}
 
+	var c Claims
+	if claims.Subject.Valid {
+		c.Subject = claims.Subject.String
+	}
+	if claims.Email.Valid {
+		c.Email = claims.Email.String
+	}
+	c.Groups = claims.Groups
+	if claims.IssuedAt.Valid {
+		c.IssuedAt = time.Unix(claims.IssuedAt.Int64, 0)
+	}
 	if claims.Expiry.Valid {
-		expiresAt := time.Unix(claims.Expiry.Int64, 0)
-		if expiresAt.Before(time.Now()) {
-			return ErrExpired
-		}
+		c.Expiry = time.Unix(claims.Expiry.Int64, 0)
 	}
+	return c, nil
+}
 
-	return nil
+// CheckUser checks that the JWT was issued for the expected user, which is
+// compared against both the email and the subject claim. An empty expected
+// user matches any JWT.
+func CheckUser(rawJWT, expectedUser string) error {
+	if expectedUser == "" {
+		return nil
+	}
+
+	claims, err := ParseClaims(rawJWT)
+	if err != nil {
+		return err
+	}
+
+	if strings.EqualFold(claims.Email, expectedUser) || claims.Subject == expectedUser {
+		return nil
+	}
+
+	actualUser := claims.Email
+	if actualUser == "" {
+		actualUser = claims.Subject
+	}
+	return fmt.Errorf("%w: signed in as %q, expected %q", ErrUnexpectedUser, actualUser, expectedUser)
+}
+
+// An Entry is a cached JWT.
+type Entry struct {
+	// Key is the cache key, see CacheKeyForHost.
+	Key    string
+	Claims Claims
+	// Err is nil if the JWT is valid, ErrExpired or ErrInvalid otherwise.
+	Err error
+	// Path is the file the JWT is stored in, if any.
+	Path string
+}
+
+func newEntry(key, rawJWT string) Entry {
+	e := Entry{Key: key}
+	e.Claims, e.Err = ParseClaims(rawJWT)
+	if e.Err == nil {
+		e.Err = checkExpiry(rawJWT)
+	}
+	return e
+}
+
+// A Lister lists cached JWTs.
+type Lister interface {
+	ListJWTs() ([]Entry, error)
 }
 
 // CacheKeyForHost returns the cache key for the given host and tls config.
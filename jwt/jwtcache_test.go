@@ -60,4 +60,85 @@ func TestLocalCache(t *testing.T) {
 		_, err = c.LoadJWT("EXPIRED")
 		assert.Equal(t, ErrExpired, err)
 	})
+	t.Run("List", func(t *testing.T) {
+		privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.PS512, Key: privateKey}, nil)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		expiry := time.Now().Add(time.Hour).Truncate(time.Second)
+		object, err := signer.Sign([]byte(`{"sub": "user1", "email": "user1@example.com", "groups": ["admins", "dev"], "exp": ` +
+			fmt.Sprint(expiry.Unix()) + `}`))
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		rawJWT, err := object.CompactSerialize()
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		err = c.StoreJWT("VALID", rawJWT)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		entries, err := c.ListJWTs()
+		if !assert.NoError(t, err) {
+			return
+		}
+		byKey := make(map[string]Entry)
+		for _, e := range entries {
+			byKey[e.Key] = e
+		}
+		assert.Len(t, byKey, 3)
+		assert.Equal(t, ErrInvalid, byKey["INVALID"].Err)
+		assert.Equal(t, ErrExpired, byKey["EXPIRED"].Err)
+		assert.NoError(t, byKey["VALID"].Err)
+		assert.Equal(t, Claims{
+			Subject: "user1", Email: "user1@example.com", Groups: []string{"admins", "dev"}, Expiry: expiry,
+		}, byKey["VALID"].Claims)
+		assert.Equal(t, filepath.Join(c.dir, c.fileName("VALID")), byKey["VALID"].Path)
+
+		err = c.DeleteJWT("VALID")
+		if !assert.NoError(t, err) {
+			return
+		}
+		entries, err = c.ListJWTs()
+		assert.NoError(t, err)
+		assert.Len(t, entries, 2)
+	})
+}
+
+func TestCheckUser(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.PS512, Key: privateKey}, nil)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	object, err := signer.Sign([]byte(`{"sub": "user1", "email": "User1@example.com"}`))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	rawJWT, err := object.CompactSerialize()
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.NoError(t, CheckUser(rawJWT, ""))
+	assert.NoError(t, CheckUser(rawJWT, "user1@example.com"))
+	assert.NoError(t, CheckUser(rawJWT, "user1"))
+	assert.ErrorIs(t, CheckUser(rawJWT, "user2@example.com"), ErrUnexpectedUser)
+	assert.ErrorIs(t, CheckUser("INVALID", "user1"), ErrInvalid)
 }
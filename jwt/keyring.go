@@ -0,0 +1,146 @@
+package jwt
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pomerium/cli/internal/cache"
+	"github.com/pomerium/cli/internal/keyring"
+	"github.com/pomerium/cli/internal/profile"
+)
+
+// keyringService is the service the JWTs of the default profile are stored
+// under in the keyring
+const keyringService = "pomerium-cli"
+
+// A KeyringCache stores JWTs in the keyring of the operating system, so that
+// they are not written to disk in plaintext. As keyrings cannot be listed,
+// the keys are kept in files in the user's cache directory.
+type KeyringCache struct {
+	dir     string
+	service string
+	keyring keyring.Keyring
+}
+
+// NewKeyringCache creates a new KeyringCache.
+func NewKeyringCache() (*KeyringCache, error) {
+	dir, err := cache.KeyringJWTsPath()
+	if err != nil {
+		return nil, err
+	}
+
+	err = os.MkdirAll(dir, 0o700)
+	if err != nil {
+		return nil, fmt.Errorf("error creating user cache directory: %w", err)
+	}
+
+	return &KeyringCache{
+		dir:     dir,
+		service: profile.KeyringService(keyringService),
+		keyring: keyring.System(),
+	}, nil
+}
+
+// DeleteJWT deletes a raw JWT from the keyring.
+func (cache *KeyringCache) DeleteJWT(key string) error {
+	err := cache.keyring.Delete(cache.service, key)
+	if err != nil {
+		return err
+	}
+	err = os.Remove(filepath.Join(cache.dir, cache.keyFileName(key)))
+	if os.IsNotExist(err) {
+		err = nil
+	}
+	return err
+}
+
+// LoadJWT loads a raw JWT from the keyring.
+func (cache *KeyringCache) LoadJWT(key string) (rawJWT string, err error) {
+	rawJWT, err = cache.keyring.Get(cache.service, key)
+	if errors.Is(err, keyring.ErrNotFound) {
+		return "", ErrNotFound
+	} else if err != nil {
+		return "", err
+	}
+
+	return rawJWT, checkExpiry(rawJWT)
+}
+
+// StoreJWT stores a raw JWT in the keyring.
+func (cache *KeyringCache) StoreJWT(key string, rawJWT string) error {
+	err := cache.keyring.Set(cache.service, key, rawJWT)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(cache.dir, 0o700)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(cache.dir, cache.keyFileName(key)), []byte(key), 0o600)
+}
+
+// ListJWTs lists the JWTs in the keyring.
+func (cache *KeyringCache) ListJWTs() ([]Entry, error) {
+	keys, err := cache.keys()
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []Entry
+	for _, key := range keys {
+		rawJWT, err := cache.keyring.Get(cache.service, key)
+		if errors.Is(err, keyring.ErrNotFound) {
+			// removed from the keyring by the user
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		entries = append(entries, newEntry(key, rawJWT))
+	}
+	return entries, nil
+}
+
+// Clear deletes all JWTs from the keyring.
+func (cache *KeyringCache) Clear() error {
+	keys, err := cache.keys()
+	if err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		if err := cache.DeleteJWT(key); err != nil {
+			return fmt.Errorf("delete %s: %w", key, err)
+		}
+	}
+	return nil
+}
+
+func (cache *KeyringCache) keys() ([]string, error) {
+	files, err := os.ReadDir(cache.dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var keys []string
+	for _, f := range files {
+		if !strings.HasSuffix(f.Name(), ".key") || f.IsDir() {
+			continue
+		}
+		keyBS, err := os.ReadFile(filepath.Join(cache.dir, f.Name()))
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, string(keyBS))
+	}
+	return keys, nil
+}
+
+func (cache *KeyringCache) keyFileName(key string) string {
+	return hashKey(key) + ".key"
+}
@@ -0,0 +1,94 @@
+package jwt
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-jose/go-jose/v3"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pomerium/cli/internal/keyring"
+)
+
+type memoryKeyring map[string]string
+
+func (k memoryKeyring) Get(service, account string) (string, error) {
+	secret, ok := k[service+"/"+account]
+	if !ok {
+		return "", keyring.ErrNotFound
+	}
+	return secret, nil
+}
+
+func (k memoryKeyring) Set(service, account, secret string) error {
+	k[service+"/"+account] = secret
+	return nil
+}
+
+func (k memoryKeyring) Delete(service, account string) error {
+	delete(k, service+"/"+account)
+	return nil
+}
+
+func TestKeyringCache(t *testing.T) {
+	kr := make(memoryKeyring)
+	c := &KeyringCache{
+		dir:     filepath.Join(t.TempDir(), "keyring-jwts"),
+		service: "pomerium-cli",
+		keyring: kr,
+	}
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if !assert.NoError(t, err) {
+		return
+	}
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.PS512, Key: privateKey}, nil)
+	if !assert.NoError(t, err) {
+		return
+	}
+	object, err := signer.Sign([]byte(`{"sub": "user1"}`))
+	if !assert.NoError(t, err) {
+		return
+	}
+	rawJWT, err := object.CompactSerialize()
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	_, err = c.LoadJWT("example.com:443|true")
+	assert.Equal(t, ErrNotFound, err)
+
+	assert.NoError(t, c.StoreJWT("example.com:443|true", rawJWT))
+	assert.NoError(t, c.StoreJWT("example.com:80|false", "INVALID"))
+	assert.Equal(t, rawJWT, kr["pomerium-cli/example.com:443|true"], "should store the JWT in the keyring")
+
+	loaded, err := c.LoadJWT("example.com:443|true")
+	assert.NoError(t, err)
+	assert.Equal(t, rawJWT, loaded)
+
+	entries, err := c.ListJWTs()
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []Entry{
+		{Key: "example.com:443|true", Claims: Claims{Subject: "user1"}},
+		{Key: "example.com:80|false", Err: ErrInvalid},
+	}, entries)
+
+	// entries removed from the keyring are skipped
+	delete(kr, "pomerium-cli/example.com:80|false")
+	entries, err = c.ListJWTs()
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+
+	assert.NoError(t, c.DeleteJWT("example.com:443|true"))
+	_, err = c.LoadJWT("example.com:443|true")
+	assert.Equal(t, ErrNotFound, err)
+
+	assert.NoError(t, c.StoreJWT("example.com:443|true", rawJWT))
+	assert.NoError(t, c.Clear())
+	assert.Empty(t, kr)
+	entries, err = c.ListJWTs()
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+}
@@ -19,13 +19,17 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	Config_List_FullMethodName        = "/pomerium.cli.Config/List"
-	Config_Delete_FullMethodName      = "/pomerium.cli.Config/Delete"
-	Config_Upsert_FullMethodName      = "/pomerium.cli.Config/Upsert"
-	Config_GetTags_FullMethodName     = "/pomerium.cli.Config/GetTags"
-	Config_Export_FullMethodName      = "/pomerium.cli.Config/Export"
-	Config_Import_FullMethodName      = "/pomerium.cli.Config/Import"
-	Config_FetchRoutes_FullMethodName = "/pomerium.cli.Config/FetchRoutes"
+	Config_List_FullMethodName             = "/pomerium.cli.Config/List"
+	Config_Delete_FullMethodName           = "/pomerium.cli.Config/Delete"
+	Config_Upsert_FullMethodName           = "/pomerium.cli.Config/Upsert"
+	Config_GetTags_FullMethodName          = "/pomerium.cli.Config/GetTags"
+	Config_RenameTag_FullMethodName        = "/pomerium.cli.Config/RenameTag"
+	Config_MergeTags_FullMethodName        = "/pomerium.cli.Config/MergeTags"
+	Config_Export_FullMethodName           = "/pomerium.cli.Config/Export"
+	Config_Import_FullMethodName           = "/pomerium.cli.Config/Import"
+	Config_FetchRoutes_FullMethodName      = "/pomerium.cli.Config/FetchRoutes"
+	Config_ImportFromPortal_FullMethodName = "/pomerium.cli.Config/ImportFromPortal"
+	Config_ListAuditEvents_FullMethodName  = "/pomerium.cli.Config/ListAuditEvents"
 )
 
 // ConfigClient is the client API for Config service.
@@ -43,12 +47,23 @@ type ConfigClient interface {
 	Upsert(ctx context.Context, in *Record, opts ...grpc.CallOption) (*Record, error)
 	// GetTags returns all tags. Note that tags are case sensitive
 	GetTags(ctx context.Context, in *GetTagsRequest, opts ...grpc.CallOption) (*GetTagsResponse, error)
+	// RenameTag renames a tag in every record that has it
+	RenameTag(ctx context.Context, in *RenameTagRequest, opts ...grpc.CallOption) (*Records, error)
+	// MergeTags replaces several tags with a single tag in every record that has
+	// any of them
+	MergeTags(ctx context.Context, in *MergeTagsRequest, opts ...grpc.CallOption) (*Records, error)
 	// Export dumps config into serialized format
 	Export(ctx context.Context, in *ExportRequest, opts ...grpc.CallOption) (*ConfigData, error)
 	// Import imports previously serialized records
 	Import(ctx context.Context, in *ImportRequest, opts ...grpc.CallOption) (*ImportResponse, error)
 	// FetchRoutes fetches all the routes from the routes portal.
 	FetchRoutes(ctx context.Context, in *FetchRoutesRequest, opts ...grpc.CallOption) (*FetchRoutesResponse, error)
+	// ImportFromPortal creates records for the TCP and UDP routes of the routes
+	// portal, importing again updates the same records
+	ImportFromPortal(ctx context.Context, in *ImportFromPortalRequest, opts ...grpc.CallOption) (*Records, error)
+	// ListAuditEvents returns the audit log of the changes to the records and
+	// listeners, oldest first
+	ListAuditEvents(ctx context.Context, in *ListAuditEventsRequest, opts ...grpc.CallOption) (*ListAuditEventsResponse, error)
 }
 
 type configClient struct {
@@ -99,6 +114,26 @@ func (c *configClient) GetTags(ctx context.Context, in *GetTagsRequest, opts ...
 	return out, nil
 }
 
+func (c *configClient) RenameTag(ctx context.Context, in *RenameTagRequest, opts ...grpc.CallOption) (*Records, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Records)
+	err := c.cc.Invoke(ctx, Config_RenameTag_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *configClient) MergeTags(ctx context.Context, in *MergeTagsRequest, opts ...grpc.CallOption) (*Records, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Records)
+	err := c.cc.Invoke(ctx, Config_MergeTags_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *configClient) Export(ctx context.Context, in *ExportRequest, opts ...grpc.CallOption) (*ConfigData, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ConfigData)
@@ -129,6 +164,26 @@ func (c *configClient) FetchRoutes(ctx context.Context, in *FetchRoutesRequest,
 	return out, nil
 }
 
+func (c *configClient) ImportFromPortal(ctx context.Context, in *ImportFromPortalRequest, opts ...grpc.CallOption) (*Records, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Records)
+	err := c.cc.Invoke(ctx, Config_ImportFromPortal_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *configClient) ListAuditEvents(ctx context.Context, in *ListAuditEventsRequest, opts ...grpc.CallOption) (*ListAuditEventsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAuditEventsResponse)
+	err := c.cc.Invoke(ctx, Config_ListAuditEvents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ConfigServer is the server API for Config service.
 // All implementations should embed UnimplementedConfigServer
 // for forward compatibility.
@@ -144,12 +199,23 @@ type ConfigServer interface {
 	Upsert(context.Context, *Record) (*Record, error)
 	// GetTags returns all tags. Note that tags are case sensitive
 	GetTags(context.Context, *GetTagsRequest) (*GetTagsResponse, error)
+	// RenameTag renames a tag in every record that has it
+	RenameTag(context.Context, *RenameTagRequest) (*Records, error)
+	// MergeTags replaces several tags with a single tag in every record that has
+	// any of them
+	MergeTags(context.Context, *MergeTagsRequest) (*Records, error)
 	// Export dumps config into serialized format
 	Export(context.Context, *ExportRequest) (*ConfigData, error)
 	// Import imports previously serialized records
 	Import(context.Context, *ImportRequest) (*ImportResponse, error)
 	// FetchRoutes fetches all the routes from the routes portal.
 	FetchRoutes(context.Context, *FetchRoutesRequest) (*FetchRoutesResponse, error)
+	// ImportFromPortal creates records for the TCP and UDP routes of the routes
+	// portal, importing again updates the same records
+	ImportFromPortal(context.Context, *ImportFromPortalRequest) (*Records, error)
+	// ListAuditEvents returns the audit log of the changes to the records and
+	// listeners, oldest first
+	ListAuditEvents(context.Context, *ListAuditEventsRequest) (*ListAuditEventsResponse, error)
 }
 
 // UnimplementedConfigServer should be embedded to have
@@ -171,6 +237,12 @@ func (UnimplementedConfigServer) Upsert(context.Context, *Record) (*Record, erro
 func (UnimplementedConfigServer) GetTags(context.Context, *GetTagsRequest) (*GetTagsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetTags not implemented")
 }
+func (UnimplementedConfigServer) RenameTag(context.Context, *RenameTagRequest) (*Records, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenameTag not implemented")
+}
+func (UnimplementedConfigServer) MergeTags(context.Context, *MergeTagsRequest) (*Records, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MergeTags not implemented")
+}
 func (UnimplementedConfigServer) Export(context.Context, *ExportRequest) (*ConfigData, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Export not implemented")
 }
@@ -180,6 +252,12 @@ func (UnimplementedConfigServer) Import(context.Context, *ImportRequest) (*Impor
 func (UnimplementedConfigServer) FetchRoutes(context.Context, *FetchRoutesRequest) (*FetchRoutesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method FetchRoutes not implemented")
 }
+func (UnimplementedConfigServer) ImportFromPortal(context.Context, *ImportFromPortalRequest) (*Records, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportFromPortal not implemented")
+}
+func (UnimplementedConfigServer) ListAuditEvents(context.Context, *ListAuditEventsRequest) (*ListAuditEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAuditEvents not implemented")
+}
 func (UnimplementedConfigServer) testEmbeddedByValue() {}
 
 // UnsafeConfigServer may be embedded to opt out of forward compatibility for this service.
@@ -272,6 +350,42 @@ func _Config_GetTags_Handler(srv interface{}, ctx context.Context, dec func(inte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Config_RenameTag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenameTagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConfigServer).RenameTag(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Config_RenameTag_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConfigServer).RenameTag(ctx, req.(*RenameTagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Config_MergeTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MergeTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConfigServer).MergeTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Config_MergeTags_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConfigServer).MergeTags(ctx, req.(*MergeTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Config_Export_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ExportRequest)
 	if err := dec(in); err != nil {
@@ -326,6 +440,42 @@ func _Config_FetchRoutes_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Config_ImportFromPortal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportFromPortalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConfigServer).ImportFromPortal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Config_ImportFromPortal_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConfigServer).ImportFromPortal(ctx, req.(*ImportFromPortalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Config_ListAuditEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAuditEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConfigServer).ListAuditEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Config_ListAuditEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConfigServer).ListAuditEvents(ctx, req.(*ListAuditEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Config_ServiceDesc is the grpc.ServiceDesc for Config service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -349,6 +499,14 @@ var Config_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetTags",
 			Handler:    _Config_GetTags_Handler,
 		},
+		{
+			MethodName: "RenameTag",
+			Handler:    _Config_RenameTag_Handler,
+		},
+		{
+			MethodName: "MergeTags",
+			Handler:    _Config_MergeTags_Handler,
+		},
 		{
 			MethodName: "Export",
 			Handler:    _Config_Export_Handler,
@@ -361,6 +519,14 @@ var Config_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "FetchRoutes",
 			Handler:    _Config_FetchRoutes_Handler,
 		},
+		{
+			MethodName: "ImportFromPortal",
+			Handler:    _Config_ImportFromPortal_Handler,
+		},
+		{
+			MethodName: "ListAuditEvents",
+			Handler:    _Config_ListAuditEvents_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/api.proto",
@@ -370,6 +536,9 @@ const (
 	Listener_Update_FullMethodName        = "/pomerium.cli.Listener/Update"
 	Listener_GetStatus_FullMethodName     = "/pomerium.cli.Listener/GetStatus"
 	Listener_StatusUpdates_FullMethodName = "/pomerium.cli.Listener/StatusUpdates"
+	Listener_GetEvents_FullMethodName     = "/pomerium.cli.Listener/GetEvents"
+	Listener_GetStats_FullMethodName      = "/pomerium.cli.Listener/GetStats"
+	Listener_StreamStats_FullMethodName   = "/pomerium.cli.Listener/StreamStats"
 )
 
 // ListenerClient is the client API for Listener service.
@@ -386,6 +555,14 @@ type ListenerClient interface {
 	// a client has to subscribe and continuously
 	// listen to the broadcasted updates
 	StatusUpdates(ctx context.Context, in *StatusUpdatesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ConnectionStatusUpdate], error)
+	// GetEvents returns the persisted history of connection status updates
+	GetEvents(ctx context.Context, in *GetEventsRequest, opts ...grpc.CallOption) (*GetEventsResponse, error)
+	// GetStats returns the live traffic statistics of the records that match
+	// Selector
+	GetStats(ctx context.Context, in *Selector, opts ...grpc.CallOption) (*TrafficStatsResponse, error)
+	// StreamStats periodically sends the live traffic statistics of the records
+	// that match the selector
+	StreamStats(ctx context.Context, in *StreamStatsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[TrafficStatsResponse], error)
 }
 
 type listenerClient struct {
@@ -435,6 +612,45 @@ func (c *listenerClient) StatusUpdates(ctx context.Context, in *StatusUpdatesReq
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type Listener_StatusUpdatesClient = grpc.ServerStreamingClient[ConnectionStatusUpdate]
 
+func (c *listenerClient) GetEvents(ctx context.Context, in *GetEventsRequest, opts ...grpc.CallOption) (*GetEventsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetEventsResponse)
+	err := c.cc.Invoke(ctx, Listener_GetEvents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *listenerClient) GetStats(ctx context.Context, in *Selector, opts ...grpc.CallOption) (*TrafficStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TrafficStatsResponse)
+	err := c.cc.Invoke(ctx, Listener_GetStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *listenerClient) StreamStats(ctx context.Context, in *StreamStatsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[TrafficStatsResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Listener_ServiceDesc.Streams[1], Listener_StreamStats_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamStatsRequest, TrafficStatsResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Listener_StreamStatsClient = grpc.ServerStreamingClient[TrafficStatsResponse]
+
 // ListenerServer is the server API for Listener service.
 // All implementations should embed UnimplementedListenerServer
 // for forward compatibility.
@@ -449,6 +665,14 @@ type ListenerServer interface {
 	// a client has to subscribe and continuously
 	// listen to the broadcasted updates
 	StatusUpdates(*StatusUpdatesRequest, grpc.ServerStreamingServer[ConnectionStatusUpdate]) error
+	// GetEvents returns the persisted history of connection status updates
+	GetEvents(context.Context, *GetEventsRequest) (*GetEventsResponse, error)
+	// GetStats returns the live traffic statistics of the records that match
+	// Selector
+	GetStats(context.Context, *Selector) (*TrafficStatsResponse, error)
+	// StreamStats periodically sends the live traffic statistics of the records
+	// that match the selector
+	StreamStats(*StreamStatsRequest, grpc.ServerStreamingServer[TrafficStatsResponse]) error
 }
 
 // UnimplementedListenerServer should be embedded to have
@@ -467,6 +691,15 @@ func (UnimplementedListenerServer) GetStatus(context.Context, *Selector) (*Liste
 func (UnimplementedListenerServer) StatusUpdates(*StatusUpdatesRequest, grpc.ServerStreamingServer[ConnectionStatusUpdate]) error {
 	return status.Errorf(codes.Unimplemented, "method StatusUpdates not implemented")
 }
+func (UnimplementedListenerServer) GetEvents(context.Context, *GetEventsRequest) (*GetEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEvents not implemented")
+}
+func (UnimplementedListenerServer) GetStats(context.Context, *Selector) (*TrafficStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStats not implemented")
+}
+func (UnimplementedListenerServer) StreamStats(*StreamStatsRequest, grpc.ServerStreamingServer[TrafficStatsResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamStats not implemented")
+}
 func (UnimplementedListenerServer) testEmbeddedByValue() {}
 
 // UnsafeListenerServer may be embedded to opt out of forward compatibility for this service.
@@ -534,6 +767,53 @@ func _Listener_StatusUpdates_Handler(srv interface{}, stream grpc.ServerStream)
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type Listener_StatusUpdatesServer = grpc.ServerStreamingServer[ConnectionStatusUpdate]
 
+func _Listener_GetEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ListenerServer).GetEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Listener_GetEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ListenerServer).GetEvents(ctx, req.(*GetEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Listener_GetStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Selector)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ListenerServer).GetStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Listener_GetStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ListenerServer).GetStats(ctx, req.(*Selector))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Listener_StreamStats_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamStatsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ListenerServer).StreamStats(m, &grpc.GenericServerStream[StreamStatsRequest, TrafficStatsResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Listener_StreamStatsServer = grpc.ServerStreamingServer[TrafficStatsResponse]
+
 // Listener_ServiceDesc is the grpc.ServiceDesc for Listener service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -549,6 +829,14 @@ var Listener_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetStatus",
 			Handler:    _Listener_GetStatus_Handler,
 		},
+		{
+			MethodName: "GetEvents",
+			Handler:    _Listener_GetEvents_Handler,
+		},
+		{
+			MethodName: "GetStats",
+			Handler:    _Listener_GetStats_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -556,6 +844,199 @@ var Listener_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _Listener_StatusUpdates_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "StreamStats",
+			Handler:       _Listener_StreamStats_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "proto/api.proto",
 }
+
+const (
+	JWTCache_LoadJWT_FullMethodName   = "/pomerium.cli.JWTCache/LoadJWT"
+	JWTCache_StoreJWT_FullMethodName  = "/pomerium.cli.JWTCache/StoreJWT"
+	JWTCache_DeleteJWT_FullMethodName = "/pomerium.cli.JWTCache/DeleteJWT"
+)
+
+// JWTCacheClient is the client API for JWTCache service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// JWTCache shares cached JWTs between the api server and CLI commands, so they
+// use a single session per proxy host
+type JWTCacheClient interface {
+	// LoadJWT loads a cached JWT
+	LoadJWT(ctx context.Context, in *LoadJWTRequest, opts ...grpc.CallOption) (*LoadJWTResponse, error)
+	// StoreJWT stores a JWT in the cache
+	StoreJWT(ctx context.Context, in *StoreJWTRequest, opts ...grpc.CallOption) (*StoreJWTResponse, error)
+	// DeleteJWT deletes a JWT from the cache
+	DeleteJWT(ctx context.Context, in *DeleteJWTRequest, opts ...grpc.CallOption) (*DeleteJWTResponse, error)
+}
+
+type jWTCacheClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewJWTCacheClient(cc grpc.ClientConnInterface) JWTCacheClient {
+	return &jWTCacheClient{cc}
+}
+
+func (c *jWTCacheClient) LoadJWT(ctx context.Context, in *LoadJWTRequest, opts ...grpc.CallOption) (*LoadJWTResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LoadJWTResponse)
+	err := c.cc.Invoke(ctx, JWTCache_LoadJWT_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jWTCacheClient) StoreJWT(ctx context.Context, in *StoreJWTRequest, opts ...grpc.CallOption) (*StoreJWTResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StoreJWTResponse)
+	err := c.cc.Invoke(ctx, JWTCache_StoreJWT_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jWTCacheClient) DeleteJWT(ctx context.Context, in *DeleteJWTRequest, opts ...grpc.CallOption) (*DeleteJWTResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteJWTResponse)
+	err := c.cc.Invoke(ctx, JWTCache_DeleteJWT_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// JWTCacheServer is the server API for JWTCache service.
+// All implementations should embed UnimplementedJWTCacheServer
+// for forward compatibility.
+//
+// JWTCache shares cached JWTs between the api server and CLI commands, so they
+// use a single session per proxy host
+type JWTCacheServer interface {
+	// LoadJWT loads a cached JWT
+	LoadJWT(context.Context, *LoadJWTRequest) (*LoadJWTResponse, error)
+	// StoreJWT stores a JWT in the cache
+	StoreJWT(context.Context, *StoreJWTRequest) (*StoreJWTResponse, error)
+	// DeleteJWT deletes a JWT from the cache
+	DeleteJWT(context.Context, *DeleteJWTRequest) (*DeleteJWTResponse, error)
+}
+
+// UnimplementedJWTCacheServer should be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedJWTCacheServer struct{}
+
+func (UnimplementedJWTCacheServer) LoadJWT(context.Context, *LoadJWTRequest) (*LoadJWTResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LoadJWT not implemented")
+}
+func (UnimplementedJWTCacheServer) StoreJWT(context.Context, *StoreJWTRequest) (*StoreJWTResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StoreJWT not implemented")
+}
+func (UnimplementedJWTCacheServer) DeleteJWT(context.Context, *DeleteJWTRequest) (*DeleteJWTResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteJWT not implemented")
+}
+func (UnimplementedJWTCacheServer) testEmbeddedByValue() {}
+
+// UnsafeJWTCacheServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to JWTCacheServer will
+// result in compilation errors.
+type UnsafeJWTCacheServer interface {
+	mustEmbedUnimplementedJWTCacheServer()
+}
+
+func RegisterJWTCacheServer(s grpc.ServiceRegistrar, srv JWTCacheServer) {
+	// If the following call pancis, it indicates UnimplementedJWTCacheServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&JWTCache_ServiceDesc, srv)
+}
+
+func _JWTCache_LoadJWT_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoadJWTRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JWTCacheServer).LoadJWT(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JWTCache_LoadJWT_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JWTCacheServer).LoadJWT(ctx, req.(*LoadJWTRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JWTCache_StoreJWT_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StoreJWTRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JWTCacheServer).StoreJWT(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JWTCache_StoreJWT_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JWTCacheServer).StoreJWT(ctx, req.(*StoreJWTRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JWTCache_DeleteJWT_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteJWTRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JWTCacheServer).DeleteJWT(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JWTCache_DeleteJWT_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JWTCacheServer).DeleteJWT(ctx, req.(*DeleteJWTRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// JWTCache_ServiceDesc is the grpc.ServiceDesc for JWTCache service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var JWTCache_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "pomerium.cli.JWTCache",
+	HandlerType: (*JWTCacheServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "LoadJWT",
+			Handler:    _JWTCache_LoadJWT_Handler,
+		},
+		{
+			MethodName: "StoreJWT",
+			Handler:    _JWTCache_StoreJWT_Handler,
+		},
+		{
+			MethodName: "DeleteJWT",
+			Handler:    _JWTCache_DeleteJWT_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/api.proto",
+}
@@ -4,12 +4,14 @@ import (
 	context "context"
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/getsentry/sentry-go"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
@@ -36,6 +38,10 @@ func UnaryLog(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
 	var evt *zerolog.Event
 
 	res, err := handler(ctx, req)
+	// health checks are made every few seconds by supervisors
+	if strings.HasPrefix(info.FullMethod, "/"+healthpb.Health_ServiceDesc.ServiceName+"/") && err == nil {
+		return res, err
+	}
 	if status.Code(err) != codes.OK {
 		evt = log.Error().Err(err)
 	} else {
@@ -117,6 +117,10 @@ type keychainError C.OSStatus
 
 func (e keychainError) Error() string {
 	s := C.SecCopyErrorMessageString(C.OSStatus(e), nil)
+	if s == 0 {
+		// not every OSStatus has a message, e.g. those of other frameworks
+		return fmt.Sprintf("keychain error %d", int32(e))
+	}
 	defer C.CFRelease(C.CFTypeRef(s))
 	return cfStringToString(s)
 }
@@ -274,6 +278,9 @@ func Cred(issuerNames [][]byte, filterCallback func(*x509.Certificate) bool) (*K
 			leafIdent = C.SecIdentityRef(identDict)
 		}
 	}
+	if leaf == nil {
+		return nil, errNoCertificateFound
+	}
 
 	caSearch := C.CFDictionaryCreateMutable(C.kCFAllocatorDefault, 0, &C.kCFTypeDictionaryKeyCallBacks, &C.kCFTypeDictionaryValueCallBacks)
 	defer C.CFRelease(C.CFTypeRef(unsafe.Pointer(caSearch)))
@@ -319,10 +326,6 @@ func Cred(issuerNames [][]byte, filterCallback func(*x509.Certificate) bool) (*K
 			}
 		}
 	}
-	if len(certs) == 0 {
-		return nil, errNoCertificateFound
-	}
-
 	skr, err := identityToSecKeyRef(leafIdent)
 	if err != nil {
 		return nil, err
@@ -342,7 +345,12 @@ func toCFArray(bs [][]byte) C.CFArrayRef {
 	if n > 0 {
 		ptr = &ds[0]
 	}
-	return C.CFArrayCreate(C.kCFAllocatorDefault, ptr, C.CFIndex(n), &C.kCFTypeArrayCallBacks)
+	a := C.CFArrayCreate(C.kCFAllocatorDefault, ptr, C.CFIndex(n), &C.kCFTypeArrayCallBacks)
+	// the array retains the values
+	for _, d := range ds {
+		cfRelease(d)
+	}
+	return a
 }
 
 // identityToX509 converts a single CFDictionary that contains the item ref and
@@ -415,15 +423,6 @@ func identityToSecKeyRef(ident C.SecIdentityRef) (C.SecKeyRef, error) {
 	return ref, nil
 }
 
-func stringIn(s string, ss []string) bool {
-	for _, s2 := range ss {
-		if s == s2 {
-			return true
-		}
-	}
-	return false
-}
-
 func certIn(xc *x509.Certificate, xcs []*x509.Certificate) bool {
 	for _, xc2 := range xcs {
 		if xc.Equal(xc2) {
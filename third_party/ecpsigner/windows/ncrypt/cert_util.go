@@ -36,6 +36,11 @@ import (
 const (
 	// wincrypt.h constants
 	signatureKeyUsage = 0x80 // CERT_DIGITAL_SIGNATURE_KEY_USAGE
+	keyProvInfoPropID = 2    // CERT_KEY_PROV_INFO_PROP_ID
+
+	// PlatformCryptoProvider is the name of the key storage provider of the
+	// TPM (MS_PLATFORM_CRYPTO_PROVIDER).
+	PlatformCryptoProvider = "Microsoft Platform Crypto Provider"
 )
 
 var (
@@ -44,6 +49,7 @@ var (
 	crypt32 = windows.MustLoadDLL("crypt32.dll")
 
 	certGetIntendedKeyUsage           = crypt32.MustFindProc("CertGetIntendedKeyUsage")
+	certGetCertificateContextProperty = crypt32.MustFindProc("CertGetCertificateContextProperty")
 	cryptAcquireCertificatePrivateKey = crypt32.MustFindProc("CryptAcquireCertificatePrivateKey")
 )
 
@@ -72,6 +78,32 @@ func intendedKeyUsage(cert *windows.CertContext) (usage uint16) {
 	return
 }
 
+// cryptKeyProvInfo is the leading part of CRYPT_KEY_PROV_INFO.
+type cryptKeyProvInfo struct {
+	containerName *uint16
+	provName      *uint16
+}
+
+// keyProviderName returns the name of the provider of the certificate's
+// private key, without acquiring the key, or "" if it cannot be determined.
+func keyProviderName(cert *windows.CertContext) string {
+	var size uint32
+	r, _, _ := certGetCertificateContextProperty.Call(
+		uintptr(unsafe.Pointer(cert)), keyProvInfoPropID, null, uintptr(unsafe.Pointer(&size)))
+	if r == 0 || size < uint32(unsafe.Sizeof(cryptKeyProvInfo{})) {
+		return ""
+	}
+	// the strings follow the struct in the buffer, which must be aligned
+	buf := make([]uint64, (size+7)/8)
+	r, _, _ = certGetCertificateContextProperty.Call(
+		uintptr(unsafe.Pointer(cert)), keyProvInfoPropID, uintptr(unsafe.Pointer(&buf[0])), uintptr(unsafe.Pointer(&size)))
+	if r == 0 {
+		return ""
+	}
+	info := (*cryptKeyProvInfo)(unsafe.Pointer(&buf[0]))
+	return windows.UTF16PtrToString(info.provName)
+}
+
 // acquirePrivateKey wraps CryptAcquireCertificatePrivateKey.
 func acquirePrivateKey(cert *windows.CertContext) (windows.Handle, error) {
 	var (
@@ -141,10 +173,11 @@ func certNameBlobs(names [][]byte) []windows.CertNameBlob {
 var errNoCertificateFound = errors.New("no matching certificate found")
 
 // Cred returns a Key wrapping the first certificate in the system store matching one of the
-// given issuerNames and satisfying the filterCallback.
+// given issuerNames and satisfying the filterCallback. If keyProvider is not empty, only
+// certificates whose private key is in this key storage provider are considered.
 func Cred(
 	issuerNames [][]byte, filterCallback func(*x509.Certificate) bool,
-	storeName string, provider string,
+	storeName string, provider string, keyProvider string,
 ) (*Key, error) {
 	var certStore uint32
 	if provider == "local_machine" {
@@ -208,6 +241,10 @@ func Cred(
 			continue
 		}
 
+		if keyProvider != "" && keyProviderName(chain[0].CertContext) != keyProvider {
+			continue
+		}
+
 		certContext := windows.CertDuplicateCertificateContext(chain[0].CertContext)
 		windows.CertFreeCertificateChain(nc)
 
@@ -21,7 +21,7 @@ import (
 )
 
 func TestCredProviderNotSupported(t *testing.T) {
-	_, err := Cred("issuer", "store", "unsupported_provider")
+	_, err := Cred([][]byte{[]byte("issuer")}, nil, "store", "unsupported_provider", "")
 	if err == nil {
 		t.Errorf("Expected error, but got nil.")
 	}
@@ -0,0 +1,66 @@
+package tunnel
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+)
+
+// dialDestination returns a connection to the destination host, tunneled
+// through the proxy host. It is used to reach the next hop of a proxy chain.
+func (tun *Tunnel) dialDestination(ctx context.Context, _, addr string) (net.Conn, error) {
+	if addr != tun.cfg.dstHost {
+		return nil, fmt.Errorf("tunnel: %s is not reachable via %s", addr, tun.cfg.proxyHost)
+	}
+
+	// the tunnel outlives the dial context, until the connection is closed
+	runCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	local, remote := net.Pipe()
+
+	events := &hopEvents{EventSink: LogEvents(), connected: make(chan struct{})}
+	errc := make(chan error, 1)
+	go func() {
+		defer cancel()
+		defer func() { _ = remote.Close() }()
+		errc <- tun.Run(runCtx, remote, events)
+	}()
+
+	select {
+	case <-events.connected:
+		return &hopConn{Conn: local, cancel: cancel}, nil
+	case err := <-errc:
+		_ = local.Close()
+		if err == nil {
+			err = fmt.Errorf("closed")
+		}
+		return nil, fmt.Errorf("tunnel: failed to connect to %s via %s: %w", addr, tun.cfg.proxyHost, err)
+	case <-ctx.Done():
+		cancel()
+		_ = local.Close()
+		return nil, context.Cause(ctx)
+	}
+}
+
+// hopEvents signals when the tunnel to the next hop is established
+type hopEvents struct {
+	EventSink
+	once      sync.Once
+	connected chan struct{}
+}
+
+func (evt *hopEvents) OnConnected(ctx context.Context, info ConnectionInfo) {
+	evt.EventSink.OnConnected(ctx, info)
+	evt.once.Do(func() { close(evt.connected) })
+}
+
+// hopConn stops the tunnel to the next hop when closed
+type hopConn struct {
+	net.Conn
+	cancel context.CancelFunc
+}
+
+func (c *hopConn) Close() error {
+	c.cancel()
+	return c.Conn.Close()
+}
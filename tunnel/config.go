@@ -2,10 +2,22 @@ package tunnel
 
 import (
 	"crypto/tls"
+	"net/url"
+	"time"
 
+	"github.com/quic-go/quic-go"
+
+	"github.com/pomerium/cli/authclient"
+	"github.com/pomerium/cli/internal/httputil"
 	"github.com/pomerium/cli/jwt"
 )
 
+const (
+	tlsSessionCacheCapacity       = 16
+	quicTokenStoreOrigins         = 4
+	quicTokenStoreTokensPerOrigin = 4
+)
+
 type config struct {
 	jwtCache           jwt.Cache
 	dstHost            string
@@ -14,10 +26,31 @@ type config struct {
 	serviceAccountFile string
 	tlsConfig          *tls.Config
 	browserConfig      string
+	callbackPath       string
+	callbackPorts      authclient.PortRange
+	redirectAddr       string
+	outboundProxyURLs  []*url.URL
+	outboundProxyURL   *url.URL
+	proxyChain         []string
+	dialContext        httputil.DialContextFunc
+	expectedUser       string
+	ipPreference       httputil.IPPreference
+	keepAlive          time.Duration
+	noBrowser          bool
+	protocol           Protocol
+	quicTokenStore     quic.TokenStore
+	proxyProtocol      int
+	qrCode             bool
+	reconnect          *ReconnectSettings
+	udpSettings        UDPSettings
 }
 
 func getConfig(options ...Option) *config {
-	cfg := new(config)
+	cfg := &config{
+		// remember the address validation tokens of the proxy host, so that
+		// new QUIC connections save a round trip
+		quicTokenStore: quic.NewLRUTokenStore(quicTokenStoreOrigins, quicTokenStoreTokensPerOrigin),
+	}
 	WithJWTCache(jwt.GetCache())(cfg)
 	for _, o := range options {
 		o(cfg)
@@ -35,6 +68,29 @@ func WithBrowserCommand(browserCommand string) Option {
 	}
 }
 
+// WithCallbackPath returns an option to configure the URL path of the login callback.
+func WithCallbackPath(callbackPath string) Option {
+	return func(cfg *config) {
+		cfg.callbackPath = callbackPath
+	}
+}
+
+// WithCallbackPortRange returns an option to configure the ports the login
+// callback listener may use.
+func WithCallbackPortRange(ports authclient.PortRange) Option {
+	return func(cfg *config) {
+		cfg.callbackPorts = ports
+	}
+}
+
+// WithRedirectAddress returns an option to configure the address of the login
+// callback listener.
+func WithRedirectAddress(addr string) Option {
+	return func(cfg *config) {
+		cfg.redirectAddr = addr
+	}
+}
+
 // WithDestinationHost returns an option to configure the destination host.
 func WithDestinationHost(dstHost string) Option {
 	return func(cfg *config) {
@@ -42,6 +98,24 @@ func WithDestinationHost(dstHost string) Option {
 	}
 }
 
+// WithExpectedUser returns an option to require that the JWT is issued for
+// the given user, identified by email or subject.
+func WithExpectedUser(expectedUser string) Option {
+	return func(cfg *config) {
+		cfg.expectedUser = expectedUser
+	}
+}
+
+// WithIPPreference returns an option to select the address family tried first
+// when connecting to the proxy host. Both are tried either way, unless the
+// proxy host is reached via QUIC, which only uses the preferred one if the
+// host has an address of it.
+func WithIPPreference(pref httputil.IPPreference) Option {
+	return func(cfg *config) {
+		cfg.ipPreference = pref
+	}
+}
+
 // WithJWTCache returns an option to configure the jwt cache.
 func WithJWTCache(jwtCache jwt.Cache) Option {
 	return func(cfg *config) {
@@ -49,6 +123,67 @@ func WithJWTCache(jwtCache jwt.Cache) Option {
 	}
 }
 
+// WithKeepAlive returns an option to periodically check that an idle
+// connection to the proxy host is still alive, using TCP keep-alives for
+// HTTP/1, PING frames for HTTP/2 and QUIC keep-alives for HTTP/3. Tunnels
+// over a dead connection are torn down, so clients can reconnect. Zero
+// disables the checks.
+func WithKeepAlive(interval time.Duration) Option {
+	return func(cfg *config) {
+		cfg.keepAlive = interval
+	}
+}
+
+// WithNoBrowser returns an option to log in by printing the login URL and
+// reading the result from stdin instead of opening a browser.
+func WithNoBrowser(enabled bool) Option {
+	return func(cfg *config) {
+		cfg.noBrowser = enabled
+	}
+}
+
+// WithOutboundProxy returns an option to connect to the proxy host via
+// outbound HTTP proxies. Each proxy is reached through the ones before it.
+// Without any, the proxy is taken from the HTTPS_PROXY, HTTP_PROXY and
+// NO_PROXY environment variables. HTTP/3 is not used in that case.
+func WithOutboundProxy(proxyURLs ...*url.URL) Option {
+	return func(cfg *config) {
+		cfg.outboundProxyURLs = nil
+		for _, proxyURL := range proxyURLs {
+			if proxyURL != nil {
+				cfg.outboundProxyURLs = append(cfg.outboundProxyURLs, proxyURL)
+			}
+		}
+	}
+}
+
+// WithProtocol returns an option to use the given protocol to connect to the
+// proxy host instead of probing for it.
+func WithProtocol(protocol Protocol) Option {
+	return func(cfg *config) {
+		cfg.protocol = protocol
+	}
+}
+
+// WithProxyProtocol returns an option to send a PROXY protocol header of the
+// given version, 1 or 2, carrying the address of the local client to the
+// destination of TCP tunnels. Zero disables the header.
+func WithProxyProtocol(version int) Option {
+	return func(cfg *config) {
+		cfg.proxyProtocol = version
+	}
+}
+
+// WithProxyChain returns an option to reach the proxy host through other
+// Pomerium proxies, given in order as host:port. Each of them must have a TCP
+// route to the next one. The hops share all other options, but authenticate
+// with their own JWTs. HTTP/3 is not used in that case.
+func WithProxyChain(proxyHosts ...string) Option {
+	return func(cfg *config) {
+		cfg.proxyChain = proxyHosts
+	}
+}
+
 // WithProxyHost returns an option to configure the proxy host.
 func WithProxyHost(proxyHost string) Option {
 	return func(cfg *config) {
@@ -56,6 +191,23 @@ func WithProxyHost(proxyHost string) Option {
 	}
 }
 
+// WithQRCode returns an option to also render the login URL as a QR code.
+func WithQRCode(enabled bool) Option {
+	return func(cfg *config) {
+		cfg.qrCode = enabled
+	}
+}
+
+// WithReconnect returns an option to retry tunnels when the connection to the
+// proxy host fails. TCP tunnels are retried until they are connected, after
+// that they cannot be resumed and their local connections are reset instead.
+// UDP sessions are re-established transparently. Nil disables reconnecting.
+func WithReconnect(settings *ReconnectSettings) Option {
+	return func(cfg *config) {
+		cfg.reconnect = settings
+	}
+}
+
 // WithServiceAccount sets the service account in the config.
 func WithServiceAccount(serviceAccount string) Option {
 	return func(cfg *config) {
@@ -70,13 +222,31 @@ func WithServiceAccountFile(file string) Option {
 	}
 }
 
-// WithTLSConfig returns an option to configure the tls config.
+// WithTLSConfig returns an option to configure the tls config. Unless the
+// config has a session cache, the connections of the tunnel share one, so
+// that they resume TLS sessions instead of performing full handshakes.
 func WithTLSConfig(tlsConfig *tls.Config) Option {
 	return func(cfg *config) {
 		if tlsConfig != nil {
 			tlsConfig = tlsConfig.Clone()
 			tlsConfig.NextProtos = []string{"http/1.1"} // disable http/2 in ALPN
+			if tlsConfig.ClientSessionCache == nil {
+				tlsConfig.ClientSessionCache = tls.NewLRUClientSessionCache(tlsSessionCacheCapacity)
+			}
 		}
 		cfg.tlsConfig = tlsConfig
 	}
 }
+
+// WithUDPSettings returns an option to configure UDP tunnels.
+func WithUDPSettings(settings UDPSettings) Option {
+	return func(cfg *config) {
+		cfg.udpSettings = settings
+	}
+}
+
+// quicAllowed reports whether the proxy host may be reached via QUIC, which
+// cannot be tunneled through an outbound HTTP proxy or another Pomerium proxy
+func (cfg *config) quicAllowed() bool {
+	return cfg.outboundProxyURL == nil && cfg.dialContext == nil
+}
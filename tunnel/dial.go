@@ -0,0 +1,114 @@
+package tunnel
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/pomerium/cli/internal/httputil"
+)
+
+// keepAliveProbes is the number of unanswered keep-alive probes after which
+// a connection to the proxy host is considered dead
+const keepAliveProbes = 3
+
+// setupOutboundProxy configures how the proxy host is reached via the outbound
+// proxies. A single proxy is used directly, so that HTTP requests are sent to
+// it as such, a chain of them is dialed by tunneling through each in turn.
+func (cfg *config) setupOutboundProxy() error {
+	if cfg.dialContext != nil {
+		return nil
+	}
+
+	switch len(cfg.outboundProxyURLs) {
+	case 0:
+		proxyURL, err := httputil.ProxyFromEnvironment(cfg.proxyHost, cfg.tlsConfig != nil)
+		if err != nil {
+			return err
+		}
+		cfg.outboundProxyURL = proxyURL
+	case 1:
+		cfg.outboundProxyURL = cfg.outboundProxyURLs[0]
+	default:
+		var dialer *httputil.ProxyDialer
+		for _, proxyURL := range cfg.outboundProxyURLs {
+			dialer = &httputil.ProxyDialer{ProxyURL: proxyURL, Via: dialer, Prefer: cfg.ipPreference}
+		}
+		cfg.dialContext = dialer.DialContext
+	}
+	return nil
+}
+
+// dialProxyHost connects to the Pomerium proxy host, through the proxy chain or
+// via the outbound proxy if one is configured, and performs a TLS handshake
+// unless tlsConfig is nil.
+func (cfg *config) dialProxyHost(ctx context.Context, tlsConfig *tls.Config) (net.Conn, error) {
+	dial := cfg.dialContext
+	if dial == nil {
+		dial = (&httputil.ProxyDialer{ProxyURL: cfg.outboundProxyURL, Prefer: cfg.ipPreference}).DialContext
+	}
+	conn, err := dial(ctx, "tcp", cfg.proxyHost)
+	if err != nil {
+		return nil, err
+	}
+	if tcpConn, ok := conn.(*net.TCPConn); ok && cfg.keepAlive > 0 {
+		err = tcpConn.SetKeepAliveConfig(net.KeepAliveConfig{
+			Enable:   true,
+			Idle:     cfg.keepAlive,
+			Interval: cfg.keepAlive,
+			Count:    keepAliveProbes,
+		})
+		if err != nil {
+			// not all settings are supported on all platforms
+			log.Ctx(ctx).Warn().Err(err).Msg("failed to configure tcp keep-alive")
+		}
+	}
+	if tlsConfig == nil {
+		return conn, nil
+	}
+
+	tlsConfig = tlsConfig.Clone()
+	if tlsConfig.ServerName == "" {
+		host, _, err := net.SplitHostPort(cfg.proxyHost)
+		if err != nil {
+			host = cfg.proxyHost
+		}
+		tlsConfig.ServerName = host
+	}
+
+	tlsConn := tls.Client(conn, tlsConfig)
+	if err := tlsConn.HandshakeContext(ctx); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	return tlsConn, nil
+}
+
+// resolveQUICAddr returns the address to dial the proxy host at via QUIC, and
+// the TLS config to use for it. QUIC connections are not raced like TCP ones,
+// so if an address family is preferred, the host is resolved to an address of
+// it, otherwise that is left to QUIC, which uses IPv4 first.
+func (cfg *config) resolveQUICAddr(ctx context.Context, addr string, tlsConfig *tls.Config) (string, *tls.Config) {
+	if cfg.ipPreference == httputil.PreferAny {
+		return addr, tlsConfig
+	}
+
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil || net.ParseIP(host) != nil {
+		return addr, tlsConfig
+	}
+	ips, err := net.DefaultResolver.LookupIP(ctx, cfg.ipPreference.Network("ip"), host)
+	if err != nil || len(ips) == 0 {
+		log.Ctx(ctx).Debug().Err(err).Msg("no address of the preferred family, using any")
+		return addr, tlsConfig
+	}
+
+	// keep verifying the certificate for the host name
+	if tlsConfig.ServerName == "" {
+		tlsConfig = tlsConfig.Clone()
+		tlsConfig.ServerName = host
+	}
+	return net.JoinHostPort(ips[0].String(), port), tlsConfig
+}
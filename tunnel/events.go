@@ -2,6 +2,9 @@ package tunnel
 
 import (
 	"context"
+	"crypto/tls"
+	"net"
+	"time"
 
 	"github.com/rs/zerolog/log"
 )
@@ -12,7 +15,7 @@ type EventSink interface {
 	OnConnecting(context.Context)
 	// OnConnected is called when a connection is successfully
 	// established to the remote destination via pomerium proxy
-	OnConnected(context.Context)
+	OnConnected(context.Context, ConnectionInfo)
 	// OnAuthRequired is called after listener accepted a new connection from client,
 	// but has to perform user authentication first
 	OnAuthRequired(context.Context, string)
@@ -20,6 +23,82 @@ type EventSink interface {
 	OnDisconnected(context.Context, error)
 }
 
+// TrafficCounter may be implemented by the EventSink of a UDP session manager
+// to count the sessions and the payload bytes of their datagrams.
+type TrafficCounter interface {
+	// OnSessionStarted and OnSessionStopped are called when a local client
+	// session starts and stops
+	OnSessionStarted()
+	OnSessionStopped()
+	// OnSent is called with the payload bytes received from a local client
+	// and sent through the tunnel
+	OnSent(n int)
+	// OnReceived is called with the payload bytes received through the tunnel
+	// and sent to a local client
+	OnReceived(n int)
+}
+
+// ConnectionInfo describes how a connection to the remote destination was established.
+type ConnectionInfo struct {
+	// Protocol is the HTTP version used to connect to the proxy host
+	Protocol Protocol
+	// ALPN is the application protocol negotiated with the proxy host, if any
+	ALPN string
+	// ProxyIP is the IP address connected to, if known. It is the address of
+	// the outbound proxy if one is used.
+	ProxyIP string
+	// TLSVersion and CipherSuite are zero if TLS is not used
+	TLSVersion  uint16
+	CipherSuite uint16
+	// TimeToConnect is the time from connecting until the connection was established
+	TimeToConnect time.Duration
+}
+
+func newConnectionInfo(protocol Protocol, start time.Time, proxyAddr net.Addr, state *tls.ConnectionState) ConnectionInfo {
+	info := ConnectionInfo{
+		Protocol:      protocol,
+		TimeToConnect: time.Since(start),
+	}
+	switch addr := proxyAddr.(type) {
+	case *net.TCPAddr:
+		info.ProxyIP = addr.IP.String()
+	case *net.UDPAddr:
+		info.ProxyIP = addr.IP.String()
+	}
+	if state != nil {
+		info.ALPN = state.NegotiatedProtocol
+		info.TLSVersion = state.Version
+		info.CipherSuite = state.CipherSuite
+	}
+	return info
+}
+
+// connInfo returns the connection info of a connection to the proxy host.
+func connInfo(protocol Protocol, start time.Time, conn net.Conn) ConnectionInfo {
+	var state *tls.ConnectionState
+	if tlsConn, ok := conn.(*tls.Conn); ok {
+		cs := tlsConn.ConnectionState()
+		state = &cs
+	}
+	return newConnectionInfo(protocol, start, conn.RemoteAddr(), state)
+}
+
+// TLSVersionName returns the name of the TLS version, or an empty string if TLS is not used.
+func (info ConnectionInfo) TLSVersionName() string {
+	if info.TLSVersion == 0 {
+		return ""
+	}
+	return tls.VersionName(info.TLSVersion)
+}
+
+// CipherSuiteName returns the name of the cipher suite, or an empty string if TLS is not used.
+func (info ConnectionInfo) CipherSuiteName() string {
+	if info.TLSVersion == 0 {
+		return ""
+	}
+	return tls.CipherSuiteName(info.CipherSuite)
+}
+
 // DiscardEvents returns an event sink that discards all events.
 func DiscardEvents() EventSink {
 	return discardEvents{}
@@ -32,7 +111,7 @@ func (discardEvents) OnConnecting(_ context.Context) {}
 
 // OnConnected is called when a connection is successfully
 // established to the remote destination via pomerium proxy
-func (discardEvents) OnConnected(_ context.Context) {}
+func (discardEvents) OnConnected(_ context.Context, _ ConnectionInfo) {}
 
 // OnAuthRequired is called after listener accepted a new connection from client,
 // but has to perform user authentication first
@@ -52,8 +131,15 @@ func (logEvents) OnConnecting(ctx context.Context) {
 	log.Ctx(ctx).Info().Msg("connecting")
 }
 
-func (logEvents) OnConnected(ctx context.Context) {
-	log.Ctx(ctx).Info().Msg("connected")
+func (logEvents) OnConnected(ctx context.Context, info ConnectionInfo) {
+	log.Ctx(ctx).Info().
+		Str("protocol", string(info.Protocol)).
+		Str("alpn", info.ALPN).
+		Str("proxy-ip", info.ProxyIP).
+		Str("tls-version", info.TLSVersionName()).
+		Str("tls-cipher-suite", info.CipherSuiteName()).
+		Dur("time-to-connect", info.TimeToConnect).
+		Msg("connected")
 }
 
 func (logEvents) OnAuthRequired(ctx context.Context, authURL string) {
@@ -0,0 +1,32 @@
+package tunnel
+
+import (
+	"crypto/tls"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConnectionInfo(t *testing.T) {
+	t.Parallel()
+
+	start := time.Now().Add(-time.Second)
+	info := newConnectionInfo(ProtocolHTTP2, start, &net.TCPAddr{IP: net.IPv4(192, 0, 2, 1), Port: 443}, &tls.ConnectionState{
+		Version:            tls.VersionTLS13,
+		CipherSuite:        tls.TLS_AES_128_GCM_SHA256,
+		NegotiatedProtocol: "h2",
+	})
+	assert.Equal(t, ProtocolHTTP2, info.Protocol)
+	assert.Equal(t, "h2", info.ALPN)
+	assert.Equal(t, "192.0.2.1", info.ProxyIP)
+	assert.Equal(t, "TLS 1.3", info.TLSVersionName())
+	assert.Equal(t, "TLS_AES_128_GCM_SHA256", info.CipherSuiteName())
+	assert.GreaterOrEqual(t, info.TimeToConnect, time.Second)
+
+	info = newConnectionInfo(ProtocolHTTP1, start, &net.UnixAddr{Name: "pipe"}, nil)
+	assert.Empty(t, info.ProxyIP)
+	assert.Empty(t, info.TLSVersionName())
+	assert.Empty(t, info.CipherSuiteName())
+}
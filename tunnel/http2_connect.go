@@ -0,0 +1,345 @@
+package tunnel
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/hpack"
+)
+
+const (
+	// http2ConnectStreamID is the id of the only stream of the connection
+	http2ConnectStreamID = 1
+	// http2ConnectWindowSize is the flow control window advertised to the server
+	http2ConnectWindowSize = 4 << 20
+	// http2DefaultWindowSize and http2DefaultMaxFrameSize are the initial values defined by RFC 9113
+	http2DefaultWindowSize   = 65535
+	http2DefaultMaxFrameSize = 16384
+)
+
+// errHTTP2ConnectClosed indicates the stream was closed before a response was received.
+var errHTTP2ConnectClosed = errors.New("stream closed before response")
+
+// An http2ConnectStream is the stream of an extended CONNECT request (RFC 8441)
+// made on its own HTTP/2 connection.
+//
+// The http2.Transport may encode the :protocol pseudo-header after regular
+// header fields, which servers reject as malformed, so this implements just
+// enough of an HTTP/2 client for a single stream instead.
+type http2ConnectStream struct {
+	conn   net.Conn
+	framer *http2.Framer
+	wmu    sync.Mutex // guards writing frames
+
+	mu            sync.Mutex
+	cond          *sync.Cond
+	err           error
+	connWindow    int64
+	streamWindow  int64
+	initialWindow int64
+	maxFrameSize  uint32
+
+	status chan int
+	pr     *io.PipeReader
+	pw     *io.PipeWriter
+}
+
+// newHTTP2ConnectStream makes an extended CONNECT request using the given
+// protocol on conn, which must have negotiated h2. The response status code
+// is returned along with the stream. The stream is closed if ctx is done.
+func newHTTP2ConnectStream(
+	ctx context.Context,
+	conn net.Conn,
+	protocol string,
+	u *url.URL,
+	hdr http.Header,
+) (*http2ConnectStream, int, error) {
+	s := &http2ConnectStream{
+		conn:          conn,
+		framer:        http2.NewFramer(conn, conn),
+		connWindow:    http2DefaultWindowSize,
+		streamWindow:  http2DefaultWindowSize,
+		initialWindow: http2DefaultWindowSize,
+		maxFrameSize:  http2DefaultMaxFrameSize,
+		status:        make(chan int, 1),
+	}
+	s.cond = sync.NewCond(&s.mu)
+	s.pr, s.pw = io.Pipe()
+	s.framer.ReadMetaHeaders = hpack.NewDecoder(4096, nil)
+	stop := context.AfterFunc(ctx, func() { _ = s.Close() })
+
+	statusCode, err := s.handshake(ctx, protocol, u, hdr)
+	if err != nil {
+		stop()
+		_ = s.Close()
+		return nil, 0, err
+	}
+	return s, statusCode, nil
+}
+
+func (s *http2ConnectStream) handshake(ctx context.Context, protocol string, u *url.URL, hdr http.Header) (int, error) {
+	if _, err := io.WriteString(s.conn, http2.ClientPreface); err != nil {
+		return 0, fmt.Errorf("error writing preface: %w", err)
+	}
+	err := s.framer.WriteSettings(http2.Setting{ID: http2.SettingInitialWindowSize, Val: http2ConnectWindowSize})
+	if err != nil {
+		return 0, fmt.Errorf("error writing settings: %w", err)
+	}
+	err = s.framer.WriteWindowUpdate(0, http2ConnectWindowSize-http2DefaultWindowSize)
+	if err != nil {
+		return 0, fmt.Errorf("error writing window update: %w", err)
+	}
+
+	// the server connection preface is a settings frame
+	f, err := s.framer.ReadFrame()
+	if err != nil {
+		return 0, fmt.Errorf("error reading settings: %w", err)
+	}
+	settings, ok := f.(*http2.SettingsFrame)
+	if !ok || settings.IsAck() {
+		return 0, fmt.Errorf("expected settings, got %v", f.Header().Type)
+	}
+	if v, _ := settings.Value(http2.SettingEnableConnectProtocol); v != 1 {
+		return 0, fmt.Errorf("%w: extended connect not enabled", errUnsupported)
+	}
+	if err := s.applySettings(settings); err != nil {
+		return 0, err
+	}
+	if err := s.framer.WriteSettingsAck(); err != nil {
+		return 0, fmt.Errorf("error writing settings: %w", err)
+	}
+
+	var buf bytes.Buffer
+	enc := hpack.NewEncoder(&buf)
+	// pseudo-header fields must precede regular fields
+	fields := []hpack.HeaderField{
+		{Name: ":method", Value: http.MethodConnect},
+		{Name: ":protocol", Value: protocol},
+		{Name: ":scheme", Value: "https"},
+		{Name: ":authority", Value: u.Host},
+		{Name: ":path", Value: u.RequestURI()},
+	}
+	for k, vs := range hdr {
+		for _, v := range vs {
+			fields = append(fields, hpack.HeaderField{Name: strings.ToLower(k), Value: v})
+		}
+	}
+	for _, field := range fields {
+		if err := enc.WriteField(field); err != nil {
+			return 0, fmt.Errorf("error encoding headers: %w", err)
+		}
+	}
+	s.wmu.Lock()
+	err = s.framer.WriteHeaders(http2.HeadersFrameParam{
+		StreamID:      http2ConnectStreamID,
+		BlockFragment: buf.Bytes(),
+		EndHeaders:    true,
+	})
+	s.wmu.Unlock()
+	if err != nil {
+		return 0, fmt.Errorf("error writing headers: %w", err)
+	}
+
+	go s.readLoop()
+
+	select {
+	case statusCode, ok := <-s.status:
+		if !ok {
+			s.mu.Lock()
+			err := s.err
+			s.mu.Unlock()
+			return 0, fmt.Errorf("%w: %w", errHTTP2ConnectClosed, err)
+		}
+		return statusCode, nil
+	case <-ctx.Done():
+		return 0, context.Cause(ctx)
+	}
+}
+
+// Read reads data sent by the server.
+func (s *http2ConnectStream) Read(p []byte) (int, error) {
+	return s.pr.Read(p)
+}
+
+// Write sends data to the server, blocking while the flow control window is exhausted.
+func (s *http2ConnectStream) Write(p []byte) (int, error) {
+	written := 0
+	for len(p) > written {
+		s.mu.Lock()
+		for s.err == nil && (s.connWindow <= 0 || s.streamWindow <= 0) {
+			s.cond.Wait()
+		}
+		if s.err != nil {
+			err := s.err
+			s.mu.Unlock()
+			return written, err
+		}
+		n := min(int64(len(p)-written), s.connWindow, s.streamWindow, int64(s.maxFrameSize))
+		s.connWindow -= n
+		s.streamWindow -= n
+		s.mu.Unlock()
+
+		s.wmu.Lock()
+		err := s.framer.WriteData(http2ConnectStreamID, false, p[written:written+int(n)])
+		s.wmu.Unlock()
+		if err != nil {
+			s.fail(err)
+			return written, err
+		}
+		written += int(n)
+	}
+	return written, nil
+}
+
+// Close resets the stream and closes the connection.
+func (s *http2ConnectStream) Close() error {
+	if s.fail(net.ErrClosed) {
+		s.wmu.Lock()
+		_ = s.framer.WriteRSTStream(http2ConnectStreamID, http2.ErrCodeCancel)
+		s.wmu.Unlock()
+	}
+	return s.conn.Close()
+}
+
+// fail stops the stream with err and reports whether it was still running.
+func (s *http2ConnectStream) fail(err error) bool {
+	s.mu.Lock()
+	running := s.err == nil
+	if running {
+		s.err = err
+	}
+	s.cond.Broadcast()
+	s.mu.Unlock()
+
+	s.pw.CloseWithError(err)
+	return running
+}
+
+func (s *http2ConnectStream) readLoop() {
+	sentStatus := false
+	for {
+		f, err := s.framer.ReadFrame()
+		if err != nil {
+			s.fail(err)
+			break
+		}
+		if err := s.handleFrame(f, &sentStatus); err != nil {
+			s.fail(err)
+			break
+		}
+	}
+	if !sentStatus {
+		// unblock the handshake
+		close(s.status)
+	}
+}
+
+func (s *http2ConnectStream) handleFrame(f http2.Frame, sentStatus *bool) error {
+	switch f := f.(type) {
+	case *http2.SettingsFrame:
+		if f.IsAck() {
+			return nil
+		}
+		if err := s.applySettings(f); err != nil {
+			return err
+		}
+		s.wmu.Lock()
+		defer s.wmu.Unlock()
+		return s.framer.WriteSettingsAck()
+	case *http2.PingFrame:
+		if f.IsAck() {
+			return nil
+		}
+		s.wmu.Lock()
+		defer s.wmu.Unlock()
+		return s.framer.WritePing(true, f.Data)
+	case *http2.WindowUpdateFrame:
+		s.mu.Lock()
+		if f.StreamID == 0 {
+			s.connWindow += int64(f.Increment)
+		} else if f.StreamID == http2ConnectStreamID {
+			s.streamWindow += int64(f.Increment)
+		}
+		s.cond.Broadcast()
+		s.mu.Unlock()
+	case *http2.MetaHeadersFrame:
+		if f.StreamID != http2ConnectStreamID {
+			return nil
+		}
+		if !*sentStatus {
+			statusCode, err := strconv.Atoi(f.PseudoValue("status"))
+			if err != nil {
+				return fmt.Errorf("invalid response status: %q", f.PseudoValue("status"))
+			}
+			if statusCode < 200 {
+				// ignore informational responses
+				return nil
+			}
+			*sentStatus = true
+			s.status <- statusCode
+		}
+		if f.StreamEnded() {
+			return io.EOF
+		}
+	case *http2.DataFrame:
+		if f.StreamID != http2ConnectStreamID {
+			return nil
+		}
+		if _, err := s.pw.Write(f.Data()); err != nil {
+			return err
+		}
+		if n := f.Length; n > 0 {
+			s.wmu.Lock()
+			err := s.framer.WriteWindowUpdate(0, n)
+			if err == nil {
+				err = s.framer.WriteWindowUpdate(http2ConnectStreamID, n)
+			}
+			s.wmu.Unlock()
+			if err != nil {
+				return err
+			}
+		}
+		if f.StreamEnded() {
+			return io.EOF
+		}
+	case *http2.RSTStreamFrame:
+		if f.StreamID == http2ConnectStreamID {
+			return fmt.Errorf("stream reset by server: %v", f.ErrCode)
+		}
+	case *http2.GoAwayFrame:
+		if f.ErrCode != http2.ErrCodeNo || f.LastStreamID < http2ConnectStreamID {
+			return fmt.Errorf("connection closed by server: %v", f.ErrCode)
+		}
+	}
+	return nil
+}
+
+func (s *http2ConnectStream) applySettings(f *http2.SettingsFrame) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return f.ForeachSetting(func(setting http2.Setting) error {
+		if err := setting.Valid(); err != nil {
+			return err
+		}
+		switch setting.ID {
+		case http2.SettingInitialWindowSize:
+			// the change applies to the open stream, see RFC 9113 Section 6.9.2
+			s.streamWindow += int64(setting.Val) - s.initialWindow
+			s.initialWindow = int64(setting.Val)
+			s.cond.Broadcast()
+		case http2.SettingMaxFrameSize:
+			s.maxFrameSize = setting.Val
+		}
+		return nil
+	})
+}
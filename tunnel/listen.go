@@ -0,0 +1,56 @@
+package tunnel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"os"
+	"strings"
+
+	"github.com/pomerium/cli/internal/systemd"
+)
+
+// unixListenPrefix marks a listen address as the path of a Unix domain socket
+const unixListenPrefix = "unix:"
+
+// Listen starts a local listener for TCP tunnels. The address is either a TCP
+// address, "systemd:" optionally followed by a name for a socket passed by
+// systemd socket activation or, prefixed with "unix:", the path of a Unix
+// domain socket. A socket left behind at the path by a previous listener is
+// replaced.
+func Listen(ctx context.Context, listenAddr string) (net.Listener, error) {
+	if name, ok := strings.CutPrefix(listenAddr, systemd.ListenPrefix); ok {
+		return systemd.Listener(name)
+	}
+
+	path, ok := strings.CutPrefix(listenAddr, unixListenPrefix)
+	if !ok {
+		return new(net.ListenConfig).Listen(ctx, "tcp", listenAddr)
+	}
+
+	if err := removeStaleSocket(path); err != nil {
+		return nil, err
+	}
+	return new(net.ListenConfig).Listen(ctx, "unix", path)
+}
+
+// removeStaleSocket removes the socket at path unless it is still in use.
+func removeStaleSocket(path string) error {
+	fi, err := os.Lstat(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	if fi.Mode().Type() != fs.ModeSocket {
+		return fmt.Errorf("%s already exists and is not a socket", path)
+	}
+	if conn, err := net.Dial("unix", path); err == nil {
+		_ = conn.Close()
+		return fmt.Errorf("%s is already in use", path)
+	}
+	return os.Remove(path)
+}
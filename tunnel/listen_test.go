@@ -0,0 +1,59 @@
+package tunnel
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListen(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("tcp", func(t *testing.T) {
+		t.Parallel()
+
+		li, err := Listen(ctx, "127.0.0.1:0")
+		require.NoError(t, err)
+		defer li.Close()
+		assert.Equal(t, "tcp", li.Addr().Network())
+	})
+	t.Run("unix", func(t *testing.T) {
+		t.Parallel()
+		if runtime.GOOS == "windows" {
+			t.Skip("unix sockets are not available on all windows versions")
+		}
+
+		path := filepath.Join(t.TempDir(), "tunnel.sock")
+		li, err := Listen(ctx, "unix:"+path)
+		require.NoError(t, err)
+		assert.Equal(t, path, li.Addr().String())
+
+		_, err = Listen(ctx, "unix:"+path)
+		assert.ErrorContains(t, err, "already in use")
+
+		// leave the socket behind as a crashed process would
+		li.(*net.UnixListener).SetUnlinkOnClose(false)
+		require.NoError(t, li.Close())
+
+		li, err = Listen(ctx, "unix:"+path)
+		require.NoError(t, err, "should replace a stale socket")
+		require.NoError(t, li.Close())
+	})
+	t.Run("not a socket", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "file")
+		require.NoError(t, os.WriteFile(path, nil, 0o600))
+
+		_, err := Listen(ctx, "unix:"+path)
+		assert.ErrorContains(t, err, "not a socket")
+	})
+}
@@ -0,0 +1,94 @@
+package tunnel
+
+import (
+	"context"
+	"io"
+	"sync/atomic"
+
+	"github.com/pomerium/cli/internal/metrics"
+)
+
+// metricsEvents records the connections of a tunnel in the metrics.
+type metricsEvents struct {
+	EventSink
+	destination string
+	connected   atomic.Bool
+}
+
+func (evt *metricsEvents) OnConnected(ctx context.Context, info ConnectionInfo) {
+	if !evt.connected.Swap(true) {
+		metrics.TunnelConnected(evt.destination, string(info.Protocol))
+	}
+	evt.EventSink.OnConnected(ctx, info)
+}
+
+func (evt *metricsEvents) OnDisconnected(ctx context.Context, err error) {
+	if evt.connected.Swap(false) {
+		metrics.TunnelDisconnected(evt.destination)
+	}
+	evt.EventSink.OnDisconnected(ctx, err)
+}
+
+// metricsReadWriter counts the bytes read from and written to a local connection.
+type metricsReadWriter struct {
+	io.ReadWriter
+	destination string
+}
+
+func (rw metricsReadWriter) Read(p []byte) (int, error) {
+	n, err := rw.ReadWriter.Read(p)
+	metrics.TunnelSent(rw.destination, n)
+	return n, err
+}
+
+func (rw metricsReadWriter) Write(p []byte) (int, error) {
+	n, err := rw.ReadWriter.Write(p)
+	metrics.TunnelReceived(rw.destination, n)
+	return n, err
+}
+
+// metricsUDPDatagramReaderWriter counts the payload bytes of the datagrams of
+// a local UDP session.
+type metricsUDPDatagramReaderWriter struct {
+	UDPDatagramReaderWriter
+	destination string
+}
+
+func (rw metricsUDPDatagramReaderWriter) ReadDatagram(ctx context.Context) (UDPDatagram, error) {
+	datagram, err := rw.UDPDatagramReaderWriter.ReadDatagram(ctx)
+	if err == nil {
+		metrics.TunnelSent(rw.destination, len(datagram.Payload()))
+	}
+	return datagram, err
+}
+
+func (rw metricsUDPDatagramReaderWriter) WriteDatagram(ctx context.Context, datagram UDPDatagram) error {
+	err := rw.UDPDatagramReaderWriter.WriteDatagram(ctx, datagram)
+	if err == nil {
+		metrics.TunnelReceived(rw.destination, len(datagram.Payload()))
+	}
+	return err
+}
+
+// countingUDPDatagramReaderWriter counts the payload bytes of the datagrams of
+// a local UDP session with a TrafficCounter.
+type countingUDPDatagramReaderWriter struct {
+	UDPDatagramReaderWriter
+	counter TrafficCounter
+}
+
+func (rw countingUDPDatagramReaderWriter) ReadDatagram(ctx context.Context) (UDPDatagram, error) {
+	datagram, err := rw.UDPDatagramReaderWriter.ReadDatagram(ctx)
+	if err == nil {
+		rw.counter.OnSent(len(datagram.Payload()))
+	}
+	return datagram, err
+}
+
+func (rw countingUDPDatagramReaderWriter) WriteDatagram(ctx context.Context, datagram UDPDatagram) error {
+	err := rw.UDPDatagramReaderWriter.WriteDatagram(ctx, datagram)
+	if err == nil {
+		rw.counter.OnReceived(len(datagram.Payload()))
+	}
+	return err
+}
@@ -0,0 +1,26 @@
+package tunnel
+
+import "fmt"
+
+// A Protocol is the HTTP version used to connect to the proxy host.
+type Protocol string
+
+// Protocols
+const (
+	// ProtocolAuto probes the proxy host for the best supported protocol.
+	ProtocolAuto  Protocol = "auto"
+	ProtocolHTTP1 Protocol = "h1"
+	ProtocolHTTP2 Protocol = "h2"
+	ProtocolHTTP3 Protocol = "h3"
+)
+
+// ParseProtocol parses a protocol name. An empty name selects ProtocolAuto.
+func ParseProtocol(name string) (Protocol, error) {
+	switch p := Protocol(name); p {
+	case "":
+		return ProtocolAuto, nil
+	case ProtocolAuto, ProtocolHTTP1, ProtocolHTTP2, ProtocolHTTP3:
+		return p, nil
+	}
+	return "", fmt.Errorf("unknown protocol %q, expected h1, h2, h3 or auto", name)
+}
@@ -0,0 +1,110 @@
+package tunnel
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync/atomic"
+	"time"
+
+	backoff "github.com/cenkalti/backoff/v4"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	defaultReconnectInitialInterval = 500 * time.Millisecond
+	defaultReconnectMaxInterval     = 30 * time.Second
+	defaultReconnectMaxElapsedTime  = 5 * time.Minute
+)
+
+// ReconnectSettings customizes how tunnels are re-established when the
+// connection to the proxy host fails. Zero values use the defaults.
+type ReconnectSettings struct {
+	// InitialInterval is the delay before the first attempt. Defaults to 500ms.
+	InitialInterval time.Duration
+	// MaxInterval caps the exponentially growing delay between attempts.
+	// Defaults to 30 seconds.
+	MaxInterval time.Duration
+	// MaxElapsedTime is how long to keep trying before giving up. Defaults
+	// to 5 minutes.
+	MaxElapsedTime time.Duration
+}
+
+func (s ReconnectSettings) newBackOff() *backoff.ExponentialBackOff {
+	bo := backoff.NewExponentialBackOff()
+	bo.InitialInterval = defaultReconnectInitialInterval
+	if s.InitialInterval > 0 {
+		bo.InitialInterval = s.InitialInterval
+	}
+	bo.MaxInterval = defaultReconnectMaxInterval
+	if s.MaxInterval > 0 {
+		bo.MaxInterval = s.MaxInterval
+	}
+	bo.MaxElapsedTime = defaultReconnectMaxElapsedTime
+	if s.MaxElapsedTime > 0 {
+		bo.MaxElapsedTime = s.MaxElapsedTime
+	}
+	bo.Reset()
+	return bo
+}
+
+// runWithReconnect calls handler until it succeeds, waiting with an
+// exponential backoff between attempts. A TCP stream cannot be resumed, so
+// unless resumable is set, it stops retrying once a connection was
+// established. Otherwise the backoff starts over after each connection.
+func (tun *Tunnel) runWithReconnect(
+	ctx context.Context,
+	eventSink EventSink,
+	resumable bool,
+	handler func(ctx context.Context, eventSink EventSink) error,
+) error {
+	if tun.cfg.reconnect == nil {
+		return handler(ctx, eventSink)
+	}
+
+	bo := tun.cfg.reconnect.newBackOff()
+	for {
+		events := &reconnectEvents{EventSink: eventSink}
+		err := handler(ctx, events)
+		if err == nil || ctx.Err() != nil || !isReconnectable(err) {
+			return err
+		}
+
+		if events.connected.Load() {
+			if !resumable {
+				return err
+			}
+			bo.Reset()
+		}
+
+		next := bo.NextBackOff()
+		if next == backoff.Stop {
+			return err
+		}
+		log.Ctx(ctx).Warn().Err(err).Dur("delay", next).Msg("tunnel: reconnecting to proxy")
+
+		select {
+		case <-ctx.Done():
+			return context.Cause(ctx)
+		case <-time.After(next):
+		}
+	}
+}
+
+// isReconnectable reports whether trying again may fix err. Authorization
+// failures are not retried, the user has to act on them first.
+func isReconnectable(err error) bool {
+	return !errors.Is(err, ErrUnauthenticated) && !errors.Is(err, ErrUnauthorized) &&
+		!errors.Is(err, net.ErrClosed)
+}
+
+// reconnectEvents records whether an attempt got connected.
+type reconnectEvents struct {
+	EventSink
+	connected atomic.Bool
+}
+
+func (evt *reconnectEvents) OnConnected(ctx context.Context, info ConnectionInfo) {
+	evt.connected.Store(true)
+	evt.EventSink.OnConnected(ctx, info)
+}
@@ -0,0 +1,71 @@
+package tunnel
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunWithReconnect(t *testing.T) {
+	t.Parallel()
+
+	errDropped := errors.New("dropped")
+	settings := &ReconnectSettings{
+		InitialInterval: time.Millisecond,
+		MaxInterval:     time.Millisecond,
+		MaxElapsedTime:  time.Second,
+	}
+
+	for _, tc := range []struct {
+		name      string
+		settings  *ReconnectSettings
+		resumable bool
+		results   []error
+		connected []bool
+		expect    error
+		calls     int
+	}{
+		{"disabled", nil, false, []error{errDropped, nil}, nil, errDropped, 1},
+		{"retry until success", settings, false, []error{errDropped, errDropped, nil}, nil, nil, 3},
+		{"unauthorized", settings, false, []error{ErrUnauthorized, nil}, nil, ErrUnauthorized, 1},
+		{"unauthenticated", settings, false, []error{ErrUnauthenticated, nil}, nil, ErrUnauthenticated, 1},
+		{"connected", settings, false, []error{errDropped, nil}, []bool{true}, errDropped, 1},
+		{"connected resumable", settings, true, []error{errDropped, nil}, []bool{true}, nil, 2},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tun := &Tunnel{cfg: getConfig(WithReconnect(tc.settings))}
+			calls := 0
+			err := tun.runWithReconnect(context.Background(), DiscardEvents(), tc.resumable,
+				func(ctx context.Context, eventSink EventSink) error {
+					if calls < len(tc.connected) && tc.connected[calls] {
+						eventSink.OnConnected(ctx, ConnectionInfo{})
+					}
+					err := tc.results[calls]
+					calls++
+					return err
+				})
+			assert.ErrorIs(t, err, tc.expect)
+			assert.Equal(t, tc.calls, calls)
+		})
+	}
+
+	t.Run("gives up", func(t *testing.T) {
+		t.Parallel()
+
+		tun := &Tunnel{cfg: getConfig(WithReconnect(&ReconnectSettings{
+			InitialInterval: time.Millisecond,
+			MaxInterval:     time.Millisecond,
+			MaxElapsedTime:  50 * time.Millisecond,
+		}))}
+		err := tun.runWithReconnect(context.Background(), DiscardEvents(), false,
+			func(_ context.Context, _ EventSink) error {
+				return errDropped
+			})
+		assert.ErrorIs(t, err, errDropped)
+	})
+}
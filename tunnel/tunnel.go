@@ -2,6 +2,7 @@
 package tunnel
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -16,14 +17,19 @@ import (
 	"github.com/rs/zerolog/log"
 
 	"github.com/pomerium/cli/authclient"
+	"github.com/pomerium/cli/internal/proxyproto"
 	"github.com/pomerium/cli/jwt"
 )
 
 var (
-	errUnavailable     = errors.New("unavailable")
-	errUnauthenticated = errors.New("unauthenticated")
-	errUnauthorized    = errors.New("unauthorized")
-	errUnsupported     = errors.New("unsupported")
+	// ErrUnavailable indicates the pomerium proxy or the destination is unavailable.
+	ErrUnavailable = errors.New("unavailable")
+	// ErrUnauthenticated indicates the user needs to log in.
+	ErrUnauthenticated = errors.New("unauthenticated")
+	// ErrUnauthorized indicates the user is not allowed to access the destination.
+	ErrUnauthorized = errors.New("unauthorized")
+
+	errUnsupported = errors.New("unsupported")
 )
 
 // A Tunnel represents a TCP tunnel over HTTP Connect.
@@ -33,27 +39,81 @@ type Tunnel struct {
 
 	mu          sync.Mutex
 	tcpTunneler TCPTunneler
+	closed      bool
+	hops        []*Tunnel
 }
 
 // New creates a new Tunnel.
 func New(options ...Option) *Tunnel {
-	cfg := getConfig(options...)
+	return newTunnel(getConfig(options...))
+}
+
+func newTunnel(cfg *config) *Tunnel {
+	// each hop of a proxy chain tunnels to the next hop through the previous ones
+	var hops []*Tunnel
+	for i, hop := range cfg.proxyChain {
+		hopCfg := *cfg
+		hopCfg.proxyChain = nil
+		hopCfg.proxyHost = hop
+		hopCfg.dstHost = cfg.proxyHost
+		if i+1 < len(cfg.proxyChain) {
+			hopCfg.dstHost = cfg.proxyChain[i+1]
+		}
+		hopTun := newTunnel(&hopCfg)
+		hops = append(hops, hopTun)
+		cfg.dialContext = hopTun.dialDestination
+	}
+	// the outbound proxy is used by the first hop of a proxy chain
+	if err := cfg.setupOutboundProxy(); err != nil {
+		log.Warn().Err(err).Msg("tunnel: ignoring outbound proxy")
+	}
+
 	return &Tunnel{
-		cfg: cfg,
+		cfg:  cfg,
+		hops: hops,
 		auth: authclient.New(
 			authclient.WithBrowserCommand(cfg.browserConfig),
+			authclient.WithCallbackPath(cfg.callbackPath),
+			authclient.WithCallbackPortRange(cfg.callbackPorts),
+			authclient.WithRedirectAddress(cfg.redirectAddr),
+			authclient.WithDialContext(cfg.dialContext),
+			authclient.WithExpectedUser(cfg.expectedUser),
+			authclient.WithNoBrowser(cfg.noBrowser),
+			authclient.WithOutboundProxy(cfg.outboundProxyURL),
+			authclient.WithQRCode(cfg.qrCode),
 			authclient.WithServiceAccount(cfg.serviceAccount),
 			authclient.WithServiceAccountFile(cfg.serviceAccountFile),
 			authclient.WithTLSConfig(cfg.tlsConfig)),
 	}
 }
 
-// RunListener runs a network listener on the given address. For each
-// incoming connection a new TCP tunnel is established via Run.
+// Close closes the connections to the proxy host shared by the TCP tunnels,
+// tearing down those tunnels, as well as those of the hops of a proxy chain.
+// No new TCP tunnels can be established afterwards.
+func (tun *Tunnel) Close() error {
+	tun.mu.Lock()
+	tun.closed = true
+	tcpTunneler := tun.tcpTunneler
+	tun.mu.Unlock()
+
+	var errs []error
+	if closer, ok := tcpTunneler.(io.Closer); ok {
+		errs = append(errs, closer.Close())
+	}
+	for _, hop := range tun.hops {
+		errs = append(errs, hop.Close())
+	}
+	return errors.Join(errs...)
+}
+
+// RunListener runs a network listener on the given address, see Listen. For
+// each incoming connection a new TCP tunnel is established via Run. The
+// tunnel is closed when it returns.
 func (tun *Tunnel) RunListener(ctx context.Context, listenerAddress string) error {
 	ctx = log.Ctx(ctx).With().Str("component", "tunnel").Logger().WithContext(ctx)
+	defer func() { _ = tun.Close() }()
 
-	li, err := net.Listen("tcp", listenerAddress)
+	li, err := Listen(ctx, listenerAddress)
 	if err != nil {
 		return err
 	}
@@ -95,6 +155,10 @@ func (tun *Tunnel) RunListener(ctx context.Context, listenerAddress string) erro
 			err := tun.Run(ctx, c, LogEvents())
 			if err != nil {
 				log.Ctx(ctx).Error().Err(err).Msg("error serving local connection")
+				// reset the local connection so the client notices the tunnel is gone
+				if tcpConn, ok := c.(*net.TCPConn); ok {
+					_ = tcpConn.SetLinger(0)
+				}
 			}
 		}(c)
 	}
@@ -102,14 +166,31 @@ func (tun *Tunnel) RunListener(ctx context.Context, listenerAddress string) erro
 
 // Run establishes a TCP tunnel via HTTP Connect and forwards all traffic from/to local.
 func (tun *Tunnel) Run(ctx context.Context, local io.ReadWriter, eventSink EventSink) error {
-	return tun.runWithJWT(ctx, eventSink, func(ctx context.Context, rawJWT string) error {
-		tun.mu.Lock()
-		if tun.tcpTunneler == nil {
-			tun.tcpTunneler = tun.pickTCPTunneler(ctx)
-		}
-		tun.mu.Unlock()
+	header, err := tun.proxyProtocolHeader(local)
+	if err != nil {
+		return err
+	}
+	local = metricsReadWriter{ReadWriter: local, destination: tun.cfg.dstHost}
+	if header != nil {
+		// the header is sent ahead of the data of the local client
+		local = readWriter{Reader: io.MultiReader(bytes.NewReader(header), local), Writer: local}
+	}
+	eventSink = &metricsEvents{EventSink: eventSink, destination: tun.cfg.dstHost}
+	return tun.runWithReconnect(ctx, eventSink, false, func(ctx context.Context, eventSink EventSink) error {
+		return tun.runWithJWT(ctx, eventSink, func(ctx context.Context, rawJWT string) error {
+			tun.mu.Lock()
+			if tun.closed {
+				tun.mu.Unlock()
+				return fmt.Errorf("tunnel: %w", net.ErrClosed)
+			}
+			if tun.tcpTunneler == nil {
+				tun.tcpTunneler = tun.pickTCPTunneler(ctx)
+			}
+			tcpTunneler := tun.tcpTunneler
+			tun.mu.Unlock()
 
-		return tun.tcpTunneler.TunnelTCP(ctx, eventSink, local, rawJWT)
+			return tcpTunneler.TunnelTCP(ctx, eventSink, local, rawJWT)
+		})
 	})
 }
 
@@ -125,9 +206,13 @@ func (tun *Tunnel) runWithJWT(ctx context.Context, eventSink EventSink, handler
 	default:
 		return fmt.Errorf("tunnel: failed to load JWT: %w", err)
 	}
+	// a cached JWT for a different user is discarded so that a new one is requested
+	if err == nil && jwt.CheckUser(rawJWT, tun.cfg.expectedUser) != nil {
+		rawJWT = ""
+	}
 
 	err = handler(ctx, rawJWT)
-	if errors.Is(err, errUnauthenticated) {
+	if errors.Is(err, ErrUnauthenticated) {
 		serverURL := &url.URL{
 			Scheme: "http",
 			Host:   tun.cfg.proxyHost,
@@ -151,7 +236,7 @@ func (tun *Tunnel) runWithJWT(ctx context.Context, eventSink EventSink, handler
 		err = handler(ctx, rawJWT)
 	}
 
-	if errors.Is(err, errUnavailable) || errors.Is(err, errUnauthorized) {
+	if errors.Is(err, ErrUnavailable) || errors.Is(err, ErrUnauthorized) {
 		// don't delete the JWT if we get a service unavailable or the user is unauthorized
 		return err
 	} else if err != nil {
@@ -162,6 +247,29 @@ func (tun *Tunnel) runWithJWT(ctx context.Context, eventSink EventSink, handler
 	return nil
 }
 
+// proxyProtocolHeader returns the PROXY protocol header for the local
+// connection, if enabled. The addresses are unknown unless local is a net.Conn.
+func (tun *Tunnel) proxyProtocolHeader(local io.ReadWriter) ([]byte, error) {
+	if tun.cfg.proxyProtocol == 0 {
+		return nil, nil
+	}
+
+	var src, dst net.Addr
+	if conn, ok := local.(net.Conn); ok {
+		src, dst = conn.RemoteAddr(), conn.LocalAddr()
+	}
+	header, err := proxyproto.Header(tun.cfg.proxyProtocol, src, dst)
+	if err != nil {
+		return nil, fmt.Errorf("tunnel: %w", err)
+	}
+	return header, nil
+}
+
+type readWriter struct {
+	io.Reader
+	io.Writer
+}
+
 func (tun *Tunnel) jwtCacheKey() string {
 	return jwt.CacheKeyForHost(tun.cfg.proxyHost, tun.cfg.tlsConfig)
 }
@@ -171,14 +279,14 @@ func httpStatusCodeToError(statusCode int) error {
 	case http.StatusOK:
 		return nil
 	case http.StatusServiceUnavailable:
-		return errUnavailable
+		return ErrUnavailable
 	case http.StatusMovedPermanently,
 		http.StatusFound,
 		http.StatusTemporaryRedirect,
 		http.StatusPermanentRedirect:
-		return errUnauthenticated
+		return ErrUnauthenticated
 	case http.StatusForbidden:
-		return errUnauthorized
+		return ErrUnauthorized
 	}
 
 	return fmt.Errorf("invalid http response code: %d", statusCode)
@@ -3,12 +3,12 @@ package tunnel
 import (
 	"bufio"
 	"context"
-	"crypto/tls"
 	"fmt"
 	"io"
 	"net"
 	"net/http"
 	"net/url"
+	"time"
 
 	"github.com/dunglas/httpsfv"
 	"github.com/quic-go/quic-go/http3"
@@ -31,6 +31,7 @@ func (t *http1tunneler) TunnelTCP(
 	ctx = log.Ctx(ctx).With().Str("component", "http1tunneler").Logger().WithContext(ctx)
 
 	eventSink.OnConnecting(ctx)
+	start := time.Now()
 
 	hdr := http.Header{}
 	if rawJWT != "" {
@@ -44,13 +45,7 @@ func (t *http1tunneler) TunnelTCP(
 		Header: hdr,
 	}).WithContext(ctx)
 
-	var remote net.Conn
-	var err error
-	if t.cfg.tlsConfig != nil {
-		remote, err = (&tls.Dialer{Config: t.cfg.tlsConfig}).DialContext(ctx, "tcp", t.cfg.proxyHost)
-	} else {
-		remote, err = (&net.Dialer{}).DialContext(ctx, "tcp", t.cfg.proxyHost)
-	}
+	remote, err := t.cfg.dialProxyHost(ctx, t.cfg.tlsConfig)
 	if err != nil {
 		return fmt.Errorf("http/1: failed to establish connection to proxy: %w", err)
 	}
@@ -83,7 +78,7 @@ func (t *http1tunneler) TunnelTCP(
 		return err
 	}
 
-	eventSink.OnConnected(ctx)
+	eventSink.OnConnected(ctx, connInfo(ProtocolHTTP1, start, remote))
 
 	errc := make(chan error, 2)
 	go func() {
@@ -114,14 +109,9 @@ func (t *http1tunneler) TunnelUDP(
 	rawJWT string,
 ) error {
 	eventSink.OnConnecting(ctx)
+	start := time.Now()
 
-	var remote net.Conn
-	var err error
-	if t.cfg.tlsConfig != nil {
-		remote, err = (&tls.Dialer{Config: t.cfg.tlsConfig}).DialContext(ctx, "tcp", t.cfg.proxyHost)
-	} else {
-		remote, err = (&net.Dialer{}).DialContext(ctx, "tcp", t.cfg.proxyHost)
-	}
+	remote, err := t.cfg.dialProxyHost(ctx, t.cfg.tlsConfig)
 	if err != nil {
 		return fmt.Errorf("http/1: failed to establish connection to proxy: %w", err)
 	}
@@ -177,7 +167,7 @@ func (t *http1tunneler) TunnelUDP(
 		return err
 	}
 
-	eventSink.OnConnected(ctx)
+	eventSink.OnConnected(ctx, connInfo(ProtocolHTTP1, start, remote))
 
 	eg, ectx := errgroup.WithContext(ctx)
 	eg.Go(func() error {
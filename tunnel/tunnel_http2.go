@@ -5,17 +5,34 @@ import (
 	"crypto/tls"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"net/url"
+	"sync"
+	"time"
 
+	"github.com/dunglas/httpsfv"
+	"github.com/quic-go/quic-go/http3"
 	"github.com/rs/zerolog/log"
 	"golang.org/x/net/http2"
+	"golang.org/x/sync/errgroup"
 )
 
+// http2IdleConnTimeout is how long a shared connection to the proxy host is
+// kept open once its last stream has finished
+const http2IdleConnTimeout = 90 * time.Second
+
 type http2tunneler struct {
 	cfg *config
+
+	mu     sync.Mutex
+	cc     *http2.ClientConn
+	remote *tls.Conn
+	closed bool
 }
 
+func (*http2tunneler) Name() string { return "http2" }
+
 func (t *http2tunneler) TunnelTCP(
 	ctx context.Context,
 	eventSink EventSink,
@@ -25,42 +42,24 @@ func (t *http2tunneler) TunnelTCP(
 	ctx = log.Ctx(ctx).With().Str("component", "http2tunneler").Logger().WithContext(ctx)
 
 	eventSink.OnConnecting(ctx)
+	start := time.Now()
 
 	hdr := http.Header{}
 	if rawJWT != "" {
 		hdr.Set("Authorization", "Pomerium "+rawJWT)
 	}
 
-	if t.cfg.tlsConfig == nil {
-		return fmt.Errorf("%w: http2 requires TLS", errUnsupported)
-	}
-
-	cfg := t.cfg.tlsConfig.Clone()
-	cfg.NextProtos = []string{"h2"}
-
-	raw, err := (&tls.Dialer{Config: cfg}).DialContext(ctx, "tcp", t.cfg.proxyHost)
+	cc, remote, err := t.getClientConn(ctx)
 	if err != nil {
-		return fmt.Errorf("http/2: failed to establish connection to proxy: %w", err)
-	}
-	defer func() { _ = raw.Close() }()
-
-	remote, ok := raw.(*tls.Conn)
-	if !ok {
-		return fmt.Errorf("http/2: unexpected connection type returned from dial: %T", raw)
-	}
-
-	protocol := remote.ConnectionState().NegotiatedProtocol
-	if protocol != "h2" {
-		return fmt.Errorf("%w: unexpected TLS protocol: %s", errUnsupported, protocol)
+		return err
 	}
 
-	cc, err := (&http2.Transport{}).NewClientConn(remote)
-	if err != nil {
-		return fmt.Errorf("http/2: failed to establish connection: %w", err)
-	}
-	defer cc.Close()
+	// the connection is shared, so only the stream is torn down on return
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
 
 	pr, pw := io.Pipe()
+	defer pw.Close()
 
 	req := (&http.Request{
 		Method:        "CONNECT",
@@ -82,7 +81,7 @@ func (t *http2tunneler) TunnelTCP(
 		return err
 	}
 
-	eventSink.OnConnected(ctx)
+	eventSink.OnConnected(ctx, connInfo(ProtocolHTTP2, start, remote))
 
 	errc := make(chan error, 2)
 	go func() {
@@ -104,3 +103,146 @@ func (t *http2tunneler) TunnelTCP(
 
 	return err
 }
+
+func (t *http2tunneler) TunnelUDP(
+	ctx context.Context,
+	eventSink EventSink,
+	local UDPDatagramReaderWriter,
+	rawJWT string,
+) error {
+	ctx = log.Ctx(ctx).With().Str("component", "http2tunneler").Logger().WithContext(ctx)
+
+	eventSink.OnConnecting(ctx)
+	start := time.Now()
+
+	dstHost, dstPort, err := net.SplitHostPort(t.cfg.dstHost)
+	if err != nil {
+		return fmt.Errorf("http/2: failed to split destination host into host and port")
+	}
+
+	u, err := url.Parse(fmt.Sprintf("https://%s/.well-known/masque/udp/%s/%s/", t.cfg.proxyHost, dstHost, dstPort))
+	if err != nil {
+		return fmt.Errorf("http/2: failed to create destination url: %w", err)
+	}
+
+	capsuleProtocolHeaderValue, err := httpsfv.Marshal(httpsfv.NewItem(true))
+	if err != nil {
+		return fmt.Errorf("http/2: failed to encode capsule protocol header value")
+	}
+
+	remote, err := t.dialTLS(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = remote.Close() }()
+
+	hdr := http.Header{
+		http3.CapsuleProtocolHeader: {capsuleProtocolHeaderValue},
+	}
+	if rawJWT != "" {
+		hdr.Set("Authorization", "Pomerium "+rawJWT)
+	}
+
+	stream, statusCode, err := newHTTP2ConnectStream(ctx, remote, "connect-udp", u, hdr)
+	if err != nil {
+		return fmt.Errorf("http/2: error making connect request: %w", err)
+	}
+	defer stream.Close()
+
+	err = httpStatusCodeToError(statusCode)
+	if err != nil {
+		return err
+	}
+
+	eventSink.OnConnected(ctx, connInfo(ProtocolHTTP2, start, remote))
+
+	eg, ectx := errgroup.WithContext(ctx)
+	eg.Go(func() error {
+		return streamFromCapsuleDatagramsToUDPDatagramWriter(ectx, local, stream)
+	})
+	eg.Go(func() error {
+		return streamFromUDPDatagramReaderToCapsuleDatagrams(ectx, stream, local)
+	})
+	context.AfterFunc(ectx, func() { _ = stream.Close() })
+	err = eg.Wait()
+
+	eventSink.OnDisconnected(ctx, err)
+
+	return err
+}
+
+// getClientConn returns the connection to the proxy host shared by all TCP
+// tunnels, dialing a new one if there is none yet or the current one can no
+// longer take new streams.
+func (t *http2tunneler) getClientConn(ctx context.Context) (*http2.ClientConn, *tls.Conn, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.closed {
+		return nil, nil, fmt.Errorf("http/2: %w", net.ErrClosed)
+	}
+	if t.cc != nil && t.cc.CanTakeNewRequest() {
+		return t.cc, t.remote, nil
+	}
+
+	remote, err := t.dialTLS(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cc, err := (&http2.Transport{
+		ReadIdleTimeout: t.cfg.keepAlive,
+		PingTimeout:     t.cfg.keepAlive,
+		IdleConnTimeout: http2IdleConnTimeout,
+	}).NewClientConn(remote)
+	if err != nil {
+		_ = remote.Close()
+		return nil, nil, fmt.Errorf("http/2: failed to establish connection: %w", err)
+	}
+
+	t.cc, t.remote = cc, remote
+	return cc, remote, nil
+}
+
+// Close closes the connection shared by the TCP tunnels, which are torn down
+// with it. No new TCP tunnels can be established afterwards.
+func (t *http2tunneler) Close() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.closed = true
+	if t.cc == nil {
+		return nil
+	}
+	err := t.cc.Close()
+	t.cc, t.remote = nil, nil
+	return err
+}
+
+// dialTLS establishes a TLS connection to the proxy host which negotiated HTTP/2.
+func (t *http2tunneler) dialTLS(ctx context.Context) (*tls.Conn, error) {
+	if t.cfg.tlsConfig == nil {
+		return nil, fmt.Errorf("%w: http2 requires TLS", errUnsupported)
+	}
+
+	cfg := t.cfg.tlsConfig.Clone()
+	cfg.NextProtos = []string{"h2"}
+
+	raw, err := t.cfg.dialProxyHost(ctx, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("http/2: failed to establish connection to proxy: %w", err)
+	}
+
+	remote, ok := raw.(*tls.Conn)
+	if !ok {
+		_ = raw.Close()
+		return nil, fmt.Errorf("http/2: unexpected connection type returned from dial: %T", raw)
+	}
+
+	protocol := remote.ConnectionState().NegotiatedProtocol
+	if protocol != "h2" {
+		_ = raw.Close()
+		return nil, fmt.Errorf("%w: unexpected TLS protocol: %s", errUnsupported, protocol)
+	}
+	return remote, nil
+}
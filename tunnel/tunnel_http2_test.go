@@ -3,14 +3,21 @@ package tunnel
 import (
 	"context"
 	"crypto/tls"
+	"errors"
 	"io"
 	"net"
 	"net/http"
 	"net/http/httptest"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/quic-go/quic-go/http3"
+	"github.com/quic-go/quic-go/quicvarint"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/http2"
 )
 
 func TestTCPTunnelViaHTTP2(t *testing.T) {
@@ -57,7 +64,7 @@ func TestTCPTunnelViaHTTP2(t *testing.T) {
 	}()
 
 	tun := &http2tunneler{
-		getConfig(
+		cfg: getConfig(
 			WithDestinationHost("example.com:9999"),
 			WithProxyHost(srv.Listener.Addr().String()),
 			WithTLSConfig(&tls.Config{
@@ -68,3 +75,193 @@ func TestTCPTunnelViaHTTP2(t *testing.T) {
 	err := tun.TunnelTCP(ctx, DiscardEvents(), c2, "JWT")
 	assert.NoError(t, err)
 }
+
+func TestTCPTunnelViaHTTP2Multiplexed(t *testing.T) {
+	t.Parallel()
+
+	ctx, clearTimeout := context.WithTimeout(context.Background(), time.Second*10)
+	defer clearTimeout()
+
+	var connections atomic.Int32
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+		w.WriteHeader(http.StatusOK)
+		w.(http.Flusher).Flush()
+
+		buf := make([]byte, 4)
+		if _, err := io.ReadFull(r.Body, buf); !assert.NoError(t, err) {
+			return
+		}
+		_, _ = w.Write(buf)
+	}))
+	srv.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			connections.Add(1)
+		}
+	}
+	srv.EnableHTTP2 = true
+	srv.StartTLS()
+	defer srv.Close()
+
+	tun := &http2tunneler{
+		cfg: getConfig(
+			WithDestinationHost("example.com:9999"),
+			WithProxyHost(srv.Listener.Addr().String()),
+			WithTLSConfig(&tls.Config{
+				InsecureSkipVerify: true,
+			}),
+		),
+	}
+
+	var wg sync.WaitGroup
+	for i := range 5 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			c1, c2 := net.Pipe()
+			go func() {
+				msg := []byte{byte(i), 2, 3, 4}
+				_, _ = c1.Write(msg)
+				buf := make([]byte, 4)
+				_, err := io.ReadFull(c1, buf)
+				assert.NoError(t, err)
+				assert.Equal(t, msg, buf)
+				_ = c1.Close()
+			}()
+			assert.NoError(t, tun.TunnelTCP(ctx, DiscardEvents(), c2, "JWT"))
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(1), connections.Load(),
+		"should share a single connection to the proxy")
+}
+
+func TestTunnelClose(t *testing.T) {
+	t.Parallel()
+
+	ctx, clearTimeout := context.WithTimeout(context.Background(), time.Second*10)
+	defer clearTimeout()
+
+	closed := make(chan struct{})
+	var closeOnce sync.Once
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+		w.WriteHeader(http.StatusOK)
+		w.(http.Flusher).Flush()
+		_, _ = w.Write([]byte{1})
+	}))
+	srv.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateClosed {
+			closeOnce.Do(func() { close(closed) })
+		}
+	}
+	srv.EnableHTTP2 = true
+	srv.StartTLS()
+	defer srv.Close()
+
+	tun := New(
+		WithDestinationHost("example.com:9999"),
+		WithProtocol(ProtocolHTTP2),
+		WithProxyHost(srv.Listener.Addr().String()),
+		WithTLSConfig(&tls.Config{
+			InsecureSkipVerify: true,
+		}),
+	)
+
+	c1, c2 := net.Pipe()
+	go func() {
+		_, _ = io.ReadFull(c1, make([]byte, 1))
+		_ = c1.Close()
+	}()
+	assert.NoError(t, tun.Run(ctx, c2, DiscardEvents()))
+
+	select {
+	case <-closed:
+		t.Fatal("the idle connection to the proxy should be kept open")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	assert.NoError(t, tun.Close())
+	select {
+	case <-closed:
+	case <-ctx.Done():
+		t.Fatal("closing the tunnel should close the connection to the proxy")
+	}
+
+	_, c2 = net.Pipe()
+	assert.ErrorIs(t, tun.Run(ctx, c2, DiscardEvents()), net.ErrClosed)
+}
+
+func TestUDPTunnelViaHTTP2(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ctx, clearTimeout := context.WithTimeout(ctx, time.Second*10)
+	defer clearTimeout()
+
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !assert.Equal(t, http.MethodConnect, r.Method) ||
+			!assert.Equal(t, "connect-udp", r.Header.Get(":protocol")) ||
+			!assert.Equal(t, "/.well-known/masque/udp/example.com/9999/", r.URL.Path) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.(http.Flusher).Flush()
+
+		payload, err := readUDPCapsuleDatagram(quicvarint.NewReader(r.Body))
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, []byte("\x00SEND HELLO WORLD"), payload)
+
+		err = http3.WriteCapsule(quicvarint.NewWriter(w), 0, []byte("\x00RECV HELLO WORLD"))
+		assert.NoError(t, err)
+		w.(http.Flusher).Flush()
+
+		<-ctx.Done()
+	}))
+	// the x/net server supports extended connect
+	require.NoError(t, http2.ConfigureServer(srv.Config, &http2.Server{}))
+	srv.TLS = &tls.Config{NextProtos: []string{"h2"}}
+	srv.StartTLS()
+	defer srv.Close()
+
+	tun := New(
+		WithDestinationHost("example.com:9999"),
+		WithProtocol(ProtocolHTTP2),
+		WithProxyHost(srv.Listener.Addr().String()),
+		WithTLSConfig(&tls.Config{
+			InsecureSkipVerify: true,
+		}),
+	)
+
+	tunnelConn, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	require.NoError(t, err)
+
+	tunErrC := make(chan error, 1)
+	go func() { tunErrC <- tun.RunUDPSessionManager(ctx, tunnelConn, DiscardEvents()) }()
+
+	conn, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	require.NoError(t, err)
+	context.AfterFunc(ctx, func() { _ = conn.Close() })
+
+	_, err = conn.WriteToUDP([]byte("SEND HELLO WORLD"), tunnelConn.LocalAddr().(*net.UDPAddr))
+	require.NoError(t, err)
+
+	payload := make([]byte, maxUDPPacketSize)
+	n, _, err := conn.ReadFromUDP(payload)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("RECV HELLO WORLD"), payload[:n])
+
+	cancel()
+	err = <-tunErrC
+	if errors.Is(err, context.Canceled) {
+		err = nil
+	}
+	assert.NoError(t, err, "tunnel should shutdown cleanly")
+}
@@ -2,6 +2,7 @@ package tunnel
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"io"
@@ -9,6 +10,7 @@ import (
 	"net/http"
 	"net/url"
 	"sync"
+	"time"
 
 	"github.com/dunglas/httpsfv"
 	"github.com/quic-go/quic-go"
@@ -20,6 +22,11 @@ import (
 
 type http3tunneler struct {
 	cfg *config
+
+	mu        sync.Mutex
+	transport *http3.Transport
+	proxyAddr net.Addr
+	closed    bool
 }
 
 func (*http3tunneler) Name() string { return "http3" }
@@ -33,19 +40,19 @@ func (t *http3tunneler) TunnelTCP(
 	ctx = log.Ctx(ctx).With().Str("component", "http3tunneler").Logger().WithContext(ctx)
 
 	eventSink.OnConnecting(ctx)
+	start := time.Now()
 
-	transport, err := t.getTransport(false)
+	transport, err := t.getTCPTransport()
 	if err != nil {
 		return err
 	}
-	defer func() {
-		err := transport.Close()
-		if err != nil {
-			log.Ctx(ctx).Error().Err(err).Msg("error closing http3 transport")
-		}
-	}()
+
+	// the transport is shared, so only the stream is torn down on return
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
 
 	pr, pw := io.Pipe()
+	defer pw.Close()
 
 	u, err := url.Parse("https://" + t.cfg.proxyHost)
 	if err != nil {
@@ -55,14 +62,14 @@ func (t *http3tunneler) TunnelTCP(
 	if rawJWT != "" {
 		hdr.Set("Authorization", "Pomerium "+rawJWT)
 	}
-	res, err := transport.RoundTrip(&http.Request{
+	res, err := transport.RoundTrip((&http.Request{
 		Method:        http.MethodConnect,
 		URL:           u,
 		Host:          t.cfg.dstHost,
 		Header:        hdr,
 		ContentLength: -1,
 		Body:          pr,
-	})
+	}).WithContext(ctx))
 	if err != nil {
 		return fmt.Errorf("http/3: %w: failed to make connect request: %w", errUnsupported, err)
 	}
@@ -73,7 +80,7 @@ func (t *http3tunneler) TunnelTCP(
 		return err
 	}
 
-	eventSink.OnConnected(ctx)
+	eventSink.OnConnected(ctx, newConnectionInfo(ProtocolHTTP3, start, t.getProxyAddr(), res.TLS))
 
 	errc := make(chan error, 2)
 	go func() {
@@ -105,6 +112,7 @@ func (t *http3tunneler) TunnelUDP(
 	ctx = log.Ctx(ctx).With().Str("component", "http3tunneler").Logger().WithContext(ctx)
 
 	eventSink.OnConnecting(ctx)
+	start := time.Now()
 
 	transport, err := t.getTransport(true)
 	if err != nil {
@@ -117,7 +125,8 @@ func (t *http3tunneler) TunnelUDP(
 		}
 	}()
 
-	conn, err := quic.DialAddr(ctx, t.cfg.proxyHost, transport.TLSClientConfig, transport.QUICConfig)
+	addr, tlsConfig := t.cfg.resolveQUICAddr(ctx, t.cfg.proxyHost, transport.TLSClientConfig)
+	conn, err := quic.DialAddr(ctx, addr, tlsConfig, transport.QUICConfig)
 	if err != nil {
 		return fmt.Errorf("http/3: %w: failed to connect to server: %w", errUnsupported, err)
 	}
@@ -164,7 +173,8 @@ func (t *http3tunneler) TunnelUDP(
 		return err
 	}
 
-	eventSink.OnConnected(ctx)
+	tlsState := conn.ConnectionState().TLS
+	eventSink.OnConnected(ctx, newConnectionInfo(ProtocolHTTP3, start, conn.RemoteAddr(), &tlsState))
 
 	eg, ectx := errgroup.WithContext(ctx)
 	eg.Go(func() error { return t.skipCapsules(ectx, rstr) })
@@ -208,6 +218,59 @@ func (t *http3tunneler) getConnectUDPRequest(ctx context.Context, rawJWT string)
 	}).WithContext(ctx), nil
 }
 
+// getTCPTransport returns the transport shared by all TCP tunnels. It keeps a
+// single QUIC connection to the proxy host and opens a stream per tunnel.
+func (t *http3tunneler) getTCPTransport() (*http3.Transport, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.closed {
+		return nil, fmt.Errorf("http/3: %w", net.ErrClosed)
+	}
+	if t.transport != nil {
+		return t.transport, nil
+	}
+
+	transport, err := t.getTransport(false)
+	if err != nil {
+		return nil, err
+	}
+	// remember the address of the proxy host for the connection info
+	transport.Dial = func(ctx context.Context, addr string, tlsCfg *tls.Config, cfg *quic.Config) (quic.EarlyConnection, error) {
+		addr, tlsCfg = t.cfg.resolveQUICAddr(ctx, addr, tlsCfg)
+		conn, err := quic.DialAddrEarly(ctx, addr, tlsCfg, cfg)
+		if err == nil {
+			t.mu.Lock()
+			t.proxyAddr = conn.RemoteAddr()
+			t.mu.Unlock()
+		}
+		return conn, err
+	}
+	t.transport = transport
+	return transport, nil
+}
+
+// Close closes the QUIC connection shared by the TCP tunnels, which are torn
+// down with it. No new TCP tunnels can be established afterwards.
+func (t *http3tunneler) Close() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.closed = true
+	if t.transport == nil {
+		return nil
+	}
+	err := t.transport.Close()
+	t.transport = nil
+	return err
+}
+
+func (t *http3tunneler) getProxyAddr() net.Addr {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.proxyAddr
+}
+
 func (t *http3tunneler) getTransport(enableDatagrams bool) (*http3.Transport, error) {
 	cfg := t.cfg.tlsConfig
 	if cfg == nil {
@@ -226,6 +289,19 @@ func (t *http3tunneler) getTransport(enableDatagrams bool) (*http3.Transport, er
 			InitialPacketSize: 1350, // use a higher initial packet size so quic itself can be proxied
 		}
 	}
+	if t.cfg.keepAlive > 0 {
+		if transport.QUICConfig == nil {
+			// as the http3 default, don't allow the server to create bidirectional streams
+			transport.QUICConfig = &quic.Config{MaxIncomingStreams: -1}
+		}
+		transport.QUICConfig.KeepAlivePeriod = t.cfg.keepAlive
+		transport.QUICConfig.MaxIdleTimeout = keepAliveProbes * t.cfg.keepAlive
+	}
+	if transport.QUICConfig == nil {
+		// the http3 defaults
+		transport.QUICConfig = &quic.Config{MaxIncomingStreams: -1, KeepAlivePeriod: 10 * time.Second}
+	}
+	transport.QUICConfig.TokenStore = t.cfg.quicTokenStore
 	return transport, nil
 }
 
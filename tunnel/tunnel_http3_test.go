@@ -122,7 +122,7 @@ func TestTCPTunnelViaHTTP3(t *testing.T) {
 	}()
 
 	tun := &http3tunneler{
-		getConfig(
+		cfg: getConfig(
 			WithDestinationHost("example.com:9999"),
 			WithProxyHost("127.0.0.1:"+port),
 			WithTLSConfig(&tls.Config{
@@ -132,6 +132,12 @@ func TestTCPTunnelViaHTTP3(t *testing.T) {
 	}
 	err = tun.TunnelTCP(ctx, DiscardEvents(), c2, "JWT")
 	assert.NoError(t, err)
+
+	assert.NoError(t, tun.Close())
+	assert.Nil(t, tun.transport, "the shared transport should be closed")
+	_, c2 = net.Pipe()
+	err = tun.TunnelTCP(ctx, DiscardEvents(), c2, "JWT")
+	assert.ErrorIs(t, err, net.ErrClosed)
 }
 
 func TestUDPTunnelViaHTTP3(t *testing.T) {
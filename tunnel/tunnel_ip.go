@@ -0,0 +1,339 @@
+package tunnel
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/dunglas/httpsfv"
+	"github.com/quic-go/quic-go"
+	"github.com/quic-go/quic-go/http3"
+	"github.com/quic-go/quic-go/quicvarint"
+	"github.com/rs/zerolog/log"
+	"golang.org/x/sync/errgroup"
+)
+
+// capsule types defined by RFC 9484
+const (
+	capsuleAddressAssign      http3.CapsuleType = 0x01
+	capsuleRouteAdvertisement http3.CapsuleType = 0x03
+)
+
+// An IPRoute is a range of addresses reachable through an IP tunnel.
+type IPRoute struct {
+	Start, End netip.Addr
+	// IPProtocol limits the route to a single IP protocol, 0 means all protocols
+	IPProtocol uint8
+}
+
+// An IPDevice is a local network interface whose IP packets are tunneled.
+type IPDevice interface {
+	// ReadPacket reads the next IP packet sent by the local network stack.
+	ReadPacket(ctx context.Context) ([]byte, error)
+	// WritePacket delivers an IP packet to the local network stack.
+	WritePacket(ctx context.Context, packet []byte) error
+	// AssignAddresses is called with the addresses assigned by the proxy.
+	AssignAddresses(ctx context.Context, prefixes []netip.Prefix) error
+	// AddRoutes is called with the routes advertised by the proxy.
+	AddRoutes(ctx context.Context, routes []IPRoute) error
+}
+
+// RunIP tunnels IP packets from the device to the proxy host using
+// CONNECT-IP (RFC 9484). It requires TLS and HTTP/3.
+func (tun *Tunnel) RunIP(ctx context.Context, dev IPDevice, eventSink EventSink) error {
+	switch tun.cfg.protocol {
+	case ProtocolHTTP1, ProtocolHTTP2:
+		return fmt.Errorf("ip-tunnel: %w: %s", errUnsupported, tun.cfg.protocol)
+	}
+	if !tun.cfg.quicAllowed() {
+		return fmt.Errorf("ip-tunnel: %w: an outbound proxy or proxy chain cannot be used", errUnsupported)
+	}
+
+	tunneler := &http3tunneler{cfg: tun.cfg}
+	return tun.runWithJWT(ctx, eventSink, func(ctx context.Context, rawJWT string) error {
+		return tunneler.TunnelIP(ctx, eventSink, dev, rawJWT)
+	})
+}
+
+func (t *http3tunneler) TunnelIP(
+	ctx context.Context,
+	eventSink EventSink,
+	dev IPDevice,
+	rawJWT string,
+) error {
+	ctx = log.Ctx(ctx).With().Str("component", "http3tunneler").Logger().WithContext(ctx)
+
+	eventSink.OnConnecting(ctx)
+	start := time.Now()
+
+	transport, err := t.getTransport(true)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err := transport.Close()
+		if err != nil {
+			log.Ctx(ctx).Error().Err(err).Msg("error closing http3 transport")
+		}
+	}()
+
+	addr, tlsConfig := t.cfg.resolveQUICAddr(ctx, t.cfg.proxyHost, transport.TLSClientConfig)
+	conn, err := quic.DialAddr(ctx, addr, tlsConfig, transport.QUICConfig)
+	if err != nil {
+		return fmt.Errorf("http/3: failed to connect to server: %w", err)
+	}
+
+	cc := transport.NewClientConn(conn)
+
+	select {
+	case <-ctx.Done():
+		return context.Cause(ctx)
+	case <-cc.ReceivedSettings():
+	}
+	settings := cc.Settings()
+	if !settings.EnableExtendedConnect {
+		return fmt.Errorf("http/3: extended connect not enabled")
+	}
+	if !settings.EnableDatagrams {
+		return fmt.Errorf("http/3: datagrams not enabled")
+	}
+
+	rstr, err := cc.OpenRequestStream(ctx)
+	if err != nil {
+		return fmt.Errorf("http/3: failed to create request stream: %w", err)
+	}
+
+	req, err := t.getConnectIPRequest(ctx, rawJWT)
+	if err != nil {
+		return err
+	}
+
+	err = rstr.SendRequestHeader(req)
+	if err != nil {
+		return fmt.Errorf("http/3: error sending request: %w", err)
+	}
+
+	res, err := rstr.ReadResponse()
+	if err != nil {
+		return fmt.Errorf("http/3: error reading response: %w", err)
+	}
+	defer res.Body.Close()
+
+	err = httpStatusCodeToError(res.StatusCode)
+	if err != nil {
+		return err
+	}
+
+	tlsState := conn.ConnectionState().TLS
+	eventSink.OnConnected(ctx, newConnectionInfo(ProtocolHTTP3, start, conn.RemoteAddr(), &tlsState))
+
+	eg, ectx := errgroup.WithContext(ctx)
+	eg.Go(func() error { return t.readIPCapsules(ectx, rstr, dev) })
+	eg.Go(func() error { return t.readLocalIP(ectx, rstr, dev) })
+	eg.Go(func() error { return t.readRemoteIP(ectx, dev, rstr) })
+	err = eg.Wait()
+
+	eventSink.OnDisconnected(ctx, err)
+
+	return err
+}
+
+func (t *http3tunneler) getConnectIPRequest(ctx context.Context, rawJWT string) (*http.Request, error) {
+	// the whole address space and all IP protocols are requested, the proxy
+	// limits them to what the route allows
+	u, err := url.Parse(fmt.Sprintf("https://%s/.well-known/masque/ip/*/*/", t.cfg.proxyHost))
+	if err != nil {
+		return nil, fmt.Errorf("http/3: failed to create destination url: %w", err)
+	}
+
+	capsuleProtocolHeaderValue, err := httpsfv.Marshal(httpsfv.NewItem(true))
+	if err != nil {
+		return nil, fmt.Errorf("http/3: failed to encode capsule protocol header value")
+	}
+
+	hdr := http.Header{
+		http3.CapsuleProtocolHeader: {capsuleProtocolHeaderValue},
+	}
+	if rawJWT != "" {
+		hdr.Set("Authorization", "Pomerium "+rawJWT)
+	}
+	return (&http.Request{
+		Method: http.MethodConnect,
+		Proto:  "connect-ip",
+		Host:   u.Host,
+		Header: hdr,
+		URL:    u,
+	}).WithContext(ctx), nil
+}
+
+func (t *http3tunneler) readIPCapsules(ctx context.Context, str http3.Stream, dev IPDevice) error {
+	stop := context.AfterFunc(ctx, func() { str.CancelRead(0) })
+	defer stop()
+	r := quicvarint.NewReader(str)
+	for {
+		ct, cr, err := http3.ParseCapsule(r)
+		if errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("error parsing http3 capsule: %w", err)
+		}
+		payload, err := io.ReadAll(cr)
+		if err != nil {
+			return fmt.Errorf("error reading http3 capsule payload: %w", err)
+		}
+
+		switch ct {
+		case capsuleAddressAssign:
+			prefixes, err := parseAddressAssign(payload)
+			if err != nil {
+				return fmt.Errorf("http/3: invalid address assign capsule: %w", err)
+			}
+			log.Ctx(ctx).Info().Interface("addresses", prefixes).Msg("addresses assigned")
+			if err := dev.AssignAddresses(ctx, prefixes); err != nil {
+				return fmt.Errorf("http/3: error assigning addresses: %w", err)
+			}
+		case capsuleRouteAdvertisement:
+			routes, err := parseRouteAdvertisement(payload)
+			if err != nil {
+				return fmt.Errorf("http/3: invalid route advertisement capsule: %w", err)
+			}
+			log.Ctx(ctx).Info().Int("routes", len(routes)).Msg("routes advertised")
+			if err := dev.AddRoutes(ctx, routes); err != nil {
+				return fmt.Errorf("http/3: error adding routes: %w", err)
+			}
+		}
+	}
+}
+
+func (t *http3tunneler) readLocalIP(ctx context.Context, dst http3.Stream, src IPDevice) error {
+	var logMaxDatagramPayloadSizeOnce sync.Once
+	for {
+		packet, err := src.ReadPacket(ctx)
+		if err != nil {
+			return fmt.Errorf("http/3: error reading packet from local device: %w", err)
+		}
+
+		err = dst.SendDatagram(append(contextIDZero[:len(contextIDZero):len(contextIDZero)], packet...))
+
+		var tooLargeError *quic.DatagramTooLargeError
+		if errors.As(err, &tooLargeError) {
+			logMaxDatagramPayloadSizeOnce.Do(func() {
+				log.Ctx(ctx).Error().
+					Int64("max-datagram-payload-size", tooLargeError.MaxDatagramPayloadSize).
+					Int("packet-size", len(packet)).
+					Msg("packet exceeded max datagram payload size and was dropped, lower the MTU")
+			})
+			// ignore
+		} else if err != nil {
+			return fmt.Errorf("http/3: error sending datagram: %w", err)
+		}
+	}
+}
+
+func (t *http3tunneler) readRemoteIP(ctx context.Context, dst IPDevice, src http3.Stream) error {
+	for {
+		data, err := src.ReceiveDatagram(ctx)
+		if err != nil {
+			return fmt.Errorf("http/3: error reading datagram: %w", err)
+		}
+
+		contextID, n, err := quicvarint.Parse(data)
+		if err != nil || contextID != 0 {
+			// we only support context-id = 0
+			continue
+		}
+
+		err = dst.WritePacket(ctx, data[n:])
+		if err != nil {
+			return fmt.Errorf("http/3: error writing packet to local device: %w", err)
+		}
+	}
+}
+
+// parseAddressAssign parses the assigned addresses of an ADDRESS_ASSIGN capsule.
+func parseAddressAssign(payload []byte) ([]netip.Prefix, error) {
+	r := bytes.NewReader(payload)
+	var prefixes []netip.Prefix
+	for r.Len() > 0 {
+		// request id
+		if _, err := quicvarint.Read(r); err != nil {
+			return nil, err
+		}
+		addr, err := readIPAddress(r)
+		if err != nil {
+			return nil, err
+		}
+		bits, err := r.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+		if int(bits) > addr.BitLen() {
+			return nil, fmt.Errorf("invalid prefix length %d", bits)
+		}
+		prefixes = append(prefixes, netip.PrefixFrom(addr, int(bits)))
+	}
+	return prefixes, nil
+}
+
+// parseRouteAdvertisement parses the address ranges of a ROUTE_ADVERTISEMENT capsule.
+func parseRouteAdvertisement(payload []byte) ([]IPRoute, error) {
+	r := bytes.NewReader(payload)
+	var routes []IPRoute
+	for r.Len() > 0 {
+		version, err := r.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+		start, err := readIPAddressVersion(r, version)
+		if err != nil {
+			return nil, err
+		}
+		end, err := readIPAddressVersion(r, version)
+		if err != nil {
+			return nil, err
+		}
+		if end.Less(start) {
+			return nil, fmt.Errorf("invalid address range %s-%s", start, end)
+		}
+		proto, err := r.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+		routes = append(routes, IPRoute{Start: start, End: end, IPProtocol: proto})
+	}
+	return routes, nil
+}
+
+// readIPAddress reads an IP version followed by an address of that version.
+func readIPAddress(r *bytes.Reader) (netip.Addr, error) {
+	version, err := r.ReadByte()
+	if err != nil {
+		return netip.Addr{}, err
+	}
+	return readIPAddressVersion(r, version)
+}
+
+func readIPAddressVersion(r *bytes.Reader, version byte) (netip.Addr, error) {
+	switch version {
+	case 4:
+		var b [4]byte
+		if _, err := io.ReadFull(r, b[:]); err != nil {
+			return netip.Addr{}, err
+		}
+		return netip.AddrFrom4(b), nil
+	case 6:
+		var b [16]byte
+		if _, err := io.ReadFull(r, b[:]); err != nil {
+			return netip.Addr{}, err
+		}
+		return netip.AddrFrom16(b), nil
+	}
+	return netip.Addr{}, fmt.Errorf("invalid ip version %d", version)
+}
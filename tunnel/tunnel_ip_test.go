@@ -0,0 +1,49 @@
+package tunnel
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAddressAssign(t *testing.T) {
+	t.Parallel()
+
+	prefixes, err := parseAddressAssign([]byte{
+		0x00, 4, 10, 1, 2, 3, 32,
+		0x01, 6, 0xfd, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1, 64,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []netip.Prefix{
+		netip.MustParsePrefix("10.1.2.3/32"),
+		netip.MustParsePrefix("fd00::1/64"),
+	}, prefixes)
+
+	_, err = parseAddressAssign([]byte{0x00, 4, 10, 1, 2, 3, 33})
+	assert.Error(t, err, "should reject an invalid prefix length")
+
+	_, err = parseAddressAssign([]byte{0x00, 5, 10, 1, 2, 3, 32})
+	assert.Error(t, err, "should reject an invalid ip version")
+
+	_, err = parseAddressAssign([]byte{0x00, 4, 10, 1})
+	assert.Error(t, err, "should reject a truncated capsule")
+}
+
+func TestParseRouteAdvertisement(t *testing.T) {
+	t.Parallel()
+
+	routes, err := parseRouteAdvertisement([]byte{
+		4, 10, 0, 0, 0, 10, 0, 255, 255, 0,
+		4, 192, 168, 1, 10, 192, 168, 1, 10, 6,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []IPRoute{
+		{Start: netip.MustParseAddr("10.0.0.0"), End: netip.MustParseAddr("10.0.255.255")},
+		{Start: netip.MustParseAddr("192.168.1.10"), End: netip.MustParseAddr("192.168.1.10"), IPProtocol: 6},
+	}, routes)
+
+	_, err = parseRouteAdvertisement([]byte{4, 10, 0, 1, 0, 10, 0, 0, 0, 0})
+	assert.Error(t, err, "should reject a reversed range")
+}
@@ -7,6 +7,8 @@ import (
 	"strings"
 
 	"github.com/rs/zerolog/log"
+
+	"github.com/pomerium/cli/internal/httputil"
 )
 
 // A TCPTunneler tunnels TCP traffic.
@@ -31,12 +33,30 @@ func (tun *Tunnel) pickTCPTunneler(ctx context.Context) TCPTunneler {
 		return fallback
 	}
 
-	client := &http.Client{
-		Transport: &http.Transport{
-			ForceAttemptHTTP2: true,
-			TLSClientConfig:   tun.cfg.tlsConfig,
-		},
+	switch tun.cfg.protocol {
+	case ProtocolHTTP1:
+		log.Ctx(ctx).Info().Msg("using http1")
+		return fallback
+	case ProtocolHTTP2:
+		log.Ctx(ctx).Info().Msg("using http2")
+		return &http2tunneler{cfg: tun.cfg}
+	case ProtocolHTTP3:
+		log.Ctx(ctx).Info().Msg("using http3")
+		return &http3tunneler{cfg: tun.cfg}
+	}
+
+	transport := &http.Transport{
+		ForceAttemptHTTP2: true,
+		TLSClientConfig:   tun.cfg.tlsConfig,
+		Proxy:             http.ProxyURL(tun.cfg.outboundProxyURL),
+		DialContext:       (&httputil.Dialer{Prefer: tun.cfg.ipPreference}).DialContext,
+	}
+	if tun.cfg.dialContext != nil {
+		transport.Proxy = nil
+		transport.DialContext = tun.cfg.dialContext
 	}
+	client := &http.Client{Transport: transport}
+	defer client.CloseIdleConnections()
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://"+tun.cfg.proxyHost, nil)
 	if err != nil {
@@ -51,7 +71,7 @@ func (tun *Tunnel) pickTCPTunneler(ctx context.Context) TCPTunneler {
 	}
 	res.Body.Close()
 
-	if v := res.Header.Get("Alt-Svc"); strings.Contains(v, "h3") {
+	if v := res.Header.Get("Alt-Svc"); strings.Contains(v, "h3") && tun.cfg.quicAllowed() {
 		log.Ctx(ctx).Info().Msg("using http3")
 		return &http3tunneler{cfg: tun.cfg}
 	} else if res.ProtoMajor == 2 {
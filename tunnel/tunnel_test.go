@@ -5,6 +5,7 @@ import (
 	"bytes"
 	"context"
 	"crypto/tls"
+	"fmt"
 	"io"
 	"net"
 	"net/http"
@@ -86,9 +87,144 @@ func TestTunnel(t *testing.T) {
 	}
 }
 
-type readWriter struct {
-	io.Reader
-	io.Writer
+func TestProxyChain(t *testing.T) {
+	ctx, clearTimeout := context.WithTimeout(context.Background(), time.Second*10)
+	defer clearTimeout()
+
+	backend, err := net.Listen("tcp", "127.0.0.1:0")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer func() { _ = backend.Close() }()
+
+	received := make(chan string, 1)
+	go func() {
+		for {
+			conn, err := backend.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer func() { _ = conn.Close() }()
+
+				ln, _, _ := bufio.NewReader(conn).ReadLine()
+				received <- string(ln)
+			}()
+		}
+	}()
+
+	// connectProxy tunnels CONNECT requests to the address returned by dst
+	connectProxy := func(dst func(requestURI string) string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !assert.Equal(t, "CONNECT", r.Method) {
+				return
+			}
+			out, err := net.Dial("tcp", dst(r.RequestURI))
+			if !assert.NoError(t, err) {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			defer func() { _ = out.Close() }()
+
+			w.WriteHeader(200)
+
+			in, brw, err := w.(http.Hijacker).Hijack()
+			if !assert.NoError(t, err) {
+				return
+			}
+			defer func() { _ = in.Close() }()
+
+			errc := make(chan error, 2)
+			go func() {
+				_, err := io.Copy(in, out)
+				errc <- err
+			}()
+			go func() {
+				_, err := io.Copy(out, deBuffer(brw.Reader, in))
+				errc <- err
+			}()
+			<-errc
+		}))
+	}
+
+	inner := connectProxy(func(requestURI string) string {
+		assert.Equal(t, "example.com:9999", requestURI)
+		return backend.Addr().String()
+	})
+	defer inner.Close()
+
+	outer := connectProxy(func(requestURI string) string {
+		assert.Equal(t, inner.Listener.Addr().String(), requestURI)
+		return requestURI
+	})
+	defer outer.Close()
+
+	var buf bytes.Buffer
+	tun := New(
+		WithDestinationHost("example.com:9999"),
+		WithProxyHost(inner.Listener.Addr().String()),
+		WithProxyChain(outer.Listener.Addr().String()))
+	err = tun.Run(ctx, readWriter{strings.NewReader("HELLO WORLD\n"), &buf}, DiscardEvents())
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	select {
+	case ln := <-received:
+		assert.Equal(t, "HELLO WORLD", ln)
+	case <-ctx.Done():
+		t.Fatal("backend should receive the data through both proxies")
+	}
+}
+
+func TestProxyProtocol(t *testing.T) {
+	ctx, clearTimeout := context.WithTimeout(context.Background(), time.Second*10)
+	defer clearTimeout()
+
+	li, err := net.Listen("tcp", "127.0.0.1:0")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer func() { _ = li.Close() }()
+	client, err := net.Dial("tcp", li.Addr().String())
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer func() { _ = client.Close() }()
+	local, err := li.Accept()
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer func() { _ = local.Close() }()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(200)
+
+		in, brw, err := w.(http.Hijacker).Hijack()
+		if !assert.NoError(t, err) {
+			return
+		}
+		defer func() { _ = in.Close() }()
+
+		header, _ := brw.ReadString('\n')
+		assert.Equal(t, fmt.Sprintf("PROXY TCP4 127.0.0.1 127.0.0.1 %d %d\r\n",
+			client.LocalAddr().(*net.TCPAddr).Port, li.Addr().(*net.TCPAddr).Port), header)
+		ln, _ := brw.ReadString('\n')
+		assert.Equal(t, "HELLO WORLD\n", ln)
+	}))
+	defer srv.Close()
+
+	_, err = client.Write([]byte("HELLO WORLD\n"))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	tun := New(
+		WithDestinationHost("example.com:9999"),
+		WithProxyHost(srv.Listener.Addr().String()),
+		WithProxyProtocol(1))
+	err = tun.Run(ctx, local, DiscardEvents())
+	assert.NoError(t, err)
 }
 
 func TestForceHTTP1(t *testing.T) {
@@ -110,3 +246,26 @@ func TestForceHTTP1(t *testing.T) {
 
 	assert.Equal(t, "HTTP/1.1", protocol)
 }
+
+func TestTLSSessionResumption(t *testing.T) {
+	ctx, clearTimeout := context.WithTimeout(context.Background(), time.Second*10)
+	defer clearTimeout()
+
+	resumed := make(chan bool, 2)
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resumed <- r.TLS.DidResume
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer srv.Close()
+
+	tun := New(
+		WithDestinationHost("example.com:9999"),
+		WithProxyHost(srv.Listener.Addr().String()),
+		WithProtocol(ProtocolHTTP1),
+		WithTLSConfig(&tls.Config{InsecureSkipVerify: true}))
+	for _, expect := range []bool{false, true} {
+		err := tun.Run(ctx, readWriter{strings.NewReader(""), io.Discard}, DiscardEvents())
+		assert.ErrorIs(t, err, ErrUnauthorized)
+		assert.Equal(t, expect, <-resumed)
+	}
+}
@@ -7,15 +7,51 @@ import (
 	"io"
 	"net"
 	"net/netip"
+	"strings"
 	"time"
 
 	"github.com/quic-go/quic-go/http3"
 	"github.com/quic-go/quic-go/quicvarint"
 	"github.com/rs/zerolog/log"
 	"golang.org/x/sync/errgroup"
+
+	"github.com/pomerium/cli/internal/metrics"
+	"github.com/pomerium/cli/internal/systemd"
+)
+
+const (
+	maxUDPPacketSize         = (2 << 15) - 1
+	defaultUDPSessionTimeout = 10 * time.Minute
 )
 
-const maxUDPPacketSize = (2 << 15) - 1
+// UDPSettings customizes the behavior of UDP tunnels. Zero values use the defaults.
+type UDPSettings struct {
+	// SessionTimeout is the maximum duration of a single UDP session,
+	// after which it is disconnected. Defaults to 10 minutes.
+	SessionTimeout time.Duration
+	// MaxSessions limits the number of concurrent sessions, datagrams from
+	// new peers are dropped once the limit is reached. Unlimited by default.
+	MaxSessions int
+	// MaxPacketSize is the largest datagram that is read from the local
+	// socket, larger datagrams are truncated. Defaults to 65535.
+	MaxPacketSize int
+	// ReadBufferSize and WriteBufferSize set the local socket buffer sizes.
+	ReadBufferSize, WriteBufferSize int
+}
+
+func (s UDPSettings) sessionTimeout() time.Duration {
+	if s.SessionTimeout > 0 {
+		return s.SessionTimeout
+	}
+	return defaultUDPSessionTimeout
+}
+
+func (s UDPSettings) maxPacketSize() int {
+	if s.MaxPacketSize > 0 && s.MaxPacketSize < maxUDPPacketSize {
+		return s.MaxPacketSize
+	}
+	return maxUDPPacketSize
+}
 
 var contextIDZero = quicvarint.Append(nil, 0)
 
@@ -58,15 +94,10 @@ type UDPTunneler interface {
 func (tun *Tunnel) RunUDPListener(ctx context.Context, listenerAddress string) error {
 	ctx = log.Ctx(ctx).With().Str("listener-addr", listenerAddress).Logger().WithContext(ctx)
 
-	addr, err := net.ResolveUDPAddr("udp", listenerAddress)
-	if err != nil {
-		return fmt.Errorf("udp-tunnel: failed to resolve udp address: %w", err)
-	}
-
 	log.Ctx(ctx).Info().Msg("starting udp listener")
-	conn, err := net.ListenUDP("udp", addr)
+	conn, err := listenUDP(listenerAddress)
 	if err != nil {
-		return fmt.Errorf("udp-tunnel: failed to listen on udp address: %w", err)
+		return err
 	}
 	defer conn.Close()
 
@@ -75,15 +106,80 @@ func (tun *Tunnel) RunUDPListener(ctx context.Context, listenerAddress string) e
 	return err
 }
 
+// listenUDP listens on the UDP address or, if prefixed with "systemd:", uses
+// the socket passed by systemd socket activation
+func listenUDP(listenerAddress string) (*net.UDPConn, error) {
+	if name, ok := strings.CutPrefix(listenerAddress, systemd.ListenPrefix); ok {
+		pc, err := systemd.PacketConn(name)
+		if err != nil {
+			return nil, fmt.Errorf("udp-tunnel: %w", err)
+		}
+		conn, ok := pc.(*net.UDPConn)
+		if !ok {
+			_ = pc.Close()
+			return nil, fmt.Errorf("udp-tunnel: the systemd socket is not a UDP socket")
+		}
+		return conn, nil
+	}
+
+	addr, err := net.ResolveUDPAddr("udp", listenerAddress)
+	if err != nil {
+		return nil, fmt.Errorf("udp-tunnel: failed to resolve udp address: %w", err)
+	}
+	conn, err := net.ListenUDP("udp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("udp-tunnel: failed to listen on udp address: %w", err)
+	}
+	return conn, nil
+}
+
 func (tun *Tunnel) RunUDPSessionManager(ctx context.Context, conn *net.UDPConn, eventSink EventSink) error {
-	tunneler := newFallbackUDPTunneler(&http3tunneler{cfg: tun.cfg}, &http1tunneler{cfg: tun.cfg})
-	return newUDPSessionManager(conn, func(ctx context.Context, urw UDPDatagramReaderWriter) error {
-		return tun.runWithJWT(ctx, eventSink, func(ctx context.Context, rawJWT string) error {
-			// always disconnect after 10 minutes
-			ctx, clearTimeout := context.WithTimeout(ctx, 10*time.Minute)
-			defer clearTimeout()
-
-			return tunneler.TunnelUDP(ctx, eventSink, urw, rawJWT)
+	settings := tun.cfg.udpSettings
+	if settings.ReadBufferSize > 0 {
+		if err := conn.SetReadBuffer(settings.ReadBufferSize); err != nil {
+			return fmt.Errorf("udp-tunnel: failed to set read buffer size: %w", err)
+		}
+	}
+	if settings.WriteBufferSize > 0 {
+		if err := conn.SetWriteBuffer(settings.WriteBufferSize); err != nil {
+			return fmt.Errorf("udp-tunnel: failed to set write buffer size: %w", err)
+		}
+	}
+
+	var tunnelers []UDPTunneler
+	switch tun.cfg.protocol {
+	case ProtocolHTTP1:
+		tunnelers = []UDPTunneler{&http1tunneler{cfg: tun.cfg}}
+	case ProtocolHTTP2:
+		tunnelers = []UDPTunneler{&http2tunneler{cfg: tun.cfg}}
+	case ProtocolHTTP3:
+		tunnelers = []UDPTunneler{&http3tunneler{cfg: tun.cfg}}
+	default:
+		tunnelers = []UDPTunneler{&http3tunneler{cfg: tun.cfg}, &http2tunneler{cfg: tun.cfg}, &http1tunneler{cfg: tun.cfg}}
+		if !tun.cfg.quicAllowed() {
+			tunnelers = tunnelers[1:]
+		}
+	}
+	tunneler := newFallbackUDPTunneler(tunnelers...)
+	return newUDPSessionManager(conn, settings, func(ctx context.Context, urw UDPDatagramReaderWriter) error {
+		// always disconnect after the session timeout
+		ctx, clearTimeout := context.WithTimeout(ctx, settings.sessionTimeout())
+		defer clearTimeout()
+
+		metrics.UDPSessionStarted(tun.cfg.dstHost)
+		defer metrics.UDPSessionStopped(tun.cfg.dstHost)
+		urw = metricsUDPDatagramReaderWriter{UDPDatagramReaderWriter: urw, destination: tun.cfg.dstHost}
+		if tc, ok := eventSink.(TrafficCounter); ok {
+			tc.OnSessionStarted()
+			defer tc.OnSessionStopped()
+			urw = countingUDPDatagramReaderWriter{UDPDatagramReaderWriter: urw, counter: tc}
+		}
+		eventSink := &metricsEvents{EventSink: eventSink, destination: tun.cfg.dstHost}
+
+		return tun.runWithReconnect(ctx, eventSink, true, func(ctx context.Context, eventSink EventSink) error {
+			return tun.runWithJWT(ctx, eventSink, func(ctx context.Context, rawJWT string) error {
+				return tunneler.TunnelUDP(ctx, eventSink, urw, rawJWT)
+			})
 		})
 	}).run(ctx)
 }
@@ -91,18 +187,20 @@ func (tun *Tunnel) RunUDPSessionManager(ctx context.Context, conn *net.UDPConn,
 type udpSessionHandler func(context.Context, UDPDatagramReaderWriter) error
 
 type udpSessionManager struct {
-	conn    *net.UDPConn
-	handler udpSessionHandler
-	in      chan UDPDatagram
-	out     chan UDPDatagram
+	conn     *net.UDPConn
+	settings UDPSettings
+	handler  udpSessionHandler
+	in       chan UDPDatagram
+	out      chan UDPDatagram
 }
 
-func newUDPSessionManager(conn *net.UDPConn, handler udpSessionHandler) *udpSessionManager {
+func newUDPSessionManager(conn *net.UDPConn, settings UDPSettings, handler udpSessionHandler) *udpSessionManager {
 	return &udpSessionManager{
-		conn:    conn,
-		handler: handler,
-		in:      make(chan UDPDatagram, 1),
-		out:     make(chan UDPDatagram, 1),
+		conn:     conn,
+		settings: settings,
+		handler:  handler,
+		in:       make(chan UDPDatagram, 1),
+		out:      make(chan UDPDatagram, maxUDPWriteBatch),
 	}
 }
 
@@ -121,7 +219,7 @@ func (mgr *udpSessionManager) read(ctx context.Context) error {
 	// if the context is cancelled, cancel the read
 	context.AfterFunc(ctx, func() { _ = mgr.conn.SetReadDeadline(time.Now()) })
 
-	buffer := make([]byte, len(contextIDZero)+maxUDPPacketSize)
+	buffer := make([]byte, len(contextIDZero)+mgr.settings.maxPacketSize())
 	for {
 		n, addr, err := mgr.conn.ReadFromUDP(buffer[len(contextIDZero):])
 		if err != nil {
@@ -154,6 +252,12 @@ func (mgr *udpSessionManager) dispatch(ctx context.Context) error {
 		case datagram := <-mgr.in:
 			s, ok := sessions[datagram.Addr]
 			if !ok {
+				if mgr.settings.MaxSessions > 0 && len(sessions) >= mgr.settings.MaxSessions {
+					log.Ctx(ctx).Warn().Str("addr", datagram.Addr.String()).
+						Int("max-sessions", mgr.settings.MaxSessions).
+						Msg("udp-session-manager: too many sessions, dropping datagram")
+					continue
+				}
 				s = newUDPSession(mgr, datagram.Addr)
 				go func() {
 					_ = s.run(ctx)
@@ -175,15 +279,29 @@ func (mgr *udpSessionManager) write(ctx context.Context) error {
 	// if the context is cancelled, cancel the write
 	context.AfterFunc(ctx, func() { _ = mgr.conn.SetWriteDeadline(time.Now()) })
 
+	w := newUDPBatchWriter(mgr.conn)
+	batch := make([]UDPDatagram, 0, maxUDPWriteBatch)
 	for {
-		var datagram UDPDatagram
+		batch = batch[:0]
 		select {
 		case <-ctx.Done():
 			return context.Cause(ctx)
-		case datagram = <-mgr.out:
+		case datagram := <-mgr.out:
+			batch = append(batch, datagram)
+		}
+
+		// write any other pending datagrams along with the first
+	drain:
+		for len(batch) < maxUDPWriteBatch {
+			select {
+			case datagram := <-mgr.out:
+				batch = append(batch, datagram)
+			default:
+				break drain
+			}
 		}
 
-		_, err := mgr.conn.WriteToUDP(datagram.Payload(), net.UDPAddrFromAddrPort(datagram.Addr))
+		err := w.writeBatch(batch)
 		if err != nil {
 			// if this error is because the context was cancelled, return that instead
 			select {
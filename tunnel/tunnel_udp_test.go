@@ -97,3 +97,18 @@ func TestUDPSessionManager(t *testing.T) {
 	}
 	assert.NoError(t, err, "tunnel should shutdown cleanly")
 }
+
+func TestUDPSettings(t *testing.T) {
+	t.Parallel()
+
+	var s UDPSettings
+	assert.Equal(t, defaultUDPSessionTimeout, s.sessionTimeout())
+	assert.Equal(t, maxUDPPacketSize, s.maxPacketSize())
+
+	s = UDPSettings{SessionTimeout: time.Minute, MaxPacketSize: 1500}
+	assert.Equal(t, time.Minute, s.sessionTimeout())
+	assert.Equal(t, 1500, s.maxPacketSize())
+
+	s = UDPSettings{MaxPacketSize: 1 << 20}
+	assert.Equal(t, maxUDPPacketSize, s.maxPacketSize())
+}
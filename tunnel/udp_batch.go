@@ -0,0 +1,26 @@
+package tunnel
+
+import "net"
+
+// maxUDPWriteBatch is the maximum number of datagrams written to local peers at once
+const maxUDPWriteBatch = 64
+
+// A udpBatchWriter writes datagrams to local peers.
+type udpBatchWriter interface {
+	writeBatch(datagrams []UDPDatagram) error
+}
+
+// udpConnWriter writes each datagram individually.
+type udpConnWriter struct {
+	conn *net.UDPConn
+}
+
+func (w udpConnWriter) writeBatch(datagrams []UDPDatagram) error {
+	for _, datagram := range datagrams {
+		_, err := w.conn.WriteToUDP(datagram.Payload(), net.UDPAddrFromAddrPort(datagram.Addr))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
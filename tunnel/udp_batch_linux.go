@@ -0,0 +1,113 @@
+//go:build linux
+
+package tunnel
+
+import (
+	"errors"
+	"net"
+	"syscall"
+	"unsafe"
+)
+
+const (
+	// udpSegment is the UDP_SEGMENT socket option, see udp(7)
+	udpSegment = 103
+	// maxGSOSize is the maximum size of a segmented write
+	maxGSOSize = 65000
+)
+
+// gsoWriter writes consecutive datagrams of the same size to the same peer
+// with a single call, using UDP generic segmentation offload. If the kernel
+// or the device does not support it, it falls back to individual writes.
+type gsoWriter struct {
+	udpConnWriter
+	disabled bool
+	buf      []byte
+	oob      []byte
+}
+
+func newUDPBatchWriter(conn *net.UDPConn) udpBatchWriter {
+	return &gsoWriter{
+		udpConnWriter: udpConnWriter{conn: conn},
+		buf:           make([]byte, 0, maxGSOSize),
+		oob:           make([]byte, syscall.CmsgSpace(2)),
+	}
+}
+
+func (w *gsoWriter) writeBatch(datagrams []UDPDatagram) error {
+	for len(datagrams) > 0 {
+		n := gsoSegments(datagrams)
+		if w.disabled || n == 1 {
+			if err := w.udpConnWriter.writeBatch(datagrams[:n]); err != nil {
+				return err
+			}
+			datagrams = datagrams[n:]
+			continue
+		}
+
+		err := w.writeSegments(datagrams[:n])
+		if isGSOError(err) {
+			w.disabled = true
+			continue
+		} else if err != nil {
+			return err
+		}
+		datagrams = datagrams[n:]
+	}
+	return nil
+}
+
+// writeSegments writes datagrams to the same peer, all of the same size
+// except for the last which may be shorter.
+func (w *gsoWriter) writeSegments(datagrams []UDPDatagram) error {
+	w.buf = w.buf[:0]
+	for _, datagram := range datagrams {
+		w.buf = append(w.buf, datagram.Payload()...)
+	}
+
+	h := (*syscall.Cmsghdr)(unsafe.Pointer(&w.oob[0]))
+	h.Level = syscall.IPPROTO_UDP
+	h.Type = udpSegment
+	h.SetLen(syscall.CmsgLen(2))
+	*(*uint16)(unsafe.Pointer(&w.oob[syscall.CmsgLen(0)])) = uint16(len(datagrams[0].Payload()))
+
+	_, _, err := w.conn.WriteMsgUDP(w.buf, w.oob, net.UDPAddrFromAddrPort(datagrams[0].Addr))
+	return err
+}
+
+// gsoSegments returns the number of datagrams at the start of the batch which
+// can be written as segments of a single write.
+func gsoSegments(datagrams []UDPDatagram) int {
+	size := len(datagrams[0].Payload())
+	if size == 0 {
+		return 1
+	}
+
+	total := size
+	n := 1
+	for ; n < len(datagrams) && n < maxUDPWriteBatch; n++ {
+		next := len(datagrams[n].Payload())
+		if datagrams[n].Addr != datagrams[0].Addr || next == 0 || next > size || total+next > maxGSOSize {
+			break
+		}
+		total += next
+		if next < size {
+			// only the last segment may be shorter
+			n++
+			break
+		}
+	}
+	return n
+}
+
+func isGSOError(err error) bool {
+	var errno syscall.Errno
+	if !errors.As(err, &errno) {
+		return false
+	}
+	switch errno {
+	case syscall.EIO, syscall.EINVAL, syscall.EOPNOTSUPP, syscall.ENOPROTOOPT:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,9 @@
+//go:build !linux
+
+package tunnel
+
+import "net"
+
+func newUDPBatchWriter(conn *net.UDPConn) udpBatchWriter {
+	return udpConnWriter{conn: conn}
+}
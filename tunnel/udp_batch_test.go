@@ -0,0 +1,40 @@
+package tunnel
+
+import (
+	"bytes"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUDPBatchWriter(t *testing.T) {
+	t.Parallel()
+
+	peer, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	require.NoError(t, err)
+	defer peer.Close()
+
+	conn, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	require.NoError(t, err)
+	defer conn.Close()
+
+	addr := peer.LocalAddr().(*net.UDPAddr).AddrPort()
+	var batch []UDPDatagram
+	for _, size := range []int{100, 100, 100, 40, 100, 200, 1, 0} {
+		data := append(append([]byte{}, contextIDZero...), bytes.Repeat([]byte{byte(len(batch))}, size)...)
+		batch = append(batch, UDPDatagram{Addr: addr, data: data})
+	}
+
+	require.NoError(t, newUDPBatchWriter(conn).writeBatch(batch))
+
+	require.NoError(t, peer.SetReadDeadline(time.Now().Add(5*time.Second)))
+	buf := make([]byte, maxUDPPacketSize)
+	for _, datagram := range batch {
+		n, _, err := peer.ReadFromUDP(buf)
+		require.NoError(t, err)
+		assert.Equal(t, datagram.Payload(), buf[:n])
+	}
+}
@@ -0,0 +1,106 @@
+package version
+
+import (
+	"cmp"
+	"strconv"
+	"strings"
+)
+
+// parsedVersion is a semantic version, such as v1.2.3-rc.1
+type parsedVersion struct {
+	core       [3]int
+	prerelease []string
+}
+
+func parseVersion(s string) (parsedVersion, bool) {
+	var v parsedVersion
+	s, ok := strings.CutPrefix(s, "v")
+	if !ok {
+		return v, false
+	}
+	s, _, _ = strings.Cut(s, "+")
+	s, pre, hasPre := strings.Cut(s, "-")
+	parts := strings.Split(s, ".")
+	if len(parts) != len(v.core) {
+		return v, false
+	}
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return v, false
+		}
+		v.core[i] = n
+	}
+	if hasPre {
+		if pre == "" {
+			return v, false
+		}
+		v.prerelease = strings.Split(pre, ".")
+	}
+	return v, true
+}
+
+// IsValid reports whether s is a semantic version, such as v1.2.3.
+func IsValid(s string) bool {
+	_, ok := parseVersion(s)
+	return ok
+}
+
+// IsPrerelease reports whether s is the semantic version of a pre-release,
+// such as v1.2.3-rc.1.
+func IsPrerelease(s string) bool {
+	v, _ := parseVersion(s)
+	return len(v.prerelease) > 0
+}
+
+// Compare compares two semantic versions, returning -1, 0 or 1. Invalid
+// versions sort before valid ones.
+func Compare(a, b string) int {
+	va, okA := parseVersion(a)
+	vb, okB := parseVersion(b)
+	switch {
+	case !okA && !okB:
+		return 0
+	case !okA:
+		return -1
+	case !okB:
+		return 1
+	}
+	for i := range va.core {
+		if c := cmp.Compare(va.core[i], vb.core[i]); c != 0 {
+			return c
+		}
+	}
+
+	// a pre-release precedes the release
+	switch {
+	case len(va.prerelease) == 0 && len(vb.prerelease) == 0:
+		return 0
+	case len(va.prerelease) == 0:
+		return 1
+	case len(vb.prerelease) == 0:
+		return -1
+	}
+	for i := 0; i < len(va.prerelease) && i < len(vb.prerelease); i++ {
+		if c := comparePrereleaseIdentifiers(va.prerelease[i], vb.prerelease[i]); c != 0 {
+			return c
+		}
+	}
+	return cmp.Compare(len(va.prerelease), len(vb.prerelease))
+}
+
+// comparePrereleaseIdentifiers compares numeric identifiers numerically, and
+// sorts them before alphanumeric identifiers, which compare lexically
+func comparePrereleaseIdentifiers(a, b string) int {
+	na, errA := strconv.Atoi(a)
+	nb, errB := strconv.Atoi(b)
+	switch {
+	case errA == nil && errB == nil:
+		return cmp.Compare(na, nb)
+	case errA == nil:
+		return -1
+	case errB == nil:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
@@ -0,0 +1,30 @@
+package version
+
+import "testing"
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"v0.29.0", "v0.29.0", 0},
+		{"v0.29.1", "v0.29.0", 1},
+		{"v0.30.0", "v0.29.9", 1},
+		{"v1.0.0", "v0.99.99", 1},
+		{"v0.29.0", "v0.29.0-rc1", 1},
+		{"v0.29.0-rc.2", "v0.29.0-rc.10", -1},
+		{"v0.29.0-rc.1", "v0.29.0-rc.1.1", -1},
+		{"v0.29.0-1", "v0.29.0-alpha", -1},
+		{"v0.29.0+abc", "v0.29.0", 0},
+		{"latest", "v0.0.0", -1},
+		{"0.29.0", "v0.0.0", -1},
+	}
+	for _, tt := range tests {
+		if got := Compare(tt.a, tt.b); got != tt.expected {
+			t.Errorf("Compare(%s, %s) = %d, want %d", tt.a, tt.b, got, tt.expected)
+		}
+		if got := Compare(tt.b, tt.a); got != -tt.expected {
+			t.Errorf("Compare(%s, %s) = %d, want %d", tt.b, tt.a, got, -tt.expected)
+		}
+	}
+}
@@ -18,6 +18,9 @@ var (
 	GitCommit = ""
 	// BuildMeta specifies release type (dev,rc1,beta,etc)
 	BuildMeta = ""
+	// ReleaseSigningKey is the base64 encoded ed25519 public key the release
+	// checksums are signed with, set by ldflags.
+	ReleaseSigningKey = ""
 
 	// Features contains a list of supported features.
 	Features []string